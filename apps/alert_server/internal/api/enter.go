@@ -2,7 +2,9 @@ package api
 
 import (
 	"alert_server/internal/api/alert_api"
+	"alert_server/internal/api/event_api"
 	"alert_server/internal/api/index_api"
+	"alert_server/internal/api/session_api"
 	"alert_server/internal/api/white_ip_api"
 )
 
@@ -14,6 +16,8 @@ type Api struct {
 	WhiteIPApi white_ip_api.WhiteIPApi
 	AlertApi   alert_api.AlertApi
 	IndexApi   index_api.IndexApi
+	SessionApi session_api.SessionApi
+	EventApi   event_api.EventApi
 }
 
 var App = Api{}
@@ -0,0 +1,8 @@
+package event_api
+
+// File: alert_server/api/event_api/enter.go
+// Description: Suricata事件API模块入口
+
+// EventApi Suricata事件API模块
+type EventApi struct {
+}
@@ -0,0 +1,87 @@
+package event_api
+
+// File: alert_server/api/event_api/list.go
+// Description: Suricata事件列表查询API接口
+
+import (
+	"alert_server/internal/es_models"
+	"alert_server/internal/global"
+	"alert_server/internal/middleware"
+	"alert_server/internal/models"
+	"alert_server/internal/utils/response"
+	"context"
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/olivere/elastic/v7"
+	"github.com/sirupsen/logrus"
+)
+
+// ListRequest Suricata事件列表查询请求参数结构体
+type ListRequest struct {
+	models.PageInfo        // 嵌入分页基础参数
+	HoneyIp         string `form:"honeyIp" binding:"required_without=FlowID"`                          // 诱捕IP（与网络流ID二选一）
+	FlowID          int64  `form:"flowID"`                                                             // 网络流ID，查询与告警同一流的全部事件
+	EventType       string `form:"eventType" binding:"omitempty,oneof=flow dns http tls ssh fileinfo"` // 事件类型
+	SrcIp           string `form:"srcIp"`                                                              // 源IP
+	SessionID       string `form:"sessionID"`                                                          // 会话记录ID
+}
+
+// ListView Suricata事件列表查询接口，按诱捕IP或网络流ID分页查询事件
+func (EventApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	// 分页参数校验与默认值设置
+	if cr.Limit <= 0 || cr.Limit > 50 {
+		cr.Limit = 10
+	}
+	if cr.Page <= 0 {
+		cr.Page = 1
+	}
+	offset := (cr.Page - 1) * cr.Limit
+
+	// 构建ES布尔查询，各条件均为可选过滤
+	query := elastic.NewBoolQuery()
+	if cr.HoneyIp != "" {
+		query = query.Filter(elastic.NewTermQuery("honeyIp", cr.HoneyIp))
+	}
+	if cr.FlowID != 0 {
+		query = query.Filter(elastic.NewTermQuery("flowID", cr.FlowID))
+	}
+	if cr.EventType != "" {
+		query = query.Filter(elastic.NewTermQuery("eventType", cr.EventType))
+	}
+	if cr.SrcIp != "" {
+		query = query.Filter(elastic.NewTermQuery("srcIp", cr.SrcIp))
+	}
+	if cr.SessionID != "" {
+		query = query.Filter(elastic.NewTermQuery("sessionID", cr.SessionID))
+	}
+
+	res, err := global.ES.Search(es_models.EventModel{}.Index()).
+		Query(query).
+		Sort("timestamp", false). // 按事件时间降序
+		Size(cr.Limit).
+		From(offset).
+		Do(context.Background())
+	if err != nil {
+		logrus.Errorf("事件查询失败 %s", err)
+		response.FailWithMsg("事件查询失败", c)
+		return
+	}
+
+	count := res.Hits.TotalHits.Value
+	var list = make([]es_models.EventModel, 0, cr.Limit)
+	for _, hit := range res.Hits.Hits {
+		var data es_models.EventModel
+		err = json.Unmarshal(hit.Source, &data)
+		if err != nil {
+			logrus.Errorf("json解析失败 %s %s %s", err, hit.Source, hit.Id)
+			continue
+		}
+		data.ID = hit.Id
+		list = append(list, data)
+	}
+
+	response.OkWithList(list, count, c)
+}
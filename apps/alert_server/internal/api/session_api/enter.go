@@ -0,0 +1,8 @@
+package session_api
+
+// File: alert_server/api/session_api/enter.go
+// Description: 会话记录API模块入口
+
+// SessionApi 会话记录API模块
+type SessionApi struct {
+}
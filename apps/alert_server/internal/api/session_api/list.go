@@ -0,0 +1,78 @@
+package session_api
+
+// File: alert_server/api/session_api/list.go
+// Description: 会话记录列表查询API接口
+
+import (
+	"alert_server/internal/es_models"
+	"alert_server/internal/global"
+	"alert_server/internal/middleware"
+	"alert_server/internal/models"
+	"alert_server/internal/utils/response"
+	"context"
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/olivere/elastic/v7"
+	"github.com/sirupsen/logrus"
+)
+
+// ListRequest 会话记录列表查询请求参数结构体
+type ListRequest struct {
+	models.PageInfo        // 嵌入分页基础参数
+	HoneyIp         string `form:"honeyIp" binding:"required"` // 诱捕IP（必填）
+	SrcIp           string `form:"srcIp"`                      // 攻击源IP
+	DestPort        int    `form:"destPort"`                   // 诱捕端口
+}
+
+// ListView 会话记录列表查询接口，按诱捕IP分页查询会话记录（不返回会话内容）
+func (SessionApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	// 分页参数校验与默认值设置
+	if cr.Limit <= 0 || cr.Limit > 20 {
+		cr.Limit = 10
+	}
+	if cr.Page <= 0 {
+		cr.Page = 1
+	}
+	offset := (cr.Page - 1) * cr.Limit
+
+	// 构建ES布尔查询：诱捕IP必选，其余条件可选
+	query := elastic.NewBoolQuery().Filter(elastic.NewTermQuery("destIp", cr.HoneyIp))
+	if cr.SrcIp != "" {
+		query = query.Filter(elastic.NewTermQuery("srcIp", cr.SrcIp))
+	}
+	if cr.DestPort != 0 {
+		query = query.Filter(elastic.NewTermQuery("destPort", cr.DestPort))
+	}
+
+	// 列表不返回会话内容，避免单次响应过大
+	res, err := global.ES.Search(es_models.SessionModel{}.Index()).
+		Query(query).
+		FetchSourceContext(elastic.NewFetchSourceContext(true).Exclude("transcript")).
+		Sort("startTime", false). // 按会话开始时间降序
+		Size(cr.Limit).
+		From(offset).
+		Do(context.Background())
+	if err != nil {
+		logrus.Errorf("会话记录查询失败 %s", err)
+		response.FailWithMsg("会话记录查询失败", c)
+		return
+	}
+
+	count := res.Hits.TotalHits.Value
+	var list = make([]es_models.SessionModel, 0, cr.Limit)
+	for _, hit := range res.Hits.Hits {
+		var data es_models.SessionModel
+		err = json.Unmarshal(hit.Source, &data)
+		if err != nil {
+			logrus.Errorf("json解析失败 %s %s %s", err, hit.Source, hit.Id)
+			continue
+		}
+		data.ID = hit.Id
+		list = append(list, data)
+	}
+
+	response.OkWithList(list, count, c)
+}
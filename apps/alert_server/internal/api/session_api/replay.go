@@ -0,0 +1,86 @@
+package session_api
+
+// File: alert_server/api/session_api/replay.go
+// Description: 会话记录回放API接口
+
+import (
+	"alert_server/internal/es_models"
+	"alert_server/internal/global"
+	"alert_server/internal/middleware"
+	"alert_server/internal/utils/response"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/olivere/elastic/v7"
+	"github.com/sirupsen/logrus"
+)
+
+// ReplayRequest 会话记录回放请求参数结构体
+type ReplayRequest struct {
+	ID string `uri:"id" binding:"required"` // 会话记录ID
+}
+
+// ReplayFrame 会话回放帧，按时间顺序还原双向数据
+type ReplayFrame struct {
+	Offset    int64  `json:"offset"`    // 相对会话首个数据片段的时间偏移（毫秒）
+	Time      string `json:"time"`      // 数据片段时间
+	Direction int8   `json:"direction"` // 数据方向 1 攻击者->诱捕服务 2 诱捕服务->攻击者
+	Data      []byte `json:"data"`      // 数据片段内容（base64编码）
+}
+
+// ReplayResponse 会话记录回放响应结构体
+type ReplayResponse struct {
+	es_models.SessionModel               // 会话基础信息
+	FrameList              []ReplayFrame `json:"frameList"` // 回放帧列表
+}
+
+// ReplayView 会话记录回放接口，返回会话基础信息及按时间偏移排列的回放帧
+func (SessionApi) ReplayView(c *gin.Context) {
+	cr := middleware.GetBind[ReplayRequest](c)
+
+	res, err := global.ES.Get().Index(es_models.SessionModel{}.Index()).Id(cr.ID).Do(context.Background())
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			response.FailWithMsg("会话记录不存在", c)
+			return
+		}
+		logrus.Errorf("会话记录查询失败 %s %s", cr.ID, err)
+		response.FailWithMsg("会话记录查询失败", c)
+		return
+	}
+
+	var data es_models.SessionModel
+	if err = json.Unmarshal(res.Source, &data); err != nil {
+		logrus.Errorf("json解析失败 %s %s", err, cr.ID)
+		response.FailWithMsg("会话记录解析失败", c)
+		return
+	}
+	data.ID = res.Id
+
+	// 将会话内容转换为回放帧，时间偏移以首个数据片段为基准
+	frameList := make([]ReplayFrame, 0, len(data.Transcript))
+	var start time.Time
+	for i, entry := range data.Transcript {
+		t, err := time.ParseInLocation("2006-01-02 15:04:05.000", entry.Time, time.Local)
+		if err != nil {
+			logrus.Warnf("会话片段时间解析失败 %s %s", entry.Time, err)
+		}
+		if i == 0 {
+			start = t
+		}
+		frameList = append(frameList, ReplayFrame{
+			Offset:    t.Sub(start).Milliseconds(),
+			Time:      entry.Time,
+			Direction: entry.Direction,
+			Data:      entry.Data,
+		})
+	}
+	data.Transcript = nil
+
+	response.OkWithData(ReplayResponse{
+		SessionModel: data,
+		FrameList:    frameList,
+	}, c)
+}
@@ -8,6 +8,7 @@ import (
 	"alert_server/internal/middleware"
 	"alert_server/internal/models"
 	"alert_server/internal/service/common_service"
+	"alert_server/internal/utils/ip"
 	"alert_server/internal/utils/response"
 	"errors"
 	"fmt"
@@ -31,6 +32,8 @@ func (WhiteIPApi) CreateView(c *gin.Context) {
 	log := middleware.GetLog(c)
 	// 绑定并校验请求参数
 	cr := middleware.GetBind[CreateRequest](c)
+	// IPv6地址统一为标准格式，与告警及事件中的源IP按字符串匹配
+	cr.IP = ip.NormalizeIP(cr.IP)
 
 	// 校验IP是否已存在于白名单（避免重复添加）
 	log.WithFields(map[string]interface{}{
@@ -106,6 +109,8 @@ func (WhiteIPApi) UpdateView(c *gin.Context) {
 	log := middleware.GetLog(c)
 	// 绑定并校验请求参数
 	cr := middleware.GetBind[UpdateRequest](c)
+	// IPv6地址统一为标准格式，与告警及事件中的源IP按字符串匹配
+	cr.IP = ip.NormalizeIP(cr.IP)
 
 	log.WithFields(map[string]interface{}{
 		"white_ip_id": cr.ID,
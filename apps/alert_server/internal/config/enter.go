@@ -16,6 +16,8 @@ type Config struct {
 	MQ        MQ       `yaml:"mq"`        // rabbitMQ配置信息
 	ES        ES       `yaml:"es"`        // elasticSearch配置信息
 	Alert     Alert    `yaml:"alert"`     // 告警配置信息
+	IPDB      IPDB     `yaml:"ipdb"`      // ip地址库配置信息
+	Metrics   Metrics  `yaml:"metrics"`   // Prometheus指标配置信息
 }
 
 // DB 数据库连接配置结构体
@@ -110,6 +112,20 @@ type ES struct {
 
 // Alert 配置结构体
 type Alert struct {
-	AlertIndex string `yaml:"alertIndex"` // 告警索引名称
-	AlertTopic string `yaml:"alertTopic"` // 告警Topic名称
+	AlertIndex   string `yaml:"alertIndex"`   // 告警索引名称
+	AlertTopic   string `yaml:"alertTopic"`   // 告警Topic名称
+	SessionIndex string `yaml:"sessionIndex"` // 会话记录索引名称
+	SessionTopic string `yaml:"sessionTopic"` // 会话记录Topic名称
+	EventIndex   string `yaml:"eventIndex"`   // Suricata事件索引名称
+	EventTopic   string `yaml:"eventTopic"`   // Suricata事件Topic名称
+}
+
+// IPDB ip地址库配置结构体
+type IPDB struct {
+	V6Path string `yaml:"v6Path"` // IPv6 ip2region数据库文件路径，为空时不解析IPv6地址归属地
+}
+
+// Metrics Prometheus指标配置结构体
+type Metrics struct {
+	Addr string `yaml:"addr"` // /metrics监听地址，为空时不启动；只监听本机或内部网络地址，不与web服务共用端口
 }
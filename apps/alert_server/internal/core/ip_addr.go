@@ -1,12 +1,14 @@
 package core
 
 // File: alert_server/core/ip_addr.go
-// Description: IP地址解析核心模块，基于ip2region数据库实现IP地址到地理位置的解析
+// Description: IP地址解析核心模块，基于ip2region数据库实现IP地址到地理位置的解析，IPv6地址使用配置的IPv6数据库解析
 
 import (
+	"alert_server/internal/global"
 	"alert_server/internal/utils/ip"
 	_ "embed"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/lionsoul2014/ip2region/binding/golang/xdb"
@@ -16,12 +18,15 @@ import (
 // searcher 全局ip2region数据库搜索器实例
 var searcher *xdb.Searcher
 
+// searcher6 IPv6 ip2region数据库搜索器实例，未配置IPv6数据库时为空
+var searcher6 *xdb.Searcher
+
 // addrDB 嵌入的ip2region数据库文件内容（二进制）
 //
 //go:embed ip2region.xdb
 var addrDB []byte
 
-// InitIPDB 初始化IP地址数据库，加载嵌入式的ip2region.xdb文件
+// InitIPDB 初始化IP地址数据库，加载嵌入式的ip2region.xdb文件及配置的IPv6数据库文件
 func InitIPDB() {
 	// 从内存缓冲区创建searcher实例
 	_searcher, err := xdb.NewWithBuffer(xdb.IPv4, addrDB)
@@ -30,15 +35,52 @@ func InitIPDB() {
 		return
 	}
 	searcher = _searcher
+
+	// IPv6数据库体积较大，不内嵌，按配置的文件路径加载
+	v6Path := global.Config.IPDB.V6Path
+	if v6Path == "" {
+		logrus.Warnf("未配置IPv6地址数据库，IPv6地址不解析归属地")
+		return
+	}
+	buff, err := xdb.LoadContentFromFile(v6Path)
+	if err != nil {
+		logrus.Fatalf("IPv6地址数据库读取失败 %s", err)
+		return
+	}
+	_searcher6, err := xdb.NewWithBuffer(xdb.IPv6, buff)
+	if err != nil {
+		logrus.Fatalf("IPv6地址数据库加载失败 %s", err)
+		return
+	}
+	searcher6 = _searcher6
 }
 
 // GetIpAddr 根据IP地址解析对应的地理位置信息
 func GetIpAddr(_ip string) (addr string) {
+	addrIP := net.ParseIP(_ip)
+	if addrIP == nil {
+		logrus.Warnf("错误的ip地址 %s", _ip)
+		return "异常地址"
+	}
+
 	// 内网IP直接返回"内网"
 	if ip.HasLocalIPAddr(_ip) {
 		return "内网"
 	}
 
+	// IPv6地址（IPv4映射地址除外）使用IPv6数据库解析
+	if addrIP.To4() == nil {
+		if searcher6 == nil {
+			return "IPv6地址"
+		}
+		region, err := searcher6.SearchByStr(addrIP.String())
+		if err != nil {
+			logrus.Warnf("错误的ip地址 %s", err)
+			return "异常地址"
+		}
+		return formatRegion(region)
+	}
+
 	// ip2region数据库未初始化
 	if searcher == nil {
 		logrus.Error("ip地址数据库未初始化，无法解析IP")
@@ -46,7 +88,7 @@ func GetIpAddr(_ip string) (addr string) {
 	}
 
 	// 从ip2region数据库中查询IP对应的地理位置信息
-	region, err := searcher.SearchByStr(_ip)
+	region, err := searcher.SearchByStr(addrIP.To4().String())
 	if err != nil {
 		logrus.Warnf("错误的ip地址 %s", err)
 		return "异常地址"
@@ -76,3 +118,25 @@ func GetIpAddr(_ip string) (addr string) {
 	}
 	return region
 }
+
+// formatRegion 格式化IPv6数据库的查询结果（格式：国家|省份|城市|运营商...），取前三项中有效的地理信息
+func formatRegion(region string) string {
+	var list []string
+	for i, item := range strings.Split(region, "|") {
+		if i >= 3 {
+			break
+		}
+		if item == "" || item == "0" {
+			continue
+		}
+		list = append(list, item)
+	}
+	if len(list) == 0 {
+		return "未知地址"
+	}
+	// 省份及城市均有效时不展示国家，与IPv4地址的展示方式保持一致
+	if len(list) == 3 {
+		list = list[1:]
+	}
+	return strings.Join(list, "·")
+}
@@ -24,6 +24,8 @@ type AlertModel struct {
 	Payload     string `json:"payload"`     // 告警关联数据包请求载荷
 	ServiceID   uint   `json:"serviceID"`   // 服务ID
 	ServiceName string `json:"serviceName"` // 服务名称
+	SessionID   string `json:"sessionID"`   // 关联的会话记录ID
+	FlowID      int64  `json:"flowID"`      // 网络流唯一标识ID，关联同一流的Suricata事件
 }
 
 // Index 获取告警数据在Elasticsearch中的存储索引名，从全局配置读取
@@ -0,0 +1,49 @@
+package es_models
+
+// File: alert_server/models/es_models/event_model.go
+// Description: Elasticsearch Suricata事件数据模型模块，定义节点上报的flow/dns/http/tls/ssh/fileinfo事件及节点连接限制事件存储结构、ES索引名及索引映射配置
+
+import (
+	"alert_server/internal/global"
+	_ "embed"
+)
+
+// EventModel Elasticsearch Suricata事件存储结构体，详情字段保留Suricata Eve原始字段名
+type EventModel struct {
+	ID          string         `json:"id,omitempty"`       // 事件唯一标识（ES文档ID）
+	NodeUid     string         `json:"nodeUid"`            // 节点唯一标识
+	EventType   string         `json:"eventType"`          // 事件类型 flow dns http tls ssh fileinfo limit
+	FlowID      int64          `json:"flowID"`             // 网络流唯一标识ID，与告警的flowID关联
+	Timestamp   string         `json:"timestamp"`          // 事件发生时间
+	SrcIp       string         `json:"srcIp"`              // 源IP地址
+	SrcPort     int            `json:"srcPort"`            // 源端口
+	Addr        string         `json:"addr"`               // 源IP归属地
+	DestIp      string         `json:"destIp"`             // 目标IP地址
+	DestPort    int            `json:"destPort"`           // 目标端口
+	Proto       string         `json:"proto"`              // 网络协议
+	AppProto    string         `json:"appProto"`           // 应用层协议
+	HoneyIp     string         `json:"honeyIp"`            // 事件涉及的诱捕IP
+	SessionID   string         `json:"sessionID"`          // 关联的会话记录ID
+	ServiceID   uint           `json:"serviceID"`          // 服务ID
+	ServiceName string         `json:"serviceName"`        // 服务名称
+	Flow        map[string]any `json:"flow,omitempty"`     // 网络流统计信息
+	Dns         map[string]any `json:"dns,omitempty"`      // DNS请求/应答详情
+	Http        map[string]any `json:"http,omitempty"`     // HTTP请求详情
+	Tls         map[string]any `json:"tls,omitempty"`      // TLS握手详情
+	Ssh         map[string]any `json:"ssh,omitempty"`      // SSH握手详情
+	Fileinfo    map[string]any `json:"fileinfo,omitempty"` // 传输文件详情
+	Limit       map[string]any `json:"limit,omitempty"`    // 连接限制触发详情
+}
+
+// Index 获取Suricata事件在Elasticsearch中的存储索引名，从全局配置读取
+func (event EventModel) Index() string {
+	return global.Config.Alert.EventIndex
+}
+
+//go:embed event_mapping.json
+var eventMapping string // 嵌入ES索引映射配置文件，避免硬编码
+
+// Mappings 返回Elasticsearch事件索引的映射配置，用于索引初始化
+func (event EventModel) Mappings() string {
+	return eventMapping
+}
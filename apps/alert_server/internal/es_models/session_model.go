@@ -0,0 +1,49 @@
+package es_models
+
+// File: alert_server/models/es_models/session_model.go
+// Description: Elasticsearch会话记录数据模型模块，定义节点上报的隧道会话存储结构、ES索引名及索引映射配置
+
+import (
+	"alert_server/internal/global"
+	_ "embed"
+)
+
+// TranscriptEntry 会话记录中的单个数据片段
+type TranscriptEntry struct {
+	Time      string `json:"time"`      // 数据片段时间（毫秒精度）
+	Direction int8   `json:"direction"` // 数据方向 1 攻击者->诱捕服务 2 诱捕服务->攻击者
+	Data      []byte `json:"data"`      // 数据片段内容（base64编码）
+}
+
+// SessionModel Elasticsearch会话记录存储结构体
+type SessionModel struct {
+	ID          string            `json:"id"`                   // 会话唯一标识（节点生成，同时作为ES文档ID）
+	NodeUid     string            `json:"nodeUid"`              // 节点唯一标识
+	SrcIp       string            `json:"srcIp"`                // 攻击源IP地址
+	SrcPort     int               `json:"srcPort"`              // 攻击源端口
+	Addr        string            `json:"addr"`                 // 攻击地址
+	DestIp      string            `json:"destIp"`               // 诱捕IP地址
+	DestPort    int               `json:"destPort"`             // 诱捕端口
+	TargetAddr  string            `json:"targetAddr"`           // 隧道目标服务地址
+	StartTime   string            `json:"startTime"`            // 会话开始时间
+	EndTime     string            `json:"endTime"`              // 会话结束时间
+	BytesIn     int64             `json:"bytesIn"`              // 攻击者发送的字节数
+	BytesOut    int64             `json:"bytesOut"`             // 诱捕服务返回的字节数
+	Truncated   bool              `json:"truncated"`            // 会话记录是否因超出上限被截断
+	ServiceID   uint              `json:"serviceID"`            // 服务ID
+	ServiceName string            `json:"serviceName"`          // 服务名称
+	Transcript  []TranscriptEntry `json:"transcript,omitempty"` // 带时间戳的双向会话记录
+}
+
+// Index 获取会话记录在Elasticsearch中的存储索引名，从全局配置读取
+func (session SessionModel) Index() string {
+	return global.Config.Alert.SessionIndex
+}
+
+//go:embed session_mapping.json
+var sessionMapping string // 嵌入ES索引映射配置文件，避免硬编码
+
+// Mappings 返回Elasticsearch会话索引的映射配置，用于索引初始化
+func (session SessionModel) Mappings() string {
+	return sessionMapping
+}
@@ -8,7 +8,7 @@ type HoneyPortModel struct {
 	HoneyIpID    uint         `gorm:"index:idx_honey_ip_id" json:"honeyIpID"` // 关联诱捕ipID
 	ServiceID    uint         `json:"serviceID"`                              // 服务id
 	ServiceModel ServiceModel `gorm:"foreignKey:ServiceID" json:"-"`          // 关联服务
-	IP           string       `gorm:"size:32" json:"ip"`                      // 服务ip
+	IP           string       `gorm:"size:64" json:"ip"`                      // 服务ip
 	Port         int          `json:"port"`                                   // 服务端口
 	DstIP        string       `gorm:"size:32" json:"dstIP"`                   // 目标转发ip
 	DstPort      int          `json:"dstPort"`                                // 目标转发端口
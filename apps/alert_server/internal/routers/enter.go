@@ -29,6 +29,8 @@ func Run() {
 	WhiteIpRouter(g) // 白名单ip相关路由
 	AlertRouter(g)   // 告警相关路由
 	IndexRouter(g)   // 首页相关路由
+	SessionRouter(g) // 会话记录相关路由
+	EventRouter(g)   // Suricata事件相关路由
 
 	// 获取HTTP服务监听地址
 	webAddr := system.WebAddr
@@ -0,0 +1,20 @@
+package routers
+
+// File: alert_server/routers/event_router.go
+// Description: Suricata事件路由配置模块
+
+import (
+	"alert_server/internal/api"
+	"alert_server/internal/api/event_api"
+	"alert_server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EventRouter 注册Suricata事件相关路由
+func EventRouter(r *gin.RouterGroup) {
+	app := api.App.EventApi
+
+	// GET /event: Suricata事件列表接口，按诱捕IP或网络流ID分页查询
+	r.GET("event", middleware.BindQueryMiddleware[event_api.ListRequest], app.ListView)
+}
@@ -0,0 +1,22 @@
+package routers
+
+// File: alert_server/routers/session_router.go
+// Description: 会话记录路由配置模块
+
+import (
+	"alert_server/internal/api"
+	"alert_server/internal/api/session_api"
+	"alert_server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionRouter 注册会话记录相关路由
+func SessionRouter(r *gin.RouterGroup) {
+	app := api.App.SessionApi
+
+	// GET /session: 会话记录列表接口，按诱捕IP分页查询
+	r.GET("session", middleware.BindQueryMiddleware[session_api.ListRequest], app.ListView)
+	// GET /session/:id/replay: 会话记录回放接口，返回完整的双向会话内容
+	r.GET("session/:id/replay", middleware.BindUriMiddleware[session_api.ReplayRequest], app.ReplayView)
+}
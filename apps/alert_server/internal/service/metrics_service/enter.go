@@ -0,0 +1,67 @@
+package metrics_service
+
+// File: alert_server/service/metrics_service/enter.go
+// Description: 告警服务Prometheus指标模块，定义MQ消息入库速率、ES写入耗时及白名单过滤丢弃数等业务指标，并按配置在独立的内部地址启动/metrics监听
+
+import (
+	"alert_server/internal/global"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// IngestTotal 从MQ消费的消息数，type取值 alert 告警 event 事件 session 会话记录
+	IngestTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "alert_server_ingest_total",
+		Help: "从MQ消费的消息数 type alert 告警 event 事件 session 会话记录",
+	}, []string{"type"})
+	// IngestErrors 消息处理失败数，reason取值 parse 消息解析失败 es ES写入失败
+	IngestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "alert_server_ingest_errors_total",
+		Help: "消息处理失败数 reason parse 消息解析失败 es ES写入失败",
+	}, []string{"type", "reason"})
+	// ESIndexSeconds 单条消息写入ES的耗时
+	ESIndexSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "alert_server_es_index_seconds",
+		Help:    "单条消息写入ES的耗时",
+		Buckets: prometheus.ExponentialBuckets(0.002, 2, 12),
+	}, []string{"type"})
+	// WhitelistDropped 因源IP或目标IP命中白名单而丢弃的消息数
+	WhitelistDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "alert_server_whitelist_dropped_total",
+		Help: "因IP命中白名单而丢弃的消息数",
+	}, []string{"type"})
+)
+
+// 消息类型标签取值
+const (
+	TypeAlert   = "alert"
+	TypeEvent   = "event"
+	TypeSession = "session"
+)
+
+// 处理失败原因标签取值
+const (
+	ReasonParse = "parse"
+	ReasonES    = "es"
+)
+
+// Run 按配置在独立地址启动指标监听，不与对外的web服务共用端口，未配置监听地址时不启动
+func Run() {
+	addr := global.Config.Metrics.Addr
+	if addr == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		logrus.Infof("指标服务监听 %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logrus.Errorf("指标服务启动失败 %s", err)
+		}
+	}()
+}
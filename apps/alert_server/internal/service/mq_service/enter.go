@@ -1,7 +1,7 @@
 package mq_service
 
 // File: alert_server/service/mq_service/enter.go
-// Description: MQ服务启动模块，负责告警队列声明及告警消息消费协程初始化，确保MQ消息接收通道就绪
+// Description: MQ服务启动模块，负责告警、会话记录及Suricata事件队列声明及消息消费协程初始化，确保MQ消息接收通道就绪
 
 import (
 	"alert_server/internal/global"
@@ -11,7 +11,7 @@ import (
 	"github.com/streadway/amqp"
 )
 
-// Run 初始化MQ服务核心流程：声明告警队列 + 启动告警消息消费协程
+// Run 初始化MQ服务核心流程：声明告警、会话记录及Suricata事件队列 + 启动消息消费协程
 func Run() {
 	cfg := global.Config.Alert
 	// 声明MQ告警队列，配置队列基础属性
@@ -28,8 +28,26 @@ func Run() {
 		return
 	}
 
+	// 声明MQ会话记录队列，属性与告警队列一致
+	_, err = global.Queue.QueueDeclare(cfg.SessionTopic, true, false, false, false, nil)
+	if err != nil {
+		logrus.Fatalf("声明队列失败: %v", err)
+		return
+	}
+
+	// 声明MQ Suricata事件队列，属性与告警队列一致
+	_, err = global.Queue.QueueDeclare(cfg.EventTopic, true, false, false, false, nil)
+	if err != nil {
+		logrus.Fatalf("声明队列失败: %v", err)
+		return
+	}
+
 	// 启动告警消息接收协程，异步处理MQ队列中的告警消息（避免阻塞当前启动流程）
 	go RevAlertMq()
+	// 启动会话记录接收协程
+	go RevSessionMq()
+	// 启动Suricata事件接收协程
+	go RevEventMq()
 }
 
 // sendQueueMessage 向指定RabbitMQ队列发送消息
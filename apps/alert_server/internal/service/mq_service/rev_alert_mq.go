@@ -8,9 +8,12 @@ import (
 	"alert_server/internal/es_models"
 	"alert_server/internal/global"
 	"alert_server/internal/models"
+	"alert_server/internal/service/metrics_service"
+	"alert_server/internal/utils/ip"
 	"context"
 	"encoding/json"
 	"log"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -34,13 +37,22 @@ func RevAlertMq() {
 
 	// 循环监听队列消息，持续消费新增告警
 	for d := range msgs {
+		metrics_service.IngestTotal.WithLabelValues(metrics_service.TypeAlert).Inc()
 		// 将消息体JSON解析为ES告警数据模型
 		var data es_models.AlertModel
 		err = json.Unmarshal(d.Body, &data)
 		if err != nil {
+			metrics_service.IngestErrors.WithLabelValues(metrics_service.TypeAlert, metrics_service.ReasonParse).Inc()
 			logrus.Errorf("消息格式解析失败 %s %s", err, d.Body)
 			continue // 解析失败跳过当前消息，继续消费下一条
 		}
+		// 未携带日志行标识的消息以节点发件箱的消息标识作为文档ID，重复发布的消息覆盖同一文档
+		if data.ID == "" {
+			data.ID = d.MessageId
+		}
+		// IPv6地址统一为标准格式，与白名单及诱捕端口记录保持一致
+		data.SrcIp = ip.NormalizeIP(data.SrcIp)
+		data.DestIp = ip.NormalizeIP(data.DestIp)
 
 		// 打印告警核心信息：规则描述、源IP、目标IP:端口
 		logrus.Infof("%s %s => %s:%d", data.Signature, data.SrcIp, data.DestIp, data.DestPort)
@@ -49,6 +61,7 @@ func RevAlertMq() {
 		var whiteModel models.WhiteIPModel
 		global.DB.Find(&whiteModel, "ip = ?", data.SrcIp)
 		if whiteModel.ID != 0 {
+			metrics_service.WhitelistDropped.WithLabelValues(metrics_service.TypeAlert).Inc()
 			logrus.Warnf("告警消息 在白名单中（源IP：%s），跳过处理", data.SrcIp)
 			continue
 		}
@@ -65,14 +78,22 @@ func RevAlertMq() {
 		addr := core.GetIpAddr(data.SrcIp)
 		data.Addr = addr
 
-		// es告警信息入库
-		response, err1 := global.ES.Index().Index(data.Index()).BodyJson(data).Do(context.Background())
+		// es告警信息入库，以消息标识作为文档ID，重复上报的告警覆盖同一文档
+		start := time.Now()
+		response, err1 := global.ES.Index().Index(data.Index()).Id(data.ID).BodyJson(data).Do(context.Background())
+		metrics_service.ESIndexSeconds.WithLabelValues(metrics_service.TypeAlert).Observe(time.Since(start).Seconds())
 		if err1 != nil {
+			metrics_service.IngestErrors.WithLabelValues(metrics_service.TypeAlert, metrics_service.ReasonES).Inc()
 			logrus.Errorf("告警消息入库失败 %s %s", err1, d.Body)
 			continue
 		}
 		logrus.Infof("告警消息入库成功 %s", response.Id)
 
+		// 重复上报的告警不再推送通知
+		if response.Result == "updated" {
+			continue
+		}
+
 		SendWsMsg(WsMsgType{
 			Type: 3,
 		})
@@ -0,0 +1,84 @@
+package mq_service
+
+// File: alert_server/service/mq_service/rev_event_mq.go
+// Description: MQ Suricata事件消费模块，负责监听事件队列、解析节点上报的flow/dns/http/tls/ssh/fileinfo及连接限制事件、白名单过滤及关联虚拟服务信息后写入ES
+
+import (
+	"alert_server/internal/core"
+	"alert_server/internal/es_models"
+	"alert_server/internal/global"
+	"alert_server/internal/models"
+	"alert_server/internal/service/metrics_service"
+	"alert_server/internal/utils/ip"
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RevEventMq 消费MQ事件队列消息，核心流程：监听队列→解析消息→白名单过滤→关联虚拟服务信息→写入ES
+func RevEventMq() {
+	cfg := global.Config.Alert
+	// 注册MQ消费者，监听Suricata事件队列
+	msgs, err := global.Queue.Consume(
+		cfg.EventTopic, // 消费队列名称：从配置读取事件队列主题
+		"",             // 消费者标识：空字符串表示使用默认标识
+		true,           // 自动确认：消息处理后自动向MQ发送ACK确认
+		false,          // 排他性：false表示非排他消费
+		false,          // 非本地：false表示接受非本地队列的消息
+		false,          // 非阻塞：false表示同步注册消费者
+		nil,            // 其他额外配置参数：无特殊配置
+	)
+	if err != nil {
+		log.Fatalf("无法注册消费者: %v", err)
+	}
+
+	for d := range msgs {
+		metrics_service.IngestTotal.WithLabelValues(metrics_service.TypeEvent).Inc()
+		var data es_models.EventModel
+		err = json.Unmarshal(d.Body, &data)
+		if err != nil {
+			metrics_service.IngestErrors.WithLabelValues(metrics_service.TypeEvent, metrics_service.ReasonParse).Inc()
+			logrus.Errorf("消息格式解析失败 %s %s", err, d.Body)
+			continue
+		}
+		// 未携带日志行标识的消息以节点发件箱的消息标识作为文档ID，重复发布的消息覆盖同一文档
+		if data.ID == "" {
+			data.ID = d.MessageId
+		}
+		// IPv6地址统一为标准格式，与白名单及诱捕端口记录保持一致
+		data.SrcIp = ip.NormalizeIP(data.SrcIp)
+		data.DestIp = ip.NormalizeIP(data.DestIp)
+
+		// 白名单过滤：源IP或目标IP在白名单中则跳过
+		var whiteModel models.WhiteIPModel
+		global.DB.Find(&whiteModel, "ip in ?", []string{data.SrcIp, data.DestIp})
+		if whiteModel.ID != 0 {
+			metrics_service.WhitelistDropped.WithLabelValues(metrics_service.TypeEvent).Inc()
+			logrus.Debugf("事件 在白名单中（IP：%s），跳过处理", whiteModel.IP)
+			continue
+		}
+
+		// 关联虚拟服务信息：查询诱捕IP和端口对应的虚拟服务配置
+		var hpModel models.HoneyPortModel
+		global.DB.Preload("ServiceModel").Find(&hpModel, "ip = ? and port = ?", data.DestIp, data.DestPort)
+		if hpModel.ID != 0 {
+			data.ServiceID = hpModel.ServiceID
+			data.ServiceName = hpModel.ServiceModel.Title
+		}
+		data.Addr = core.GetIpAddr(data.SrcIp)
+
+		// 以消息标识作为文档ID，重复上报的事件覆盖同一文档
+		start := time.Now()
+		_, err1 := global.ES.Index().Index(data.Index()).Id(data.ID).BodyJson(data).Do(context.Background())
+		metrics_service.ESIndexSeconds.WithLabelValues(metrics_service.TypeEvent).Observe(time.Since(start).Seconds())
+		if err1 != nil {
+			metrics_service.IngestErrors.WithLabelValues(metrics_service.TypeEvent, metrics_service.ReasonES).Inc()
+			logrus.Errorf("事件入库失败 %s %s", err1, d.Body)
+			continue
+		}
+		logrus.Debugf("事件入库成功 %s %d %s:%d => %s:%d", data.EventType, data.FlowID, data.SrcIp, data.SrcPort, data.DestIp, data.DestPort)
+	}
+}
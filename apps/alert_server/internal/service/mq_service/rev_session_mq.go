@@ -0,0 +1,78 @@
+package mq_service
+
+// File: alert_server/service/mq_service/rev_session_mq.go
+// Description: MQ会话记录消费模块，负责监听会话队列、解析节点上报的隧道会话、关联虚拟服务信息后写入ES
+
+import (
+	"alert_server/internal/core"
+	"alert_server/internal/es_models"
+	"alert_server/internal/global"
+	"alert_server/internal/models"
+	"alert_server/internal/service/metrics_service"
+	"alert_server/internal/utils/ip"
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RevSessionMq 消费MQ会话队列消息，核心流程：监听队列→解析消息→关联虚拟服务信息→写入ES
+func RevSessionMq() {
+	cfg := global.Config.Alert
+	// 注册MQ消费者，监听会话记录队列
+	msgs, err := global.Queue.Consume(
+		cfg.SessionTopic, // 消费队列名称：从配置读取会话记录队列主题
+		"",               // 消费者标识：空字符串表示使用默认标识
+		true,             // 自动确认：消息处理后自动向MQ发送ACK确认
+		false,            // 排他性：false表示非排他消费
+		false,            // 非本地：false表示接受非本地队列的消息
+		false,            // 非阻塞：false表示同步注册消费者
+		nil,              // 其他额外配置参数：无特殊配置
+	)
+	if err != nil {
+		log.Fatalf("无法注册消费者: %v", err)
+	}
+
+	for d := range msgs {
+		metrics_service.IngestTotal.WithLabelValues(metrics_service.TypeSession).Inc()
+		var data es_models.SessionModel
+		err = json.Unmarshal(d.Body, &data)
+		if err != nil {
+			metrics_service.IngestErrors.WithLabelValues(metrics_service.TypeSession, metrics_service.ReasonParse).Inc()
+			logrus.Errorf("消息格式解析失败 %s", err)
+			continue
+		}
+		// IPv6地址统一为标准格式，与白名单及诱捕端口记录保持一致
+		data.SrcIp = ip.NormalizeIP(data.SrcIp)
+		data.DestIp = ip.NormalizeIP(data.DestIp)
+		if data.ID == "" {
+			logrus.Errorf("会话记录缺少ID %s:%d => %s:%d", data.SrcIp, data.SrcPort, data.DestIp, data.DestPort)
+			continue
+		}
+
+		logrus.Infof("会话记录 %s %s:%d => %s:%d 上行%d字节 下行%d字节",
+			data.ID, data.SrcIp, data.SrcPort, data.DestIp, data.DestPort, data.BytesIn, data.BytesOut)
+
+		// 关联虚拟服务信息：查询诱捕IP和端口对应的虚拟服务配置
+		var hpModel models.HoneyPortModel
+		global.DB.Preload("ServiceModel").Find(&hpModel, "ip = ? and port = ?", data.DestIp, data.DestPort)
+		if hpModel.ID != 0 {
+			data.ServiceID = hpModel.ServiceID
+			data.ServiceName = hpModel.ServiceModel.Title
+		}
+		data.Addr = core.GetIpAddr(data.SrcIp)
+
+		// 以节点生成的会话ID作为ES文档ID，告警通过该ID引用会话记录
+		start := time.Now()
+		_, err1 := global.ES.Index().Index(data.Index()).Id(data.ID).BodyJson(data).Do(context.Background())
+		metrics_service.ESIndexSeconds.WithLabelValues(metrics_service.TypeSession).Observe(time.Since(start).Seconds())
+		if err1 != nil {
+			metrics_service.IngestErrors.WithLabelValues(metrics_service.TypeSession, metrics_service.ReasonES).Inc()
+			logrus.Errorf("会话记录入库失败 %s %s", data.ID, err1)
+			continue
+		}
+		logrus.Infof("会话记录入库成功 %s", data.ID)
+	}
+}
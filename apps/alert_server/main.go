@@ -5,14 +5,15 @@ import (
 	"alert_server/internal/flags"
 	"alert_server/internal/global"
 	"alert_server/internal/routers"
+	"alert_server/internal/service/metrics_service"
 	"alert_server/internal/service/mq_service"
 )
 
 func main() {
-	core.InitIPDB()                      // 初始化IP地址数据库
 	global.Config = core.ReadConfig()    // 读取配置文件
 	core.SetLogDefault()                 // 设置默认日志配置
 	global.Log = core.GetLogger()        // 获取日志实例
+	core.InitIPDB()                      // 初始化IP地址数据库
 	global.DB = core.GetDB()             // 获取MySQL数据库实例
 	global.Redis = core.GetRedisClient() // 获取Redis实例
 	global.ES = core.ConnectEs()         // 初始化ElasticSearch客户端
@@ -20,5 +21,6 @@ func main() {
 	global.Queue = core.InitMQ()         // 初始化消息队列
 	flags.Run()                          // 运行命令行参数
 	mq_service.Run()                     // 启动rabbitMQ服务
+	metrics_service.Run()                // 启动指标监听
 	routers.Run()                        // 启动路由
 }
@@ -14,6 +14,10 @@ type Config struct {
 	FilterNetworkList []string `yaml:"filterNetworkList"` // 网卡过滤列表
 	MQ                MQ       `yaml:"mq"`                // rabbitMQ配置信息
 	DB                DB       `yaml:"db"`                // 数据库配置信息
+	Suricata          Suricata `yaml:"suricata"`          // Suricata配置信息
+	Tunnel            Tunnel   `yaml:"tunnel"`            // TCP隧道配置信息
+	Limit             Limit    `yaml:"limit"`             // 诱捕端口连接限制配置信息
+	Metrics           Metrics  `yaml:"metrics"`           // Prometheus指标配置信息
 }
 
 // Logger 日志配置结构体
@@ -28,9 +32,48 @@ type System struct {
 	GrpcManageAddr string `yaml:"grpcManageAddr"` // gRPC管理服务监听地址
 	Network        string `yaml:"network"`        // 网卡
 	Uid            string `yaml:"uid"`            // 节点uid
+	Token          string `yaml:"token"`          // 节点接入令牌，安装脚本生成，有效时节点免审批接入
+	EnrollCode     string `yaml:"enrollCode"`     // 一次性接入码，管理员重置节点证书后生成，用于重新申请节点证书
 	EvePath        string `yaml:"evePath"`        // eve文件路径
 }
 
+// Suricata Suricata规则下发相关配置结构体
+type Suricata struct {
+	RulePath      string `yaml:"rulePath"`      // 管理端下发的规则文件路径
+	ThresholdPath string `yaml:"thresholdPath"` // 阈值配置文件路径
+	SocketPath    string `yaml:"socketPath"`    // Suricata unix socket路径
+}
+
+// Tunnel TCP隧道传输配置结构体
+type Tunnel struct {
+	Mux         bool `yaml:"mux"`         // 是否使用多路复用隧道（多个连接共用少量gRPC流）
+	StreamCount int  `yaml:"streamCount"` // 多路复用隧道的gRPC流数量
+}
+
+// Limit 诱捕端口连接限制配置结构体，数值为0表示不限制
+type Limit struct {
+	Port           LimitRule `yaml:"port"`           // 单个诱捕端口（诱捕ip:端口）的限制
+	HoneyIp        LimitRule `yaml:"honeyIp"`        // 单个诱捕ip的限制
+	SrcIp          LimitRule `yaml:"srcIp"`          // 单个来源ip的限制
+	IdleTimeout    int       `yaml:"idleTimeout"`    // 会话空闲超时（秒），双向均无数据超过该时间后断开
+	SessionTimeout int       `yaml:"sessionTimeout"` // 会话最长持续时间（秒）
+	Action         string    `yaml:"action"`         // 超出限制的连接处理方式 tarpit 保持连接不响应 reset 发送RST断开 drop 直接关闭
+	TarpitTime     int       `yaml:"tarpitTime"`     // tarpit方式下保持连接的时间（秒）
+	MaxTarpit      int       `yaml:"maxTarpit"`      // 同时处于tarpit的连接数上限，超出后按reset处理
+	ReportInterval int       `yaml:"reportInterval"` // 限制触发事件的汇总上报间隔（秒）
+}
+
+// Metrics Prometheus指标配置结构体
+type Metrics struct {
+	Addr string `yaml:"addr"` // /metrics监听地址，为空时不启动；诱捕ip均为本机地址，不可监听0.0.0.0
+}
+
+// LimitRule 单个限制范围的连接限制
+type LimitRule struct {
+	MaxConn int `yaml:"maxConn"` // 最大并发会话数
+	Rate    int `yaml:"rate"`    // 每秒最多新建连接数
+}
+
 // rabbitMQ 配置结构体
 type MQ struct {
 	User                          string `yaml:"user"`                          // 用户名
@@ -51,6 +94,10 @@ type MQ struct {
 	BatchUpdateDeployStatusTopic  string `yaml:"batchUpdateDeployStatusTopic"`  // 批量更新部署上报状态的topic
 	BatchRemoveDeployExchangeName string `yaml:"batchRemoveDeployExchangeName"` // 批量删除部署交换机名称
 	BatchRemoveDeployStatusTopic  string `yaml:"batchRemoveDeployStatusTopic"`  // 批量删除部署上报状态的topic
+	SessionTopic                  string `yaml:"sessionTopic"`                  // 会话记录上报的topic
+	EventTopic                    string `yaml:"eventTopic"`                    // Suricata事件上报的topic
+	OutboxMaxCount                int    `yaml:"outboxMaxCount"`                // 待发送消息最大保留条数，超出时丢弃最早的消息
+	OutboxMaxAge                  int    `yaml:"outboxMaxAge"`                  // 待发送消息最长保留时间（小时），超时的消息丢弃
 	InitMQ                        bool   `yaml:"initMQ"`                        // 是否初始化MQ
 }
 
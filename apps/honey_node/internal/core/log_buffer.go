@@ -0,0 +1,84 @@
+package core
+
+// File: honey_node/core/log_buffer.go
+// Description: 最近错误日志缓存，以环形缓冲区保存最近的警告及错误日志，供节点诊断报告使用
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logBufferSize 缓存的最大日志行数
+const logBufferSize = 200
+
+// logBufferHook 最近日志缓存钩子，仅处理警告及以上级别
+type logBufferHook struct {
+	mu    sync.Mutex // 缓冲区读写锁
+	lines []string   // 环形缓冲区
+	next  int        // 下一条日志写入位置
+	full  bool       // 缓冲区是否已写满
+}
+
+// logBuffer 全局共享的最近日志缓存，标准logger及GetLogger创建的logger共用
+var logBuffer = &logBufferHook{lines: make([]string, logBufferSize)}
+
+// Levels 指定钩子处理的日志级别范围（警告及以上）
+func (hook *logBufferHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel, logrus.WarnLevel}
+}
+
+// Fire 格式化日志并写入环形缓冲区
+func (hook *logBufferHook) Fire(entry *logrus.Entry) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s [%s]", entry.Time.Format(time.DateTime), entry.Level)
+	if entry.HasCaller() {
+		fmt.Fprintf(&b, " %s:%d", path.Base(entry.Caller.File), entry.Caller.Line)
+	}
+	b.WriteString(" " + entry.Message)
+
+	// 附加结构化字段，按字段名排序保证输出稳定
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		if key != "appName" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%v", key, entry.Data[key])
+	}
+
+	hook.mu.Lock()
+	hook.lines[hook.next] = b.String()
+	hook.next = (hook.next + 1) % logBufferSize
+	if hook.next == 0 {
+		hook.full = true
+	}
+	hook.mu.Unlock()
+	return nil
+}
+
+// RecentErrorLogs 返回最近n条警告及错误日志，按时间先后排列
+func RecentErrorLogs(n int) []string {
+	logBuffer.mu.Lock()
+	defer logBuffer.mu.Unlock()
+
+	count := logBuffer.next
+	if logBuffer.full {
+		count = logBufferSize
+	}
+	if n <= 0 || n > count {
+		n = count
+	}
+	list := make([]string, 0, n)
+	for i := n; i > 0; i-- {
+		list = append(list, logBuffer.lines[(logBuffer.next-i+logBufferSize)%logBufferSize])
+	}
+	return list
+}
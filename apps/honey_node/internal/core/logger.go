@@ -176,6 +176,8 @@ func GetLogger() *logrus.Entry {
 	logger.SetLevel(level)
 	// 添加自定义日志钩子（处理文件分割）
 	logger.AddHook(&MyHook{logPath: "logs"})
+	// 添加最近错误日志缓存钩子（供节点诊断使用）
+	logger.AddHook(logBuffer)
 
 	// 根据配置选择日志格式（JSON或自定义彩色格式）
 	if l.Format == "json" {
@@ -197,5 +199,6 @@ func SetLogDefault() {
 	l := global.Config.Logger
 	logrus.SetFormatter(&MyLog{})
 	logrus.SetReportCaller(true)
+	logrus.AddHook(logBuffer)
 	logrus.WithField("appName", l.AppName)
 }
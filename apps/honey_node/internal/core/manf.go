@@ -9,6 +9,7 @@ import (
 	_ "embed"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -89,6 +90,19 @@ func (db *OUIDatabase) LookupVendor(mac string) (string, bool) {
 	return vendor, exists
 }
 
+// VendorOUIList 查询厂商名称包含关键字（不区分大小写）的全部OUI，按OUI排序保证结果稳定
+func (db *OUIDatabase) VendorOUIList(keyword string) []string {
+	keyword = strings.ToUpper(keyword)
+	var list []string
+	for oui, vendor := range db.vendors {
+		if strings.Contains(strings.ToUpper(vendor), keyword) {
+			list = append(list, oui)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
 //go:embed oui.txt
 var oui []byte
 
@@ -111,3 +125,8 @@ func init() {
 func ManufQuery(mac string) (string, bool) {
 	return manufDB.LookupVendor(mac)
 }
+
+// ManufOUIList 对外提供的厂商OUI查询接口
+func ManufOUIList(keyword string) []string {
+	return manufDB.VendorOUIList(keyword)
+}
@@ -1,7 +1,7 @@
 package flags
 
 // File: honey_node/flags/clear.go
-// Description: 节点数据清理模块，提供节点全量数据重置能力，清空IP、端口、任务数据库记录的同时清理关联的网络接口资源
+// Description: 节点数据清理模块，提供节点全量数据重置能力，清空IP、端口、任务、VLAN子接口数据库记录的同时清理关联的网络接口资源
 
 import (
 	"honey_node/internal/global"
@@ -39,5 +39,17 @@ func Clear(log *logrus.Entry) {
 	if len(taskList) > 0 {
 		global.DB.Delete(&taskList) // 批量删除任务记录
 	}
+	global.DB.Where("1 = 1").Delete(&models.TaskItemModel{}) // 删除全部任务项记录
 	log.Infof("删除任务记录%d条", len(taskList))
+
+	// 4. 清理VLAN子接口及记录（诱捕ip的接口可能基于子接口创建，需在其之后删除）
+	var vlanList []models.VlanModel
+	global.DB.Find(&vlanList) // 查询所有VLAN子接口记录
+	for _, model := range vlanList {
+		ip_service.RemoveInterface(model.Name) // 移除VLAN子接口
+	}
+	if len(vlanList) > 0 {
+		global.DB.Delete(&vlanList) // 批量删除VLAN子接口记录
+	}
+	log.Infof("删除VLAN子接口记录%d条", len(vlanList))
 }
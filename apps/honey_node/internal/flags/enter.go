@@ -22,6 +22,7 @@ type FlagOptions struct {
 	Type    string // 功能子类型参数
 	Value   string // 功能参数值
 	Help    bool   // 帮助信息展示开关
+	Updater bool   // 升级服务开关（仅由升级服务容器使用）
 }
 
 // Options 全局命令行参数实例，存储解析后的参数数据
@@ -38,6 +39,7 @@ func init() {
 	flag.StringVar(&Options.Menu, "m", "", "菜单 user")
 	flag.StringVar(&Options.Type, "t", "", "类型 create list")
 	flag.StringVar(&Options.Value, "v", "", "值")
+	flag.BoolVar(&Options.Updater, "updater", false, "启动升级服务（由挂载docker.sock的升级服务容器调用，负责节点容器切换）")
 	flag.Parse() // 解析命令行参数
 
 	// 注册业务命令
@@ -49,10 +51,16 @@ func RegisterCommand() {
 	// 注册任务列表命令
 	tk := taskService{}
 	registerCommand("task", "list", "任务列表", tk.List)
+	registerCommand("task", "cancel", "取消任务 -v 任务ID", tk.Cancel)
+	registerCommand("task", "retry", "重试失败或已取消的任务 -v 任务ID", tk.Retry)
 
 	// 注册IP列表命令
 	is := ipService{}
 	registerCommand("ip", "list", "ip列表", is.List)
+
+	// 注册发件箱积压查看命令
+	ob := outboxService{}
+	registerCommand("outbox", "list", "待发送消息积压", ob.List)
 }
 
 // runBaseCommand 执行基础命令
@@ -17,6 +17,13 @@ func Migrate() {
 		&models.PortModel{},
 		&models.IpModel{},
 		&models.TaskModel{},
+		&models.TaskItemModel{},
+		&models.EmuLoginModel{},
+		&models.EveCheckpointModel{},
+		&models.OutboxModel{},
+		&models.UpgradeModel{},
+		&models.VlanModel{},
+		&models.CertModel{},
 	)
 	if err != nil {
 		logrus.Fatalf("表结构迁移失败 %s", err)
@@ -0,0 +1,42 @@
+package flags
+
+// File: honey_node/flags/outbox.go
+// Description: 提供发件箱相关的命令行标识（flag）操作，实现节点侧待发送MQ消息的积压统计及查看功能
+
+import (
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"time"
+)
+
+// outboxService 发件箱相关命令行操作服务结构体
+type outboxService struct{}
+
+// List 按队列统计积压的待发送消息，并打印最早的若干条消息
+func (outboxService) List() {
+	// 按队列分组统计积压条数及最早写入时间
+	type queueStat struct {
+		Queue  string
+		Count  int64
+		Oldest string
+	}
+	var statList []queueStat
+	global.DB.Model(&models.OutboxModel{}).
+		Select("queue, count(*) as count, min(created_at) as oldest").
+		Group("queue").Scan(&statList)
+	if len(statList) == 0 {
+		fmt.Println("发件箱为空")
+		return
+	}
+	for _, stat := range statList {
+		fmt.Printf("%s 积压 %d条 最早 %s\n", stat.Queue, stat.Count, stat.Oldest)
+	}
+
+	// 打印最早的待发送消息
+	var list []models.OutboxModel
+	global.DB.Order("id").Limit(20).Find(&list)
+	for _, model := range list {
+		fmt.Printf("%d %s %s %s 重试%d次 %s\n", model.ID, model.MessageID, model.CreatedAt.Format(time.DateTime), model.Queue, model.Retry, model.Body)
+	}
+}
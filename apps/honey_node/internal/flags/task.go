@@ -1,24 +1,51 @@
 package flags
 
 // File: honey_node/flags/task.go
-// Description: 提供任务相关的命令行标识（flag）操作，实现节点侧已执行任务列表的查询功能
+// Description: 提供任务相关的命令行标识（flag）操作，实现节点侧任务列表的查询及任务的取消、重试功能
 
 import (
 	"fmt"
-	"honey_node/internal/global"
-	"honey_node/internal/models"
+	"honey_node/internal/service/task_service"
+	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 // taskService 任务相关命令行操作服务结构体
 type taskService struct{}
 
-// List 查询并打印节点侧所有任务记录
+// List 查询并打印节点侧所有任务记录及执行进度
 func (taskService) List() {
-	// 查询数据库中所有任务记录
-	var list []models.TaskModel
-	global.DB.Find(&list)
+	list := task_service.List()
+	if len(list) == 0 {
+		fmt.Println("暂无任务")
+		return
+	}
 	// 遍历并格式化打印每条任务记录
 	for _, model := range list {
-		fmt.Printf("%#v\n", model)
+		fmt.Printf("%s %s 类型%d 状态%d 进度 %d/%d 失败%d %s\n", model.TaskID, model.CreatedAt.Format(time.DateTime),
+			model.Type, model.Status, model.Done, model.Total, model.Failed, model.ErrMsg)
+	}
+}
+
+// Cancel 取消指定任务，任务ID通过 -v 参数传入
+func (taskService) Cancel() {
+	if Options.Value == "" {
+		logrus.Fatalf("请使用 -v 指定任务ID")
+	}
+	if err := task_service.Cancel(Options.Value); err != nil {
+		logrus.Fatalf("取消任务失败 %s", err)
+	}
+	fmt.Printf("任务 %s 已取消\n", Options.Value)
+}
+
+// Retry 重试失败或已取消的任务，任务ID通过 -v 参数传入，由运行中的节点重新执行未完成的任务项
+func (taskService) Retry() {
+	if Options.Value == "" {
+		logrus.Fatalf("请使用 -v 指定任务ID")
+	}
+	if err := task_service.Retry(Options.Value); err != nil {
+		logrus.Fatalf("重试任务失败 %s", err)
 	}
+	fmt.Printf("任务 %s 已重新提交\n", Options.Value)
 }
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// CertModel 节点客户端证书，由管理端内置CA签发，用于gRPC双向认证时证明节点身份
+type CertModel struct {
+	Model
+	Serial   string    `gorm:"size:64" json:"serial"` // 证书序列号（十六进制）
+	CertPEM  string    `json:"certPEM"`               // PEM格式的证书
+	KeyPEM   string    `json:"-"`                     // PEM格式的私钥
+	NotAfter time.Time `json:"notAfter"`              // 证书过期时间
+}
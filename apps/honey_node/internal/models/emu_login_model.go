@@ -0,0 +1,13 @@
+package models
+
+// EmuLoginModel 模拟器登录尝试记录模型
+type EmuLoginModel struct {
+	Model
+	Emulator string `gorm:"size:16" json:"emulator"`  // 模拟器名称（ssh/telnet/redis/mysql/http）
+	SrcIp    string `gorm:"size:64" json:"srcIp"`     // 攻击源IP
+	SrcPort  int    `json:"srcPort"`                  // 攻击源端口
+	DestIp   string `gorm:"size:64" json:"destIp"`    // 诱捕IP
+	DestPort int    `json:"destPort"`                 // 诱捕端口
+	Username string `gorm:"size:256" json:"username"` // 登录用户名
+	Password string `gorm:"size:256" json:"password"` // 登录密码（mysql为口令摘要十六进制）
+}
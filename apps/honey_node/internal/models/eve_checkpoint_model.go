@@ -0,0 +1,9 @@
+package models
+
+// EveCheckpointModel Suricata Eve日志读取检查点模型，记录已处理到的文件inode及偏移，重启后从该位置继续读取
+type EveCheckpointModel struct {
+	Model
+	Path   string `gorm:"size:256;uniqueIndex" json:"path"` // 日志文件路径
+	Inode  uint64 `json:"inode"`                            // 日志文件inode，用于识别日志轮转
+	Offset int64  `json:"offset"`                           // 已处理完成的字节偏移（完整行末尾）
+}
@@ -0,0 +1,112 @@
+package models
+
+// EveEvent Suricata Eve日志中的非告警事件（flow/dns/http/tls/ssh/fileinfo），按事件类型填充对应的详情字段
+type EveEvent struct {
+	Timestamp string       `json:"timestamp"`          // 事件发生时间
+	FlowID    int64        `json:"flow_id"`            // 网络流唯一标识ID（与告警的flow_id一致）
+	InIface   string       `json:"in_iface"`           // 接收流量的网卡接口
+	EventType string       `json:"event_type"`         // 事件类型
+	SrcIp     string       `json:"src_ip"`             // 源IP地址
+	SrcPort   int          `json:"src_port"`           // 源端口
+	DestIp    string       `json:"dest_ip"`            // 目标IP地址
+	DestPort  int          `json:"dest_port"`          // 目标端口
+	Proto     string       `json:"proto"`              // 网络协议（如TCP、UDP）
+	AppProto  string       `json:"app_proto"`          // 应用层协议（如http、tls）
+	Flow      *EveFlow     `json:"flow,omitempty"`     // 网络流统计信息
+	Dns       *EveDns      `json:"dns,omitempty"`      // DNS请求/应答详情
+	Http      *EveHttp     `json:"http,omitempty"`     // HTTP请求详情
+	Tls       *EveTls      `json:"tls,omitempty"`      // TLS握手详情
+	Ssh       *EveSsh      `json:"ssh,omitempty"`      // SSH握手详情
+	Fileinfo  *EveFileinfo `json:"fileinfo,omitempty"` // 传输文件详情
+}
+
+// EveFlow 网络流统计信息
+type EveFlow struct {
+	PktsToserver  int64  `json:"pkts_toserver"`  // 发送到服务端的数据包数量
+	PktsToclient  int64  `json:"pkts_toclient"`  // 发送到客户端的数据包数量
+	BytesToserver int64  `json:"bytes_toserver"` // 发送到服务端的字节数
+	BytesToclient int64  `json:"bytes_toclient"` // 发送到客户端的字节数
+	Start         string `json:"start"`          // 流建立时间
+	End           string `json:"end"`            // 流结束时间
+	Age           int64  `json:"age"`            // 流持续时间（秒）
+	State         string `json:"state"`          // 流状态（如new、established、closed）
+	Reason        string `json:"reason"`         // 流结束原因（如timeout）
+	Alerted       bool   `json:"alerted"`        // 该流是否触发过告警
+}
+
+// EveDns DNS请求/应答详情
+type EveDns struct {
+	Type    string         `json:"type"`    // 记录类型 query 请求 answer 应答
+	Id      int            `json:"id"`      // DNS事务ID
+	Rrname  string         `json:"rrname"`  // 查询的域名
+	Rrtype  string         `json:"rrtype"`  // 查询的记录类型（如A、TXT）
+	Rcode   string         `json:"rcode"`   // 应答码（如NOERROR、NXDOMAIN）
+	Answers []EveDnsAnswer `json:"answers"` // 应答记录列表
+}
+
+// EveDnsAnswer DNS应答记录
+type EveDnsAnswer struct {
+	Rrname string `json:"rrname"` // 域名
+	Rrtype string `json:"rrtype"` // 记录类型
+	Ttl    int    `json:"ttl"`    // 存活时间
+	Rdata  string `json:"rdata"`  // 记录数据
+}
+
+// EveHttp HTTP请求详情
+type EveHttp struct {
+	Hostname        string `json:"hostname"`          // HTTP请求目标主机名
+	Url             string `json:"url"`               // HTTP请求URL
+	HttpUserAgent   string `json:"http_user_agent"`   // HTTP请求User-Agent头
+	HttpContentType string `json:"http_content_type"` // HTTP响应内容类型
+	HttpRefer       string `json:"http_refer"`        // HTTP请求Referer头
+	HttpMethod      string `json:"http_method"`       // HTTP请求方法（如GET、POST）
+	Protocol        string `json:"protocol"`          // HTTP协议版本（如HTTP/1.1）
+	Status          int    `json:"status"`            // HTTP响应状态码
+	Length          int64  `json:"length"`            // 响应数据长度
+}
+
+// EveTls TLS握手详情
+type EveTls struct {
+	Subject     string     `json:"subject"`        // 证书主题
+	Issuerdn    string     `json:"issuerdn"`       // 证书颁发者
+	Serial      string     `json:"serial"`         // 证书序列号
+	Fingerprint string     `json:"fingerprint"`    // 证书指纹
+	Sni         string     `json:"sni"`            // 客户端请求的服务器名称
+	Version     string     `json:"version"`        // TLS版本
+	Notbefore   string     `json:"notbefore"`      // 证书生效时间
+	Notafter    string     `json:"notafter"`       // 证书过期时间
+	Ja3         *EveFinger `json:"ja3,omitempty"`  // 客户端JA3指纹
+	Ja3s        *EveFinger `json:"ja3s,omitempty"` // 服务端JA3S指纹
+}
+
+// EveFinger 握手指纹（JA3/HASSH）
+type EveFinger struct {
+	Hash   string `json:"hash"`   // 指纹哈希
+	String string `json:"string"` // 指纹原始字符串
+}
+
+// EveSsh SSH握手详情
+type EveSsh struct {
+	Client *EveSshPeer `json:"client,omitempty"` // 客户端信息
+	Server *EveSshPeer `json:"server,omitempty"` // 服务端信息
+}
+
+// EveSshPeer SSH握手单端信息
+type EveSshPeer struct {
+	ProtoVersion    string     `json:"proto_version"`    // 协议版本
+	SoftwareVersion string     `json:"software_version"` // 软件版本（如OpenSSH_8.9）
+	Hassh           *EveFinger `json:"hassh,omitempty"`  // HASSH指纹
+}
+
+// EveFileinfo 传输文件详情
+type EveFileinfo struct {
+	Filename string `json:"filename"` // 文件名
+	Magic    string `json:"magic"`    // 文件类型描述
+	State    string `json:"state"`    // 传输状态（如CLOSED、TRUNCATED）
+	Gaps     bool   `json:"gaps"`     // 传输是否存在缺失
+	Stored   bool   `json:"stored"`   // 是否已落盘
+	Size     int64  `json:"size"`     // 文件大小
+	Md5      string `json:"md5"`      // MD5
+	Sha1     string `json:"sha1"`     // SHA1
+	Sha256   string `json:"sha256"`   // SHA256
+}
@@ -0,0 +1,10 @@
+package models
+
+// OutboxModel 待发送MQ消息模型，节点上报的消息先写入该表，收到MQ发布确认后删除，断线期间的消息在重连后按顺序补发
+type OutboxModel struct {
+	Model
+	MessageID string `gorm:"size:64" json:"messageID"` // 消息唯一标识，写入时生成，重复发布时保持不变，服务端用于去重
+	Queue     string `gorm:"size:128" json:"queue"`    // 目标队列名称
+	Body      string `json:"body"`                     // 消息体（JSON）
+	Retry     int    `json:"retry"`                    // 发送失败次数
+}
@@ -3,6 +3,7 @@ package models
 // PortModel 端口映射模型
 type PortModel struct {
 	Model
-	LocalAddr  string `gorm:"size:64" json:"localAddr"`  // 本地监听地址（IP:Port）
-	TargetAddr string `gorm:"size:64" json:"targetAddr"` // 目标服务地址（DestIP:DestPort）
+	LocalAddr  string `gorm:"size:64" json:"localAddr"`   // 本地监听地址（IP:Port）
+	TargetAddr string `gorm:"size:64" json:"targetAddr"`  // 目标服务地址（DestIP:DestPort 或 emu://<模拟器名称>）
+	Agreement  int8   `gorm:"default:1" json:"agreement"` // 通信协议 1 TCP 2 UDP
 }
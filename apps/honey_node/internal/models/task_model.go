@@ -1,16 +1,36 @@
 package models
 
-import "fmt"
+import (
+	"net"
+	"strconv"
+)
 
 // TaskModel 任务模型
 type TaskModel struct {
 	Model
-	TaskID                string                    `json:"taskID"`                                       // 任务ID
-	Type                  int8                      `json:"type"`                                         // 任务类型 1 批量部署
+	TaskID                string                    `gorm:"index" json:"taskID"`                          // 任务ID
+	Type                  int8                      `json:"type"`                                         // 任务类型 1 批量部署 2 批量更新部署 3 批量删除部署 4 端口绑定 5 网络扫描
+	LogID                 string                    `json:"logID"`                                        // 日志ID
 	BatchDeployData       *BatchDeployRequest       `gorm:"serializer:json" json:"batchDeployData"`       // 批量部署参数 值 json字符串
 	BatchUpdateDeployData *BatchUpdateDeployRequest `gorm:"serializer:json" json:"batchUpdateDeployData"` // 批量更新部署参数 值 json字符串
 	BatchRemoveDeployData *BatchRemoveDeployRequest `gorm:"serializer:json" json:"batchRemoveDeployData"` // 批量删除部署参数 值 json字符串
-	Status                int8                      `json:"status"`                                       // 任务状态 0 运行中 1 运行完成
+	BindPortData          *BindPortRequest          `gorm:"serializer:json" json:"bindPortData"`          // 端口绑定参数 值 json字符串
+	NetScanData           *NetScanRequest           `gorm:"serializer:json" json:"netScanData"`           // 网络扫描参数 值 json字符串
+	Total                 int                       `json:"total"`                                        // 任务项总数
+	Done                  int                       `json:"done"`                                         // 已完成的任务项数
+	Failed                int                       `json:"failed"`                                       // 失败（重试耗尽或取消）的任务项数
+	ErrMsg                string                    `json:"errMsg"`                                       // 错误信息
+	Status                int8                      `json:"status"`                                       // 任务状态 0 运行中 1 运行完成 2 失败 3 已取消
+}
+
+// TaskItemModel 任务项模型，记录任务中每一项（如单个ip）的执行进度，节点重启后仅恢复未完成的任务项
+type TaskItemModel struct {
+	Model
+	TaskID  string `gorm:"index" json:"taskID"` // 所属任务ID
+	Item    string `json:"item"`                // 任务项标识（ip）
+	Status  int8   `json:"status"`              // 任务项状态 0 待执行 1 完成 2 失败 3 已取消
+	Attempt int    `json:"attempt"`             // 已执行次数
+	ErrMsg  string `json:"errMsg"`              // 最近一次执行的错误信息
 }
 
 // BatchDeployRequest MQ消费的批量部署请求结构体
@@ -38,6 +58,26 @@ type BatchRemoveDeployRequest struct {
 	IPList []RemoveDeployIp `json:"ipList"` // 待删除IP列表
 }
 
+// BindPortRequest MQ消费的端口绑定请求结构体
+type BindPortRequest struct {
+	IP        string     `json:"ip"`        // 待绑定端口的目标IP地址
+	HoneyIpID uint       `json:"honeyIpID"` // 关联的诱捕IP ID，用于上报状态时关联管理服务的诱捕IP记录
+	PortList  []PortInfo `json:"portList"`  // 端口配置列表，包含待绑定的端口号、本地/目标地址等信息
+	LogID     string     `json:"logID"`     // 日志ID，用于关联端口绑定操作的全链路日志
+}
+
+// NetScanRequest 管理端下发的网络扫描请求结构体
+type NetScanRequest struct {
+	NetID        uint     `json:"netID"`        // 子网ID
+	Network      string   `json:"network"`      // 扫描使用的网卡
+	IpRange      string   `json:"ipRange"`      // 扫描ip范围
+	FilterIPList []string `json:"filterIPList"` // 跳过扫描的ip（已部署的诱捕ip）
+	ServiceScan  bool     `json:"serviceScan"`  // 是否对存活主机进行端口服务探测
+	ScanMode     int      `json:"scanMode"`     // 端口探测方式 1 TCP connect 2 SYN
+	PortList     []int    `json:"portList"`     // 探测端口列表
+	Timeout      int      `json:"timeout"`      // 单个端口探测超时（毫秒）
+}
+
 // RemoveDeployIp 单IP删除配置信息结构体
 type RemoveDeployIp struct {
 	Ip       string `json:"ip"`       // 待删除的IP地址
@@ -46,25 +86,38 @@ type RemoveDeployIp struct {
 
 // DeployIp 单IP部署配置信息结构体
 type DeployIp struct {
-	Ip       string     `json:"ip"`       // 待部署的诱捕IP地址
-	Mask     int8       `json:"mask"`     // IP子网掩码
-	PortList []PortInfo `json:"portList"` // 该IP关联的端口转发配置列表
+	Ip            string       `json:"ip"`            // 待部署的诱捕IP地址
+	Mask          int8         `json:"mask"`          // IP子网掩码
+	DeviceProfile string       `json:"deviceProfile"` // 设备画像（为空时MAC地址由系统随机分配）
+	DeviceVendor  DeviceVendor `json:"deviceVendor"`  // 设备画像的厂商信息（由管理端随部署指令下发）
+	PortList      []PortInfo   `json:"portList"`      // 该IP关联的端口转发配置列表
+}
+
+// DeviceVendor 设备画像的厂商信息
+type DeviceVendor struct {
+	VendorList  []string `json:"vendorList"`  // 厂商名称关键字，用于在OUI数据库中匹配厂商
+	FallbackOUI []string `json:"fallbackOUI"` // OUI数据库未匹配到时使用的内置OUI
 }
 
 // PortInfo 端口信息结构体
 type PortInfo struct {
-	IP       string `json:"ip"`       // 源ip
-	Port     int    `json:"port"`     // 源端口
-	DestIP   string `json:"destIP"`   // 目标ip
-	DestPort int    `json:"destPort"` // 目标端口
+	IP        string `json:"ip"`        // 源ip
+	Port      int    `json:"port"`      // 源端口
+	DestIP    string `json:"destIP"`    // 目标ip
+	DestPort  int    `json:"destPort"`  // 目标端口
+	Agreement int8   `json:"agreement"` // 通信协议 1 TCP 2 UDP
+	Emulator  string `json:"emulator"`  // 内置模拟器名称，非空时不转发到目标服务
 }
 
-// LocalAddr 本地监听地址
+// LocalAddr 本地监听地址，IPv6地址带方括号
 func (p PortInfo) LocalAddr() string {
-	return fmt.Sprintf("%s:%d", p.IP, p.Port)
+	return net.JoinHostPort(p.IP, strconv.Itoa(p.Port))
 }
 
-// TargetAddr 目标服务地址
+// TargetAddr 目标服务地址，使用内置模拟器时为 emu://<模拟器名称>
 func (p PortInfo) TargetAddr() string {
-	return fmt.Sprintf("%s:%d", p.DestIP, p.DestPort)
+	if p.Emulator != "" {
+		return "emu://" + p.Emulator
+	}
+	return net.JoinHostPort(p.DestIP, strconv.Itoa(p.DestPort))
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// UpgradeModel 节点升级切换记录，旧版本下载新镜像后写入，由升级服务导入镜像并执行新旧容器切换，新版本注册成功后确认
+type UpgradeModel struct {
+	Model
+	UpgradeID     uint      `json:"upgradeID"`                     // 管理端升级记录ID
+	FromVersion   string    `gorm:"size:64" json:"fromVersion"`    // 升级前版本
+	ToVersion     string    `gorm:"size:64" json:"toVersion"`      // 目标版本
+	ToImage       string    `gorm:"size:256" json:"toImage"`       // 目标镜像（镜像名:标签）
+	ImageID       string    `gorm:"size:128" json:"imageID"`       // 目标镜像ID，升级服务导入镜像后校验
+	ContainerID   string    `gorm:"size:64" json:"containerID"`    // 升级前的节点容器ID，由升级服务写入
+	ContainerName string    `gorm:"size:128" json:"containerName"` // 节点容器名称，切换后新容器沿用
+	Deadline      time.Time `json:"deadline"`                      // 新版本注册的截止时间
+	Status        int8      `json:"status"`                        // 切换状态 1 切换中 2 升级成功 3 已回滚 4 回滚失败
+	ErrMsg        string    `gorm:"size:256" json:"errMsg"`        // 错误信息
+}
@@ -0,0 +1,11 @@
+package models
+
+// VlanModel VLAN子接口模型
+type VlanModel struct {
+	Model
+	Name    string `json:"name"`    // 子接口名称
+	Network string `json:"network"` // 父网卡名称（trunk口）
+	VlanID  int    `json:"vlanID"`  // VLAN ID 1-4094
+	Ip      string `json:"ip"`      // 子接口ip
+	Mask    int8   `json:"mask"`    // 子网掩码
+}
@@ -1,26 +1,101 @@
 package rpc
 
 // File: honey_node/rpc/enter.go
-// Description: gRPC连接管理工具，封装基于TLS双向认证的gRPC连接创建逻辑，提供安全的连接建立入口
+// Description: gRPC连接管理工具，封装基于TLS双向认证的gRPC连接创建逻辑，提供安全的连接建立入口；支持运行中切换客户端证书并重建底层连接
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
+// maxRecvMsgSize 单条gRPC消息的最大接收字节数
+const maxRecvMsgSize = 64 << 20
+
+var (
+	certLock   sync.RWMutex
+	clientCert *tls.Certificate // 当前使用的客户端证书，未设置节点证书时为镜像内置的引导证书
+
+	connLock sync.Mutex
+	connMap  = map[*trackConn]struct{}{} // 已建立的底层连接，切换证书后关闭以重新握手
+)
+
+// SetCert 设置客户端证书，之后新建立的连接使用该证书
+func SetCert(cert tls.Certificate) {
+	certLock.Lock()
+	clientCert = &cert
+	certLock.Unlock()
+}
+
+// getClientCertificate TLS握手时返回当前客户端证书
+func getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	certLock.RLock()
+	defer certLock.RUnlock()
+	return clientCert, nil
+}
+
+// trackConn 记录存活状态的底层连接
+type trackConn struct {
+	net.Conn
+	once sync.Once
+}
+
+// Close 关闭连接并移出记录
+func (c *trackConn) Close() error {
+	c.once.Do(func() {
+		connLock.Lock()
+		delete(connMap, c)
+		connLock.Unlock()
+	})
+	return c.Conn.Close()
+}
+
+// dial 建立底层TCP连接并记录
+func dial(ctx context.Context, addr string) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &trackConn{Conn: conn}
+	connLock.Lock()
+	connMap[c] = struct{}{}
+	connLock.Unlock()
+	return c, nil
+}
+
+// Reconnect 关闭已建立的底层连接，gRPC自动重连并使用当前客户端证书重新握手，进行中的流随之断开
+func Reconnect() {
+	connLock.Lock()
+	list := make([]*trackConn, 0, len(connMap))
+	for c := range connMap {
+		list = append(list, c)
+	}
+	connLock.Unlock()
+	for _, c := range list {
+		c.Close()
+	}
+}
+
 // GetConn 创建基于TLS双向认证的gRPC客户端连接
 func GetConn(addr string) (conn *grpc.ClientConn) {
-	// 客户端证书加载（用于服务端验证客户端身份）
-	cert, err := tls.LoadX509KeyPair("cert/client.crt", "cert/client.key")
-	if err != nil {
-		logrus.Fatalf("failed to load client key pair: %v", err)
+	// 未设置节点证书时加载引导证书（仅用于申请节点证书）
+	certLock.Lock()
+	if clientCert == nil {
+		cert, err := tls.LoadX509KeyPair("cert/client.crt", "cert/client.key")
+		if err != nil {
+			logrus.Fatalf("failed to load client key pair: %v", err)
+		}
+		clientCert = &cert
 	}
+	certLock.Unlock()
 
 	// CA根证书加载（用于客户端验证服务端证书合法性）
 	caCert, err := ioutil.ReadFile("cert/ca.crt")
@@ -33,15 +108,17 @@ func GetConn(addr string) (conn *grpc.ClientConn) {
 
 	// TLS双向认证配置
 	config := &tls.Config{
-		Certificates: []tls.Certificate{cert}, // 客户端证书链（包含公钥+私钥）
-		RootCAs:      caCertPool,              // 信任的CA根证书池（用于服务端证书验证）
+		GetClientCertificate: getClientCertificate, // 客户端证书（握手时取当前证书，支持证书轮换）
+		RootCAs:              caCertPool,           // 信任的CA根证书池（用于服务端证书验证）
 	}
 
 	// 将TLS配置转换为gRPC可识别的传输凭证
 	creds := credentials.NewTLS(config)
 
 	// 建立gRPC连接
-	conn, err = grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	// 规则集等命令体积较大，放宽单条消息的接收上限
+	conn, err = grpc.NewClient(addr, grpc.WithTransportCredentials(creds), grpc.WithContextDialer(dial),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRecvMsgSize)))
 	if err != nil {
 		logrus.Fatalf(fmt.Sprintf("grpc connect addr [%s] 连接失败 %s", addr, err))
 	}
@@ -25,22 +25,49 @@ const (
 type CmdType int32
 
 const (
-	CmdType_cmdNetworkFlushType CmdType = 0 // 网卡刷新
-	CmdType_cmdNetScanType      CmdType = 1 // 扫描网卡
-	CmdType_cmdNodeRemoveType   CmdType = 2 // 删除节点
+	CmdType_cmdNetworkFlushType CmdType = 0  // 网卡刷新
+	CmdType_cmdNetScanType      CmdType = 1  // 扫描网卡
+	CmdType_cmdNodeRemoveType   CmdType = 2  // 删除节点
+	CmdType_cmdPcapCaptureType  CmdType = 3  // 抓包
+	CmdType_cmdSyncStateType    CmdType = 4  // 全量状态同步
+	CmdType_cmdRuleSetType      CmdType = 5  // 规则集下发
+	CmdType_cmdNodeUpgradeType  CmdType = 6  // 节点升级
+	CmdType_cmdDiagnoseType     CmdType = 7  // 节点诊断
+	CmdType_cmdDiscoveryType    CmdType = 8  // 被动资产发现配置
+	CmdType_cmdVlanType         CmdType = 9  // VLAN子接口管理
+	CmdType_cmdTaskType         CmdType = 10 // 节点任务管理
+	CmdType_cmdCertRotateType   CmdType = 11 // 节点证书轮换
 )
 
 // Enum value maps for CmdType.
 var (
 	CmdType_name = map[int32]string{
-		0: "cmdNetworkFlushType",
-		1: "cmdNetScanType",
-		2: "cmdNodeRemoveType",
+		0:  "cmdNetworkFlushType",
+		1:  "cmdNetScanType",
+		2:  "cmdNodeRemoveType",
+		3:  "cmdPcapCaptureType",
+		4:  "cmdSyncStateType",
+		5:  "cmdRuleSetType",
+		6:  "cmdNodeUpgradeType",
+		7:  "cmdDiagnoseType",
+		8:  "cmdDiscoveryType",
+		9:  "cmdVlanType",
+		10: "cmdTaskType",
+		11: "cmdCertRotateType",
 	}
 	CmdType_value = map[string]int32{
 		"cmdNetworkFlushType": 0,
 		"cmdNetScanType":      1,
 		"cmdNodeRemoveType":   2,
+		"cmdPcapCaptureType":  3,
+		"cmdSyncStateType":    4,
+		"cmdRuleSetType":      5,
+		"cmdNodeUpgradeType":  6,
+		"cmdDiagnoseType":     7,
+		"cmdDiscoveryType":    8,
+		"cmdVlanType":         9,
+		"cmdTaskType":         10,
+		"cmdCertRotateType":   11,
 	}
 )
 
@@ -135,6 +162,7 @@ type RegisterRequest struct {
 	SystemInfo    *SystemInfoMessage     `protobuf:"bytes,6,opt,name=systemInfo,proto3" json:"systemInfo,omitempty"`          // 系统信息
 	ResourceInfo  *ResourceMessage       `protobuf:"bytes,7,opt,name=resourceInfo,proto3" json:"resourceInfo,omitempty"`      // 节点资源信息
 	NetworkList   []*NetworkInfoMessage  `protobuf:"bytes,8,rep,name=networkList,proto3" json:"networkList,omitempty"`        // 网卡信息
+	Token         string                 `protobuf:"bytes,9,opt,name=token,proto3" json:"token,omitempty"`                    // 节点接入令牌，有效时节点免审批接入
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -225,6 +253,13 @@ func (x *RegisterRequest) GetNetworkList() []*NetworkInfoMessage {
 	return nil
 }
 
+func (x *RegisterRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
 // 节点资源检测请求结构体
 type NodeResourceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -463,6 +498,11 @@ type NetworkInfoMessage struct {
 	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`           // 网卡ip
 	Net           string                 `protobuf:"bytes,3,opt,name=net,proto3" json:"net,omitempty"`         // 网卡所属子网
 	Mask          int32                  `protobuf:"varint,4,opt,name=mask,proto3" json:"mask,omitempty"`      // 网卡掩码
+	Ip6           string                 `protobuf:"bytes,5,opt,name=ip6,proto3" json:"ip6,omitempty"`         // 网卡IPv6地址（全局单播），无则为空
+	Net6          string                 `protobuf:"bytes,6,opt,name=net6,proto3" json:"net6,omitempty"`       // 网卡IPv6所属子网
+	Mask6         int32                  `protobuf:"varint,7,opt,name=mask6,proto3" json:"mask6,omitempty"`    // 网卡IPv6前缀长度
+	VlanID        int32                  `protobuf:"varint,8,opt,name=vlanID,proto3" json:"vlanID,omitempty"`  // VLAN ID，VLAN子接口才有
+	Parent        string                 `protobuf:"bytes,9,opt,name=parent,proto3" json:"parent,omitempty"`   // VLAN子接口所在的父网卡
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -525,6 +565,41 @@ func (x *NetworkInfoMessage) GetMask() int32 {
 	return 0
 }
 
+func (x *NetworkInfoMessage) GetIp6() string {
+	if x != nil {
+		return x.Ip6
+	}
+	return ""
+}
+
+func (x *NetworkInfoMessage) GetNet6() string {
+	if x != nil {
+		return x.Net6
+	}
+	return ""
+}
+
+func (x *NetworkInfoMessage) GetMask6() int32 {
+	if x != nil {
+		return x.Mask6
+	}
+	return 0
+}
+
+func (x *NetworkInfoMessage) GetVlanID() int32 {
+	if x != nil {
+		return x.VlanID
+	}
+	return 0
+}
+
+func (x *NetworkInfoMessage) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
 // 命令请求结构体
 type CmdRequest struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
@@ -534,6 +609,15 @@ type CmdRequest struct {
 	NetworkFlushInMessage *NetworkFlushInMessage `protobuf:"bytes,4,opt,name=NetworkFlushInMessage,proto3" json:"NetworkFlushInMessage,omitempty"` // 网卡刷新信息
 	NetScanInMessage      *NetScanInMessage      `protobuf:"bytes,5,opt,name=NetScanInMessage,proto3" json:"NetScanInMessage,omitempty"`           // 扫描网卡信息
 	NodeRemoveInMessage   *NodeRemoveInMessage   `protobuf:"bytes,6,opt,name=NodeRemoveInMessage,proto3" json:"NodeRemoveInMessage,omitempty"`     // 删除节点信息
+	PcapCaptureInMessage  *PcapCaptureInMessage  `protobuf:"bytes,7,opt,name=PcapCaptureInMessage,proto3" json:"PcapCaptureInMessage,omitempty"`   // 抓包信息
+	SyncStateInMessage    *SyncStateInMessage    `protobuf:"bytes,8,opt,name=SyncStateInMessage,proto3" json:"SyncStateInMessage,omitempty"`       // 全量状态同步信息
+	RuleSetInMessage      *RuleSetInMessage      `protobuf:"bytes,9,opt,name=RuleSetInMessage,proto3" json:"RuleSetInMessage,omitempty"`           // 规则集下发信息
+	NodeUpgradeInMessage  *NodeUpgradeInMessage  `protobuf:"bytes,10,opt,name=NodeUpgradeInMessage,proto3" json:"NodeUpgradeInMessage,omitempty"`  // 节点升级信息
+	DiagnoseInMessage     *DiagnoseInMessage     `protobuf:"bytes,11,opt,name=DiagnoseInMessage,proto3" json:"DiagnoseInMessage,omitempty"`        // 节点诊断信息
+	DiscoveryInMessage    *DiscoveryInMessage    `protobuf:"bytes,12,opt,name=DiscoveryInMessage,proto3" json:"DiscoveryInMessage,omitempty"`      // 被动资产发现配置信息
+	VlanInMessage         *VlanInMessage         `protobuf:"bytes,13,opt,name=VlanInMessage,proto3" json:"VlanInMessage,omitempty"`                // VLAN子接口管理信息
+	TaskInMessage         *TaskInMessage         `protobuf:"bytes,14,opt,name=TaskInMessage,proto3" json:"TaskInMessage,omitempty"`                // 节点任务管理信息
+	CertRotateInMessage   *CertRotateInMessage   `protobuf:"bytes,15,opt,name=CertRotateInMessage,proto3" json:"CertRotateInMessage,omitempty"`    // 节点证书轮换信息
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -610,6 +694,69 @@ func (x *CmdRequest) GetNodeRemoveInMessage() *NodeRemoveInMessage {
 	return nil
 }
 
+func (x *CmdRequest) GetPcapCaptureInMessage() *PcapCaptureInMessage {
+	if x != nil {
+		return x.PcapCaptureInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetSyncStateInMessage() *SyncStateInMessage {
+	if x != nil {
+		return x.SyncStateInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetRuleSetInMessage() *RuleSetInMessage {
+	if x != nil {
+		return x.RuleSetInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetNodeUpgradeInMessage() *NodeUpgradeInMessage {
+	if x != nil {
+		return x.NodeUpgradeInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetDiagnoseInMessage() *DiagnoseInMessage {
+	if x != nil {
+		return x.DiagnoseInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetDiscoveryInMessage() *DiscoveryInMessage {
+	if x != nil {
+		return x.DiscoveryInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetVlanInMessage() *VlanInMessage {
+	if x != nil {
+		return x.VlanInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetTaskInMessage() *TaskInMessage {
+	if x != nil {
+		return x.TaskInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetCertRotateInMessage() *CertRotateInMessage {
+	if x != nil {
+		return x.CertRotateInMessage
+	}
+	return nil
+}
+
 // 网卡刷新请求结构体
 type NetworkFlushInMessage struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -662,6 +809,10 @@ type NetScanInMessage struct {
 	IpRange       string                 `protobuf:"bytes,2,opt,name=ipRange,proto3" json:"ipRange,omitempty"`           // 扫描IP范围
 	FilterIPList  []string               `protobuf:"bytes,3,rep,name=filterIPList,proto3" json:"filterIPList,omitempty"` // IP过滤列表
 	NetID         uint32                 `protobuf:"varint,4,opt,name=netID,proto3" json:"netID,omitempty"`              // 网络ID
+	ServiceScan   bool                   `protobuf:"varint,5,opt,name=serviceScan,proto3" json:"serviceScan,omitempty"`  // 是否对存活主机进行端口服务探测
+	ScanMode      int32                  `protobuf:"varint,6,opt,name=scanMode,proto3" json:"scanMode,omitempty"`        // 端口探测方式 1 TCP connect 2 SYN
+	PortList      []int32                `protobuf:"varint,7,rep,packed,name=portList,proto3" json:"portList,omitempty"` // 探测端口列表
+	Timeout       int32                  `protobuf:"varint,8,opt,name=timeout,proto3" json:"timeout,omitempty"`          // 单个端口探测超时（毫秒）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -724,6 +875,34 @@ func (x *NetScanInMessage) GetNetID() uint32 {
 	return 0
 }
 
+func (x *NetScanInMessage) GetServiceScan() bool {
+	if x != nil {
+		return x.ServiceScan
+	}
+	return false
+}
+
+func (x *NetScanInMessage) GetScanMode() int32 {
+	if x != nil {
+		return x.ScanMode
+	}
+	return 0
+}
+
+func (x *NetScanInMessage) GetPortList() []int32 {
+	if x != nil {
+		return x.PortList
+	}
+	return nil
+}
+
+func (x *NetScanInMessage) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
 // 删除节点请求结构体
 type NodeRemoveInMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -761,28 +940,33 @@ func (*NodeRemoveInMessage) Descriptor() ([]byte, []int) {
 	return file_internal_rpc_node_proto_rawDescGZIP(), []int{9}
 }
 
-// 网卡刷新响应结构体
-type NetworkFlushOutMessage struct {
+// 抓包请求结构体
+type PcapCaptureInMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NetworkList   []*NetworkInfoMessage  `protobuf:"bytes,1,rep,name=networkList,proto3" json:"networkList,omitempty"` // 网卡信息
+	CaptureID     string                 `protobuf:"bytes,1,opt,name=captureID,proto3" json:"captureID,omitempty"` // 抓包任务id
+	Stop          bool                   `protobuf:"varint,2,opt,name=stop,proto3" json:"stop,omitempty"`          // true 停止抓包并上传 false 开始抓包
+	LinkName      string                 `protobuf:"bytes,3,opt,name=linkName,proto3" json:"linkName,omitempty"`   // 抓包接口名称（hy_开头的诱捕网卡），为空时按ip查找
+	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`               // 诱捕ip，仅保留与该ip相关的数据包
+	Duration      int32                  `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`  // 最长抓包时间（秒），到时自动停止并上传
+	MaxBytes      int64                  `protobuf:"varint,6,opt,name=maxBytes,proto3" json:"maxBytes,omitempty"`  // 环形缓冲区容量（字节），超出后丢弃最早的数据包
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NetworkFlushOutMessage) Reset() {
-	*x = NetworkFlushOutMessage{}
+func (x *PcapCaptureInMessage) Reset() {
+	*x = PcapCaptureInMessage{}
 	mi := &file_internal_rpc_node_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NetworkFlushOutMessage) String() string {
+func (x *PcapCaptureInMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NetworkFlushOutMessage) ProtoMessage() {}
+func (*PcapCaptureInMessage) ProtoMessage() {}
 
-func (x *NetworkFlushOutMessage) ProtoReflect() protoreflect.Message {
+func (x *PcapCaptureInMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_rpc_node_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -794,133 +978,77 @@ func (x *NetworkFlushOutMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NetworkFlushOutMessage.ProtoReflect.Descriptor instead.
-func (*NetworkFlushOutMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use PcapCaptureInMessage.ProtoReflect.Descriptor instead.
+func (*PcapCaptureInMessage) Descriptor() ([]byte, []int) {
 	return file_internal_rpc_node_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *NetworkFlushOutMessage) GetNetworkList() []*NetworkInfoMessage {
-	if x != nil {
-		return x.NetworkList
-	}
-	return nil
-}
-
-// 扫描网卡响应结构体
-type NetScanOutMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	End           bool                   `protobuf:"varint,1,opt,name=end,proto3" json:"end,omitempty"`            // 是否结束
-	Progress      float32                `protobuf:"fixed32,2,opt,name=progress,proto3" json:"progress,omitempty"` // 扫描进度
-	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`               // ip
-	Mac           string                 `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`             // mac地址
-	Manuf         string                 `protobuf:"bytes,5,opt,name=manuf,proto3" json:"manuf,omitempty"`         // mac对应厂商
-	NetID         uint32                 `protobuf:"varint,6,opt,name=netID,proto3" json:"netID,omitempty"`        // 网络id
-	ErrMsg        string                 `protobuf:"bytes,7,opt,name=errMsg,proto3" json:"errMsg,omitempty"`       // 错误信息
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *NetScanOutMessage) Reset() {
-	*x = NetScanOutMessage{}
-	mi := &file_internal_rpc_node_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *NetScanOutMessage) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*NetScanOutMessage) ProtoMessage() {}
-
-func (x *NetScanOutMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[11]
+func (x *PcapCaptureInMessage) GetCaptureID() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CaptureID
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use NetScanOutMessage.ProtoReflect.Descriptor instead.
-func (*NetScanOutMessage) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{11}
+	return ""
 }
 
-func (x *NetScanOutMessage) GetEnd() bool {
+func (x *PcapCaptureInMessage) GetStop() bool {
 	if x != nil {
-		return x.End
+		return x.Stop
 	}
 	return false
 }
 
-func (x *NetScanOutMessage) GetProgress() float32 {
-	if x != nil {
-		return x.Progress
-	}
-	return 0
-}
-
-func (x *NetScanOutMessage) GetIp() string {
-	if x != nil {
-		return x.Ip
-	}
-	return ""
-}
-
-func (x *NetScanOutMessage) GetMac() string {
+func (x *PcapCaptureInMessage) GetLinkName() string {
 	if x != nil {
-		return x.Mac
+		return x.LinkName
 	}
 	return ""
 }
 
-func (x *NetScanOutMessage) GetManuf() string {
+func (x *PcapCaptureInMessage) GetIp() string {
 	if x != nil {
-		return x.Manuf
+		return x.Ip
 	}
 	return ""
 }
 
-func (x *NetScanOutMessage) GetNetID() uint32 {
+func (x *PcapCaptureInMessage) GetDuration() int32 {
 	if x != nil {
-		return x.NetID
+		return x.Duration
 	}
 	return 0
 }
 
-func (x *NetScanOutMessage) GetErrMsg() string {
+func (x *PcapCaptureInMessage) GetMaxBytes() int64 {
 	if x != nil {
-		return x.ErrMsg
+		return x.MaxBytes
 	}
-	return ""
+	return 0
 }
 
-// 删除节点响应结构体
-type NodeRemoveOutMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// 全量状态同步请求结构体（管理端下发节点应有的全部诱捕ip及端口转发）
+type SyncStateInMessage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IpList          []*SyncIpInfo          `protobuf:"bytes,1,rep,name=ipList,proto3" json:"ipList,omitempty"`                   // 诱捕ip列表
+	SkipNetworkList []string               `protobuf:"bytes,2,rep,name=skipNetworkList,proto3" json:"skipNetworkList,omitempty"` // 跳过同步的网卡名称（所属子网正在操作中）
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *NodeRemoveOutMessage) Reset() {
-	*x = NodeRemoveOutMessage{}
-	mi := &file_internal_rpc_node_proto_msgTypes[12]
+func (x *SyncStateInMessage) Reset() {
+	*x = SyncStateInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NodeRemoveOutMessage) String() string {
+func (x *SyncStateInMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NodeRemoveOutMessage) ProtoMessage() {}
+func (*SyncStateInMessage) ProtoMessage() {}
 
-func (x *NodeRemoveOutMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[12]
+func (x *SyncStateInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -931,42 +1059,55 @@ func (x *NodeRemoveOutMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NodeRemoveOutMessage.ProtoReflect.Descriptor instead.
-func (*NodeRemoveOutMessage) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use SyncStateInMessage.ProtoReflect.Descriptor instead.
+func (*SyncStateInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{11}
 }
 
-// 命令响应结构体
-type CmdResponse struct {
-	state                  protoimpl.MessageState  `protogen:"open.v1"`
-	CmdType                CmdType                 `protobuf:"varint,1,opt,name=cmdType,proto3,enum=node_rpc.CmdType" json:"cmdType,omitempty"`        // 命令类型
-	TaskID                 string                  `protobuf:"bytes,2,opt,name=taskID,proto3" json:"taskID,omitempty"`                                 // 任务id
-	NodeID                 string                  `protobuf:"bytes,3,opt,name=nodeID,proto3" json:"nodeID,omitempty"`                                 // 节点id
-	Code                   int32                   `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`                                    // 状态码
-	ErrorMsg               string                  `protobuf:"bytes,5,opt,name=errorMsg,proto3" json:"errorMsg,omitempty"`                             // 错误信息
-	LogID                  string                  `protobuf:"bytes,6,opt,name=logID,proto3" json:"logID,omitempty"`                                   // 日志id
-	NetworkFlushOutMessage *NetworkFlushOutMessage `protobuf:"bytes,7,opt,name=NetworkFlushOutMessage,proto3" json:"NetworkFlushOutMessage,omitempty"` // 网卡刷新信息
-	NetScanOutMessage      *NetScanOutMessage      `protobuf:"bytes,8,opt,name=NetScanOutMessage,proto3" json:"NetScanOutMessage,omitempty"`           // 扫描网卡信息
-	NodeRemoveOutMessage   *NodeRemoveOutMessage   `protobuf:"bytes,9,opt,name=NodeRemoveOutMessage,proto3" json:"NodeRemoveOutMessage,omitempty"`     // 删除节点信息
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+func (x *SyncStateInMessage) GetIpList() []*SyncIpInfo {
+	if x != nil {
+		return x.IpList
+	}
+	return nil
 }
 
-func (x *CmdResponse) Reset() {
-	*x = CmdResponse{}
-	mi := &file_internal_rpc_node_proto_msgTypes[13]
+func (x *SyncStateInMessage) GetSkipNetworkList() []string {
+	if x != nil {
+		return x.SkipNetworkList
+	}
+	return nil
+}
+
+// 同步的诱捕ip信息
+type SyncIpInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoneyIpID     uint32                 `protobuf:"varint,1,opt,name=honeyIpID,proto3" json:"honeyIpID,omitempty"` // 诱捕ipID
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`                // 诱捕ip
+	Mask          int32                  `protobuf:"varint,3,opt,name=mask,proto3" json:"mask,omitempty"`           // 子网掩码
+	Network       string                 `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`      // 基础网卡名称
+	LinkName      string                 `protobuf:"bytes,5,opt,name=linkName,proto3" json:"linkName,omitempty"`    // 诱捕网卡名称（为空时由节点生成）
+	Mac           string                 `protobuf:"bytes,6,opt,name=mac,proto3" json:"mac,omitempty"`              // mac地址（为空时由系统分配）
+	IsTan         bool                   `protobuf:"varint,7,opt,name=isTan,proto3" json:"isTan,omitempty"`         // 是否是探针ip
+	PortList      []*SyncPortInfo        `protobuf:"bytes,8,rep,name=portList,proto3" json:"portList,omitempty"`    // 端口转发列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncIpInfo) Reset() {
+	*x = SyncIpInfo{}
+	mi := &file_internal_rpc_node_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CmdResponse) String() string {
+func (x *SyncIpInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CmdResponse) ProtoMessage() {}
+func (*SyncIpInfo) ProtoMessage() {}
 
-func (x *CmdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[13]
+func (x *SyncIpInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -977,101 +1118,2350 @@ func (x *CmdResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CmdResponse.ProtoReflect.Descriptor instead.
-func (*CmdResponse) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use SyncIpInfo.ProtoReflect.Descriptor instead.
+func (*SyncIpInfo) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *CmdResponse) GetCmdType() CmdType {
+func (x *SyncIpInfo) GetHoneyIpID() uint32 {
 	if x != nil {
-		return x.CmdType
+		return x.HoneyIpID
 	}
-	return CmdType_cmdNetworkFlushType
+	return 0
 }
 
-func (x *CmdResponse) GetTaskID() string {
+func (x *SyncIpInfo) GetIp() string {
 	if x != nil {
-		return x.TaskID
+		return x.Ip
 	}
 	return ""
 }
 
-func (x *CmdResponse) GetNodeID() string {
+func (x *SyncIpInfo) GetMask() int32 {
 	if x != nil {
-		return x.NodeID
+		return x.Mask
 	}
-	return ""
+	return 0
 }
 
-func (x *CmdResponse) GetCode() int32 {
+func (x *SyncIpInfo) GetNetwork() string {
 	if x != nil {
-		return x.Code
+		return x.Network
 	}
-	return 0
+	return ""
 }
 
-func (x *CmdResponse) GetErrorMsg() string {
+func (x *SyncIpInfo) GetLinkName() string {
+	if x != nil {
+		return x.LinkName
+	}
+	return ""
+}
+
+func (x *SyncIpInfo) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *SyncIpInfo) GetIsTan() bool {
+	if x != nil {
+		return x.IsTan
+	}
+	return false
+}
+
+func (x *SyncIpInfo) GetPortList() []*SyncPortInfo {
+	if x != nil {
+		return x.PortList
+	}
+	return nil
+}
+
+// 同步的端口转发信息
+type SyncPortInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Port          int64                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`           // 诱捕端口
+	Agreement     int32                  `protobuf:"varint,2,opt,name=agreement,proto3" json:"agreement,omitempty"` // 通信协议 1 TCP 2 UDP
+	DestIP        string                 `protobuf:"bytes,3,opt,name=destIP,proto3" json:"destIP,omitempty"`        // 目标转发ip
+	DestPort      int64                  `protobuf:"varint,4,opt,name=destPort,proto3" json:"destPort,omitempty"`   // 目标转发端口
+	Emulator      string                 `protobuf:"bytes,5,opt,name=emulator,proto3" json:"emulator,omitempty"`    // 内置模拟器名称，非空时不转发到目标服务
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncPortInfo) Reset() {
+	*x = SyncPortInfo{}
+	mi := &file_internal_rpc_node_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncPortInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPortInfo) ProtoMessage() {}
+
+func (x *SyncPortInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPortInfo.ProtoReflect.Descriptor instead.
+func (*SyncPortInfo) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SyncPortInfo) GetPort() int64 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *SyncPortInfo) GetAgreement() int32 {
+	if x != nil {
+		return x.Agreement
+	}
+	return 0
+}
+
+func (x *SyncPortInfo) GetDestIP() string {
+	if x != nil {
+		return x.DestIP
+	}
+	return ""
+}
+
+func (x *SyncPortInfo) GetDestPort() int64 {
+	if x != nil {
+		return x.DestPort
+	}
+	return 0
+}
+
+func (x *SyncPortInfo) GetEmulator() string {
+	if x != nil {
+		return x.Emulator
+	}
+	return ""
+}
+
+// 规则集下发请求结构体
+type RuleSetInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleSetID     uint32                 `protobuf:"varint,1,opt,name=ruleSetID,proto3" json:"ruleSetID,omitempty"` // 规则集id
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`      // 规则集版本（名称:v版本号）
+	Hash          string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`            // 规则内容摘要
+	Rules         []byte                 `protobuf:"bytes,4,opt,name=rules,proto3" json:"rules,omitempty"`          // gzip压缩的规则文件内容（已应用签名禁用配置）
+	Threshold     string                 `protobuf:"bytes,5,opt,name=threshold,proto3" json:"threshold,omitempty"`  // 阈值配置文件内容（threshold.config）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleSetInMessage) Reset() {
+	*x = RuleSetInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleSetInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleSetInMessage) ProtoMessage() {}
+
+func (x *RuleSetInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleSetInMessage.ProtoReflect.Descriptor instead.
+func (*RuleSetInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RuleSetInMessage) GetRuleSetID() uint32 {
+	if x != nil {
+		return x.RuleSetID
+	}
+	return 0
+}
+
+func (x *RuleSetInMessage) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RuleSetInMessage) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *RuleSetInMessage) GetRules() []byte {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *RuleSetInMessage) GetThreshold() string {
+	if x != nil {
+		return x.Threshold
+	}
+	return ""
+}
+
+// 节点升级请求结构体
+type NodeUpgradeInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpgradeID     uint32                 `protobuf:"varint,1,opt,name=upgradeID,proto3" json:"upgradeID,omitempty"` // 升级记录id
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`      // 目标版本（镜像标签）
+	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`          // 目标镜像（镜像名:标签）
+	ImageID       string                 `protobuf:"bytes,4,opt,name=imageID,proto3" json:"imageID,omitempty"`      // 镜像id
+	Url           string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`              // 镜像文件下载地址
+	FileSize      int64                  `protobuf:"varint,6,opt,name=fileSize,proto3" json:"fileSize,omitempty"`   // 镜像文件大小
+	Hash          string                 `protobuf:"bytes,7,opt,name=hash,proto3" json:"hash,omitempty"`            // 镜像文件sha256摘要
+	Timeout       int32                  `protobuf:"varint,8,opt,name=timeout,proto3" json:"timeout,omitempty"`     // 新版本重连超时时间（秒），超时未重连则回滚
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeUpgradeInMessage) Reset() {
+	*x = NodeUpgradeInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeUpgradeInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeUpgradeInMessage) ProtoMessage() {}
+
+func (x *NodeUpgradeInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeUpgradeInMessage.ProtoReflect.Descriptor instead.
+func (*NodeUpgradeInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NodeUpgradeInMessage) GetUpgradeID() uint32 {
+	if x != nil {
+		return x.UpgradeID
+	}
+	return 0
+}
+
+func (x *NodeUpgradeInMessage) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *NodeUpgradeInMessage) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *NodeUpgradeInMessage) GetImageID() string {
+	if x != nil {
+		return x.ImageID
+	}
+	return ""
+}
+
+func (x *NodeUpgradeInMessage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *NodeUpgradeInMessage) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *NodeUpgradeInMessage) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *NodeUpgradeInMessage) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+// 节点诊断请求结构体
+type DiagnoseInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LogLines      int32                  `protobuf:"varint,1,opt,name=logLines,proto3" json:"logLines,omitempty"` // 返回的最近错误日志行数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnoseInMessage) Reset() {
+	*x = DiagnoseInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnoseInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnoseInMessage) ProtoMessage() {}
+
+func (x *DiagnoseInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnoseInMessage.ProtoReflect.Descriptor instead.
+func (*DiagnoseInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DiagnoseInMessage) GetLogLines() int32 {
+	if x != nil {
+		return x.LogLines
+	}
+	return 0
+}
+
+// 被动资产发现配置请求结构体（全量下发，未包含的网卡停止监听）
+type DiscoveryInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkList   []*DiscoveryNetwork    `protobuf:"bytes,1,rep,name=networkList,proto3" json:"networkList,omitempty"` // 需要监听的网卡列表
+	VanishTime    int32                  `protobuf:"varint,2,opt,name=vanishTime,proto3" json:"vanishTime,omitempty"`  // 主机持续无流量超过该时间（秒）判定为离线
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoveryInMessage) Reset() {
+	*x = DiscoveryInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoveryInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveryInMessage) ProtoMessage() {}
+
+func (x *DiscoveryInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveryInMessage.ProtoReflect.Descriptor instead.
+func (*DiscoveryInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DiscoveryInMessage) GetNetworkList() []*DiscoveryNetwork {
+	if x != nil {
+		return x.NetworkList
+	}
+	return nil
+}
+
+func (x *DiscoveryInMessage) GetVanishTime() int32 {
+	if x != nil {
+		return x.VanishTime
+	}
+	return 0
+}
+
+// 被动资产发现监听的网卡
+type DiscoveryNetwork struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"` // 网卡名称
+	NetID         uint32                 `protobuf:"varint,2,opt,name=netID,proto3" json:"netID,omitempty"`    // 网络ID
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`           // 探针ip
+	Mask          int32                  `protobuf:"varint,4,opt,name=mask,proto3" json:"mask,omitempty"`      // 子网掩码
+	Ip6           string                 `protobuf:"bytes,5,opt,name=ip6,proto3" json:"ip6,omitempty"`         // 探针IPv6地址，无则为空
+	Mask6         int32                  `protobuf:"varint,6,opt,name=mask6,proto3" json:"mask6,omitempty"`    // IPv6前缀长度
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoveryNetwork) Reset() {
+	*x = DiscoveryNetwork{}
+	mi := &file_internal_rpc_node_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoveryNetwork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveryNetwork) ProtoMessage() {}
+
+func (x *DiscoveryNetwork) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveryNetwork.ProtoReflect.Descriptor instead.
+func (*DiscoveryNetwork) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DiscoveryNetwork) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *DiscoveryNetwork) GetNetID() uint32 {
+	if x != nil {
+		return x.NetID
+	}
+	return 0
+}
+
+func (x *DiscoveryNetwork) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *DiscoveryNetwork) GetMask() int32 {
+	if x != nil {
+		return x.Mask
+	}
+	return 0
+}
+
+func (x *DiscoveryNetwork) GetIp6() string {
+	if x != nil {
+		return x.Ip6
+	}
+	return ""
+}
+
+func (x *DiscoveryNetwork) GetMask6() int32 {
+	if x != nil {
+		return x.Mask6
+	}
+	return 0
+}
+
+// VLAN子接口管理请求结构体
+type VlanInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        int32                  `protobuf:"varint,1,opt,name=action,proto3" json:"action,omitempty"`  // 操作类型 1 创建 2 删除
+	Network       string                 `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"` // 父网卡名称（trunk口），创建时使用
+	VlanID        int32                  `protobuf:"varint,3,opt,name=vlanID,proto3" json:"vlanID,omitempty"`  // VLAN ID 1-4094，创建时使用
+	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`           // 子接口ip，创建时使用
+	Mask          int32                  `protobuf:"varint,5,opt,name=mask,proto3" json:"mask,omitempty"`      // 子接口子网掩码，创建时使用
+	Name          string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`       // 子接口名称，删除时使用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VlanInMessage) Reset() {
+	*x = VlanInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VlanInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VlanInMessage) ProtoMessage() {}
+
+func (x *VlanInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VlanInMessage.ProtoReflect.Descriptor instead.
+func (*VlanInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VlanInMessage) GetAction() int32 {
+	if x != nil {
+		return x.Action
+	}
+	return 0
+}
+
+func (x *VlanInMessage) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *VlanInMessage) GetVlanID() int32 {
+	if x != nil {
+		return x.VlanID
+	}
+	return 0
+}
+
+func (x *VlanInMessage) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *VlanInMessage) GetMask() int32 {
+	if x != nil {
+		return x.Mask
+	}
+	return 0
+}
+
+func (x *VlanInMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 节点任务管理请求结构体
+type TaskInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        int32                  `protobuf:"varint,1,opt,name=action,proto3" json:"action,omitempty"` // 操作类型 1 列表 2 取消 3 重试
+	TaskID        string                 `protobuf:"bytes,2,opt,name=taskID,proto3" json:"taskID,omitempty"`  // 任务id，取消及重试时使用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskInMessage) Reset() {
+	*x = TaskInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskInMessage) ProtoMessage() {}
+
+func (x *TaskInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskInMessage.ProtoReflect.Descriptor instead.
+func (*TaskInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TaskInMessage) GetAction() int32 {
+	if x != nil {
+		return x.Action
+	}
+	return 0
+}
+
+func (x *TaskInMessage) GetTaskID() string {
+	if x != nil {
+		return x.TaskID
+	}
+	return ""
+}
+
+// 节点证书轮换请求结构体
+type CertRotateInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CertRotateInMessage) Reset() {
+	*x = CertRotateInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CertRotateInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CertRotateInMessage) ProtoMessage() {}
+
+func (x *CertRotateInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CertRotateInMessage.ProtoReflect.Descriptor instead.
+func (*CertRotateInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{21}
+}
+
+// 网卡刷新响应结构体
+type NetworkFlushOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkList   []*NetworkInfoMessage  `protobuf:"bytes,1,rep,name=networkList,proto3" json:"networkList,omitempty"` // 网卡信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkFlushOutMessage) Reset() {
+	*x = NetworkFlushOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkFlushOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkFlushOutMessage) ProtoMessage() {}
+
+func (x *NetworkFlushOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkFlushOutMessage.ProtoReflect.Descriptor instead.
+func (*NetworkFlushOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *NetworkFlushOutMessage) GetNetworkList() []*NetworkInfoMessage {
+	if x != nil {
+		return x.NetworkList
+	}
+	return nil
+}
+
+// 扫描网卡响应结构体
+type NetScanOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	End           bool                   `protobuf:"varint,1,opt,name=end,proto3" json:"end,omitempty"`            // 是否结束
+	Progress      float32                `protobuf:"fixed32,2,opt,name=progress,proto3" json:"progress,omitempty"` // 扫描进度
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`               // ip
+	Mac           string                 `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`             // mac地址
+	Manuf         string                 `protobuf:"bytes,5,opt,name=manuf,proto3" json:"manuf,omitempty"`         // mac对应厂商
+	NetID         uint32                 `protobuf:"varint,6,opt,name=netID,proto3" json:"netID,omitempty"`        // 网络id
+	ErrMsg        string                 `protobuf:"bytes,7,opt,name=errMsg,proto3" json:"errMsg,omitempty"`       // 错误信息
+	PortList      []*NetScanPort         `protobuf:"bytes,8,rep,name=portList,proto3" json:"portList,omitempty"`   // 开放端口列表（开启端口服务探测时）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetScanOutMessage) Reset() {
+	*x = NetScanOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetScanOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetScanOutMessage) ProtoMessage() {}
+
+func (x *NetScanOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetScanOutMessage.ProtoReflect.Descriptor instead.
+func (*NetScanOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *NetScanOutMessage) GetEnd() bool {
+	if x != nil {
+		return x.End
+	}
+	return false
+}
+
+func (x *NetScanOutMessage) GetProgress() float32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *NetScanOutMessage) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *NetScanOutMessage) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *NetScanOutMessage) GetManuf() string {
+	if x != nil {
+		return x.Manuf
+	}
+	return ""
+}
+
+func (x *NetScanOutMessage) GetNetID() uint32 {
+	if x != nil {
+		return x.NetID
+	}
+	return 0
+}
+
+func (x *NetScanOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+func (x *NetScanOutMessage) GetPortList() []*NetScanPort {
+	if x != nil {
+		return x.PortList
+	}
+	return nil
+}
+
+// 扫描发现的开放端口
+type NetScanPort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Port          int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`    // 端口号
+	Banner        string                 `protobuf:"bytes,2,opt,name=banner,proto3" json:"banner,omitempty"` // 服务banner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetScanPort) Reset() {
+	*x = NetScanPort{}
+	mi := &file_internal_rpc_node_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetScanPort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetScanPort) ProtoMessage() {}
+
+func (x *NetScanPort) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetScanPort.ProtoReflect.Descriptor instead.
+func (*NetScanPort) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NetScanPort) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *NetScanPort) GetBanner() string {
+	if x != nil {
+		return x.Banner
+	}
+	return ""
+}
+
+// 删除节点响应结构体
+type NodeRemoveOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeRemoveOutMessage) Reset() {
+	*x = NodeRemoveOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeRemoveOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeRemoveOutMessage) ProtoMessage() {}
+
+func (x *NodeRemoveOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeRemoveOutMessage.ProtoReflect.Descriptor instead.
+func (*NodeRemoveOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{25}
+}
+
+// 抓包响应结构体
+type PcapCaptureOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaptureID     string                 `protobuf:"bytes,1,opt,name=captureID,proto3" json:"captureID,omitempty"` // 抓包任务id
+	ErrMsg        string                 `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`       // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PcapCaptureOutMessage) Reset() {
+	*x = PcapCaptureOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PcapCaptureOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PcapCaptureOutMessage) ProtoMessage() {}
+
+func (x *PcapCaptureOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PcapCaptureOutMessage.ProtoReflect.Descriptor instead.
+func (*PcapCaptureOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PcapCaptureOutMessage) GetCaptureID() string {
+	if x != nil {
+		return x.CaptureID
+	}
+	return ""
+}
+
+func (x *PcapCaptureOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 全量状态同步响应结构体
+type SyncStateOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IpList        []*SyncIpResult        `protobuf:"bytes,1,rep,name=ipList,proto3" json:"ipList,omitempty"`         // 期望诱捕ip的逐项同步结果
+	RemoveList    []*SyncIpResult        `protobuf:"bytes,2,rep,name=removeList,proto3" json:"removeList,omitempty"` // 节点上多余诱捕ip的删除结果
+	ErrMsg        string                 `protobuf:"bytes,3,opt,name=errMsg,proto3" json:"errMsg,omitempty"`         // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncStateOutMessage) Reset() {
+	*x = SyncStateOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncStateOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStateOutMessage) ProtoMessage() {}
+
+func (x *SyncStateOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStateOutMessage.ProtoReflect.Descriptor instead.
+func (*SyncStateOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SyncStateOutMessage) GetIpList() []*SyncIpResult {
+	if x != nil {
+		return x.IpList
+	}
+	return nil
+}
+
+func (x *SyncStateOutMessage) GetRemoveList() []*SyncIpResult {
+	if x != nil {
+		return x.RemoveList
+	}
+	return nil
+}
+
+func (x *SyncStateOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 单个诱捕ip的同步结果
+type SyncIpResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoneyIpID     uint32                 `protobuf:"varint,1,opt,name=honeyIpID,proto3" json:"honeyIpID,omitempty"` // 诱捕ipID
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`                // 诱捕ip
+	LinkName      string                 `protobuf:"bytes,3,opt,name=linkName,proto3" json:"linkName,omitempty"`    // 诱捕网卡名称
+	Mac           string                 `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`              // mac地址
+	ErrMsg        string                 `protobuf:"bytes,5,opt,name=errMsg,proto3" json:"errMsg,omitempty"`        // 错误信息
+	PortList      []*StatusPortInfo      `protobuf:"bytes,6,rep,name=portList,proto3" json:"portList,omitempty"`    // 端口转发结果
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncIpResult) Reset() {
+	*x = SyncIpResult{}
+	mi := &file_internal_rpc_node_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncIpResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncIpResult) ProtoMessage() {}
+
+func (x *SyncIpResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncIpResult.ProtoReflect.Descriptor instead.
+func (*SyncIpResult) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SyncIpResult) GetHoneyIpID() uint32 {
+	if x != nil {
+		return x.HoneyIpID
+	}
+	return 0
+}
+
+func (x *SyncIpResult) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *SyncIpResult) GetLinkName() string {
+	if x != nil {
+		return x.LinkName
+	}
+	return ""
+}
+
+func (x *SyncIpResult) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *SyncIpResult) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+func (x *SyncIpResult) GetPortList() []*StatusPortInfo {
+	if x != nil {
+		return x.PortList
+	}
+	return nil
+}
+
+// 规则集下发响应结构体
+type RuleSetOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleSetID     uint32                 `protobuf:"varint,1,opt,name=ruleSetID,proto3" json:"ruleSetID,omitempty"`     // 当前生效的规则集id
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`          // 当前生效的规则集版本
+	Hash          string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`                // 当前生效的规则内容摘要
+	RulesLoaded   int32                  `protobuf:"varint,4,opt,name=rulesLoaded,proto3" json:"rulesLoaded,omitempty"` // 加载成功的规则数
+	RulesFailed   int32                  `protobuf:"varint,5,opt,name=rulesFailed,proto3" json:"rulesFailed,omitempty"` // 加载失败的规则数
+	ErrList       []string               `protobuf:"bytes,6,rep,name=errList,proto3" json:"errList,omitempty"`          // 加载失败的规则及原因
+	ErrMsg        string                 `protobuf:"bytes,7,opt,name=errMsg,proto3" json:"errMsg,omitempty"`            // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleSetOutMessage) Reset() {
+	*x = RuleSetOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleSetOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleSetOutMessage) ProtoMessage() {}
+
+func (x *RuleSetOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleSetOutMessage.ProtoReflect.Descriptor instead.
+func (*RuleSetOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RuleSetOutMessage) GetRuleSetID() uint32 {
+	if x != nil {
+		return x.RuleSetID
+	}
+	return 0
+}
+
+func (x *RuleSetOutMessage) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RuleSetOutMessage) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *RuleSetOutMessage) GetRulesLoaded() int32 {
+	if x != nil {
+		return x.RulesLoaded
+	}
+	return 0
+}
+
+func (x *RuleSetOutMessage) GetRulesFailed() int32 {
+	if x != nil {
+		return x.RulesFailed
+	}
+	return 0
+}
+
+func (x *RuleSetOutMessage) GetErrList() []string {
+	if x != nil {
+		return x.ErrList
+	}
+	return nil
+}
+
+func (x *RuleSetOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 节点升级响应结构体
+type NodeUpgradeOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpgradeID     uint32                 `protobuf:"varint,1,opt,name=upgradeID,proto3" json:"upgradeID,omitempty"` // 升级记录id
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`       // 升级阶段 1 下载中 2 切换中 3 失败
+	Progress      float32                `protobuf:"fixed32,3,opt,name=progress,proto3" json:"progress,omitempty"`  // 下载进度
+	ErrMsg        string                 `protobuf:"bytes,4,opt,name=errMsg,proto3" json:"errMsg,omitempty"`        // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeUpgradeOutMessage) Reset() {
+	*x = NodeUpgradeOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeUpgradeOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeUpgradeOutMessage) ProtoMessage() {}
+
+func (x *NodeUpgradeOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeUpgradeOutMessage.ProtoReflect.Descriptor instead.
+func (*NodeUpgradeOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *NodeUpgradeOutMessage) GetUpgradeID() uint32 {
+	if x != nil {
+		return x.UpgradeID
+	}
+	return 0
+}
+
+func (x *NodeUpgradeOutMessage) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *NodeUpgradeOutMessage) GetProgress() float32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *NodeUpgradeOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 节点诊断响应结构体
+type DiagnoseOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        string                 `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"` // 诊断报告（JSON）
+	ErrMsg        string                 `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"` // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnoseOutMessage) Reset() {
+	*x = DiagnoseOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnoseOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnoseOutMessage) ProtoMessage() {}
+
+func (x *DiagnoseOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnoseOutMessage.ProtoReflect.Descriptor instead.
+func (*DiagnoseOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DiagnoseOutMessage) GetReport() string {
+	if x != nil {
+		return x.Report
+	}
+	return ""
+}
+
+func (x *DiagnoseOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 被动资产发现配置响应结构体
+type DiscoveryOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkList   []string               `protobuf:"bytes,1,rep,name=networkList,proto3" json:"networkList,omitempty"` // 监听中的网卡列表
+	ErrMsg        string                 `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`           // 错误信息（部分网卡启动监听失败）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoveryOutMessage) Reset() {
+	*x = DiscoveryOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoveryOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveryOutMessage) ProtoMessage() {}
+
+func (x *DiscoveryOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveryOutMessage.ProtoReflect.Descriptor instead.
+func (*DiscoveryOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DiscoveryOutMessage) GetNetworkList() []string {
+	if x != nil {
+		return x.NetworkList
+	}
+	return nil
+}
+
+func (x *DiscoveryOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// VLAN子接口管理响应结构体
+type VlanOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       *NetworkInfoMessage    `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"` // 创建的子接口网卡信息
+	ErrMsg        string                 `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`   // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VlanOutMessage) Reset() {
+	*x = VlanOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VlanOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VlanOutMessage) ProtoMessage() {}
+
+func (x *VlanOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VlanOutMessage.ProtoReflect.Descriptor instead.
+func (*VlanOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *VlanOutMessage) GetNetwork() *NetworkInfoMessage {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+func (x *VlanOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 节点任务信息结构体
+type TaskInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskID        string                 `protobuf:"bytes,1,opt,name=taskID,proto3" json:"taskID,omitempty"`       // 任务id
+	Type          int32                  `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`          // 任务类型 1 批量部署 2 批量更新部署 3 批量删除部署 4 端口绑定 5 网络扫描
+	Status        int32                  `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`      // 任务状态 0 运行中 1 运行完成 2 失败 3 已取消
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`        // 任务项总数
+	Done          int32                  `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`          // 已完成的任务项数
+	Failed        int32                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`      // 失败的任务项数
+	ErrMsg        string                 `protobuf:"bytes,7,opt,name=errMsg,proto3" json:"errMsg,omitempty"`       // 错误信息
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"` // 创建时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskInfo) Reset() {
+	*x = TaskInfo{}
+	mi := &file_internal_rpc_node_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskInfo) ProtoMessage() {}
+
+func (x *TaskInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskInfo.ProtoReflect.Descriptor instead.
+func (*TaskInfo) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TaskInfo) GetTaskID() string {
+	if x != nil {
+		return x.TaskID
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// 节点任务管理响应结构体
+type TaskOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskList      []*TaskInfo            `protobuf:"bytes,1,rep,name=taskList,proto3" json:"taskList,omitempty"` // 任务列表
+	ErrMsg        string                 `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`     // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskOutMessage) Reset() {
+	*x = TaskOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskOutMessage) ProtoMessage() {}
+
+func (x *TaskOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskOutMessage.ProtoReflect.Descriptor instead.
+func (*TaskOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TaskOutMessage) GetTaskList() []*TaskInfo {
+	if x != nil {
+		return x.TaskList
+	}
+	return nil
+}
+
+func (x *TaskOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 节点证书轮换响应结构体
+type CertRotateOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serial        string                 `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`     // 新证书序列号
+	NotAfter      string                 `protobuf:"bytes,2,opt,name=notAfter,proto3" json:"notAfter,omitempty"` // 新证书过期时间
+	ErrMsg        string                 `protobuf:"bytes,3,opt,name=errMsg,proto3" json:"errMsg,omitempty"`     // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CertRotateOutMessage) Reset() {
+	*x = CertRotateOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CertRotateOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CertRotateOutMessage) ProtoMessage() {}
+
+func (x *CertRotateOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CertRotateOutMessage.ProtoReflect.Descriptor instead.
+func (*CertRotateOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CertRotateOutMessage) GetSerial() string {
+	if x != nil {
+		return x.Serial
+	}
+	return ""
+}
+
+func (x *CertRotateOutMessage) GetNotAfter() string {
+	if x != nil {
+		return x.NotAfter
+	}
+	return ""
+}
+
+func (x *CertRotateOutMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 命令响应结构体
+type CmdResponse struct {
+	state                  protoimpl.MessageState  `protogen:"open.v1"`
+	CmdType                CmdType                 `protobuf:"varint,1,opt,name=cmdType,proto3,enum=node_rpc.CmdType" json:"cmdType,omitempty"`        // 命令类型
+	TaskID                 string                  `protobuf:"bytes,2,opt,name=taskID,proto3" json:"taskID,omitempty"`                                 // 任务id
+	NodeID                 string                  `protobuf:"bytes,3,opt,name=nodeID,proto3" json:"nodeID,omitempty"`                                 // 节点id
+	Code                   int32                   `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`                                    // 状态码
+	ErrorMsg               string                  `protobuf:"bytes,5,opt,name=errorMsg,proto3" json:"errorMsg,omitempty"`                             // 错误信息
+	LogID                  string                  `protobuf:"bytes,6,opt,name=logID,proto3" json:"logID,omitempty"`                                   // 日志id
+	NetworkFlushOutMessage *NetworkFlushOutMessage `protobuf:"bytes,7,opt,name=NetworkFlushOutMessage,proto3" json:"NetworkFlushOutMessage,omitempty"` // 网卡刷新信息
+	NetScanOutMessage      *NetScanOutMessage      `protobuf:"bytes,8,opt,name=NetScanOutMessage,proto3" json:"NetScanOutMessage,omitempty"`           // 扫描网卡信息
+	NodeRemoveOutMessage   *NodeRemoveOutMessage   `protobuf:"bytes,9,opt,name=NodeRemoveOutMessage,proto3" json:"NodeRemoveOutMessage,omitempty"`     // 删除节点信息
+	PcapCaptureOutMessage  *PcapCaptureOutMessage  `protobuf:"bytes,10,opt,name=PcapCaptureOutMessage,proto3" json:"PcapCaptureOutMessage,omitempty"`  // 抓包信息
+	SyncStateOutMessage    *SyncStateOutMessage    `protobuf:"bytes,11,opt,name=SyncStateOutMessage,proto3" json:"SyncStateOutMessage,omitempty"`      // 全量状态同步信息
+	RuleSetOutMessage      *RuleSetOutMessage      `protobuf:"bytes,12,opt,name=RuleSetOutMessage,proto3" json:"RuleSetOutMessage,omitempty"`          // 规则集下发信息
+	NodeUpgradeOutMessage  *NodeUpgradeOutMessage  `protobuf:"bytes,13,opt,name=NodeUpgradeOutMessage,proto3" json:"NodeUpgradeOutMessage,omitempty"`  // 节点升级信息
+	DiagnoseOutMessage     *DiagnoseOutMessage     `protobuf:"bytes,14,opt,name=DiagnoseOutMessage,proto3" json:"DiagnoseOutMessage,omitempty"`        // 节点诊断信息
+	DiscoveryOutMessage    *DiscoveryOutMessage    `protobuf:"bytes,15,opt,name=DiscoveryOutMessage,proto3" json:"DiscoveryOutMessage,omitempty"`      // 被动资产发现配置信息
+	VlanOutMessage         *VlanOutMessage         `protobuf:"bytes,16,opt,name=VlanOutMessage,proto3" json:"VlanOutMessage,omitempty"`                // VLAN子接口管理信息
+	TaskOutMessage         *TaskOutMessage         `protobuf:"bytes,17,opt,name=TaskOutMessage,proto3" json:"TaskOutMessage,omitempty"`                // 节点任务管理信息
+	CertRotateOutMessage   *CertRotateOutMessage   `protobuf:"bytes,18,opt,name=CertRotateOutMessage,proto3" json:"CertRotateOutMessage,omitempty"`    // 节点证书轮换信息
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CmdResponse) Reset() {
+	*x = CmdResponse{}
+	mi := &file_internal_rpc_node_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CmdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdResponse) ProtoMessage() {}
+
+func (x *CmdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdResponse.ProtoReflect.Descriptor instead.
+func (*CmdResponse) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CmdResponse) GetCmdType() CmdType {
+	if x != nil {
+		return x.CmdType
+	}
+	return CmdType_cmdNetworkFlushType
+}
+
+func (x *CmdResponse) GetTaskID() string {
+	if x != nil {
+		return x.TaskID
+	}
+	return ""
+}
+
+func (x *CmdResponse) GetNodeID() string {
+	if x != nil {
+		return x.NodeID
+	}
+	return ""
+}
+
+func (x *CmdResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *CmdResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-func (x *CmdResponse) GetLogID() string {
+func (x *CmdResponse) GetLogID() string {
+	if x != nil {
+		return x.LogID
+	}
+	return ""
+}
+
+func (x *CmdResponse) GetNetworkFlushOutMessage() *NetworkFlushOutMessage {
+	if x != nil {
+		return x.NetworkFlushOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetNetScanOutMessage() *NetScanOutMessage {
+	if x != nil {
+		return x.NetScanOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetNodeRemoveOutMessage() *NodeRemoveOutMessage {
+	if x != nil {
+		return x.NodeRemoveOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetPcapCaptureOutMessage() *PcapCaptureOutMessage {
+	if x != nil {
+		return x.PcapCaptureOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetSyncStateOutMessage() *SyncStateOutMessage {
+	if x != nil {
+		return x.SyncStateOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetRuleSetOutMessage() *RuleSetOutMessage {
+	if x != nil {
+		return x.RuleSetOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetNodeUpgradeOutMessage() *NodeUpgradeOutMessage {
+	if x != nil {
+		return x.NodeUpgradeOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetDiagnoseOutMessage() *DiagnoseOutMessage {
+	if x != nil {
+		return x.DiagnoseOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetDiscoveryOutMessage() *DiscoveryOutMessage {
+	if x != nil {
+		return x.DiscoveryOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetVlanOutMessage() *VlanOutMessage {
+	if x != nil {
+		return x.VlanOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetTaskOutMessage() *TaskOutMessage {
+	if x != nil {
+		return x.TaskOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetCertRotateOutMessage() *CertRotateOutMessage {
+	if x != nil {
+		return x.CertRotateOutMessage
+	}
+	return nil
+}
+
+// 创建IP状态回调结构体
+type StatusCreateIPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoneyIPID     uint32                 `protobuf:"varint,1,opt,name=honeyIPID,proto3" json:"honeyIPID,omitempty"` // 诱捕ipID
+	ErrMsg        string                 `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`        // 错误信息
+	Network       string                 `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`      // 网卡名称
+	Mac           string                 `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`              // mac地址
+	LogID         string                 `protobuf:"bytes,5,opt,name=logID,proto3" json:"logID,omitempty"`          // 日志id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusCreateIPRequest) Reset() {
+	*x = StatusCreateIPRequest{}
+	mi := &file_internal_rpc_node_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusCreateIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusCreateIPRequest) ProtoMessage() {}
+
+func (x *StatusCreateIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusCreateIPRequest.ProtoReflect.Descriptor instead.
+func (*StatusCreateIPRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StatusCreateIPRequest) GetHoneyIPID() uint32 {
+	if x != nil {
+		return x.HoneyIPID
+	}
+	return 0
+}
+
+func (x *StatusCreateIPRequest) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+func (x *StatusCreateIPRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *StatusCreateIPRequest) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *StatusCreateIPRequest) GetLogID() string {
+	if x != nil {
+		return x.LogID
+	}
+	return ""
+}
+
+// 端口转发状态回调结构体
+type StatusBindPortRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoneyIPID     uint32                 `protobuf:"varint,1,opt,name=honeyIPID,proto3" json:"honeyIPID,omitempty"`      // 诱捕ipID
+	PortInfoList  []*StatusPortInfo      `protobuf:"bytes,2,rep,name=portInfoList,proto3" json:"portInfoList,omitempty"` // 端口信息列表
+	LogID         string                 `protobuf:"bytes,3,opt,name=logID,proto3" json:"logID,omitempty"`               // 日志id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusBindPortRequest) Reset() {
+	*x = StatusBindPortRequest{}
+	mi := &file_internal_rpc_node_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusBindPortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusBindPortRequest) ProtoMessage() {}
+
+func (x *StatusBindPortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusBindPortRequest.ProtoReflect.Descriptor instead.
+func (*StatusBindPortRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StatusBindPortRequest) GetHoneyIPID() uint32 {
+	if x != nil {
+		return x.HoneyIPID
+	}
+	return 0
+}
+
+func (x *StatusBindPortRequest) GetPortInfoList() []*StatusPortInfo {
+	if x != nil {
+		return x.PortInfoList
+	}
+	return nil
+}
+
+func (x *StatusBindPortRequest) GetLogID() string {
+	if x != nil {
+		return x.LogID
+	}
+	return ""
+}
+
+// 端口信息结构体
+type StatusPortInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Port          int64                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`           // 端口号
+	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`              // 相关信息
+	Agreement     int32                  `protobuf:"varint,3,opt,name=agreement,proto3" json:"agreement,omitempty"` // 通信协议 1 TCP 2 UDP
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusPortInfo) Reset() {
+	*x = StatusPortInfo{}
+	mi := &file_internal_rpc_node_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusPortInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusPortInfo) ProtoMessage() {}
+
+func (x *StatusPortInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusPortInfo.ProtoReflect.Descriptor instead.
+func (*StatusPortInfo) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StatusPortInfo) GetPort() int64 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *StatusPortInfo) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *StatusPortInfo) GetAgreement() int32 {
+	if x != nil {
+		return x.Agreement
+	}
+	return 0
+}
+
+// 删除IP状态回调结构体
+type StatusDeleteIPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoneyIPIDList []uint32               `protobuf:"varint,1,rep,packed,name=honeyIPIDList,proto3" json:"honeyIPIDList,omitempty"` // 诱捕ipID列表
+	NetID         int64                  `protobuf:"varint,2,opt,name=netID,proto3" json:"netID,omitempty"`                        // 网络id
+	LogID         string                 `protobuf:"bytes,3,opt,name=logID,proto3" json:"logID,omitempty"`                         // 日志id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusDeleteIPRequest) Reset() {
+	*x = StatusDeleteIPRequest{}
+	mi := &file_internal_rpc_node_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusDeleteIPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusDeleteIPRequest) ProtoMessage() {}
+
+func (x *StatusDeleteIPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusDeleteIPRequest.ProtoReflect.Descriptor instead.
+func (*StatusDeleteIPRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StatusDeleteIPRequest) GetHoneyIPIDList() []uint32 {
+	if x != nil {
+		return x.HoneyIPIDList
+	}
+	return nil
+}
+
+func (x *StatusDeleteIPRequest) GetNetID() int64 {
+	if x != nil {
+		return x.NetID
+	}
+	return 0
+}
+
+func (x *StatusDeleteIPRequest) GetLogID() string {
+	if x != nil {
+		return x.LogID
+	}
+	return ""
+}
+
+// 传输的数据块
+type TunnelData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`       // 数据块
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`   // 目标地址
+	SrcAddr       string                 `protobuf:"bytes,3,opt,name=srcAddr,proto3" json:"srcAddr,omitempty"`   // 来源地址（攻击方ip:port，初始化消息携带）
+	DestAddr      string                 `protobuf:"bytes,4,opt,name=destAddr,proto3" json:"destAddr,omitempty"` // 诱捕地址（诱捕ip:port，初始化消息携带）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TunnelData) Reset() {
+	*x = TunnelData{}
+	mi := &file_internal_rpc_node_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TunnelData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelData) ProtoMessage() {}
+
+func (x *TunnelData) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelData.ProtoReflect.Descriptor instead.
+func (*TunnelData) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TunnelData) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *TunnelData) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TunnelData) GetSrcAddr() string {
+	if x != nil {
+		return x.SrcAddr
+	}
+	return ""
+}
+
+func (x *TunnelData) GetDestAddr() string {
+	if x != nil {
+		return x.DestAddr
+	}
+	return ""
+}
+
+// UDP传输的数据报
+type UdpTunnelData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Datagram      []byte                 `protobuf:"bytes,1,opt,name=datagram,proto3" json:"datagram,omitempty"` // 单个完整数据报
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`   // 目标地址
+	Peer          string                 `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`         // 来源地址（攻击方ip:port）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UdpTunnelData) Reset() {
+	*x = UdpTunnelData{}
+	mi := &file_internal_rpc_node_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UdpTunnelData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UdpTunnelData) ProtoMessage() {}
+
+func (x *UdpTunnelData) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UdpTunnelData.ProtoReflect.Descriptor instead.
+func (*UdpTunnelData) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UdpTunnelData) GetDatagram() []byte {
+	if x != nil {
+		return x.Datagram
+	}
+	return nil
+}
+
+func (x *UdpTunnelData) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *UdpTunnelData) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+// 多路复用隧道数据帧
+type MuxFrame struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionID     uint32                 `protobuf:"varint,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"` // 会话id（由节点分配）
+	Type          int32                  `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`           // 帧类型 1 打开会话 2 数据 3 关闭会话 4 窗口更新
+	Chunk         []byte                 `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`          // 数据块
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`      // 目标地址（打开会话时携带）
+	Window        uint32                 `protobuf:"varint,5,opt,name=window,proto3" json:"window,omitempty"`       // 窗口增量（窗口更新时携带）
+	ErrMsg        string                 `protobuf:"bytes,6,opt,name=errMsg,proto3" json:"errMsg,omitempty"`        // 错误信息（关闭会话时携带）
+	SrcAddr       string                 `protobuf:"bytes,7,opt,name=srcAddr,proto3" json:"srcAddr,omitempty"`      // 来源地址（攻击方ip:port，打开会话时携带）
+	DestAddr      string                 `protobuf:"bytes,8,opt,name=destAddr,proto3" json:"destAddr,omitempty"`    // 诱捕地址（诱捕ip:port，打开会话时携带）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuxFrame) Reset() {
+	*x = MuxFrame{}
+	mi := &file_internal_rpc_node_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuxFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuxFrame) ProtoMessage() {}
+
+func (x *MuxFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuxFrame.ProtoReflect.Descriptor instead.
+func (*MuxFrame) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *MuxFrame) GetSessionID() uint32 {
+	if x != nil {
+		return x.SessionID
+	}
+	return 0
+}
+
+func (x *MuxFrame) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *MuxFrame) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *MuxFrame) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *MuxFrame) GetWindow() uint32 {
 	if x != nil {
-		return x.LogID
+		return x.Window
 	}
-	return ""
+	return 0
 }
 
-func (x *CmdResponse) GetNetworkFlushOutMessage() *NetworkFlushOutMessage {
+func (x *MuxFrame) GetErrMsg() string {
 	if x != nil {
-		return x.NetworkFlushOutMessage
+		return x.ErrMsg
 	}
-	return nil
+	return ""
 }
 
-func (x *CmdResponse) GetNetScanOutMessage() *NetScanOutMessage {
+func (x *MuxFrame) GetSrcAddr() string {
 	if x != nil {
-		return x.NetScanOutMessage
+		return x.SrcAddr
 	}
-	return nil
+	return ""
 }
 
-func (x *CmdResponse) GetNodeRemoveOutMessage() *NodeRemoveOutMessage {
+func (x *MuxFrame) GetDestAddr() string {
 	if x != nil {
-		return x.NodeRemoveOutMessage
+		return x.DestAddr
 	}
-	return nil
+	return ""
 }
 
-// 创建IP状态回调结构体
-type StatusCreateIPRequest struct {
+// 抓包文件数据块（首个数据块携带抓包结果信息）
+type PcapChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	HoneyIPID     uint32                 `protobuf:"varint,1,opt,name=honeyIPID,proto3" json:"honeyIPID,omitempty"` // 诱捕ipID
-	ErrMsg        string                 `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`        // 错误信息
-	Network       string                 `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`      // 网卡名称
-	Mac           string                 `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`              // mac地址
-	LogID         string                 `protobuf:"bytes,5,opt,name=logID,proto3" json:"logID,omitempty"`          // 日志id
+	CaptureID     string                 `protobuf:"bytes,1,opt,name=captureID,proto3" json:"captureID,omitempty"`      // 抓包任务id
+	Chunk         []byte                 `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`              // 文件数据块
+	PacketCount   int64                  `protobuf:"varint,3,opt,name=packetCount,proto3" json:"packetCount,omitempty"` // 数据包数量
+	DropCount     int64                  `protobuf:"varint,4,opt,name=dropCount,proto3" json:"dropCount,omitempty"`     // 因环形缓冲区已满被丢弃的数据包数量
+	ErrMsg        string                 `protobuf:"bytes,5,opt,name=errMsg,proto3" json:"errMsg,omitempty"`            // 抓包错误信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StatusCreateIPRequest) Reset() {
-	*x = StatusCreateIPRequest{}
-	mi := &file_internal_rpc_node_proto_msgTypes[14]
+func (x *PcapChunk) Reset() {
+	*x = PcapChunk{}
+	mi := &file_internal_rpc_node_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatusCreateIPRequest) String() string {
+func (x *PcapChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusCreateIPRequest) ProtoMessage() {}
+func (*PcapChunk) ProtoMessage() {}
 
-func (x *StatusCreateIPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[14]
+func (x *PcapChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1082,71 +3472,130 @@ func (x *StatusCreateIPRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusCreateIPRequest.ProtoReflect.Descriptor instead.
-func (*StatusCreateIPRequest) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use PcapChunk.ProtoReflect.Descriptor instead.
+func (*PcapChunk) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *StatusCreateIPRequest) GetHoneyIPID() uint32 {
+func (x *PcapChunk) GetCaptureID() string {
 	if x != nil {
-		return x.HoneyIPID
+		return x.CaptureID
+	}
+	return ""
+}
+
+func (x *PcapChunk) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *PcapChunk) GetPacketCount() int64 {
+	if x != nil {
+		return x.PacketCount
 	}
 	return 0
 }
 
-func (x *StatusCreateIPRequest) GetErrMsg() string {
+func (x *PcapChunk) GetDropCount() int64 {
+	if x != nil {
+		return x.DropCount
+	}
+	return 0
+}
+
+func (x *PcapChunk) GetErrMsg() string {
 	if x != nil {
 		return x.ErrMsg
 	}
 	return ""
 }
 
-func (x *StatusCreateIPRequest) GetNetwork() string {
+// 被动发现主机上报请求结构体
+type ReportHostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeUid       string                 `protobuf:"bytes,1,opt,name=nodeUid,proto3" json:"nodeUid,omitempty"`     // 节点uid
+	EventList     []*HostEvent           `protobuf:"bytes,2,rep,name=eventList,proto3" json:"eventList,omitempty"` // 主机变化列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportHostRequest) Reset() {
+	*x = ReportHostRequest{}
+	mi := &file_internal_rpc_node_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportHostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportHostRequest) ProtoMessage() {}
+
+func (x *ReportHostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[46]
 	if x != nil {
-		return x.Network
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *StatusCreateIPRequest) GetMac() string {
+// Deprecated: Use ReportHostRequest.ProtoReflect.Descriptor instead.
+func (*ReportHostRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ReportHostRequest) GetNodeUid() string {
 	if x != nil {
-		return x.Mac
+		return x.NodeUid
 	}
 	return ""
 }
 
-func (x *StatusCreateIPRequest) GetLogID() string {
+func (x *ReportHostRequest) GetEventList() []*HostEvent {
 	if x != nil {
-		return x.LogID
+		return x.EventList
 	}
-	return ""
+	return nil
 }
 
-// 端口转发状态回调结构体
-type StatusBindPortRequest struct {
+// 被动发现的主机变化
+type HostEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	HoneyIPID     uint32                 `protobuf:"varint,1,opt,name=honeyIPID,proto3" json:"honeyIPID,omitempty"`      // 诱捕ipID
-	PortInfoList  []*StatusPortInfo      `protobuf:"bytes,2,rep,name=portInfoList,proto3" json:"portInfoList,omitempty"` // 端口信息列表
-	LogID         string                 `protobuf:"bytes,3,opt,name=logID,proto3" json:"logID,omitempty"`               // 日志id
+	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`          // 变化类型 1 新增 2 信息变化 3 离线 4 在线刷新
+	NetID         uint32                 `protobuf:"varint,2,opt,name=netID,proto3" json:"netID,omitempty"`        // 网络ID
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`               // 主机ip
+	Mac           string                 `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`             // mac地址
+	Manuf         string                 `protobuf:"bytes,5,opt,name=manuf,proto3" json:"manuf,omitempty"`         // mac对应厂商
+	Hostname      string                 `protobuf:"bytes,6,opt,name=hostname,proto3" json:"hostname,omitempty"`   // 主机名
+	Source        string                 `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`       // 最近一次发现来源 arp dhcp mdns nbns lldp
+	FirstSeen     string                 `protobuf:"bytes,8,opt,name=firstSeen,proto3" json:"firstSeen,omitempty"` // 首次发现时间
+	LastSeen      string                 `protobuf:"bytes,9,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`   // 最近发现时间
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StatusBindPortRequest) Reset() {
-	*x = StatusBindPortRequest{}
-	mi := &file_internal_rpc_node_proto_msgTypes[15]
+func (x *HostEvent) Reset() {
+	*x = HostEvent{}
+	mi := &file_internal_rpc_node_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatusBindPortRequest) String() string {
+func (x *HostEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusBindPortRequest) ProtoMessage() {}
+func (*HostEvent) ProtoMessage() {}
 
-func (x *StatusBindPortRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[15]
+func (x *HostEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1157,56 +3606,104 @@ func (x *StatusBindPortRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusBindPortRequest.ProtoReflect.Descriptor instead.
-func (*StatusBindPortRequest) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use HostEvent.ProtoReflect.Descriptor instead.
+func (*HostEvent) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *StatusBindPortRequest) GetHoneyIPID() uint32 {
+func (x *HostEvent) GetType() int32 {
 	if x != nil {
-		return x.HoneyIPID
+		return x.Type
 	}
 	return 0
 }
 
-func (x *StatusBindPortRequest) GetPortInfoList() []*StatusPortInfo {
+func (x *HostEvent) GetNetID() uint32 {
 	if x != nil {
-		return x.PortInfoList
+		return x.NetID
 	}
-	return nil
+	return 0
 }
 
-func (x *StatusBindPortRequest) GetLogID() string {
+func (x *HostEvent) GetIp() string {
 	if x != nil {
-		return x.LogID
+		return x.Ip
 	}
 	return ""
 }
 
-// 端口信息结构体
-type StatusPortInfo struct {
+func (x *HostEvent) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *HostEvent) GetManuf() string {
+	if x != nil {
+		return x.Manuf
+	}
+	return ""
+}
+
+func (x *HostEvent) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *HostEvent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *HostEvent) GetFirstSeen() string {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return ""
+}
+
+func (x *HostEvent) GetLastSeen() string {
+	if x != nil {
+		return x.LastSeen
+	}
+	return ""
+}
+
+// 诱捕ip冲突上报结构体（真实主机占用诱捕ip后节点已撤下诱捕ip）
+type StatusIpConflictRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Port          int64                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"` // 端口号
-	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`    // 相关信息
+	NodeUid       string                 `protobuf:"bytes,1,opt,name=nodeUid,proto3" json:"nodeUid,omitempty"`       // 节点uid
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`                 // 冲突的诱捕ip
+	Network       string                 `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`       // 网卡名称
+	Mac           string                 `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`               // 真实主机mac地址
+	Manuf         string                 `protobuf:"bytes,5,opt,name=manuf,proto3" json:"manuf,omitempty"`           // 真实主机mac对应厂商
+	ArpType       string                 `protobuf:"bytes,6,opt,name=arpType,proto3" json:"arpType,omitempty"`       // 检测到的ARP报文类型 reply gratuitous request
+	DetectTime    string                 `protobuf:"bytes,7,opt,name=detectTime,proto3" json:"detectTime,omitempty"` // 检测时间
+	ErrMsg        string                 `protobuf:"bytes,8,opt,name=errMsg,proto3" json:"errMsg,omitempty"`         // 撤下诱捕ip时的错误信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StatusPortInfo) Reset() {
-	*x = StatusPortInfo{}
-	mi := &file_internal_rpc_node_proto_msgTypes[16]
+func (x *StatusIpConflictRequest) Reset() {
+	*x = StatusIpConflictRequest{}
+	mi := &file_internal_rpc_node_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatusPortInfo) String() string {
+func (x *StatusIpConflictRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusPortInfo) ProtoMessage() {}
+func (*StatusIpConflictRequest) ProtoMessage() {}
 
-func (x *StatusPortInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[16]
+func (x *StatusIpConflictRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1217,50 +3714,92 @@ func (x *StatusPortInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusPortInfo.ProtoReflect.Descriptor instead.
-func (*StatusPortInfo) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use StatusIpConflictRequest.ProtoReflect.Descriptor instead.
+func (*StatusIpConflictRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *StatusPortInfo) GetPort() int64 {
+func (x *StatusIpConflictRequest) GetNodeUid() string {
 	if x != nil {
-		return x.Port
+		return x.NodeUid
 	}
-	return 0
+	return ""
 }
 
-func (x *StatusPortInfo) GetMsg() string {
+func (x *StatusIpConflictRequest) GetIp() string {
 	if x != nil {
-		return x.Msg
+		return x.Ip
 	}
 	return ""
 }
 
-// 删除IP状态回调结构体
-type StatusDeleteIPRequest struct {
+func (x *StatusIpConflictRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *StatusIpConflictRequest) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *StatusIpConflictRequest) GetManuf() string {
+	if x != nil {
+		return x.Manuf
+	}
+	return ""
+}
+
+func (x *StatusIpConflictRequest) GetArpType() string {
+	if x != nil {
+		return x.ArpType
+	}
+	return ""
+}
+
+func (x *StatusIpConflictRequest) GetDetectTime() string {
+	if x != nil {
+		return x.DetectTime
+	}
+	return ""
+}
+
+func (x *StatusIpConflictRequest) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 节点证书申请结构体
+type EnrollRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	HoneyIPIDList []uint32               `protobuf:"varint,1,rep,packed,name=honeyIPIDList,proto3" json:"honeyIPIDList,omitempty"` // 诱捕ipID列表
-	NetID         int64                  `protobuf:"varint,2,opt,name=netID,proto3" json:"netID,omitempty"`                        // 网络id
-	LogID         string                 `protobuf:"bytes,3,opt,name=logID,proto3" json:"logID,omitempty"`                         // 日志id
+	NodeUid       string                 `protobuf:"bytes,1,opt,name=nodeUid,proto3" json:"nodeUid,omitempty"`       // 节点uid
+	Csr           []byte                 `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"`               // PEM格式的证书签名请求，CN须为节点uid
+	EnrollCode    string                 `protobuf:"bytes,3,opt,name=enrollCode,proto3" json:"enrollCode,omitempty"` // 管理员重置节点证书后生成的一次性接入码，已接入的节点使用引导证书申请时必填
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StatusDeleteIPRequest) Reset() {
-	*x = StatusDeleteIPRequest{}
-	mi := &file_internal_rpc_node_proto_msgTypes[17]
+func (x *EnrollRequest) Reset() {
+	*x = EnrollRequest{}
+	mi := &file_internal_rpc_node_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatusDeleteIPRequest) String() string {
+func (x *EnrollRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusDeleteIPRequest) ProtoMessage() {}
+func (*EnrollRequest) ProtoMessage() {}
 
-func (x *StatusDeleteIPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[17]
+func (x *EnrollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1271,56 +3810,56 @@ func (x *StatusDeleteIPRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusDeleteIPRequest.ProtoReflect.Descriptor instead.
-func (*StatusDeleteIPRequest) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use EnrollRequest.ProtoReflect.Descriptor instead.
+func (*EnrollRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *StatusDeleteIPRequest) GetHoneyIPIDList() []uint32 {
+func (x *EnrollRequest) GetNodeUid() string {
 	if x != nil {
-		return x.HoneyIPIDList
+		return x.NodeUid
 	}
-	return nil
+	return ""
 }
 
-func (x *StatusDeleteIPRequest) GetNetID() int64 {
+func (x *EnrollRequest) GetCsr() []byte {
 	if x != nil {
-		return x.NetID
+		return x.Csr
 	}
-	return 0
+	return nil
 }
 
-func (x *StatusDeleteIPRequest) GetLogID() string {
+func (x *EnrollRequest) GetEnrollCode() string {
 	if x != nil {
-		return x.LogID
+		return x.EnrollCode
 	}
 	return ""
 }
 
-// 传输的数据块
-type TunnelData struct {
+// 节点证书申请响应结构体
+type EnrollResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`     // 数据块
-	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // 目标地址
+	Cert          []byte                 `protobuf:"bytes,1,opt,name=cert,proto3" json:"cert,omitempty"` // PEM格式的节点客户端证书
+	Ca            []byte                 `protobuf:"bytes,2,opt,name=ca,proto3" json:"ca,omitempty"`     // PEM格式的CA证书
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TunnelData) Reset() {
-	*x = TunnelData{}
-	mi := &file_internal_rpc_node_proto_msgTypes[18]
+func (x *EnrollResponse) Reset() {
+	*x = EnrollResponse{}
+	mi := &file_internal_rpc_node_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TunnelData) String() string {
+func (x *EnrollResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TunnelData) ProtoMessage() {}
+func (*EnrollResponse) ProtoMessage() {}
 
-func (x *TunnelData) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[18]
+func (x *EnrollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1331,23 +3870,23 @@ func (x *TunnelData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TunnelData.ProtoReflect.Descriptor instead.
-func (*TunnelData) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use EnrollResponse.ProtoReflect.Descriptor instead.
+func (*EnrollResponse) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *TunnelData) GetChunk() []byte {
+func (x *EnrollResponse) GetCert() []byte {
 	if x != nil {
-		return x.Chunk
+		return x.Cert
 	}
 	return nil
 }
 
-func (x *TunnelData) GetAddress() string {
+func (x *EnrollResponse) GetCa() []byte {
 	if x != nil {
-		return x.Address
+		return x.Ca
 	}
-	return ""
+	return nil
 }
 
 var File_internal_rpc_node_proto protoreflect.FileDescriptor
@@ -1357,7 +3896,7 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\x17internal/rpc/node.proto\x12\bnode_rpc\"4\n" +
 	"\fBaseResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x10\n" +
-	"\x03msg\x18\x02 \x01(\tR\x03msg\"\xbc\x02\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\"\xd2\x02\n" +
 	"\x0fRegisterRequest\x12\x0e\n" +
 	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x10\n" +
 	"\x03mac\x18\x02 \x01(\tR\x03mac\x12\x19\n" +
@@ -1368,7 +3907,8 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"systemInfo\x18\x06 \x01(\v2\x1b.node_rpc.systemInfoMessageR\n" +
 	"systemInfo\x12=\n" +
 	"\fresourceInfo\x18\a \x01(\v2\x19.node_rpc.resourceMessageR\fresourceInfo\x12>\n" +
-	"\vnetworkList\x18\b \x03(\v2\x1c.node_rpc.networkInfoMessageR\vnetworkList\"o\n" +
+	"\vnetworkList\x18\b \x03(\v2\x1c.node_rpc.networkInfoMessageR\vnetworkList\x12\x14\n" +
+	"\x05token\x18\t \x01(\tR\x05token\"o\n" +
 	"\x13NodeResourceRequest\x12\x19\n" +
 	"\bnode_uid\x18\x01 \x01(\tR\anodeUid\x12=\n" +
 	"\fresourceInfo\x18\x02 \x01(\v2\x19.node_rpc.resourceMessageR\fresourceInfo\"\xc1\x01\n" +
@@ -1392,12 +3932,17 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\tdiskTotal\x18\x05 \x01(\x03R\tdiskTotal\x12 \n" +
 	"\vdiskUseRate\x18\x06 \x01(\x02R\vdiskUseRate\x12\x1a\n" +
 	"\bnodePath\x18\a \x01(\tR\bnodePath\x124\n" +
-	"\x15nodeResourceOccupancy\x18\b \x01(\x03R\x15nodeResourceOccupancy\"d\n" +
+	"\x15nodeResourceOccupancy\x18\b \x01(\x03R\x15nodeResourceOccupancy\"\xd0\x01\n" +
 	"\x12networkInfoMessage\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x0e\n" +
 	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x10\n" +
 	"\x03net\x18\x03 \x01(\tR\x03net\x12\x12\n" +
-	"\x04mask\x18\x04 \x01(\x05R\x04mask\"\xd7\x02\n" +
+	"\x04mask\x18\x04 \x01(\x05R\x04mask\x12\x10\n" +
+	"\x03ip6\x18\x05 \x01(\tR\x03ip6\x12\x12\n" +
+	"\x04net6\x18\x06 \x01(\tR\x04net6\x12\x14\n" +
+	"\x05mask6\x18\a \x01(\x05R\x05mask6\x12\x16\n" +
+	"\x06vlanID\x18\b \x01(\x05R\x06vlanID\x12\x16\n" +
+	"\x06parent\x18\t \x01(\tR\x06parent\"\xfd\a\n" +
 	"\n" +
 	"CmdRequest\x12+\n" +
 	"\acmdType\x18\x01 \x01(\x0e2\x11.node_rpc.CmdTypeR\acmdType\x12\x16\n" +
@@ -1405,17 +3950,97 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\x05logID\x18\x03 \x01(\tR\x05logID\x12U\n" +
 	"\x15NetworkFlushInMessage\x18\x04 \x01(\v2\x1f.node_rpc.NetworkFlushInMessageR\x15NetworkFlushInMessage\x12F\n" +
 	"\x10NetScanInMessage\x18\x05 \x01(\v2\x1a.node_rpc.NetScanInMessageR\x10NetScanInMessage\x12O\n" +
-	"\x13NodeRemoveInMessage\x18\x06 \x01(\v2\x1d.node_rpc.NodeRemoveInMessageR\x13NodeRemoveInMessage\"E\n" +
+	"\x13NodeRemoveInMessage\x18\x06 \x01(\v2\x1d.node_rpc.NodeRemoveInMessageR\x13NodeRemoveInMessage\x12R\n" +
+	"\x14PcapCaptureInMessage\x18\a \x01(\v2\x1e.node_rpc.PcapCaptureInMessageR\x14PcapCaptureInMessage\x12L\n" +
+	"\x12SyncStateInMessage\x18\b \x01(\v2\x1c.node_rpc.SyncStateInMessageR\x12SyncStateInMessage\x12F\n" +
+	"\x10RuleSetInMessage\x18\t \x01(\v2\x1a.node_rpc.RuleSetInMessageR\x10RuleSetInMessage\x12R\n" +
+	"\x14NodeUpgradeInMessage\x18\n" +
+	" \x01(\v2\x1e.node_rpc.NodeUpgradeInMessageR\x14NodeUpgradeInMessage\x12I\n" +
+	"\x11DiagnoseInMessage\x18\v \x01(\v2\x1b.node_rpc.DiagnoseInMessageR\x11DiagnoseInMessage\x12L\n" +
+	"\x12DiscoveryInMessage\x18\f \x01(\v2\x1c.node_rpc.DiscoveryInMessageR\x12DiscoveryInMessage\x12=\n" +
+	"\rVlanInMessage\x18\r \x01(\v2\x17.node_rpc.VlanInMessageR\rVlanInMessage\x12=\n" +
+	"\rTaskInMessage\x18\x0e \x01(\v2\x17.node_rpc.TaskInMessageR\rTaskInMessage\x12O\n" +
+	"\x13CertRotateInMessage\x18\x0f \x01(\v2\x1d.node_rpc.CertRotateInMessageR\x13CertRotateInMessage\"E\n" +
 	"\x15NetworkFlushInMessage\x12,\n" +
-	"\x11filterNetworkName\x18\x01 \x03(\tR\x11filterNetworkName\"\x80\x01\n" +
+	"\x11filterNetworkName\x18\x01 \x03(\tR\x11filterNetworkName\"\xf4\x01\n" +
 	"\x10NetScanInMessage\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x18\n" +
 	"\aipRange\x18\x02 \x01(\tR\aipRange\x12\"\n" +
 	"\ffilterIPList\x18\x03 \x03(\tR\ffilterIPList\x12\x14\n" +
-	"\x05netID\x18\x04 \x01(\rR\x05netID\"\x15\n" +
-	"\x13NodeRemoveInMessage\"X\n" +
+	"\x05netID\x18\x04 \x01(\rR\x05netID\x12 \n" +
+	"\vserviceScan\x18\x05 \x01(\bR\vserviceScan\x12\x1a\n" +
+	"\bscanMode\x18\x06 \x01(\x05R\bscanMode\x12\x1a\n" +
+	"\bportList\x18\a \x03(\x05R\bportList\x12\x18\n" +
+	"\atimeout\x18\b \x01(\x05R\atimeout\"\x15\n" +
+	"\x13NodeRemoveInMessage\"\xac\x01\n" +
+	"\x14PcapCaptureInMessage\x12\x1c\n" +
+	"\tcaptureID\x18\x01 \x01(\tR\tcaptureID\x12\x12\n" +
+	"\x04stop\x18\x02 \x01(\bR\x04stop\x12\x1a\n" +
+	"\blinkName\x18\x03 \x01(\tR\blinkName\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\x12\x1a\n" +
+	"\bduration\x18\x05 \x01(\x05R\bduration\x12\x1a\n" +
+	"\bmaxBytes\x18\x06 \x01(\x03R\bmaxBytes\"l\n" +
+	"\x12SyncStateInMessage\x12,\n" +
+	"\x06ipList\x18\x01 \x03(\v2\x14.node_rpc.syncIpInfoR\x06ipList\x12(\n" +
+	"\x0fskipNetworkList\x18\x02 \x03(\tR\x0fskipNetworkList\"\xe0\x01\n" +
+	"\n" +
+	"syncIpInfo\x12\x1c\n" +
+	"\thoneyIpID\x18\x01 \x01(\rR\thoneyIpID\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x12\n" +
+	"\x04mask\x18\x03 \x01(\x05R\x04mask\x12\x18\n" +
+	"\anetwork\x18\x04 \x01(\tR\anetwork\x12\x1a\n" +
+	"\blinkName\x18\x05 \x01(\tR\blinkName\x12\x10\n" +
+	"\x03mac\x18\x06 \x01(\tR\x03mac\x12\x14\n" +
+	"\x05isTan\x18\a \x01(\bR\x05isTan\x122\n" +
+	"\bportList\x18\b \x03(\v2\x16.node_rpc.syncPortInfoR\bportList\"\x90\x01\n" +
+	"\fsyncPortInfo\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\x03R\x04port\x12\x1c\n" +
+	"\tagreement\x18\x02 \x01(\x05R\tagreement\x12\x16\n" +
+	"\x06destIP\x18\x03 \x01(\tR\x06destIP\x12\x1a\n" +
+	"\bdestPort\x18\x04 \x01(\x03R\bdestPort\x12\x1a\n" +
+	"\bemulator\x18\x05 \x01(\tR\bemulator\"\x92\x01\n" +
+	"\x10RuleSetInMessage\x12\x1c\n" +
+	"\truleSetID\x18\x01 \x01(\rR\truleSetID\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\tR\x04hash\x12\x14\n" +
+	"\x05rules\x18\x04 \x01(\fR\x05rules\x12\x1c\n" +
+	"\tthreshold\x18\x05 \x01(\tR\tthreshold\"\xda\x01\n" +
+	"\x14NodeUpgradeInMessage\x12\x1c\n" +
+	"\tupgradeID\x18\x01 \x01(\rR\tupgradeID\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x18\n" +
+	"\aimageID\x18\x04 \x01(\tR\aimageID\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\x1a\n" +
+	"\bfileSize\x18\x06 \x01(\x03R\bfileSize\x12\x12\n" +
+	"\x04hash\x18\a \x01(\tR\x04hash\x12\x18\n" +
+	"\atimeout\x18\b \x01(\x05R\atimeout\"/\n" +
+	"\x11DiagnoseInMessage\x12\x1a\n" +
+	"\blogLines\x18\x01 \x01(\x05R\blogLines\"r\n" +
+	"\x12DiscoveryInMessage\x12<\n" +
+	"\vnetworkList\x18\x01 \x03(\v2\x1a.node_rpc.discoveryNetworkR\vnetworkList\x12\x1e\n" +
+	"\n" +
+	"vanishTime\x18\x02 \x01(\x05R\n" +
+	"vanishTime\"\x8e\x01\n" +
+	"\x10discoveryNetwork\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x14\n" +
+	"\x05netID\x18\x02 \x01(\rR\x05netID\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x12\n" +
+	"\x04mask\x18\x04 \x01(\x05R\x04mask\x12\x10\n" +
+	"\x03ip6\x18\x05 \x01(\tR\x03ip6\x12\x14\n" +
+	"\x05mask6\x18\x06 \x01(\x05R\x05mask6\"\x91\x01\n" +
+	"\rVlanInMessage\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\x05R\x06action\x12\x18\n" +
+	"\anetwork\x18\x02 \x01(\tR\anetwork\x12\x16\n" +
+	"\x06vlanID\x18\x03 \x01(\x05R\x06vlanID\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\x12\x12\n" +
+	"\x04mask\x18\x05 \x01(\x05R\x04mask\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\"?\n" +
+	"\rTaskInMessage\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\x05R\x06action\x12\x16\n" +
+	"\x06taskID\x18\x02 \x01(\tR\x06taskID\"\x15\n" +
+	"\x13CertRotateInMessage\"X\n" +
 	"\x16NetworkFlushOutMessage\x12>\n" +
-	"\vnetworkList\x18\x01 \x03(\v2\x1c.node_rpc.networkInfoMessageR\vnetworkList\"\xa7\x01\n" +
+	"\vnetworkList\x18\x01 \x03(\v2\x1c.node_rpc.networkInfoMessageR\vnetworkList\"\xda\x01\n" +
 	"\x11NetScanOutMessage\x12\x10\n" +
 	"\x03end\x18\x01 \x01(\bR\x03end\x12\x1a\n" +
 	"\bprogress\x18\x02 \x01(\x02R\bprogress\x12\x0e\n" +
@@ -1423,8 +4048,66 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\x03mac\x18\x04 \x01(\tR\x03mac\x12\x14\n" +
 	"\x05manuf\x18\x05 \x01(\tR\x05manuf\x12\x14\n" +
 	"\x05netID\x18\x06 \x01(\rR\x05netID\x12\x16\n" +
-	"\x06errMsg\x18\a \x01(\tR\x06errMsg\"\x16\n" +
-	"\x14NodeRemoveOutMessage\"\xa9\x03\n" +
+	"\x06errMsg\x18\a \x01(\tR\x06errMsg\x121\n" +
+	"\bportList\x18\b \x03(\v2\x15.node_rpc.NetScanPortR\bportList\"9\n" +
+	"\vNetScanPort\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\x05R\x04port\x12\x16\n" +
+	"\x06banner\x18\x02 \x01(\tR\x06banner\"\x16\n" +
+	"\x14NodeRemoveOutMessage\"M\n" +
+	"\x15PcapCaptureOutMessage\x12\x1c\n" +
+	"\tcaptureID\x18\x01 \x01(\tR\tcaptureID\x12\x16\n" +
+	"\x06errMsg\x18\x02 \x01(\tR\x06errMsg\"\x95\x01\n" +
+	"\x13SyncStateOutMessage\x12.\n" +
+	"\x06ipList\x18\x01 \x03(\v2\x16.node_rpc.syncIpResultR\x06ipList\x126\n" +
+	"\n" +
+	"removeList\x18\x02 \x03(\v2\x16.node_rpc.syncIpResultR\n" +
+	"removeList\x12\x16\n" +
+	"\x06errMsg\x18\x03 \x01(\tR\x06errMsg\"\xb8\x01\n" +
+	"\fsyncIpResult\x12\x1c\n" +
+	"\thoneyIpID\x18\x01 \x01(\rR\thoneyIpID\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x1a\n" +
+	"\blinkName\x18\x03 \x01(\tR\blinkName\x12\x10\n" +
+	"\x03mac\x18\x04 \x01(\tR\x03mac\x12\x16\n" +
+	"\x06errMsg\x18\x05 \x01(\tR\x06errMsg\x124\n" +
+	"\bportList\x18\x06 \x03(\v2\x18.node_rpc.statusPortInfoR\bportList\"\xd5\x01\n" +
+	"\x11RuleSetOutMessage\x12\x1c\n" +
+	"\truleSetID\x18\x01 \x01(\rR\truleSetID\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\tR\x04hash\x12 \n" +
+	"\vrulesLoaded\x18\x04 \x01(\x05R\vrulesLoaded\x12 \n" +
+	"\vrulesFailed\x18\x05 \x01(\x05R\vrulesFailed\x12\x18\n" +
+	"\aerrList\x18\x06 \x03(\tR\aerrList\x12\x16\n" +
+	"\x06errMsg\x18\a \x01(\tR\x06errMsg\"\x81\x01\n" +
+	"\x15NodeUpgradeOutMessage\x12\x1c\n" +
+	"\tupgradeID\x18\x01 \x01(\rR\tupgradeID\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1a\n" +
+	"\bprogress\x18\x03 \x01(\x02R\bprogress\x12\x16\n" +
+	"\x06errMsg\x18\x04 \x01(\tR\x06errMsg\"D\n" +
+	"\x12DiagnoseOutMessage\x12\x16\n" +
+	"\x06report\x18\x01 \x01(\tR\x06report\x12\x16\n" +
+	"\x06errMsg\x18\x02 \x01(\tR\x06errMsg\"O\n" +
+	"\x13DiscoveryOutMessage\x12 \n" +
+	"\vnetworkList\x18\x01 \x03(\tR\vnetworkList\x12\x16\n" +
+	"\x06errMsg\x18\x02 \x01(\tR\x06errMsg\"`\n" +
+	"\x0eVlanOutMessage\x126\n" +
+	"\anetwork\x18\x01 \x01(\v2\x1c.node_rpc.networkInfoMessageR\anetwork\x12\x16\n" +
+	"\x06errMsg\x18\x02 \x01(\tR\x06errMsg\"\xc6\x01\n" +
+	"\btaskInfo\x12\x16\n" +
+	"\x06taskID\x18\x01 \x01(\tR\x06taskID\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\x05R\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\x05R\x04done\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x12\x16\n" +
+	"\x06errMsg\x18\a \x01(\tR\x06errMsg\x12\x1c\n" +
+	"\tcreatedAt\x18\b \x01(\tR\tcreatedAt\"X\n" +
+	"\x0eTaskOutMessage\x12.\n" +
+	"\btaskList\x18\x01 \x03(\v2\x12.node_rpc.taskInfoR\btaskList\x12\x16\n" +
+	"\x06errMsg\x18\x02 \x01(\tR\x06errMsg\"b\n" +
+	"\x14CertRotateOutMessage\x12\x16\n" +
+	"\x06serial\x18\x01 \x01(\tR\x06serial\x12\x1a\n" +
+	"\bnotAfter\x18\x02 \x01(\tR\bnotAfter\x12\x16\n" +
+	"\x06errMsg\x18\x03 \x01(\tR\x06errMsg\"\xea\b\n" +
 	"\vCmdResponse\x12+\n" +
 	"\acmdType\x18\x01 \x01(\x0e2\x11.node_rpc.CmdTypeR\acmdType\x12\x16\n" +
 	"\x06taskID\x18\x02 \x01(\tR\x06taskID\x12\x16\n" +
@@ -1434,7 +4117,17 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\x05logID\x18\x06 \x01(\tR\x05logID\x12X\n" +
 	"\x16NetworkFlushOutMessage\x18\a \x01(\v2 .node_rpc.NetworkFlushOutMessageR\x16NetworkFlushOutMessage\x12I\n" +
 	"\x11NetScanOutMessage\x18\b \x01(\v2\x1b.node_rpc.NetScanOutMessageR\x11NetScanOutMessage\x12R\n" +
-	"\x14NodeRemoveOutMessage\x18\t \x01(\v2\x1e.node_rpc.NodeRemoveOutMessageR\x14NodeRemoveOutMessage\"\x8f\x01\n" +
+	"\x14NodeRemoveOutMessage\x18\t \x01(\v2\x1e.node_rpc.NodeRemoveOutMessageR\x14NodeRemoveOutMessage\x12U\n" +
+	"\x15PcapCaptureOutMessage\x18\n" +
+	" \x01(\v2\x1f.node_rpc.PcapCaptureOutMessageR\x15PcapCaptureOutMessage\x12O\n" +
+	"\x13SyncStateOutMessage\x18\v \x01(\v2\x1d.node_rpc.SyncStateOutMessageR\x13SyncStateOutMessage\x12I\n" +
+	"\x11RuleSetOutMessage\x18\f \x01(\v2\x1b.node_rpc.RuleSetOutMessageR\x11RuleSetOutMessage\x12U\n" +
+	"\x15NodeUpgradeOutMessage\x18\r \x01(\v2\x1f.node_rpc.NodeUpgradeOutMessageR\x15NodeUpgradeOutMessage\x12L\n" +
+	"\x12DiagnoseOutMessage\x18\x0e \x01(\v2\x1c.node_rpc.DiagnoseOutMessageR\x12DiagnoseOutMessage\x12O\n" +
+	"\x13DiscoveryOutMessage\x18\x0f \x01(\v2\x1d.node_rpc.DiscoveryOutMessageR\x13DiscoveryOutMessage\x12@\n" +
+	"\x0eVlanOutMessage\x18\x10 \x01(\v2\x18.node_rpc.VlanOutMessageR\x0eVlanOutMessage\x12@\n" +
+	"\x0eTaskOutMessage\x18\x11 \x01(\v2\x18.node_rpc.TaskOutMessageR\x0eTaskOutMessage\x12R\n" +
+	"\x14CertRotateOutMessage\x18\x12 \x01(\v2\x1e.node_rpc.CertRotateOutMessageR\x14CertRotateOutMessage\"\x8f\x01\n" +
 	"\x15StatusCreateIPRequest\x12\x1c\n" +
 	"\thoneyIPID\x18\x01 \x01(\rR\thoneyIPID\x12\x16\n" +
 	"\x06errMsg\x18\x02 \x01(\tR\x06errMsg\x12\x18\n" +
@@ -1444,22 +4137,87 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\x15StatusBindPortRequest\x12\x1c\n" +
 	"\thoneyIPID\x18\x01 \x01(\rR\thoneyIPID\x12<\n" +
 	"\fportInfoList\x18\x02 \x03(\v2\x18.node_rpc.statusPortInfoR\fportInfoList\x12\x14\n" +
-	"\x05logID\x18\x03 \x01(\tR\x05logID\"6\n" +
+	"\x05logID\x18\x03 \x01(\tR\x05logID\"T\n" +
 	"\x0estatusPortInfo\x12\x12\n" +
 	"\x04port\x18\x01 \x01(\x03R\x04port\x12\x10\n" +
-	"\x03msg\x18\x02 \x01(\tR\x03msg\"i\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\x12\x1c\n" +
+	"\tagreement\x18\x03 \x01(\x05R\tagreement\"i\n" +
 	"\x15StatusDeleteIPRequest\x12$\n" +
 	"\rhoneyIPIDList\x18\x01 \x03(\rR\rhoneyIPIDList\x12\x14\n" +
 	"\x05netID\x18\x02 \x01(\x03R\x05netID\x12\x14\n" +
-	"\x05logID\x18\x03 \x01(\tR\x05logID\"<\n" +
+	"\x05logID\x18\x03 \x01(\tR\x05logID\"r\n" +
 	"\n" +
 	"TunnelData\x12\x14\n" +
 	"\x05chunk\x18\x01 \x01(\fR\x05chunk\x12\x18\n" +
-	"\aaddress\x18\x02 \x01(\tR\aaddress*M\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x18\n" +
+	"\asrcAddr\x18\x03 \x01(\tR\asrcAddr\x12\x1a\n" +
+	"\bdestAddr\x18\x04 \x01(\tR\bdestAddr\"Y\n" +
+	"\rUdpTunnelData\x12\x1a\n" +
+	"\bdatagram\x18\x01 \x01(\fR\bdatagram\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04peer\x18\x03 \x01(\tR\x04peer\"\xd2\x01\n" +
+	"\bMuxFrame\x12\x1c\n" +
+	"\tsessionID\x18\x01 \x01(\rR\tsessionID\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\x05R\x04type\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06window\x18\x05 \x01(\rR\x06window\x12\x16\n" +
+	"\x06errMsg\x18\x06 \x01(\tR\x06errMsg\x12\x18\n" +
+	"\asrcAddr\x18\a \x01(\tR\asrcAddr\x12\x1a\n" +
+	"\bdestAddr\x18\b \x01(\tR\bdestAddr\"\x97\x01\n" +
+	"\tPcapChunk\x12\x1c\n" +
+	"\tcaptureID\x18\x01 \x01(\tR\tcaptureID\x12\x14\n" +
+	"\x05chunk\x18\x02 \x01(\fR\x05chunk\x12 \n" +
+	"\vpacketCount\x18\x03 \x01(\x03R\vpacketCount\x12\x1c\n" +
+	"\tdropCount\x18\x04 \x01(\x03R\tdropCount\x12\x16\n" +
+	"\x06errMsg\x18\x05 \x01(\tR\x06errMsg\"`\n" +
+	"\x11ReportHostRequest\x12\x18\n" +
+	"\anodeUid\x18\x01 \x01(\tR\anodeUid\x121\n" +
+	"\teventList\x18\x02 \x03(\v2\x13.node_rpc.hostEventR\teventList\"\xdb\x01\n" +
+	"\thostEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x05R\x04type\x12\x14\n" +
+	"\x05netID\x18\x02 \x01(\rR\x05netID\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x10\n" +
+	"\x03mac\x18\x04 \x01(\tR\x03mac\x12\x14\n" +
+	"\x05manuf\x18\x05 \x01(\tR\x05manuf\x12\x1a\n" +
+	"\bhostname\x18\x06 \x01(\tR\bhostname\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\x12\x1c\n" +
+	"\tfirstSeen\x18\b \x01(\tR\tfirstSeen\x12\x1a\n" +
+	"\blastSeen\x18\t \x01(\tR\blastSeen\"\xd7\x01\n" +
+	"\x17StatusIpConflictRequest\x12\x18\n" +
+	"\anodeUid\x18\x01 \x01(\tR\anodeUid\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x18\n" +
+	"\anetwork\x18\x03 \x01(\tR\anetwork\x12\x10\n" +
+	"\x03mac\x18\x04 \x01(\tR\x03mac\x12\x14\n" +
+	"\x05manuf\x18\x05 \x01(\tR\x05manuf\x12\x18\n" +
+	"\aarpType\x18\x06 \x01(\tR\aarpType\x12\x1e\n" +
+	"\n" +
+	"detectTime\x18\a \x01(\tR\n" +
+	"detectTime\x12\x16\n" +
+	"\x06errMsg\x18\b \x01(\tR\x06errMsg\"[\n" +
+	"\rEnrollRequest\x12\x18\n" +
+	"\anodeUid\x18\x01 \x01(\tR\anodeUid\x12\x10\n" +
+	"\x03csr\x18\x02 \x01(\fR\x03csr\x12\x1e\n" +
+	"\n" +
+	"enrollCode\x18\x03 \x01(\tR\n" +
+	"enrollCode\"4\n" +
+	"\x0eEnrollResponse\x12\x12\n" +
+	"\x04cert\x18\x01 \x01(\fR\x04cert\x12\x0e\n" +
+	"\x02ca\x18\x02 \x01(\fR\x02ca*\x8b\x02\n" +
 	"\aCmdType\x12\x17\n" +
 	"\x13cmdNetworkFlushType\x10\x00\x12\x12\n" +
 	"\x0ecmdNetScanType\x10\x01\x12\x15\n" +
-	"\x11cmdNodeRemoveType\x10\x022\xf8\x03\n" +
+	"\x11cmdNodeRemoveType\x10\x02\x12\x16\n" +
+	"\x12cmdPcapCaptureType\x10\x03\x12\x14\n" +
+	"\x10cmdSyncStateType\x10\x04\x12\x12\n" +
+	"\x0ecmdRuleSetType\x10\x05\x12\x16\n" +
+	"\x12cmdNodeUpgradeType\x10\x06\x12\x13\n" +
+	"\x0fcmdDiagnoseType\x10\a\x12\x14\n" +
+	"\x10cmdDiscoveryType\x10\b\x12\x0f\n" +
+	"\vcmdVlanType\x10\t\x12\x0f\n" +
+	"\vcmdTaskType\x10\n" +
+	"\x12\x15\n" +
+	"\x11cmdCertRotateType\x10\v2\x8c\a\n" +
 	"\vNodeService\x12?\n" +
 	"\bRegister\x12\x19.node_rpc.RegisterRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12G\n" +
 	"\fNodeResource\x12\x1d.node_rpc.NodeResourceRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12<\n" +
@@ -1467,7 +4225,15 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\x0eStatusCreateIP\x12\x1f.node_rpc.StatusCreateIPRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12K\n" +
 	"\x0eStatusBindPort\x12\x1f.node_rpc.StatusBindPortRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12K\n" +
 	"\x0eStatusDeleteIP\x12\x1f.node_rpc.StatusDeleteIPRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12:\n" +
-	"\x06Tunnel\x12\x14.node_rpc.TunnelData\x1a\x14.node_rpc.TunnelData\"\x00(\x010\x01B\vZ\t/node_rpcb\x06proto3"
+	"\x06Tunnel\x12\x14.node_rpc.TunnelData\x1a\x14.node_rpc.TunnelData\"\x00(\x010\x01\x12C\n" +
+	"\tUdpTunnel\x12\x17.node_rpc.UdpTunnelData\x1a\x17.node_rpc.UdpTunnelData\"\x00(\x010\x01\x129\n" +
+	"\tMuxTunnel\x12\x12.node_rpc.MuxFrame\x1a\x12.node_rpc.MuxFrame\"\x00(\x010\x01\x12=\n" +
+	"\n" +
+	"UploadPcap\x12\x13.node_rpc.PcapChunk\x1a\x16.node_rpc.BaseResponse\"\x00(\x01\x12C\n" +
+	"\n" +
+	"ReportHost\x12\x1b.node_rpc.ReportHostRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12O\n" +
+	"\x10StatusIpConflict\x12!.node_rpc.StatusIpConflictRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12=\n" +
+	"\x06Enroll\x12\x17.node_rpc.EnrollRequest\x1a\x18.node_rpc.EnrollResponse\"\x00B\vZ\t/node_rpcb\x06proto3"
 
 var (
 	file_internal_rpc_node_proto_rawDescOnce sync.Once
@@ -1482,28 +4248,60 @@ func file_internal_rpc_node_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_rpc_node_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_rpc_node_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_internal_rpc_node_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_internal_rpc_node_proto_goTypes = []any{
-	(CmdType)(0),                   // 0: node_rpc.CmdType
-	(*BaseResponse)(nil),           // 1: node_rpc.BaseResponse
-	(*RegisterRequest)(nil),        // 2: node_rpc.RegisterRequest
-	(*NodeResourceRequest)(nil),    // 3: node_rpc.NodeResourceRequest
-	(*SystemInfoMessage)(nil),      // 4: node_rpc.systemInfoMessage
-	(*ResourceMessage)(nil),        // 5: node_rpc.resourceMessage
-	(*NetworkInfoMessage)(nil),     // 6: node_rpc.networkInfoMessage
-	(*CmdRequest)(nil),             // 7: node_rpc.CmdRequest
-	(*NetworkFlushInMessage)(nil),  // 8: node_rpc.NetworkFlushInMessage
-	(*NetScanInMessage)(nil),       // 9: node_rpc.NetScanInMessage
-	(*NodeRemoveInMessage)(nil),    // 10: node_rpc.NodeRemoveInMessage
-	(*NetworkFlushOutMessage)(nil), // 11: node_rpc.NetworkFlushOutMessage
-	(*NetScanOutMessage)(nil),      // 12: node_rpc.NetScanOutMessage
-	(*NodeRemoveOutMessage)(nil),   // 13: node_rpc.NodeRemoveOutMessage
-	(*CmdResponse)(nil),            // 14: node_rpc.CmdResponse
-	(*StatusCreateIPRequest)(nil),  // 15: node_rpc.StatusCreateIPRequest
-	(*StatusBindPortRequest)(nil),  // 16: node_rpc.StatusBindPortRequest
-	(*StatusPortInfo)(nil),         // 17: node_rpc.statusPortInfo
-	(*StatusDeleteIPRequest)(nil),  // 18: node_rpc.StatusDeleteIPRequest
-	(*TunnelData)(nil),             // 19: node_rpc.TunnelData
+	(CmdType)(0),                    // 0: node_rpc.CmdType
+	(*BaseResponse)(nil),            // 1: node_rpc.BaseResponse
+	(*RegisterRequest)(nil),         // 2: node_rpc.RegisterRequest
+	(*NodeResourceRequest)(nil),     // 3: node_rpc.NodeResourceRequest
+	(*SystemInfoMessage)(nil),       // 4: node_rpc.systemInfoMessage
+	(*ResourceMessage)(nil),         // 5: node_rpc.resourceMessage
+	(*NetworkInfoMessage)(nil),      // 6: node_rpc.networkInfoMessage
+	(*CmdRequest)(nil),              // 7: node_rpc.CmdRequest
+	(*NetworkFlushInMessage)(nil),   // 8: node_rpc.NetworkFlushInMessage
+	(*NetScanInMessage)(nil),        // 9: node_rpc.NetScanInMessage
+	(*NodeRemoveInMessage)(nil),     // 10: node_rpc.NodeRemoveInMessage
+	(*PcapCaptureInMessage)(nil),    // 11: node_rpc.PcapCaptureInMessage
+	(*SyncStateInMessage)(nil),      // 12: node_rpc.SyncStateInMessage
+	(*SyncIpInfo)(nil),              // 13: node_rpc.syncIpInfo
+	(*SyncPortInfo)(nil),            // 14: node_rpc.syncPortInfo
+	(*RuleSetInMessage)(nil),        // 15: node_rpc.RuleSetInMessage
+	(*NodeUpgradeInMessage)(nil),    // 16: node_rpc.NodeUpgradeInMessage
+	(*DiagnoseInMessage)(nil),       // 17: node_rpc.DiagnoseInMessage
+	(*DiscoveryInMessage)(nil),      // 18: node_rpc.DiscoveryInMessage
+	(*DiscoveryNetwork)(nil),        // 19: node_rpc.discoveryNetwork
+	(*VlanInMessage)(nil),           // 20: node_rpc.VlanInMessage
+	(*TaskInMessage)(nil),           // 21: node_rpc.TaskInMessage
+	(*CertRotateInMessage)(nil),     // 22: node_rpc.CertRotateInMessage
+	(*NetworkFlushOutMessage)(nil),  // 23: node_rpc.NetworkFlushOutMessage
+	(*NetScanOutMessage)(nil),       // 24: node_rpc.NetScanOutMessage
+	(*NetScanPort)(nil),             // 25: node_rpc.NetScanPort
+	(*NodeRemoveOutMessage)(nil),    // 26: node_rpc.NodeRemoveOutMessage
+	(*PcapCaptureOutMessage)(nil),   // 27: node_rpc.PcapCaptureOutMessage
+	(*SyncStateOutMessage)(nil),     // 28: node_rpc.SyncStateOutMessage
+	(*SyncIpResult)(nil),            // 29: node_rpc.syncIpResult
+	(*RuleSetOutMessage)(nil),       // 30: node_rpc.RuleSetOutMessage
+	(*NodeUpgradeOutMessage)(nil),   // 31: node_rpc.NodeUpgradeOutMessage
+	(*DiagnoseOutMessage)(nil),      // 32: node_rpc.DiagnoseOutMessage
+	(*DiscoveryOutMessage)(nil),     // 33: node_rpc.DiscoveryOutMessage
+	(*VlanOutMessage)(nil),          // 34: node_rpc.VlanOutMessage
+	(*TaskInfo)(nil),                // 35: node_rpc.taskInfo
+	(*TaskOutMessage)(nil),          // 36: node_rpc.TaskOutMessage
+	(*CertRotateOutMessage)(nil),    // 37: node_rpc.CertRotateOutMessage
+	(*CmdResponse)(nil),             // 38: node_rpc.CmdResponse
+	(*StatusCreateIPRequest)(nil),   // 39: node_rpc.StatusCreateIPRequest
+	(*StatusBindPortRequest)(nil),   // 40: node_rpc.StatusBindPortRequest
+	(*StatusPortInfo)(nil),          // 41: node_rpc.statusPortInfo
+	(*StatusDeleteIPRequest)(nil),   // 42: node_rpc.StatusDeleteIPRequest
+	(*TunnelData)(nil),              // 43: node_rpc.TunnelData
+	(*UdpTunnelData)(nil),           // 44: node_rpc.UdpTunnelData
+	(*MuxFrame)(nil),                // 45: node_rpc.MuxFrame
+	(*PcapChunk)(nil),               // 46: node_rpc.PcapChunk
+	(*ReportHostRequest)(nil),       // 47: node_rpc.ReportHostRequest
+	(*HostEvent)(nil),               // 48: node_rpc.hostEvent
+	(*StatusIpConflictRequest)(nil), // 49: node_rpc.StatusIpConflictRequest
+	(*EnrollRequest)(nil),           // 50: node_rpc.EnrollRequest
+	(*EnrollResponse)(nil),          // 51: node_rpc.EnrollResponse
 }
 var file_internal_rpc_node_proto_depIdxs = []int32{
 	4,  // 0: node_rpc.RegisterRequest.systemInfo:type_name -> node_rpc.systemInfoMessage
@@ -1514,31 +4312,71 @@ var file_internal_rpc_node_proto_depIdxs = []int32{
 	8,  // 5: node_rpc.CmdRequest.NetworkFlushInMessage:type_name -> node_rpc.NetworkFlushInMessage
 	9,  // 6: node_rpc.CmdRequest.NetScanInMessage:type_name -> node_rpc.NetScanInMessage
 	10, // 7: node_rpc.CmdRequest.NodeRemoveInMessage:type_name -> node_rpc.NodeRemoveInMessage
-	6,  // 8: node_rpc.NetworkFlushOutMessage.networkList:type_name -> node_rpc.networkInfoMessage
-	0,  // 9: node_rpc.CmdResponse.cmdType:type_name -> node_rpc.CmdType
-	11, // 10: node_rpc.CmdResponse.NetworkFlushOutMessage:type_name -> node_rpc.NetworkFlushOutMessage
-	12, // 11: node_rpc.CmdResponse.NetScanOutMessage:type_name -> node_rpc.NetScanOutMessage
-	13, // 12: node_rpc.CmdResponse.NodeRemoveOutMessage:type_name -> node_rpc.NodeRemoveOutMessage
-	17, // 13: node_rpc.StatusBindPortRequest.portInfoList:type_name -> node_rpc.statusPortInfo
-	2,  // 14: node_rpc.NodeService.Register:input_type -> node_rpc.RegisterRequest
-	3,  // 15: node_rpc.NodeService.NodeResource:input_type -> node_rpc.NodeResourceRequest
-	14, // 16: node_rpc.NodeService.Command:input_type -> node_rpc.CmdResponse
-	15, // 17: node_rpc.NodeService.StatusCreateIP:input_type -> node_rpc.StatusCreateIPRequest
-	16, // 18: node_rpc.NodeService.StatusBindPort:input_type -> node_rpc.StatusBindPortRequest
-	18, // 19: node_rpc.NodeService.StatusDeleteIP:input_type -> node_rpc.StatusDeleteIPRequest
-	19, // 20: node_rpc.NodeService.Tunnel:input_type -> node_rpc.TunnelData
-	1,  // 21: node_rpc.NodeService.Register:output_type -> node_rpc.BaseResponse
-	1,  // 22: node_rpc.NodeService.NodeResource:output_type -> node_rpc.BaseResponse
-	7,  // 23: node_rpc.NodeService.Command:output_type -> node_rpc.CmdRequest
-	1,  // 24: node_rpc.NodeService.StatusCreateIP:output_type -> node_rpc.BaseResponse
-	1,  // 25: node_rpc.NodeService.StatusBindPort:output_type -> node_rpc.BaseResponse
-	1,  // 26: node_rpc.NodeService.StatusDeleteIP:output_type -> node_rpc.BaseResponse
-	19, // 27: node_rpc.NodeService.Tunnel:output_type -> node_rpc.TunnelData
-	21, // [21:28] is the sub-list for method output_type
-	14, // [14:21] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	11, // 8: node_rpc.CmdRequest.PcapCaptureInMessage:type_name -> node_rpc.PcapCaptureInMessage
+	12, // 9: node_rpc.CmdRequest.SyncStateInMessage:type_name -> node_rpc.SyncStateInMessage
+	15, // 10: node_rpc.CmdRequest.RuleSetInMessage:type_name -> node_rpc.RuleSetInMessage
+	16, // 11: node_rpc.CmdRequest.NodeUpgradeInMessage:type_name -> node_rpc.NodeUpgradeInMessage
+	17, // 12: node_rpc.CmdRequest.DiagnoseInMessage:type_name -> node_rpc.DiagnoseInMessage
+	18, // 13: node_rpc.CmdRequest.DiscoveryInMessage:type_name -> node_rpc.DiscoveryInMessage
+	20, // 14: node_rpc.CmdRequest.VlanInMessage:type_name -> node_rpc.VlanInMessage
+	21, // 15: node_rpc.CmdRequest.TaskInMessage:type_name -> node_rpc.TaskInMessage
+	22, // 16: node_rpc.CmdRequest.CertRotateInMessage:type_name -> node_rpc.CertRotateInMessage
+	13, // 17: node_rpc.SyncStateInMessage.ipList:type_name -> node_rpc.syncIpInfo
+	14, // 18: node_rpc.syncIpInfo.portList:type_name -> node_rpc.syncPortInfo
+	19, // 19: node_rpc.DiscoveryInMessage.networkList:type_name -> node_rpc.discoveryNetwork
+	6,  // 20: node_rpc.NetworkFlushOutMessage.networkList:type_name -> node_rpc.networkInfoMessage
+	25, // 21: node_rpc.NetScanOutMessage.portList:type_name -> node_rpc.NetScanPort
+	29, // 22: node_rpc.SyncStateOutMessage.ipList:type_name -> node_rpc.syncIpResult
+	29, // 23: node_rpc.SyncStateOutMessage.removeList:type_name -> node_rpc.syncIpResult
+	41, // 24: node_rpc.syncIpResult.portList:type_name -> node_rpc.statusPortInfo
+	6,  // 25: node_rpc.VlanOutMessage.network:type_name -> node_rpc.networkInfoMessage
+	35, // 26: node_rpc.TaskOutMessage.taskList:type_name -> node_rpc.taskInfo
+	0,  // 27: node_rpc.CmdResponse.cmdType:type_name -> node_rpc.CmdType
+	23, // 28: node_rpc.CmdResponse.NetworkFlushOutMessage:type_name -> node_rpc.NetworkFlushOutMessage
+	24, // 29: node_rpc.CmdResponse.NetScanOutMessage:type_name -> node_rpc.NetScanOutMessage
+	26, // 30: node_rpc.CmdResponse.NodeRemoveOutMessage:type_name -> node_rpc.NodeRemoveOutMessage
+	27, // 31: node_rpc.CmdResponse.PcapCaptureOutMessage:type_name -> node_rpc.PcapCaptureOutMessage
+	28, // 32: node_rpc.CmdResponse.SyncStateOutMessage:type_name -> node_rpc.SyncStateOutMessage
+	30, // 33: node_rpc.CmdResponse.RuleSetOutMessage:type_name -> node_rpc.RuleSetOutMessage
+	31, // 34: node_rpc.CmdResponse.NodeUpgradeOutMessage:type_name -> node_rpc.NodeUpgradeOutMessage
+	32, // 35: node_rpc.CmdResponse.DiagnoseOutMessage:type_name -> node_rpc.DiagnoseOutMessage
+	33, // 36: node_rpc.CmdResponse.DiscoveryOutMessage:type_name -> node_rpc.DiscoveryOutMessage
+	34, // 37: node_rpc.CmdResponse.VlanOutMessage:type_name -> node_rpc.VlanOutMessage
+	36, // 38: node_rpc.CmdResponse.TaskOutMessage:type_name -> node_rpc.TaskOutMessage
+	37, // 39: node_rpc.CmdResponse.CertRotateOutMessage:type_name -> node_rpc.CertRotateOutMessage
+	41, // 40: node_rpc.StatusBindPortRequest.portInfoList:type_name -> node_rpc.statusPortInfo
+	48, // 41: node_rpc.ReportHostRequest.eventList:type_name -> node_rpc.hostEvent
+	2,  // 42: node_rpc.NodeService.Register:input_type -> node_rpc.RegisterRequest
+	3,  // 43: node_rpc.NodeService.NodeResource:input_type -> node_rpc.NodeResourceRequest
+	38, // 44: node_rpc.NodeService.Command:input_type -> node_rpc.CmdResponse
+	39, // 45: node_rpc.NodeService.StatusCreateIP:input_type -> node_rpc.StatusCreateIPRequest
+	40, // 46: node_rpc.NodeService.StatusBindPort:input_type -> node_rpc.StatusBindPortRequest
+	42, // 47: node_rpc.NodeService.StatusDeleteIP:input_type -> node_rpc.StatusDeleteIPRequest
+	43, // 48: node_rpc.NodeService.Tunnel:input_type -> node_rpc.TunnelData
+	44, // 49: node_rpc.NodeService.UdpTunnel:input_type -> node_rpc.UdpTunnelData
+	45, // 50: node_rpc.NodeService.MuxTunnel:input_type -> node_rpc.MuxFrame
+	46, // 51: node_rpc.NodeService.UploadPcap:input_type -> node_rpc.PcapChunk
+	47, // 52: node_rpc.NodeService.ReportHost:input_type -> node_rpc.ReportHostRequest
+	49, // 53: node_rpc.NodeService.StatusIpConflict:input_type -> node_rpc.StatusIpConflictRequest
+	50, // 54: node_rpc.NodeService.Enroll:input_type -> node_rpc.EnrollRequest
+	1,  // 55: node_rpc.NodeService.Register:output_type -> node_rpc.BaseResponse
+	1,  // 56: node_rpc.NodeService.NodeResource:output_type -> node_rpc.BaseResponse
+	7,  // 57: node_rpc.NodeService.Command:output_type -> node_rpc.CmdRequest
+	1,  // 58: node_rpc.NodeService.StatusCreateIP:output_type -> node_rpc.BaseResponse
+	1,  // 59: node_rpc.NodeService.StatusBindPort:output_type -> node_rpc.BaseResponse
+	1,  // 60: node_rpc.NodeService.StatusDeleteIP:output_type -> node_rpc.BaseResponse
+	43, // 61: node_rpc.NodeService.Tunnel:output_type -> node_rpc.TunnelData
+	44, // 62: node_rpc.NodeService.UdpTunnel:output_type -> node_rpc.UdpTunnelData
+	45, // 63: node_rpc.NodeService.MuxTunnel:output_type -> node_rpc.MuxFrame
+	1,  // 64: node_rpc.NodeService.UploadPcap:output_type -> node_rpc.BaseResponse
+	1,  // 65: node_rpc.NodeService.ReportHost:output_type -> node_rpc.BaseResponse
+	1,  // 66: node_rpc.NodeService.StatusIpConflict:output_type -> node_rpc.BaseResponse
+	51, // 67: node_rpc.NodeService.Enroll:output_type -> node_rpc.EnrollResponse
+	55, // [55:68] is the sub-list for method output_type
+	42, // [42:55] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_internal_rpc_node_proto_init() }
@@ -1552,7 +4390,7 @@ func file_internal_rpc_node_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_rpc_node_proto_rawDesc), len(file_internal_rpc_node_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   19,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NodeService_Register_FullMethodName       = "/node_rpc.NodeService/Register"
-	NodeService_NodeResource_FullMethodName   = "/node_rpc.NodeService/NodeResource"
-	NodeService_Command_FullMethodName        = "/node_rpc.NodeService/Command"
-	NodeService_StatusCreateIP_FullMethodName = "/node_rpc.NodeService/StatusCreateIP"
-	NodeService_StatusBindPort_FullMethodName = "/node_rpc.NodeService/StatusBindPort"
-	NodeService_StatusDeleteIP_FullMethodName = "/node_rpc.NodeService/StatusDeleteIP"
-	NodeService_Tunnel_FullMethodName         = "/node_rpc.NodeService/Tunnel"
+	NodeService_Register_FullMethodName         = "/node_rpc.NodeService/Register"
+	NodeService_NodeResource_FullMethodName     = "/node_rpc.NodeService/NodeResource"
+	NodeService_Command_FullMethodName          = "/node_rpc.NodeService/Command"
+	NodeService_StatusCreateIP_FullMethodName   = "/node_rpc.NodeService/StatusCreateIP"
+	NodeService_StatusBindPort_FullMethodName   = "/node_rpc.NodeService/StatusBindPort"
+	NodeService_StatusDeleteIP_FullMethodName   = "/node_rpc.NodeService/StatusDeleteIP"
+	NodeService_Tunnel_FullMethodName           = "/node_rpc.NodeService/Tunnel"
+	NodeService_UdpTunnel_FullMethodName        = "/node_rpc.NodeService/UdpTunnel"
+	NodeService_MuxTunnel_FullMethodName        = "/node_rpc.NodeService/MuxTunnel"
+	NodeService_UploadPcap_FullMethodName       = "/node_rpc.NodeService/UploadPcap"
+	NodeService_ReportHost_FullMethodName       = "/node_rpc.NodeService/ReportHost"
+	NodeService_StatusIpConflict_FullMethodName = "/node_rpc.NodeService/StatusIpConflict"
+	NodeService_Enroll_FullMethodName           = "/node_rpc.NodeService/Enroll"
 )
 
 // NodeServiceClient is the client API for NodeService service.
@@ -48,6 +54,18 @@ type NodeServiceClient interface {
 	StatusDeleteIP(ctx context.Context, in *StatusDeleteIPRequest, opts ...grpc.CallOption) (*BaseResponse, error)
 	// 端口转发通道
 	Tunnel(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TunnelData, TunnelData], error)
+	// UDP端口转发通道（每条消息对应一个完整数据报）
+	UdpTunnel(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[UdpTunnelData, UdpTunnelData], error)
+	// 多路复用TCP端口转发通道（单个流承载多个会话）
+	MuxTunnel(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[MuxFrame, MuxFrame], error)
+	// 节点分块上传抓包文件
+	UploadPcap(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[PcapChunk, BaseResponse], error)
+	// 节点上报被动发现的主机变化
+	ReportHost(ctx context.Context, in *ReportHostRequest, opts ...grpc.CallOption) (*BaseResponse, error)
+	// 节点上报诱捕ip被真实主机占用
+	StatusIpConflict(ctx context.Context, in *StatusIpConflictRequest, opts ...grpc.CallOption) (*BaseResponse, error)
+	// 节点申请签发或轮换客户端证书
+	Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error)
 }
 
 type nodeServiceClient struct {
@@ -134,6 +152,75 @@ func (c *nodeServiceClient) Tunnel(ctx context.Context, opts ...grpc.CallOption)
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type NodeService_TunnelClient = grpc.BidiStreamingClient[TunnelData, TunnelData]
 
+func (c *nodeServiceClient) UdpTunnel(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[UdpTunnelData, UdpTunnelData], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NodeService_ServiceDesc.Streams[2], NodeService_UdpTunnel_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UdpTunnelData, UdpTunnelData]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeService_UdpTunnelClient = grpc.BidiStreamingClient[UdpTunnelData, UdpTunnelData]
+
+func (c *nodeServiceClient) MuxTunnel(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[MuxFrame, MuxFrame], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NodeService_ServiceDesc.Streams[3], NodeService_MuxTunnel_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MuxFrame, MuxFrame]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeService_MuxTunnelClient = grpc.BidiStreamingClient[MuxFrame, MuxFrame]
+
+func (c *nodeServiceClient) UploadPcap(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[PcapChunk, BaseResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NodeService_ServiceDesc.Streams[4], NodeService_UploadPcap_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PcapChunk, BaseResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeService_UploadPcapClient = grpc.ClientStreamingClient[PcapChunk, BaseResponse]
+
+func (c *nodeServiceClient) ReportHost(ctx context.Context, in *ReportHostRequest, opts ...grpc.CallOption) (*BaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BaseResponse)
+	err := c.cc.Invoke(ctx, NodeService_ReportHost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) StatusIpConflict(ctx context.Context, in *StatusIpConflictRequest, opts ...grpc.CallOption) (*BaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BaseResponse)
+	err := c.cc.Invoke(ctx, NodeService_StatusIpConflict_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollResponse)
+	err := c.cc.Invoke(ctx, NodeService_Enroll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeServiceServer is the server API for NodeService service.
 // All implementations must embed UnimplementedNodeServiceServer
 // for forward compatibility.
@@ -154,6 +241,18 @@ type NodeServiceServer interface {
 	StatusDeleteIP(context.Context, *StatusDeleteIPRequest) (*BaseResponse, error)
 	// 端口转发通道
 	Tunnel(grpc.BidiStreamingServer[TunnelData, TunnelData]) error
+	// UDP端口转发通道（每条消息对应一个完整数据报）
+	UdpTunnel(grpc.BidiStreamingServer[UdpTunnelData, UdpTunnelData]) error
+	// 多路复用TCP端口转发通道（单个流承载多个会话）
+	MuxTunnel(grpc.BidiStreamingServer[MuxFrame, MuxFrame]) error
+	// 节点分块上传抓包文件
+	UploadPcap(grpc.ClientStreamingServer[PcapChunk, BaseResponse]) error
+	// 节点上报被动发现的主机变化
+	ReportHost(context.Context, *ReportHostRequest) (*BaseResponse, error)
+	// 节点上报诱捕ip被真实主机占用
+	StatusIpConflict(context.Context, *StatusIpConflictRequest) (*BaseResponse, error)
+	// 节点申请签发或轮换客户端证书
+	Enroll(context.Context, *EnrollRequest) (*EnrollResponse, error)
 	mustEmbedUnimplementedNodeServiceServer()
 }
 
@@ -185,6 +284,24 @@ func (UnimplementedNodeServiceServer) StatusDeleteIP(context.Context, *StatusDel
 func (UnimplementedNodeServiceServer) Tunnel(grpc.BidiStreamingServer[TunnelData, TunnelData]) error {
 	return status.Error(codes.Unimplemented, "method Tunnel not implemented")
 }
+func (UnimplementedNodeServiceServer) UdpTunnel(grpc.BidiStreamingServer[UdpTunnelData, UdpTunnelData]) error {
+	return status.Error(codes.Unimplemented, "method UdpTunnel not implemented")
+}
+func (UnimplementedNodeServiceServer) MuxTunnel(grpc.BidiStreamingServer[MuxFrame, MuxFrame]) error {
+	return status.Error(codes.Unimplemented, "method MuxTunnel not implemented")
+}
+func (UnimplementedNodeServiceServer) UploadPcap(grpc.ClientStreamingServer[PcapChunk, BaseResponse]) error {
+	return status.Error(codes.Unimplemented, "method UploadPcap not implemented")
+}
+func (UnimplementedNodeServiceServer) ReportHost(context.Context, *ReportHostRequest) (*BaseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportHost not implemented")
+}
+func (UnimplementedNodeServiceServer) StatusIpConflict(context.Context, *StatusIpConflictRequest) (*BaseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StatusIpConflict not implemented")
+}
+func (UnimplementedNodeServiceServer) Enroll(context.Context, *EnrollRequest) (*EnrollResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Enroll not implemented")
+}
 func (UnimplementedNodeServiceServer) mustEmbedUnimplementedNodeServiceServer() {}
 func (UnimplementedNodeServiceServer) testEmbeddedByValue()                     {}
 
@@ -310,6 +427,81 @@ func _NodeService_Tunnel_Handler(srv interface{}, stream grpc.ServerStream) erro
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type NodeService_TunnelServer = grpc.BidiStreamingServer[TunnelData, TunnelData]
 
+func _NodeService_UdpTunnel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NodeServiceServer).UdpTunnel(&grpc.GenericServerStream[UdpTunnelData, UdpTunnelData]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeService_UdpTunnelServer = grpc.BidiStreamingServer[UdpTunnelData, UdpTunnelData]
+
+func _NodeService_MuxTunnel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NodeServiceServer).MuxTunnel(&grpc.GenericServerStream[MuxFrame, MuxFrame]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeService_MuxTunnelServer = grpc.BidiStreamingServer[MuxFrame, MuxFrame]
+
+func _NodeService_UploadPcap_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NodeServiceServer).UploadPcap(&grpc.GenericServerStream[PcapChunk, BaseResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NodeService_UploadPcapServer = grpc.ClientStreamingServer[PcapChunk, BaseResponse]
+
+func _NodeService_ReportHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ReportHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_ReportHost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ReportHost(ctx, req.(*ReportHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_StatusIpConflict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusIpConflictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).StatusIpConflict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_StatusIpConflict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).StatusIpConflict(ctx, req.(*StatusIpConflictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_Enroll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).Enroll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_Enroll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).Enroll(ctx, req.(*EnrollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NodeService_ServiceDesc is the grpc.ServiceDesc for NodeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -337,6 +529,18 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StatusDeleteIP",
 			Handler:    _NodeService_StatusDeleteIP_Handler,
 		},
+		{
+			MethodName: "ReportHost",
+			Handler:    _NodeService_ReportHost_Handler,
+		},
+		{
+			MethodName: "StatusIpConflict",
+			Handler:    _NodeService_StatusIpConflict_Handler,
+		},
+		{
+			MethodName: "Enroll",
+			Handler:    _NodeService_Enroll_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -351,6 +555,23 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "UdpTunnel",
+			Handler:       _NodeService_UdpTunnel_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "MuxTunnel",
+			Handler:       _NodeService_MuxTunnel_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadPcap",
+			Handler:       _NodeService_UploadPcap_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "internal/rpc/node.proto",
 }
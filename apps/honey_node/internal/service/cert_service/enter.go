@@ -0,0 +1,167 @@
+package cert_service
+
+// File: honey_node/service/cert_service/enter.go
+// Description: 节点证书管理模块，首次接入时使用镜像内置的引导证书向管理端申请节点证书，证书保存在节点数据库中，剩余有效期不足三分之一时自动轮换
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/rpc"
+	"honey_node/internal/rpc/node_rpc"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// renewLock 避免定时轮换与管理端下发的轮换命令同时执行
+var renewLock sync.Mutex
+
+// Ensure 加载节点证书，尚未申请或需要轮换时向管理端申请新证书
+// 已有证书仍在有效期内时轮换失败不影响节点运行
+func Ensure(client node_rpc.NodeServiceClient) error {
+	model, ok := load()
+	if ok && !needRenew(model) {
+		return nil
+	}
+	_, err := Renew(client)
+	if err == nil {
+		rpc.Reconnect()
+		return nil
+	}
+	if ok && time.Now().Before(model.NotAfter) {
+		logrus.Warnf("节点证书轮换失败，继续使用当前证书 %s", err)
+		return nil
+	}
+	return err
+}
+
+// Check 定时检查节点证书有效期，需要轮换时申请新证书并重建连接
+func Check() {
+	model, ok := load()
+	if !ok || !needRenew(model) {
+		return
+	}
+	if _, err := Renew(global.GrpcClient); err != nil {
+		logrus.Errorf("节点证书轮换失败 %s", err)
+		return
+	}
+	rpc.Reconnect()
+}
+
+// Reset 删除本地保存的节点证书，下次启动时重新申请
+func Reset() {
+	global.DB.Where("1 = 1").Delete(&models.CertModel{})
+}
+
+// IsRejected 判断错误是否为管理端拒绝当前证书（证书已吊销或与节点不一致）
+func IsRejected(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unauthenticated || code == codes.PermissionDenied
+}
+
+// load 从数据库加载节点证书并设置为gRPC客户端证书
+func load() (model models.CertModel, ok bool) {
+	if err := global.DB.Take(&model).Error; err != nil {
+		return model, false
+	}
+	cert, err := tls.X509KeyPair([]byte(model.CertPEM), []byte(model.KeyPEM))
+	if err != nil {
+		logrus.Errorf("节点证书解析失败 %s", err)
+		return model, false
+	}
+	rpc.SetCert(cert)
+	return model, true
+}
+
+// needRenew 剩余有效期不足三分之一时需要轮换
+func needRenew(model models.CertModel) bool {
+	lifetime := model.NotAfter.Sub(model.CreatedAt)
+	return time.Until(model.NotAfter) < lifetime/3
+}
+
+// Renew 生成新的私钥向管理端申请节点证书，保存后设置为gRPC客户端证书
+// 新证书在底层连接重建后生效，调用方按需调用rpc.Reconnect
+func Renew(client node_rpc.NodeServiceClient) (model models.CertModel, err error) {
+	renewLock.Lock()
+	defer renewLock.Unlock()
+
+	uid := global.Config.System.Uid
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return model, fmt.Errorf("生成私钥失败 %s", err)
+	}
+	csrDer, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: uid},
+	}, key)
+	if err != nil {
+		return model, fmt.Errorf("生成证书签名请求失败 %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	res, err := client.Enroll(ctx, &node_rpc.EnrollRequest{
+		NodeUid:    uid,
+		Csr:        pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDer}),
+		EnrollCode: global.Config.System.EnrollCode,
+	})
+	if err != nil {
+		return model, fmt.Errorf("申请节点证书失败 %s", err)
+	}
+
+	block, _ := pem.Decode(res.Cert)
+	if block == nil {
+		return model, errors.New("节点证书格式错误")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return model, fmt.Errorf("节点证书解析失败 %s", err)
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) || cert.Subject.CommonName != uid {
+		return model, errors.New("节点证书与申请不一致")
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return model, err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	tlsCert, err := tls.X509KeyPair(res.Cert, keyPEM)
+	if err != nil {
+		return model, err
+	}
+
+	// 节点只保留一份证书，新证书入库后替换旧证书
+	model = models.CertModel{
+		Serial:   fmt.Sprintf("%x", cert.SerialNumber),
+		CertPEM:  string(res.Cert),
+		KeyPEM:   string(keyPEM),
+		NotAfter: cert.NotAfter,
+	}
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.CertModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model).Error
+	})
+	if err != nil {
+		return model, fmt.Errorf("保存节点证书失败 %s", err)
+	}
+
+	rpc.SetCert(tlsCert)
+	logrus.Infof("节点证书申请成功 serial=%s notAfter=%s", model.Serial, model.NotAfter.Format(time.DateTime))
+	return model, nil
+}
@@ -0,0 +1,40 @@
+package command
+
+// File: honey_node/service/command/command_cert_rotate.go
+// Description: 节点证书轮换命令实现，按管理端请求重新申请节点证书，响应发出后重建gRPC连接使新证书生效
+
+import (
+	"honey_node/internal/core"
+	"honey_node/internal/rpc"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/cert_service"
+	"time"
+)
+
+// certReconnectDelay 轮换成功后重建连接前的等待时间，保证轮换响应已通过当前命令流发出
+const certReconnectDelay = 2 * time.Second
+
+// CmdCertRotate 处理节点证书轮换命令请求
+func (nc *NodeClient) CmdCertRotate(request *node_rpc.CmdRequest) {
+	log := core.GetLogger().WithField("logID", request.LogID)
+
+	res := new(node_rpc.CertRotateOutMessage)
+	model, err := cert_service.Renew(nc.client)
+	if err != nil {
+		log.Errorf("节点证书轮换失败 %s", err)
+		res.ErrMsg = err.Error()
+	} else {
+		log.Infof("节点证书轮换成功 %s", model.Serial)
+		res.Serial = model.Serial
+		res.NotAfter = model.NotAfter.Format(time.DateTime)
+		time.AfterFunc(certReconnectDelay, rpc.Reconnect)
+	}
+
+	nc.cmdResponseChan <- &node_rpc.CmdResponse{
+		CmdType:              node_rpc.CmdType_cmdCertRotateType, // 命令类型：节点证书轮换
+		TaskID:               request.TaskID,                     // 关联的任务ID
+		NodeID:               nc.config.System.Uid,               // 当前节点唯一标识
+		LogID:                request.LogID,                      // 日志ID
+		CertRotateOutMessage: res,                                // 节点证书轮换响应体
+	}
+}
@@ -0,0 +1,42 @@
+package command
+
+// File: honey_node/service/command/command_diagnose.go
+// Description: 节点诊断命令实现，采集节点运行状态并以JSON诊断报告返回管理端
+
+import (
+	"context"
+	"encoding/json"
+	"honey_node/internal/core"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/diagnose_service"
+	"time"
+)
+
+// diagnoseTimeout 采集诊断报告的总超时时间，需小于管理端等待诊断报告的超时时间
+const diagnoseTimeout = 20 * time.Second
+
+// CmdDiagnose 处理节点诊断命令请求（由命令分发协程异步调用，采集过程不阻塞其他命令）
+func (nc *NodeClient) CmdDiagnose(request *node_rpc.CmdRequest) {
+	req := request.GetDiagnoseInMessage()
+	log := core.GetLogger().WithField("logID", request.LogID)
+	log.Infof("节点诊断 错误日志行数 %d", req.GetLogLines())
+
+	ctx, cancel := context.WithTimeout(context.Background(), diagnoseTimeout)
+	defer cancel()
+	res := new(node_rpc.DiagnoseOutMessage)
+	byteData, err := json.Marshal(diagnose_service.Collect(ctx, int(req.GetLogLines())))
+	if err != nil {
+		log.Errorf("诊断报告序列化失败 %s", err)
+		res.ErrMsg = err.Error()
+	} else {
+		res.Report = string(byteData)
+	}
+
+	nc.cmdResponseChan <- &node_rpc.CmdResponse{
+		CmdType:            node_rpc.CmdType_cmdDiagnoseType, // 命令类型：节点诊断
+		TaskID:             request.TaskID,                   // 关联的任务ID
+		NodeID:             nc.config.System.Uid,             // 当前节点唯一标识
+		LogID:              request.LogID,                    // 日志ID
+		DiagnoseOutMessage: res,                              // 诊断响应体
+	}
+}
@@ -0,0 +1,59 @@
+package command
+
+// File: honey_node/service/command/command_discovery.go
+// Description: 被动资产发现配置命令实现，按管理端下发的网卡列表启停被动监听并返回监听中的网卡
+
+import (
+	"fmt"
+	"honey_node/internal/core"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/discovery_service"
+	"net"
+	"strings"
+	"time"
+)
+
+// CmdDiscovery 处理被动资产发现配置命令请求
+func (nc *NodeClient) CmdDiscovery(request *node_rpc.CmdRequest) {
+	req := request.GetDiscoveryInMessage()
+	log := core.GetLogger().WithField("logID", request.LogID)
+	log.Infof("被动资产发现配置 网卡数 %d", len(req.GetNetworkList()))
+
+	var networkList []discovery_service.Network
+	var errList []string
+	for _, network := range req.GetNetworkList() {
+		_, subnet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", network.Ip, network.Mask))
+		if err != nil {
+			log.Errorf("网卡子网解析失败 %s %s", network.Network, err)
+			errList = append(errList, fmt.Sprintf("%s 子网解析失败", network.Network))
+			continue
+		}
+		item := discovery_service.Network{
+			Name:   network.Network,
+			NetID:  network.NetID,
+			Subnet: subnet,
+		}
+		// 双栈网络同时监听IPv6子网内的主机
+		if network.Ip6 != "" {
+			if _, subnet6, err := net.ParseCIDR(fmt.Sprintf("%s/%d", network.Ip6, network.Mask6)); err == nil {
+				item.Subnet6 = subnet6
+			} else {
+				log.Errorf("网卡IPv6子网解析失败 %s %s", network.Network, err)
+			}
+		}
+		networkList = append(networkList, item)
+	}
+	running, applyErrList := discovery_service.Apply(networkList, time.Duration(req.GetVanishTime())*time.Second)
+	errList = append(errList, applyErrList...)
+
+	nc.cmdResponseChan <- &node_rpc.CmdResponse{
+		CmdType: node_rpc.CmdType_cmdDiscoveryType, // 命令类型：被动资产发现配置
+		TaskID:  request.TaskID,                    // 关联的任务ID
+		NodeID:  nc.config.System.Uid,              // 当前节点唯一标识
+		LogID:   request.LogID,                     // 日志ID
+		DiscoveryOutMessage: &node_rpc.DiscoveryOutMessage{ // 被动资产发现响应体
+			NetworkList: running,
+			ErrMsg:      strings.Join(errList, "; "),
+		},
+	}
+}
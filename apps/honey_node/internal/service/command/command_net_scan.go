@@ -1,132 +1,214 @@
 package command
 
 // File: honey_node/service/command/command_net_scan.go
-// Description: 节点客户端网络扫描命令实现，基于ARP协议进行IP存活探测，支持并发扫描及进度反馈
+// Description: 节点客户端网络扫描命令实现，基于ARP（IPv4）及NDP（IPv6）协议进行IP存活探测，可选对存活主机进行端口服务探测及banner抓取，扫描任务由任务引擎并发执行，支持进度反馈及取消
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"honey_node/internal/core"
+	"honey_node/internal/models"
 	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/task_service"
 	"honey_node/internal/utils/ip"
+	"honey_node/internal/utils/neighbor"
+	"honey_node/internal/utils/portscan"
 	"net"
 	"sync"
 	"time"
 
-	"github.com/j-keck/arping"
+	"github.com/sirupsen/logrus"
 )
 
-// CmdNetScan 处理网络扫描命令请求，执行ARP扫描并返回扫描结果
+// portScanConcurrency 端口服务探测的最大并发数（所有主机共用）
+const portScanConcurrency = 256
+
+// netScanKind 网络扫描任务类型，任务项为待扫描的IP，扫描结果实时推送至等待中的管理端，节点重启后不可恢复
+func (nc *NodeClient) netScanKind() task_service.Kind {
+	return task_service.Kind{
+		Name:        "网络扫描",
+		Concurrency: 200, // 最大并发扫描数
+		Resumable:   false,
+		Items: func(task models.TaskModel) (itemList []string) {
+			ipList, _ := ip.ParseIPRange(task.NetScanData.IpRange)
+			// 构建过滤IP集合，用于跳过指定IP的扫描
+			filterIPList := map[string]struct{}{}
+			for _, s := range task.NetScanData.FilterIPList {
+				filterIPList[s] = struct{}{}
+			}
+			for _, s := range ipList {
+				if _, exists := filterIPList[s]; exists {
+					continue
+				}
+				itemList = append(itemList, s)
+			}
+			return
+		},
+		New: nc.newNetScanHandler,
+	}
+}
+
+// CmdNetScan 处理网络扫描命令请求，扫描任务提交至任务引擎执行，可通过任务命令取消
 func (nc *NodeClient) CmdNetScan(request *node_rpc.CmdRequest) {
 	// 获取网络扫描请求参数
 	req := request.GetNetScanInMessage()
 	fmt.Printf("网络扫描 %v\n", req)
-	t1 := time.Now()
 
-	// 解析IP范围，生成待扫描的IP列表
-	ipList, err := ip.ParseIPRange(req.IpRange)
+	// 解析IP范围，校验待扫描的IP列表
+	_, err := ip.ParseIPRange(req.IpRange)
 	if err != nil {
 		fmt.Println(err)
 		// 解析失败时返回错误响应
-		nc.cmdResponseChan <- &node_rpc.CmdResponse{
-			CmdType: node_rpc.CmdType_cmdNetScanType,
-			TaskID:  request.TaskID,
-			NodeID:  nc.config.System.Uid,
-			NetScanOutMessage: &node_rpc.NetScanOutMessage{
-				End:      true,
-				Progress: 0,
-				NetID:    req.NetID,
-				ErrMsg:   fmt.Sprintf("解析扫描ip列表出错 %s", err),
-			},
-		}
+		nc.sendNetScanEnd(request.TaskID, req.NetID, 0, fmt.Sprintf("解析扫描ip列表出错 %s", err))
 		return
 	}
 
-	// 构建过滤IP集合，用于跳过指定IP的扫描
-	filterIPList := map[string]struct{}{}
-	for _, s := range req.FilterIPList {
-		filterIPList[s] = struct{}{}
+	data := models.NetScanRequest{
+		NetID:        uint(req.NetID),
+		Network:      req.Network,
+		IpRange:      req.IpRange,
+		FilterIPList: req.FilterIPList,
+		ServiceScan:  req.ServiceScan,
+		ScanMode:     int(req.ScanMode),
+		Timeout:      int(req.Timeout),
 	}
+	for _, port := range req.PortList {
+		data.PortList = append(data.PortList, int(port))
+	}
+	// 扫描任务沿用管理端下发的任务ID，管理端可按该ID取消扫描
+	_, err = task_service.Submit(models.TaskModel{
+		TaskID:      request.TaskID,
+		Type:        5,
+		LogID:       request.LogID,
+		NetScanData: &data,
+	})
+	if err != nil {
+		nc.sendNetScanEnd(request.TaskID, req.NetID, 0, fmt.Sprintf("扫描任务创建失败 %s", err))
+	}
+}
 
-	iface := req.Network          // 扫描使用的网络接口
-	concurrency := 200            // 最大并发扫描数
-	totalIPs := len(ipList)       // 待扫描IP总数
-	processed := 0                // 已处理IP数量
-	var processedMutex sync.Mutex // 保护processed变量的互斥锁
-
-	// 创建并发控制信号量通道
-	semaphore := make(chan struct{}, concurrency)
+// netScanHandler 网络扫描任务处理器
+type netScanHandler struct {
+	nc             *NodeClient
+	taskID         string
+	req            models.NetScanRequest
+	totalIPs       int               // 待扫描IP总数
+	processed      int               // 已处理IP数量
+	processedMutex sync.Mutex        // 保护processed变量的互斥锁
+	scanner        *portscan.Scanner // 端口扫描器，未开启端口服务探测时为空
+	t1             time.Time
+}
 
-	fmt.Printf("开始扫描 %d 个IP地址，并发数: %d\n", totalIPs, concurrency)
+// newNetScanHandler 创建网络扫描任务处理器
+func (nc *NodeClient) newNetScanHandler(task models.TaskModel) (task_service.Handler, error) {
+	if task.NetScanData == nil {
+		return nil, errors.New("网络扫描参数为空")
+	}
+	h := &netScanHandler{
+		nc:       nc,
+		taskID:   task.TaskID,
+		req:      *task.NetScanData,
+		totalIPs: task.Total,
+		t1:       time.Now(),
+	}
 
-	var wg sync.WaitGroup // 等待所有扫描协程完成
-	for _, s := range ipList {
-		// 跳过过滤列表中的IP
-		if _, exists := filterIPList[s]; exists {
-			continue
+	// 开启端口服务探测时创建端口扫描器，SYN方式不可用（如无原始套接字权限）时使用TCP connect方式
+	if h.req.ServiceScan && len(h.req.PortList) > 0 {
+		timeout := time.Duration(h.req.Timeout) * time.Millisecond
+		scanner, err := portscan.New(h.req.ScanMode, h.req.Network, timeout, portScanConcurrency)
+		if err != nil {
+			logrus.Warnf("SYN探测不可用，使用TCP connect方式 %s", err)
+			scanner, _ = portscan.New(portscan.ModeConnect, h.req.Network, timeout, portScanConcurrency)
 		}
+		h.scanner = scanner
+	}
 
-		wg.Add(1)
-
-		// 获取信号量，控制并发数
-		semaphore <- struct{}{}
+	fmt.Printf("开始扫描 %d 个IP地址\n", h.totalIPs)
+	return h, nil
+}
 
-		// 启动协程执行ARP扫描
-		go func(s string) {
-			defer wg.Done()
-			defer func() {
-				// 协程结束时释放信号量
-				<-semaphore
-			}()
+// Exec 对单个IP执行存活探测，存活主机按需进行端口服务探测并推送扫描结果
+func (h *netScanHandler) Exec(ctx context.Context, s string) error {
+	// 通过指定网络接口执行ARP/NDP探测
+	mac, _, err := neighbor.Ping(net.ParseIP(s), h.req.Network)
 
-			// 通过指定网络接口执行ARP ping
-			mac, _, err := arping.PingOverIfaceByName(net.ParseIP(s), iface)
+	// 更新扫描进度
+	h.processedMutex.Lock()
+	h.processed++
+	progress := float64(h.processed) / float64(h.totalIPs) * 100
+	h.processedMutex.Unlock()
 
-			// 更新扫描进度
-			processedMutex.Lock()
-			processed++
-			progress := float64(processed) / float64(totalIPs) * 100
-			processedMutex.Unlock()
+	// 目标主机不可达时跳过
+	if err != nil {
+		return nil
+	}
 
-			// 目标主机不可达时跳过
-			if err != nil {
-				return
-			}
+	// 查询MAC地址对应的厂商信息
+	manuf, _ := core.ManufQuery(mac.String())
+	fmt.Printf("%s %s %s %.2f\n", s, mac, manuf, progress)
+
+	// 探测存活主机的开放端口及服务banner
+	var scanPortList []*node_rpc.NetScanPort
+	if h.scanner != nil {
+		for _, port := range h.scanner.Scan(s, h.req.PortList) {
+			scanPortList = append(scanPortList, &node_rpc.NetScanPort{
+				Port:   int32(port.Port),
+				Banner: port.Banner,
+			})
+		}
+		logrus.WithFields(logrus.Fields{
+			"ip":       s,
+			"netID":    h.req.NetID,
+			"portList": len(scanPortList),
+		}).Debug("存活主机端口探测完成")
+	}
 
-			// 查询MAC地址对应的厂商信息
-			manuf, _ := core.ManufQuery(mac.String())
-			fmt.Printf("%s %s %s %.2f\n", s, mac, manuf, progress)
-
-			// 发送扫描结果响应
-			nc.cmdResponseChan <- &node_rpc.CmdResponse{
-				CmdType: node_rpc.CmdType_cmdNetScanType,
-				TaskID:  request.TaskID,
-				NodeID:  nc.config.System.Uid,
-				NetScanOutMessage: &node_rpc.NetScanOutMessage{
-					End:      false,
-					Progress: float32(progress),
-					NetID:    req.NetID,
-					Ip:       s,
-					Mac:      mac.String(),
-					Manuf:    manuf,
-				},
-			}
-		}(s)
+	// 发送扫描结果响应
+	h.nc.cmdResponseChan <- &node_rpc.CmdResponse{
+		CmdType: node_rpc.CmdType_cmdNetScanType,
+		TaskID:  h.taskID,
+		NodeID:  h.nc.config.System.Uid,
+		NetScanOutMessage: &node_rpc.NetScanOutMessage{
+			End:      false,
+			Progress: float32(progress),
+			NetID:    uint32(h.req.NetID),
+			Ip:       s,
+			Mac:      mac.String(),
+			Manuf:    manuf,
+			PortList: scanPortList,
+		},
 	}
+	return nil
+}
 
-	// 等待所有扫描协程完成
-	wg.Wait()
-	close(semaphore) // 关闭信号量通道
+// Fail 扫描任务被取消时未扫描的IP，无需处理
+func (h *netScanHandler) Fail(item string, err error) {}
+
+// Close 扫描结束，释放端口扫描器并发送扫描完成响应
+func (h *netScanHandler) Close(status int8) {
+	if h.scanner != nil {
+		h.scanner.Close()
+	}
+	var errMsg string
+	if status == 3 {
+		errMsg = "扫描任务已取消"
+	}
+	h.nc.sendNetScanEnd(h.taskID, uint32(h.req.NetID), 100, errMsg)
+	fmt.Printf("\n扫描完成，耗时: %v\n", time.Since(h.t1))
+}
 
-	// 发送扫描完成响应
+// sendNetScanEnd 发送扫描结束响应
+func (nc *NodeClient) sendNetScanEnd(taskID string, netID uint32, progress float32, errMsg string) {
 	nc.cmdResponseChan <- &node_rpc.CmdResponse{
 		CmdType: node_rpc.CmdType_cmdNetScanType,
-		TaskID:  request.TaskID,
+		TaskID:  taskID,
 		NodeID:  nc.config.System.Uid,
 		NetScanOutMessage: &node_rpc.NetScanOutMessage{
 			End:      true,
-			Progress: 100,
-			NetID:    req.NetID,
+			Progress: progress,
+			NetID:    netID,
+			ErrMsg:   errMsg,
 		},
 	}
-	fmt.Printf("\n扫描完成，耗时: %v\n", time.Since(t1))
 }
@@ -20,10 +20,15 @@ func (nc *NodeClient) getNetworkList(filters []string) ([]*node_rpc.NetworkInfoM
 	var networkList []*node_rpc.NetworkInfoMessage
 	for _, networkInfo := range _networkList {
 		networkList = append(networkList, &node_rpc.NetworkInfoMessage{
-			Network: networkInfo.Network,     // 网卡名称
-			Ip:      networkInfo.Ip,          // IP地址
-			Net:     networkInfo.Net,         // 网络段
-			Mask:    int32(networkInfo.Mask), // 子网掩码位数
+			Network: networkInfo.Network,       // 网卡名称
+			Ip:      networkInfo.Ip,            // IP地址
+			Net:     networkInfo.Net,           // 网络段
+			Mask:    int32(networkInfo.Mask),   // 子网掩码位数
+			Ip6:     networkInfo.Ip6,           // IPv6地址
+			Net6:    networkInfo.Net6,          // IPv6网络段
+			Mask6:   int32(networkInfo.Mask6),  // IPv6前缀长度
+			VlanID:  int32(networkInfo.VlanID), // VLAN ID
+			Parent:  networkInfo.Parent,        // VLAN子接口所在的父网卡
 		})
 	}
 
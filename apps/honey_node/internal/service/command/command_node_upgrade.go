@@ -0,0 +1,33 @@
+package command
+
+// File: honey_node/service/command/command_node_upgrade.go
+// Description: 节点升级命令实现，下载校验新版本镜像并启动版本切换，持续上报下载进度及切换状态
+
+import (
+	"honey_node/internal/core"
+	"honey_node/internal/global"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/upgrade_service"
+)
+
+// CmdNodeUpgrade 处理节点升级命令请求
+func (nc *NodeClient) CmdNodeUpgrade(request *node_rpc.CmdRequest) {
+	req := request.GetNodeUpgradeInMessage()
+	log := core.GetLogger().WithField("logID", request.LogID)
+	log.Infof("节点升级 %s -> %s", global.Version, req.GetVersion())
+
+	upgrade_service.Upgrade(req, func(status int32, progress float32, errMsg string) {
+		nc.cmdResponseChan <- &node_rpc.CmdResponse{
+			CmdType: node_rpc.CmdType_cmdNodeUpgradeType, // 命令类型：节点升级
+			TaskID:  request.TaskID,                      // 关联的任务ID
+			NodeID:  nc.config.System.Uid,                // 当前节点唯一标识
+			LogID:   request.LogID,                       // 日志ID
+			NodeUpgradeOutMessage: &node_rpc.NodeUpgradeOutMessage{ // 节点升级响应体
+				UpgradeID: req.UpgradeID,
+				Status:    status,
+				Progress:  progress,
+				ErrMsg:    errMsg,
+			},
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package command
+
+// File: honey_node/service/command/command_pcap_capture.go
+// Description: 节点抓包命令实现，启动或停止诱捕网卡上的抓包任务，抓包结果由抓包服务异步上传
+
+import (
+	"honey_node/internal/core"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/pcap_service"
+)
+
+// CmdPcapCapture 处理抓包命令请求，立即返回启动/停止结果
+func (nc *NodeClient) CmdPcapCapture(request *node_rpc.CmdRequest) {
+	req := request.GetPcapCaptureInMessage()
+	log := core.GetLogger().WithField("logID", request.LogID)
+
+	res := &node_rpc.PcapCaptureOutMessage{
+		CaptureID: req.GetCaptureID(),
+	}
+
+	var err error
+	if req.GetStop() {
+		err = pcap_service.Stop(req.GetCaptureID())
+	} else {
+		err = pcap_service.Start(req)
+	}
+	if err != nil {
+		log.Errorf("抓包命令执行失败 %s %s", req.GetCaptureID(), err)
+		res.ErrMsg = err.Error()
+	}
+
+	nc.cmdResponseChan <- &node_rpc.CmdResponse{
+		CmdType:               node_rpc.CmdType_cmdPcapCaptureType, // 命令类型：抓包
+		TaskID:                request.TaskID,                      // 关联的任务ID
+		NodeID:                nc.config.System.Uid,                // 当前节点唯一标识
+		LogID:                 request.LogID,                       // 日志ID
+		PcapCaptureOutMessage: res,                                 // 抓包响应体
+	}
+}
@@ -0,0 +1,27 @@
+package command
+
+// File: honey_node/service/command/command_rule_set.go
+// Description: 规则集下发命令实现，写入管理端下发的规则并重载Suricata，返回生效版本及加载结果
+
+import (
+	"honey_node/internal/core"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/rule_service"
+)
+
+// CmdRuleSet 处理规则集下发命令请求
+func (nc *NodeClient) CmdRuleSet(request *node_rpc.CmdRequest) {
+	req := request.GetRuleSetInMessage()
+	log := core.GetLogger().WithField("logID", request.LogID)
+	log.Infof("规则集下发 %s", req.GetVersion())
+
+	res := rule_service.Apply(req)
+
+	nc.cmdResponseChan <- &node_rpc.CmdResponse{
+		CmdType:           node_rpc.CmdType_cmdRuleSetType, // 命令类型：规则集下发
+		TaskID:            request.TaskID,                  // 关联的任务ID
+		NodeID:            nc.config.System.Uid,            // 当前节点唯一标识
+		LogID:             request.LogID,                   // 日志ID
+		RuleSetOutMessage: res,                             // 规则集下发响应体
+	}
+}
@@ -0,0 +1,30 @@
+package command
+
+// File: honey_node/service/command/command_sync_state.go
+// Description: 节点全量状态同步命令实现，按管理端下发的期望状态同步本地诱捕ip及端口转发并返回逐项结果
+
+import (
+	"honey_node/internal/core"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/sync_service"
+)
+
+// CmdSyncState 处理全量状态同步命令请求
+func (nc *NodeClient) CmdSyncState(request *node_rpc.CmdRequest) {
+	req := request.GetSyncStateInMessage()
+	log := core.GetLogger().WithField("logID", request.LogID)
+	log.Infof("全量状态同步 诱捕ip %d个", len(req.GetIpList()))
+
+	res := sync_service.Apply(req)
+	if res.ErrMsg != "" {
+		log.Errorf("全量状态同步失败 %s", res.ErrMsg)
+	}
+
+	nc.cmdResponseChan <- &node_rpc.CmdResponse{
+		CmdType:             node_rpc.CmdType_cmdSyncStateType, // 命令类型：全量状态同步
+		TaskID:              request.TaskID,                    // 关联的任务ID
+		NodeID:              nc.config.System.Uid,              // 当前节点唯一标识
+		LogID:               request.LogID,                     // 日志ID
+		SyncStateOutMessage: res,                               // 同步响应体
+	}
+}
@@ -0,0 +1,70 @@
+package command
+
+// File: honey_node/service/command/command_task.go
+// Description: 节点任务管理命令实现，按管理端请求查询节点任务列表、取消或重试任务
+
+import (
+	"honey_node/internal/core"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/task_service"
+	"time"
+)
+
+// 节点任务操作类型
+const (
+	taskActionList   = 1 // 列表
+	taskActionCancel = 2 // 取消
+	taskActionRetry  = 3 // 重试
+)
+
+// taskListLimit 返回给管理端的最近任务数量上限
+const taskListLimit = 100
+
+// CmdTask 处理节点任务管理命令请求
+func (nc *NodeClient) CmdTask(request *node_rpc.CmdRequest) {
+	req := request.GetTaskInMessage()
+	log := core.GetLogger().WithField("logID", request.LogID)
+
+	res := new(node_rpc.TaskOutMessage)
+	switch req.GetAction() {
+	case taskActionList:
+		list := task_service.List()
+		if len(list) > taskListLimit {
+			list = list[:taskListLimit]
+		}
+		for _, model := range list {
+			res.TaskList = append(res.TaskList, &node_rpc.TaskInfo{
+				TaskID:    model.TaskID,
+				Type:      int32(model.Type),
+				Status:    int32(model.Status),
+				Total:     int32(model.Total),
+				Done:      int32(model.Done),
+				Failed:    int32(model.Failed),
+				ErrMsg:    model.ErrMsg,
+				CreatedAt: model.CreatedAt.Format(time.DateTime),
+			})
+		}
+	case taskActionCancel:
+		log.Infof("取消任务 %s", req.TaskID)
+		if err := task_service.Cancel(req.TaskID); err != nil {
+			log.Errorf("取消任务失败 %s", err)
+			res.ErrMsg = err.Error()
+		}
+	case taskActionRetry:
+		log.Infof("重试任务 %s", req.TaskID)
+		if err := task_service.Retry(req.TaskID); err != nil {
+			log.Errorf("重试任务失败 %s", err)
+			res.ErrMsg = err.Error()
+		}
+	default:
+		res.ErrMsg = "未知的任务操作类型"
+	}
+
+	nc.cmdResponseChan <- &node_rpc.CmdResponse{
+		CmdType:        node_rpc.CmdType_cmdTaskType, // 命令类型：节点任务管理
+		TaskID:         request.TaskID,               // 关联的任务ID
+		NodeID:         nc.config.System.Uid,         // 当前节点唯一标识
+		LogID:          request.LogID,                // 日志ID
+		TaskOutMessage: res,                          // 节点任务管理响应体
+	}
+}
@@ -0,0 +1,70 @@
+package command
+
+// File: honey_node/service/command/command_vlan.go
+// Description: VLAN子接口管理命令实现，按管理端请求在trunk口上创建或删除VLAN子接口，创建成功后返回子接口网卡信息
+
+import (
+	"honey_node/internal/core"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/ip_service"
+)
+
+// VLAN子接口操作类型
+const (
+	vlanActionCreate = 1 // 创建
+	vlanActionRemove = 2 // 删除
+)
+
+// CmdVlan 处理VLAN子接口管理命令请求
+func (nc *NodeClient) CmdVlan(request *node_rpc.CmdRequest) {
+	req := request.GetVlanInMessage()
+	log := core.GetLogger().WithField("logID", request.LogID)
+
+	res := new(node_rpc.VlanOutMessage)
+	switch req.GetAction() {
+	case vlanActionCreate:
+		log.Infof("创建VLAN子接口 %s vlan %d %s/%d", req.Network, req.VlanID, req.Ip, req.Mask)
+		model, err := ip_service.CreateVlan(ip_service.CreateVlanRequest{
+			Network: req.Network,
+			VlanID:  int(req.VlanID),
+			Ip:      req.Ip,
+			Mask:    int8(req.Mask),
+		})
+		if err != nil {
+			log.Errorf("创建VLAN子接口失败 %s", err)
+			res.ErrMsg = err.Error()
+			break
+		}
+		res.Network = nc.getVlanNetwork(model.Name)
+	case vlanActionRemove:
+		log.Infof("删除VLAN子接口 %s", req.Name)
+		if err := ip_service.RemoveVlan(req.Name); err != nil {
+			log.Errorf("删除VLAN子接口失败 %s", err)
+			res.ErrMsg = err.Error()
+		}
+	default:
+		res.ErrMsg = "未知的VLAN子接口操作类型"
+	}
+
+	nc.cmdResponseChan <- &node_rpc.CmdResponse{
+		CmdType:        node_rpc.CmdType_cmdVlanType, // 命令类型：VLAN子接口管理
+		TaskID:         request.TaskID,               // 关联的任务ID
+		NodeID:         nc.config.System.Uid,         // 当前节点唯一标识
+		LogID:          request.LogID,                // 日志ID
+		VlanOutMessage: res,                          // VLAN子接口管理响应体
+	}
+}
+
+// getVlanNetwork 获取新建VLAN子接口的网卡信息
+func (nc *NodeClient) getVlanNetwork(name string) *node_rpc.NetworkInfoMessage {
+	networkList, err := nc.getNetworkList(nil)
+	if err != nil {
+		return nil
+	}
+	for _, network := range networkList {
+		if network.Network == name {
+			return network
+		}
+	}
+	return nil
+}
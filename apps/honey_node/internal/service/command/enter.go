@@ -7,6 +7,7 @@ import (
 	"context"
 	"honey_node/internal/config"
 	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/task_service"
 	"net"
 	"sync"
 	"time"
@@ -34,13 +35,16 @@ type NodeClient struct {
 // NewNodeClient 创建节点客户端实例
 func NewNodeClient(grpcClient node_rpc.NodeServiceClient,
 	config *config.Config) *NodeClient {
-	return &NodeClient{
+	nc := &NodeClient{
 		client:          grpcClient,
 		config:          config,
 		cmdResponseChan: make(chan *node_rpc.CmdResponse, 10), // 带缓冲的响应通道，容量10
 		reconnectTimer:  time.NewTimer(0),                     // 初始化重连定时器（初始状态停止）
 		retryDelay:      1 * time.Second,                      // 初始重试延迟1秒
 	}
+	// 注册网络扫描任务类型，扫描结果通过当前客户端的命令流返回
+	task_service.Register(5, nc.netScanKind())
+	return nc
 }
 
 // StartCommandHandling 启动命令处理主流程，初始化上下文并建立初始连接
@@ -208,7 +212,7 @@ func (nc *NodeClient) receiveRequests() {
 			return
 		}
 
-		logrus.Infof("收到命令: %+v", request)
+		logrus.Infof("收到命令: %s taskID %s", request.CmdType, request.TaskID)
 		// 分发处理具体命令
 		nc.handleCommand(request)
 	}
@@ -224,6 +228,24 @@ func (nc *NodeClient) handleCommand(request *node_rpc.CmdRequest) {
 		nc.CmdNetScan(request)
 	case node_rpc.CmdType_cmdNodeRemoveType: // 节点移除命令
 		nc.CmdNodeRemove(request)
+	case node_rpc.CmdType_cmdPcapCaptureType: // 抓包命令
+		nc.CmdPcapCapture(request)
+	case node_rpc.CmdType_cmdSyncStateType: // 全量状态同步命令
+		nc.CmdSyncState(request)
+	case node_rpc.CmdType_cmdRuleSetType: // 规则集下发命令（重载规则耗时较长，异步处理避免阻塞其他命令）
+		go nc.CmdRuleSet(request)
+	case node_rpc.CmdType_cmdNodeUpgradeType: // 节点升级命令（下载镜像耗时较长，异步处理）
+		go nc.CmdNodeUpgrade(request)
+	case node_rpc.CmdType_cmdDiagnoseType: // 节点诊断命令（采集耗时不确定，异步处理避免阻塞其他命令）
+		go nc.CmdDiagnose(request)
+	case node_rpc.CmdType_cmdDiscoveryType: // 被动资产发现配置命令
+		nc.CmdDiscovery(request)
+	case node_rpc.CmdType_cmdVlanType: // VLAN子接口管理命令
+		nc.CmdVlan(request)
+	case node_rpc.CmdType_cmdTaskType: // 节点任务管理命令
+		nc.CmdTask(request)
+	case node_rpc.CmdType_cmdCertRotateType: // 节点证书轮换命令（申请证书需等待管理端响应，异步处理）
+		go nc.CmdCertRotate(request)
 	default: // 未知命令类型
 		logrus.Warnf("未知命令类型: %v", request.CmdType)
 	}
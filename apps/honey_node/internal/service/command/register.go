@@ -14,14 +14,16 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// approvalRetryInterval 节点等待管理员审批期间重新注册的间隔
+const approvalRetryInterval = 30 * time.Second
+
 // Register 向服务端完成节点注册流程，上报节点基础信息、网络信息及系统信息
+// 节点未通过管理员审批时持续等待，审批通过后注册完成
 func (nc *NodeClient) Register() error {
-	// 创建带10秒超时的上下文，控制注册请求生命周期
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
 	// 获取指定网卡的IP地址和MAC地址
 	_ip, mac, err := ip.GetNetworkInfo(nc.config.System.Network)
 	if err != nil {
@@ -48,11 +50,12 @@ func (nc *NodeClient) Register() error {
 
 	// 构建注册请求结构体，包含节点身份标识、系统信息及网络信息
 	req := &node_rpc.RegisterRequest{
-		Ip:      _ip,                  // 节点主IP地址
-		Mac:     mac,                  // 节点主网卡MAC地址
-		NodeUid: nc.config.System.Uid, // 节点唯一标识
-		Version: global.Version,       // 节点程序版本号
-		Commit:  global.Commit,        // 节点代码提交哈希
+		Ip:      _ip,                    // 节点主IP地址
+		Mac:     mac,                    // 节点主网卡MAC地址
+		NodeUid: nc.config.System.Uid,   // 节点唯一标识
+		Token:   nc.config.System.Token, // 节点接入令牌
+		Version: global.Version,         // 节点程序版本号
+		Commit:  global.Commit,          // 节点代码提交哈希
 		SystemInfo: &node_rpc.SystemInfoMessage{
 			HostName:            hostname,                // 主机名称
 			DistributionVersion: systemInfo.OSVersion,    // 操作系统发行版本
@@ -63,10 +66,20 @@ func (nc *NodeClient) Register() error {
 		NetworkList: networkList, // 节点网络接口列表
 	}
 
-	// 调用服务端注册接口完成注册
-	_, err = nc.client.Register(ctx, req)
+	// 调用服务端注册接口完成注册，待审批期间不启动命令处理及MQ消费
+	for {
+		// 创建带10秒超时的上下文，控制注册请求生命周期
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err = nc.client.Register(ctx, req)
+		cancel()
+		if status.Code(err) != codes.FailedPrecondition {
+			break
+		}
+		logrus.Warnf("%s, %s后重新注册", status.Convert(err).Message(), approvalRetryInterval)
+		time.Sleep(approvalRetryInterval)
+	}
 	if err != nil {
-		return fmt.Errorf("注册请求失败: %v", err)
+		return fmt.Errorf("注册请求失败: %w", err)
 	}
 
 	logrus.Infof("%s 节点注册成功", nc.config.System.GrpcManageAddr)
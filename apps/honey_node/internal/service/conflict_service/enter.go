@@ -0,0 +1,261 @@
+package conflict_service
+
+// File: honey_node/service/conflict_service/enter.go
+// Description: 诱捕ip冲突监测模块，监听已部署诱捕ip所在网卡的ARP报文，发现真实主机（非本机mac）以诱捕ip发送ARP应答或免费ARP时立即撤下诱捕ip并上报管理端，避免与生产网络产生ip冲突
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_node/internal/core"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/ip_service"
+	"honey_node/internal/service/port_service"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	refreshInterval = 10 * time.Second // 诱捕ip列表及网卡监听刷新间隔
+	reportTimeout   = 10 * time.Second // 单次上报超时时间
+)
+
+var (
+	lock        sync.Mutex                          // 监测状态读写锁
+	ipMap       = map[string]models.IpModel{}       // 已部署的诱捕ip -> 诱捕ip记录
+	localMac    = map[string]bool{}                 // 本机网卡mac地址（含诱捕网卡）
+	listenerMap = map[string]*listener{}            // 网卡名称 -> ARP监听实例
+	handling    = map[string]bool{}                 // 正在撤下的诱捕ip，避免重复处理
+	pendingList []*node_rpc.StatusIpConflictRequest // 上报失败待重试的冲突
+)
+
+// listener 单个网卡的ARP监听实例
+type listener struct {
+	network string        // 监听的网卡
+	fd      int           // AF_PACKET套接字
+	done    chan struct{} // 停止信号
+}
+
+// Run 启动诱捕ip冲突监测
+func Run() {
+	go func() {
+		for {
+			refresh()
+			retryReport()
+			time.Sleep(refreshInterval)
+		}
+	}()
+}
+
+// refresh 重新加载已部署的诱捕ip及本机mac地址，按诱捕ip所在网卡启停ARP监听
+func refresh() {
+	var ipList []models.IpModel
+	global.DB.Find(&ipList)
+
+	macMap := map[string]bool{}
+	if ifaceList, err := net.Interfaces(); err == nil {
+		for _, iface := range ifaceList {
+			if len(iface.HardwareAddr) > 0 {
+				macMap[strings.ToLower(iface.HardwareAddr.String())] = true
+			}
+		}
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	ipMap = map[string]models.IpModel{}
+	networkMap := map[string]bool{}
+	for _, model := range ipList {
+		ipMap[model.Ip] = model
+		networkMap[model.Network] = true
+	}
+	localMac = macMap
+
+	for network, l := range listenerMap {
+		if !networkMap[network] {
+			close(l.done)
+			delete(listenerMap, network)
+		}
+	}
+	for network := range networkMap {
+		if _, ok := listenerMap[network]; ok {
+			continue
+		}
+		l, err := newListener(network)
+		if err != nil {
+			logrus.Errorf("诱捕ip冲突监测启动失败 %s %s", network, err)
+			continue
+		}
+		listenerMap[network] = l
+		go l.run()
+	}
+}
+
+// newListener 在指定网卡上创建只接收ARP报文的监听套接字
+func newListener(network string) (*listener, error) {
+	iface, err := net.InterfaceByName(network)
+	if err != nil {
+		return nil, fmt.Errorf("网卡 %s 不存在 %s", network, err)
+	}
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, int(htons(unix.ETH_P_ARP)))
+	if err != nil {
+		return nil, fmt.Errorf("创建监听套接字失败 %s", err)
+	}
+	if err = unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(unix.ETH_P_ARP), Ifindex: iface.Index}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("绑定监听网卡失败 %s", err)
+	}
+	// 设置读超时，保证停止信号能及时生效
+	tv := unix.Timeval{Sec: 1}
+	if err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("设置读超时失败 %s", err)
+	}
+	return &listener{network: network, fd: fd, done: make(chan struct{})}, nil
+}
+
+// htons 主机字节序转网络字节序
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
+
+// run 持续接收ARP报文并检查发送方是否占用了诱捕ip，停止后关闭套接字
+func (l *listener) run() {
+	defer unix.Close(l.fd)
+	logrus.Infof("诱捕ip冲突监测启动 %s", l.network)
+
+	buffer := make([]byte, 1500)
+	for {
+		select {
+		case <-l.done:
+			logrus.Infof("诱捕ip冲突监测停止 %s", l.network)
+			return
+		default:
+		}
+
+		n, from, err := unix.Recvfrom(l.fd, buffer, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue // 读超时，重新检查停止信号
+			}
+			logrus.Errorf("诱捕ip冲突监测读取报文失败 %s %s", l.network, err)
+			return
+		}
+		// 本机发出的报文（含诱捕ip自身的ARP应答）不检查
+		if sa, ok := from.(*unix.SockaddrLinklayer); ok && sa.Pkttype == unix.PACKET_OUTGOING {
+			continue
+		}
+		l.check(buffer[:n])
+	}
+}
+
+// check 解析ARP报文，发送方ip为诱捕ip且mac地址不属于本机时判定为冲突
+func (l *listener) check(frame []byte) {
+	// 以太网头14字节 + 以太网/IPv4的ARP报文28字节
+	if len(frame) < 42 {
+		return
+	}
+	arp := frame[14:]
+	if arp[4] != 6 || arp[5] != 4 {
+		return
+	}
+	op := uint16(arp[6])<<8 | uint16(arp[7])
+	senderMac := net.HardwareAddr(arp[8:14]).String()
+	senderIP := net.IP(arp[14:18]).String()
+	targetIP := net.IP(arp[24:28]).String()
+
+	var arpType string
+	switch {
+	case senderIP == targetIP:
+		arpType = "gratuitous" // 免费ARP，主机宣告自己使用该ip
+	case op == 2:
+		arpType = "reply"
+	case op == 1:
+		arpType = "request" // 主机以该ip为源地址发起ARP请求，说明已在使用该ip
+	default:
+		return
+	}
+
+	lock.Lock()
+	model, ok := ipMap[senderIP]
+	if !ok || model.Network != l.network || localMac[senderMac] || strings.EqualFold(model.Mac, senderMac) || handling[senderIP] {
+		lock.Unlock()
+		return
+	}
+	handling[senderIP] = true
+	delete(ipMap, senderIP)
+	lock.Unlock()
+
+	go withdraw(model, senderMac, arpType)
+}
+
+// withdraw 撤下被真实主机占用的诱捕ip：关闭端口服务、删除网络接口及记录，并上报管理端
+func withdraw(model models.IpModel, mac string, arpType string) {
+	defer func() {
+		lock.Lock()
+		delete(handling, model.Ip)
+		lock.Unlock()
+	}()
+
+	manuf, _ := core.ManufQuery(mac)
+	logrus.Warnf("诱捕ip %s 被真实主机占用 mac %s %s %s，撤下诱捕ip", model.Ip, mac, manuf, arpType)
+
+	port_service.CloseIpTunnel(model.Ip)
+	req := &node_rpc.StatusIpConflictRequest{
+		NodeUid:    global.Config.System.Uid,
+		Ip:         model.Ip,
+		Network:    model.Network,
+		Mac:        mac,
+		Manuf:      manuf,
+		ArpType:    arpType,
+		DetectTime: time.Now().Format(time.DateTime),
+	}
+	// 先删除记录再删除接口，避免巡检在两步之间重建该诱捕ip
+	global.DB.Delete(&models.IpModel{}, "ip = ?", model.Ip)
+	if err := ip_service.RemoveInterface(model.LinkName); err != nil {
+		logrus.Errorf("撤下诱捕ip %s 删除接口失败 %s", model.Ip, err)
+		req.ErrMsg = err.Error()
+	}
+
+	if err := report(req); err != nil {
+		logrus.Warnf("诱捕ip冲突上报失败 %s %s", model.Ip, err)
+		lock.Lock()
+		pendingList = append(pendingList, req)
+		lock.Unlock()
+	}
+}
+
+// retryReport 重试上报失败的冲突
+func retryReport() {
+	lock.Lock()
+	list := pendingList
+	pendingList = nil
+	lock.Unlock()
+
+	for i, req := range list {
+		if err := report(req); err != nil {
+			lock.Lock()
+			pendingList = append(list[i:], pendingList...)
+			lock.Unlock()
+			return
+		}
+	}
+}
+
+// report 向管理端上报诱捕ip冲突
+func report(req *node_rpc.StatusIpConflictRequest) error {
+	if global.GrpcClient == nil {
+		return errors.New("管理端未连接")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+	defer cancel()
+	_, err := global.GrpcClient.StatusIpConflict(ctx, req)
+	return err
+}
@@ -4,6 +4,8 @@ package cron_service
 // Description: 定时任务服务管理模块，基于robfig/cron实现秒级定时任务调度，负责周期性执行系统资源采集与上报任务
 
 import (
+	"honey_node/internal/service/cert_service"
+	"honey_node/internal/service/ip_service"
 	"time"
 
 	"github.com/robfig/cron/v3"
@@ -23,6 +25,12 @@ func Run() {
 	// 添加定时任务：每5秒执行一次Resource函数（资源采集与上报）
 	crontab.AddFunc("*/5 * * * * *", Resource)
 
+	// 添加定时任务：每30秒巡检一次诱捕网卡（比对数据库配置与内核接口状态并修复差异）
+	crontab.AddFunc("*/30 * * * * *", ip_service.Reconcile)
+
+	// 添加定时任务：每小时检查一次节点证书有效期（剩余有效期不足三分之一时自动轮换）
+	crontab.AddFunc("0 0 * * * *", cert_service.Check)
+
 	// 启动Cron调度器（非阻塞，后台运行）
 	crontab.Start()
 }
@@ -0,0 +1,368 @@
+package diagnose_service
+
+// File: honey_node/service/diagnose_service/enter.go
+// Description: 节点诊断模块，汇总内核网卡及地址、诱捕网卡、端口监听及会话、连接限制、MQ消费端、Suricata存活、最近错误日志及协程统计，生成结构化诊断报告
+
+import (
+	"bytes"
+	"context"
+	"honey_node/internal/core"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/service/mq_service"
+	"honey_node/internal/service/port_service"
+	"honey_node/internal/service/rule_service"
+	"net"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	defaultLogLines  = 50              // 默认返回的错误日志行数
+	maxGoroutineList = 20              // 返回的协程分组数上限（按数量降序）
+	probeTimeout     = 3 * time.Second // 单项采集的超时时间
+)
+
+// startTime 节点进程启动时间
+var startTime = time.Now()
+
+// Report 节点诊断报告
+type Report struct {
+	NodeUid       string                     `json:"nodeUid"`       // 节点uid
+	Version       string                     `json:"version"`       // 节点版本
+	Commit        string                     `json:"commit"`        // 节点commit
+	Time          string                     `json:"time"`          // 报告生成时间
+	StartTime     string                     `json:"startTime"`     // 节点进程启动时间
+	LinkList      []LinkInfo                 `json:"linkList"`      // 内核网卡及地址
+	HoneyLinkList []HoneyLinkInfo            `json:"honeyLinkList"` // 诱捕网卡（数据库记录与内核状态比对）
+	ListenerList  []ListenerInfo             `json:"listenerList"`  // 端口监听（数据库记录与运行状态比对）
+	SessionList   []port_service.SessionInfo `json:"sessionList"`   // 进行中的隧道会话
+	Limit         port_service.LimitInfo     `json:"limit"`         // 连接限制统计
+	MQ            MQInfo                     `json:"mq"`            // MQ状态
+	Suricata      SuricataInfo               `json:"suricata"`      // Suricata状态
+	ErrorLogList  []string                   `json:"errorLogList"`  // 最近的警告及错误日志
+	Goroutine     GoroutineInfo              `json:"goroutine"`     // 协程统计
+	ErrList       []string                   `json:"errList"`       // 采集过程中的错误
+}
+
+// LinkInfo 内核网卡信息
+type LinkInfo struct {
+	Name     string   `json:"name"`     // 网卡名称
+	Type     string   `json:"type"`     // 网卡类型（device、macvlan、bridge等）
+	State    string   `json:"state"`    // 运行状态
+	Up       bool     `json:"up"`       // 是否已启用
+	Mac      string   `json:"mac"`      // mac地址
+	Mtu      int      `json:"mtu"`      // MTU
+	Parent   string   `json:"parent"`   // 父网卡名称（macvlan等虚拟网卡）
+	AddrList []string `json:"addrList"` // 地址列表（CIDR）
+}
+
+// HoneyLinkInfo 诱捕网卡状态
+type HoneyLinkInfo struct {
+	LinkName string `json:"linkName"` // 诱捕网卡名称
+	Ip       string `json:"ip"`       // 诱捕ip
+	Mask     int8   `json:"mask"`     // 子网掩码
+	Mac      string `json:"mac"`      // 记录的mac地址
+	Recorded bool   `json:"recorded"` // 数据库中是否有记录（false表示残留网卡）
+	Exists   bool   `json:"exists"`   // 内核中是否存在
+	Up       bool   `json:"up"`       // 是否已启用
+	MacOk    bool   `json:"macOk"`    // mac地址是否与记录一致
+	AddrOk   bool   `json:"addrOk"`   // 是否已配置诱捕ip
+}
+
+// ListenerInfo 端口监听状态
+type ListenerInfo struct {
+	Agreement  int8   `json:"agreement"`  // 通信协议 1 TCP 2 UDP
+	LocalAddr  string `json:"localAddr"`  // 本地监听地址
+	TargetAddr string `json:"targetAddr"` // 目标服务地址
+	Recorded   bool   `json:"recorded"`   // 数据库中是否有记录
+	Running    bool   `json:"running"`    // 是否正在监听
+	Sessions   int    `json:"sessions"`   // 当前会话数
+}
+
+// MQInfo MQ状态
+type MQInfo struct {
+	Connected    bool                       `json:"connected"`    // 连接是否正常
+	OutboxCount  int64                      `json:"outboxCount"`  // 发件箱待发送消息数
+	ConsumerList []mq_service.ConsumerState `json:"consumerList"` // 业务消费端状态
+}
+
+// SuricataInfo Suricata状态
+type SuricataInfo struct {
+	Alive            bool                     `json:"alive"`            // unix socket是否可用
+	Uptime           int                      `json:"uptime"`           // 运行时长（秒）
+	ErrMsg           string                   `json:"errMsg"`           // unix socket访问错误
+	RuleSet          rule_service.ActiveState `json:"ruleSet"`          // 当前生效的规则集
+	EvePath          string                   `json:"evePath"`          // eve日志路径
+	EveSize          int64                    `json:"eveSize"`          // eve日志大小
+	EveModTime       string                   `json:"eveModTime"`       // eve日志最近写入时间
+	CheckpointOffset int64                    `json:"checkpointOffset"` // eve日志已处理的偏移
+}
+
+// GoroutineInfo 协程统计
+type GoroutineInfo struct {
+	Total     int              `json:"total"`     // 协程总数
+	GroupList []GoroutineGroup `json:"groupList"` // 按入口函数分组的协程数
+}
+
+// GoroutineGroup 同一调用栈的协程分组
+type GoroutineGroup struct {
+	Count    int    `json:"count"`    // 协程数
+	Function string `json:"function"` // 协程入口函数
+	Top      string `json:"top"`      // 当前阻塞所在函数
+}
+
+// Collect 采集节点诊断报告，logLines为返回的最近错误日志行数
+// 每项采集单独限时，超时的项留空并记录到ErrList，避免单项卡住导致整个报告无法返回
+func Collect(ctx context.Context, logLines int) Report {
+	if logLines <= 0 {
+		logLines = defaultLogLines
+	}
+	report := Report{
+		NodeUid:   global.Config.System.Uid,
+		Version:   global.Version,
+		Commit:    global.Commit,
+		Time:      time.Now().Format(time.DateTime),
+		StartTime: startTime.Format(time.DateTime),
+		ErrList:   make([]string, 0),
+	}
+
+	linkList, err := netlink.LinkList()
+	if err != nil {
+		report.ErrList = append(report.ErrList, "获取网卡列表失败 "+err.Error())
+	}
+	report.LinkList = probe(ctx, &report, "网卡地址", func() []LinkInfo { return collectLinks(linkList) })
+	report.HoneyLinkList = probe(ctx, &report, "诱捕网卡", func() []HoneyLinkInfo { return collectHoneyLinks(linkList) })
+	report.ListenerList = probe(ctx, &report, "端口监听", collectListeners)
+	report.SessionList = probe(ctx, &report, "隧道会话", port_service.ActiveSessionList)
+	report.Limit = probe(ctx, &report, "连接限制", port_service.LimitStat)
+	report.MQ = probe(ctx, &report, "MQ状态", func() MQInfo {
+		return MQInfo{
+			Connected:    mq_service.Connected(),
+			OutboxCount:  mq_service.OutboxCount(),
+			ConsumerList: mq_service.ConsumerStateList(),
+		}
+	})
+	report.Suricata = probe(ctx, &report, "Suricata状态", collectSuricata)
+	report.ErrorLogList = probe(ctx, &report, "错误日志", func() []string { return core.RecentErrorLogs(logLines) })
+	report.Goroutine = probe(ctx, &report, "协程统计", collectGoroutines)
+	return report
+}
+
+// probe 在独立协程中执行单项采集并限时等待，超时或整体取消时返回零值并记录错误
+func probe[T any](ctx context.Context, report *Report, name string, fn func() T) T {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	ch := make(chan T, 1)
+	go func() {
+		ch <- fn()
+	}()
+	select {
+	case value := <-ch:
+		return value
+	case <-ctx.Done():
+		report.ErrList = append(report.ErrList, name+"采集超时")
+		var zero T
+		return zero
+	}
+}
+
+// collectLinks 汇总内核网卡及地址
+func collectLinks(linkList []netlink.Link) []LinkInfo {
+	nameMap := map[int]string{}
+	for _, link := range linkList {
+		nameMap[link.Attrs().Index] = link.Attrs().Name
+	}
+
+	list := make([]LinkInfo, 0, len(linkList))
+	for _, link := range linkList {
+		attrs := link.Attrs()
+		info := LinkInfo{
+			Name:     attrs.Name,
+			Type:     link.Type(),
+			State:    attrs.OperState.String(),
+			Up:       attrs.Flags&net.FlagUp != 0,
+			Mac:      attrs.HardwareAddr.String(),
+			Mtu:      attrs.MTU,
+			Parent:   nameMap[attrs.ParentIndex],
+			AddrList: make([]string, 0),
+		}
+		addrList, _ := netlink.AddrList(link, netlink.FAMILY_ALL)
+		for _, addr := range addrList {
+			info.AddrList = append(info.AddrList, addr.IPNet.String())
+		}
+		list = append(list, info)
+	}
+	return list
+}
+
+// collectHoneyLinks 比对数据库诱捕ip记录与内核hy_网卡状态
+func collectHoneyLinks(linkList []netlink.Link) []HoneyLinkInfo {
+	linkMap := map[string]netlink.Link{}
+	for _, link := range linkList {
+		if strings.HasPrefix(link.Attrs().Name, "hy_") {
+			linkMap[link.Attrs().Name] = link
+		}
+	}
+
+	var ipList []models.IpModel
+	global.DB.Find(&ipList)
+	list := make([]HoneyLinkInfo, 0, len(ipList))
+	for _, model := range ipList {
+		info := HoneyLinkInfo{
+			LinkName: model.LinkName,
+			Ip:       model.Ip,
+			Mask:     model.Mask,
+			Mac:      model.Mac,
+			Recorded: true,
+		}
+		if link, ok := linkMap[model.LinkName]; ok {
+			delete(linkMap, model.LinkName)
+			attrs := link.Attrs()
+			info.Exists = true
+			info.Up = attrs.Flags&net.FlagUp != 0
+			info.MacOk = model.Mac == "" || strings.EqualFold(attrs.HardwareAddr.String(), model.Mac)
+			addrList, _ := netlink.AddrList(link, netlink.FAMILY_ALL)
+			for _, addr := range addrList {
+				if addr.IP.String() == model.Ip {
+					info.AddrOk = true
+				}
+			}
+		}
+		list = append(list, info)
+	}
+	// 内核中存在但数据库没有记录的残留网卡
+	for name, link := range linkMap {
+		list = append(list, HoneyLinkInfo{
+			LinkName: name,
+			Mac:      link.Attrs().HardwareAddr.String(),
+			Exists:   true,
+			Up:       link.Attrs().Flags&net.FlagUp != 0,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LinkName < list[j].LinkName
+	})
+	return list
+}
+
+// collectListeners 比对数据库端口转发记录与当前运行的端口监听
+func collectListeners() []ListenerInfo {
+	runningMap := map[string]port_service.ListenerInfo{}
+	for _, listener := range port_service.ListenerList() {
+		runningMap[strconv.Itoa(int(listener.Agreement))+"/"+listener.LocalAddr] = listener
+	}
+
+	var portList []models.PortModel
+	global.DB.Find(&portList)
+	list := make([]ListenerInfo, 0, len(portList))
+	for _, model := range portList {
+		agreement := model.Agreement
+		if agreement != 2 {
+			agreement = 1
+		}
+		info := ListenerInfo{
+			Agreement:  agreement,
+			LocalAddr:  model.LocalAddr,
+			TargetAddr: model.TargetAddr,
+			Recorded:   true,
+		}
+		key := strconv.Itoa(int(agreement)) + "/" + model.LocalAddr
+		if listener, ok := runningMap[key]; ok {
+			delete(runningMap, key)
+			info.Running = true
+			info.Sessions = listener.Sessions
+		}
+		list = append(list, info)
+	}
+	// 正在监听但数据库没有记录的端口
+	for _, listener := range runningMap {
+		list = append(list, ListenerInfo{
+			Agreement:  listener.Agreement,
+			LocalAddr:  listener.LocalAddr,
+			TargetAddr: listener.TargetAddr,
+			Running:    true,
+			Sessions:   listener.Sessions,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Agreement != list[j].Agreement {
+			return list[i].Agreement < list[j].Agreement
+		}
+		return list[i].LocalAddr < list[j].LocalAddr
+	})
+	return list
+}
+
+// collectSuricata 检查Suricata存活状态及eve日志读取进度
+func collectSuricata() (info SuricataInfo) {
+	uptime, err := rule_service.SuricataUptime(global.Config.Suricata.SocketPath)
+	if err != nil {
+		info.ErrMsg = err.Error()
+	} else {
+		info.Alive = true
+		info.Uptime = uptime
+	}
+	info.RuleSet = rule_service.GetActiveState()
+
+	info.EvePath = global.Config.System.EvePath
+	if stat, err := os.Stat(info.EvePath); err == nil {
+		info.EveSize = stat.Size()
+		info.EveModTime = stat.ModTime().Format(time.DateTime)
+	}
+	var checkpoint models.EveCheckpointModel
+	global.DB.Find(&checkpoint, "path = ?", info.EvePath)
+	info.CheckpointOffset = checkpoint.Offset
+	return
+}
+
+// collectGoroutines 统计协程总数，并按调用栈分组返回数量最多的分组
+func collectGoroutines() GoroutineInfo {
+	info := GoroutineInfo{Total: runtime.NumGoroutine()}
+
+	// debug=1 格式：每个分组以 "<数量> @ <地址列表>" 开头，随后为 "#\t<地址>\t<函数>+<偏移>\t<文件:行号>" 调用栈，分组间以空行分隔
+	var buf bytes.Buffer
+	pprof.Lookup("goroutine").WriteTo(&buf, 1)
+	var group *GoroutineGroup
+	for _, line := range strings.Split(buf.String(), "\n") {
+		switch {
+		case strings.Contains(line, " @ "):
+			count, err := strconv.Atoi(strings.SplitN(line, " ", 2)[0])
+			if err != nil {
+				group = nil
+				continue
+			}
+			info.GroupList = append(info.GroupList, GoroutineGroup{Count: count})
+			group = &info.GroupList[len(info.GroupList)-1]
+		case strings.HasPrefix(line, "#") && group != nil:
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			function := strings.Split(fields[2], "+")[0]
+			if group.Top == "" {
+				group.Top = function
+			}
+			// 调用栈最底层为协程入口函数
+			group.Function = function
+		case line == "":
+			group = nil
+		}
+	}
+
+	sort.SliceStable(info.GroupList, func(i, j int) bool {
+		return info.GroupList[i].Count > info.GroupList[j].Count
+	})
+	if len(info.GroupList) > maxGoroutineList {
+		info.GroupList = info.GroupList[:maxGoroutineList]
+	}
+	return info
+}
@@ -0,0 +1,203 @@
+package discovery_service
+
+// File: honey_node/service/discovery_service/enter.go
+// Description: 被动资产发现模块，按管理端下发的网卡列表启停网卡监听，汇总各网卡的主机新增、变化、离线及在线刷新并批量上报管理端
+
+import (
+	"context"
+	"honey_node/internal/global"
+	"honey_node/internal/rpc/node_rpc"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 主机变化类型
+const (
+	EventNew      int32 = 1 // 新增
+	EventChanged  int32 = 2 // 信息变化（mac地址或主机名）
+	EventVanished int32 = 3 // 离线
+	EventSeen     int32 = 4 // 在线刷新
+)
+
+const (
+	defaultVanishTime = 30 * time.Minute // 未配置时的离线判定时间
+	reportInterval    = 2 * time.Second  // 主机变化批量上报间隔
+	reportTimeout     = 10 * time.Second // 单次上报超时时间
+	maxReportBatch    = 500              // 单次上报的最大主机变化数
+	maxPendingEvent   = 10000            // 上报失败时最多保留的主机变化数
+)
+
+// Network 被动监听的网卡
+type Network struct {
+	Name    string     // 网卡名称
+	NetID   uint32     // 网络ID
+	Subnet  *net.IPNet // 网卡所在子网，仅记录子网内的主机
+	Subnet6 *net.IPNet // 网卡所在IPv6子网，双栈网络才有
+}
+
+// Contains 判断ip是否属于网卡所在的IPv4或IPv6子网
+func (n Network) Contains(ip net.IP) bool {
+	if n.Subnet != nil && n.Subnet.Contains(ip) {
+		return true
+	}
+	return n.Subnet6 != nil && n.Subnet6.Contains(ip)
+}
+
+// subnetKey 网卡子网配置标识，配置变化时重新启动监听
+func (n Network) subnetKey() string {
+	key := n.Subnet.String()
+	if n.Subnet6 != nil {
+		key += "," + n.Subnet6.String()
+	}
+	return key
+}
+
+var (
+	lock        sync.Mutex               // 监听表读写锁
+	listenerMap = map[string]*listener{} // 网卡名称 -> 监听实例
+	vanishTime  = defaultVanishTime      // 离线判定时间
+	eventLock   sync.Mutex               // 待上报主机变化读写锁
+	eventList   []*node_rpc.HostEvent    // 待上报的主机变化
+	reportOnce  sync.Once                // 保证上报协程只启动一次
+)
+
+// Apply 按管理端下发的全量网卡列表启停监听，配置变化的网卡重新启动，返回监听中的网卡及启动失败的错误
+func Apply(networkList []Network, vanish time.Duration) (running []string, errList []string) {
+	reportOnce.Do(func() {
+		go reportLoop()
+	})
+
+	lock.Lock()
+	defer lock.Unlock()
+	if vanish <= 0 {
+		vanish = defaultVanishTime
+	}
+	vanishTime = vanish
+
+	wantMap := map[string]Network{}
+	for _, network := range networkList {
+		wantMap[network.Name] = network
+	}
+	// 停止不再需要或配置变化的监听
+	for name, l := range listenerMap {
+		want, ok := wantMap[name]
+		if ok && want.NetID == l.network.NetID && want.subnetKey() == l.network.subnetKey() {
+			continue
+		}
+		l.stop()
+		delete(listenerMap, name)
+	}
+	// 启动新增的监听
+	for name, network := range wantMap {
+		if _, ok := listenerMap[name]; ok {
+			continue
+		}
+		l, err := newListener(network)
+		if err != nil {
+			logrus.Errorf("被动资产发现启动失败 %s %s", name, err)
+			errList = append(errList, err.Error())
+			continue
+		}
+		listenerMap[name] = l
+		go l.run()
+	}
+
+	for name := range listenerMap {
+		running = append(running, name)
+	}
+	sort.Strings(running)
+	return running, errList
+}
+
+// RunningList 获取监听中的网卡列表
+func RunningList() []string {
+	lock.Lock()
+	defer lock.Unlock()
+	list := make([]string, 0, len(listenerMap))
+	for name := range listenerMap {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// getVanishTime 获取离线判定时间
+func getVanishTime() time.Duration {
+	lock.Lock()
+	defer lock.Unlock()
+	return vanishTime
+}
+
+// pushEvent 加入待上报的主机变化
+func pushEvent(netID uint32, h *host, eventType int32) {
+	event := &node_rpc.HostEvent{
+		Type:      eventType,
+		NetID:     netID,
+		Ip:        h.ip,
+		Mac:       h.mac,
+		Manuf:     h.manuf,
+		Hostname:  h.hostname,
+		Source:    h.source,
+		FirstSeen: h.firstSeen.Format(time.DateTime),
+		LastSeen:  h.lastSeen.Format(time.DateTime),
+	}
+	eventLock.Lock()
+	defer eventLock.Unlock()
+	// 上报持续失败时丢弃最早的变化，主机仍在线时会通过在线刷新重新上报
+	if len(eventList) >= maxPendingEvent {
+		eventList = eventList[1:]
+	}
+	eventList = append(eventList, event)
+}
+
+// reportLoop 定期批量上报主机变化，上报失败的变化保留到下次上报
+func reportLoop() {
+	for {
+		time.Sleep(reportInterval)
+		eventLock.Lock()
+		list := eventList
+		eventList = nil
+		eventLock.Unlock()
+
+		for len(list) > 0 {
+			batch := list[:min(len(list), maxReportBatch)]
+			if err := report(batch); err != nil {
+				logrus.Warnf("被动资产发现上报失败 %d条 %s", len(batch), err)
+				eventLock.Lock()
+				eventList = append(list, eventList...)
+				if len(eventList) > maxPendingEvent {
+					eventList = eventList[len(eventList)-maxPendingEvent:]
+				}
+				eventLock.Unlock()
+				break
+			}
+			list = list[len(batch):]
+		}
+	}
+}
+
+// report 上报一批主机变化
+func report(batch []*node_rpc.HostEvent) error {
+	if global.GrpcClient == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+	defer cancel()
+	_, err := global.GrpcClient.ReportHost(ctx, &node_rpc.ReportHostRequest{
+		NodeUid:   global.Config.System.Uid,
+		EventList: batch,
+	})
+	if err == nil {
+		var ipList []string
+		for _, event := range batch {
+			ipList = append(ipList, event.Ip)
+		}
+		logrus.Debugf("被动资产发现上报 %s", strings.Join(ipList, ","))
+	}
+	return err
+}
@@ -0,0 +1,287 @@
+package discovery_service
+
+// File: honey_node/service/discovery_service/listener.go
+// Description: 被动资产发现网卡监听，基于AF_PACKET原始套接字及BPF过滤只接收ARP、NDP、LLDP及DHCP/NBNS/mDNS报文，维护网卡所在子网的主机表并产生主机变化
+
+import (
+	"errors"
+	"fmt"
+	"honey_node/internal/core"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	refreshInterval  = 5 * time.Minute  // 主机持续在线时上报在线刷新的间隔
+	sweepInterval    = time.Minute      // 离线检查间隔
+	localMacInterval = 30 * time.Second // 本机网卡mac地址刷新间隔（诱捕网卡会动态增删）
+)
+
+// host 主机表中的主机
+type host struct {
+	ip         string    // 主机ip
+	mac        string    // mac地址
+	manuf      string    // mac对应厂商
+	hostname   string    // 主机名
+	source     string    // 最近一次发现来源
+	firstSeen  time.Time // 首次发现时间
+	lastSeen   time.Time // 最近发现时间
+	reportedAt time.Time // 最近一次上报时间
+}
+
+// listener 单个网卡的被动监听实例
+type listener struct {
+	network     Network           // 监听的网卡
+	fd          int               // AF_PACKET套接字
+	done        chan struct{}     // 停止信号
+	stopOnce    sync.Once         // 保证只停止一次
+	hosts       map[string]*host  // 主机ip -> 主机
+	macHostname map[string]string // 未关联ip的主机名，mac地址 -> 主机名
+	localMac    map[string]bool   // 本机网卡mac地址（含诱捕网卡），不作为主机记录
+	localMacAt  time.Time         // 本机网卡mac地址最近刷新时间
+	lock        sync.Mutex        // 主机表读写锁
+}
+
+// filter 仅接收ARP、LLDP、目的端口为67、137、5353的非分片IPv4 UDP报文及IPv6邻居请求、邻居通告
+var filter = []bpf.Instruction{
+	bpf.LoadAbsolute{Off: 12, Size: 2},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: etherTypeARP, SkipTrue: 18},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: etherTypeLLDP, SkipTrue: 17},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: etherTypeIPv6, SkipTrue: 10},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: etherTypeIPv4, SkipFalse: 14},
+	bpf.LoadAbsolute{Off: 23, Size: 1},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: 17, SkipFalse: 12},
+	bpf.LoadAbsolute{Off: 20, Size: 2},
+	bpf.JumpIf{Cond: bpf.JumpBitsSet, Val: 0x3fff, SkipTrue: 10},
+	bpf.LoadMemShift{Off: 14},
+	bpf.LoadIndirect{Off: 16, Size: 2},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: portDHCPServer, SkipTrue: 8},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: portNBNS, SkipTrue: 7},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: portMDNS, SkipTrue: 6, SkipFalse: 5},
+	// IPv6：下一首部为ICMPv6且类型为邻居请求或邻居通告
+	bpf.LoadAbsolute{Off: 20, Size: 1},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: ipProtoICMPv6, SkipFalse: 3},
+	bpf.LoadAbsolute{Off: 54, Size: 1},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: icmpv6NeighborSolicitation, SkipTrue: 2},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: icmpv6NeighborAdvertisement, SkipTrue: 1},
+	bpf.RetConstant{Val: 0},
+	bpf.RetConstant{Val: 65535},
+}
+
+// newListener 在指定网卡上创建监听套接字，接收全部组播报文（mDNS、LLDP目的地址为组播）
+func newListener(network Network) (*listener, error) {
+	iface, err := net.InterfaceByName(network.Name)
+	if err != nil {
+		return nil, fmt.Errorf("网卡 %s 不存在 %s", network.Name, err)
+	}
+	program, err := bpf.Assemble(filter)
+	if err != nil {
+		return nil, fmt.Errorf("生成报文过滤规则失败 %s", err)
+	}
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, int(htons(unix.ETH_P_ALL)))
+	if err != nil {
+		return nil, fmt.Errorf("创建监听套接字失败 %s", err)
+	}
+	// 先设置过滤规则再绑定网卡，避免绑定后收到未过滤的报文
+	fprog := make([]unix.SockFilter, len(program))
+	for i, ins := range program {
+		fprog[i] = unix.SockFilter{Code: ins.Op, Jt: ins.Jt, Jf: ins.Jf, K: ins.K}
+	}
+	if err = unix.SetsockoptSockFprog(fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, &unix.SockFprog{
+		Len:    uint16(len(fprog)),
+		Filter: &fprog[0],
+	}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("设置报文过滤规则失败 %s", err)
+	}
+	if err = unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(unix.ETH_P_ALL), Ifindex: iface.Index}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("绑定监听网卡失败 %s", err)
+	}
+	if err = unix.SetsockoptPacketMreq(fd, unix.SOL_PACKET, unix.PACKET_ADD_MEMBERSHIP, &unix.PacketMreq{
+		Ifindex: int32(iface.Index),
+		Type:    unix.PACKET_MR_ALLMULTI,
+	}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("设置接收组播报文失败 %s", err)
+	}
+	// 设置读超时，保证停止信号能及时生效
+	tv := unix.Timeval{Sec: 1}
+	if err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("设置读超时失败 %s", err)
+	}
+
+	return &listener{
+		network:     network,
+		fd:          fd,
+		done:        make(chan struct{}),
+		hosts:       map[string]*host{},
+		macHostname: map[string]string{},
+	}, nil
+}
+
+// htons 主机字节序转网络字节序
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
+
+// stop 发送停止信号
+func (l *listener) stop() {
+	l.stopOnce.Do(func() {
+		close(l.done)
+	})
+}
+
+// run 持续接收报文更新主机表，并定期检查离线主机，停止后关闭套接字
+func (l *listener) run() {
+	defer unix.Close(l.fd)
+	logrus.Infof("被动资产发现启动 %s %s", l.network.Name, l.network.Subnet)
+
+	buffer := make([]byte, 65536)
+	lastSweep := time.Now()
+	for {
+		select {
+		case <-l.done:
+			logrus.Infof("被动资产发现停止 %s", l.network.Name)
+			return
+		default:
+		}
+
+		if time.Since(lastSweep) > sweepInterval {
+			l.sweep()
+			lastSweep = time.Now()
+		}
+
+		n, from, err := unix.Recvfrom(l.fd, buffer, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue // 读超时，重新检查停止信号
+			}
+			logrus.Errorf("被动资产发现读取报文失败 %s %s", l.network.Name, err)
+			return
+		}
+		// 本机发出的报文不作为主机记录
+		if sa, ok := from.(*unix.SockaddrLinklayer); ok && sa.Pkttype == unix.PACKET_OUTGOING {
+			continue
+		}
+		for _, ob := range parseFrame(buffer[:n]) {
+			l.observe(ob)
+		}
+	}
+}
+
+// observe 根据报文中的主机信息更新主机表，产生新增、信息变化或在线刷新
+func (l *listener) observe(ob observation) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.refreshLocalMac()
+	if l.localMac[ob.mac] {
+		return
+	}
+
+	// 未携带ip的报文（DHCP DISCOVER、LLDP等）先暂存主机名，获取到ip后关联
+	if ob.ip == nil {
+		if ob.hostname == "" {
+			return
+		}
+		l.macHostname[ob.mac] = ob.hostname
+		for _, h := range l.hosts {
+			if h.mac == ob.mac && h.hostname != ob.hostname {
+				h.hostname = ob.hostname
+				h.source = ob.source
+				l.emit(h, EventChanged)
+			}
+		}
+		return
+	}
+	if !l.network.Contains(ob.ip) {
+		return
+	}
+	hostname := ob.hostname
+	if hostname == "" {
+		hostname = l.macHostname[ob.mac]
+	}
+
+	now := time.Now()
+	ip := ob.ip.String()
+	h, ok := l.hosts[ip]
+	if !ok {
+		manuf, _ := core.ManufQuery(ob.mac)
+		h = &host{
+			ip:        ip,
+			mac:       ob.mac,
+			manuf:     manuf,
+			hostname:  hostname,
+			source:    ob.source,
+			firstSeen: now,
+			lastSeen:  now,
+		}
+		l.hosts[ip] = h
+		l.emit(h, EventNew)
+		return
+	}
+
+	h.lastSeen = now
+	h.source = ob.source
+	changed := false
+	if h.mac != ob.mac {
+		h.mac = ob.mac
+		h.manuf, _ = core.ManufQuery(ob.mac)
+		changed = true
+	}
+	if hostname != "" && h.hostname != hostname {
+		h.hostname = hostname
+		changed = true
+	}
+	switch {
+	case changed:
+		l.emit(h, EventChanged)
+	case now.Sub(h.reportedAt) > refreshInterval:
+		l.emit(h, EventSeen)
+	}
+}
+
+// sweep 将超过离线判定时间未发现的主机移出主机表
+func (l *listener) sweep() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	vanish := getVanishTime()
+	for ip, h := range l.hosts {
+		if time.Since(h.lastSeen) > vanish {
+			delete(l.hosts, ip)
+			l.emit(h, EventVanished)
+		}
+	}
+}
+
+// emit 记录主机上报时间并加入待上报的主机变化
+func (l *listener) emit(h *host, eventType int32) {
+	h.reportedAt = time.Now()
+	pushEvent(l.network.NetID, h, eventType)
+}
+
+// refreshLocalMac 定期刷新本机网卡mac地址
+func (l *listener) refreshLocalMac() {
+	if time.Since(l.localMacAt) < localMacInterval {
+		return
+	}
+	l.localMacAt = time.Now()
+	ifaceList, err := net.Interfaces()
+	if err != nil {
+		return
+	}
+	l.localMac = map[string]bool{}
+	for _, iface := range ifaceList {
+		if len(iface.HardwareAddr) > 0 {
+			l.localMac[iface.HardwareAddr.String()] = true
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package discovery_service
+
+// File: honey_node/service/discovery_service/parse.go
+// Description: 被动资产发现报文解析，从ARP、NDP、DHCP、mDNS、NBNS及LLDP报文中提取主机ip、mac地址及主机名
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// 以太网类型
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeARP  = 0x0806
+	etherTypeLLDP = 0x88cc
+	etherTypeIPv6 = 0x86dd
+)
+
+// NDP报文类型及选项
+const (
+	icmpv6NeighborSolicitation  = 135
+	icmpv6NeighborAdvertisement = 136
+	ndpOptionSourceLinkAddr     = 1 // 源链路层地址
+	ndpOptionTargetLinkAddr     = 2 // 目标链路层地址
+	ipProtoICMPv6               = 58
+)
+
+// UDP端口
+const (
+	portDHCPServer = 67
+	portNBNS       = 137
+	portMDNS       = 5353
+)
+
+// 发现来源
+const (
+	SourceARP  = "arp"
+	SourceNDP  = "ndp"
+	SourceDHCP = "dhcp"
+	SourceMDNS = "mdns"
+	SourceNBNS = "nbns"
+	SourceLLDP = "lldp"
+)
+
+// dhcpMagicCookie DHCP选项起始标识
+var dhcpMagicCookie = []byte{99, 130, 83, 99}
+
+// observation 从单个报文中提取的主机信息，ip为空时仅关联mac地址与主机名
+type observation struct {
+	ip       net.IP // 主机ip
+	mac      string // mac地址
+	hostname string // 主机名
+	source   string // 发现来源
+}
+
+// parseFrame 解析以太网帧，返回报文中携带的主机信息
+func parseFrame(frame []byte) []observation {
+	if len(frame) < 14 {
+		return nil
+	}
+	srcMac := net.HardwareAddr(frame[6:12]).String()
+	payload := frame[14:]
+	switch binary.BigEndian.Uint16(frame[12:14]) {
+	case etherTypeARP:
+		return parseARP(payload)
+	case etherTypeLLDP:
+		return parseLLDP(srcMac, payload)
+	case etherTypeIPv4:
+		return parseIPv4(srcMac, payload)
+	case etherTypeIPv6:
+		return parseNDP(srcMac, payload)
+	}
+	return nil
+}
+
+// parseARP 解析ARP请求及应答的发送方地址
+func parseARP(p []byte) []observation {
+	// 仅处理以太网/IPv4的ARP报文
+	if len(p) < 28 || binary.BigEndian.Uint16(p[0:2]) != 1 || binary.BigEndian.Uint16(p[2:4]) != etherTypeIPv4 || p[4] != 6 || p[5] != 4 {
+		return nil
+	}
+	ip := net.IP(p[14:18])
+	// 发送方ip为0.0.0.0的ARP探测不携带有效地址
+	if ip.IsUnspecified() {
+		return nil
+	}
+	return []observation{{ip: copyIP(ip), mac: net.HardwareAddr(p[8:14]).String(), source: SourceARP}}
+}
+
+// parseNDP 解析IPv6邻居请求及邻居通告，邻居请求取发送方地址，邻居通告取目标地址
+// 仅处理IPv6首部后直接为ICMPv6的报文（NDP报文不携带扩展首部）
+func parseNDP(srcMac string, p []byte) []observation {
+	if len(p) < 40+24 || p[0]>>4 != 6 || p[6] != ipProtoICMPv6 {
+		return nil
+	}
+	icmp := p[40:]
+	var ip net.IP
+	var optionType byte
+	switch icmp[0] {
+	case icmpv6NeighborSolicitation:
+		// 源地址为::的邻居请求为重复地址检测，地址尚未生效
+		ip = net.IP(p[8:24])
+		optionType = ndpOptionSourceLinkAddr
+	case icmpv6NeighborAdvertisement:
+		ip = net.IP(icmp[8:24])
+		optionType = ndpOptionTargetLinkAddr
+	default:
+		return nil
+	}
+	if ip.IsUnspecified() || ip.IsMulticast() {
+		return nil
+	}
+
+	mac := srcMac
+	options := icmp[24:]
+	for len(options) >= 8 {
+		length := int(options[1]) * 8
+		if length == 0 || len(options) < length {
+			break
+		}
+		if options[0] == optionType {
+			mac = net.HardwareAddr(options[2:8]).String()
+			break
+		}
+		options = options[length:]
+	}
+	return []observation{{ip: copyIP(ip), mac: mac, source: SourceNDP}}
+}
+
+// parseLLDP 解析LLDP报文的系统名称及IPv4管理地址
+func parseLLDP(srcMac string, p []byte) []observation {
+	ob := observation{mac: srcMac, source: SourceLLDP}
+	for len(p) >= 2 {
+		header := binary.BigEndian.Uint16(p[0:2])
+		tlvType, tlvLen := header>>9, int(header&0x1ff)
+		if tlvType == 0 || len(p) < 2+tlvLen {
+			break
+		}
+		value := p[2 : 2+tlvLen]
+		switch tlvType {
+		case 5: // 系统名称
+			ob.hostname = cleanHostname(string(value))
+		case 8: // 管理地址：地址长度（含地址类型）、地址类型（1 IPv4）、地址
+			if len(value) >= 6 && value[0] == 5 && value[1] == 1 && ob.ip == nil {
+				ob.ip = copyIP(value[2:6])
+			}
+		}
+		p = p[2+tlvLen:]
+	}
+	if ob.ip == nil && ob.hostname == "" {
+		return nil
+	}
+	return []observation{ob}
+}
+
+// parseIPv4 解析IPv4 UDP报文，按目的端口分发到DHCP、NBNS及mDNS解析
+func parseIPv4(srcMac string, p []byte) []observation {
+	if len(p) < 20 || p[0]>>4 != 4 || p[9] != 17 {
+		return nil
+	}
+	ihl := int(p[0]&0x0f) * 4
+	// 分片报文无法完整解析，直接忽略
+	if binary.BigEndian.Uint16(p[6:8])&0x3fff != 0 || len(p) < ihl+8 {
+		return nil
+	}
+	srcIP := copyIP(p[12:16])
+	udp := p[ihl:]
+	payload := udp[8:]
+	switch binary.BigEndian.Uint16(udp[2:4]) {
+	case portDHCPServer:
+		return parseDHCP(payload)
+	case portNBNS:
+		return parseNBNS(srcMac, srcIP, payload)
+	case portMDNS:
+		return parseMDNS(srcMac, srcIP, payload)
+	}
+	return nil
+}
+
+// parseDHCP 解析DHCP客户端请求的mac地址、主机名及已有或请求的ip
+func parseDHCP(p []byte) []observation {
+	if len(p) < 240 || p[0] != 1 || p[1] != 1 || p[2] != 6 {
+		return nil
+	}
+	if string(p[236:240]) != string(dhcpMagicCookie) {
+		return nil
+	}
+	ob := observation{mac: net.HardwareAddr(p[28:34]).String(), source: SourceDHCP}
+	if ciaddr := net.IP(p[12:16]); !ciaddr.IsUnspecified() {
+		ob.ip = copyIP(ciaddr)
+	}
+
+	options := p[240:]
+	for len(options) > 0 {
+		code := options[0]
+		if code == 255 {
+			break
+		}
+		if code == 0 {
+			options = options[1:]
+			continue
+		}
+		if len(options) < 2 || len(options) < 2+int(options[1]) {
+			break
+		}
+		value := options[2 : 2+int(options[1])]
+		switch code {
+		case 12: // 主机名
+			ob.hostname = cleanHostname(string(value))
+		case 50: // 请求的ip
+			if len(value) == 4 && ob.ip == nil {
+				ob.ip = copyIP(value)
+			}
+		}
+		options = options[2+len(value):]
+	}
+	return []observation{ob}
+}
+
+// parseNBNS 解析NBNS报文，名称注册及刷新请求中的名称即为发送方的主机名
+func parseNBNS(srcMac string, srcIP net.IP, p []byte) []observation {
+	if srcIP.IsUnspecified() {
+		return nil
+	}
+	ob := observation{ip: srcIP, mac: srcMac, source: SourceNBNS}
+	var parser dnsmessage.Parser
+	header, err := parser.Start(p)
+	if err != nil {
+		return []observation{ob}
+	}
+	// 操作码 5 注册 8、9 刷新
+	if header.OpCode == 5 || header.OpCode == 8 || header.OpCode == 9 {
+		if question, err := parser.Question(); err == nil {
+			ob.hostname = decodeNetbiosName(question.Name.String())
+		}
+	}
+	return []observation{ob}
+}
+
+// parseMDNS 解析mDNS报文，A记录地址与发送方一致时记录对应的主机名
+func parseMDNS(srcMac string, srcIP net.IP, p []byte) []observation {
+	if srcIP.IsUnspecified() {
+		return nil
+	}
+	ob := observation{ip: srcIP, mac: srcMac, source: SourceMDNS}
+	var parser dnsmessage.Parser
+	header, err := parser.Start(p)
+	if err != nil || !header.Response {
+		return []observation{ob}
+	}
+	if err = parser.SkipAllQuestions(); err != nil {
+		return []observation{ob}
+	}
+	for {
+		h, err := parser.AnswerHeader()
+		if err != nil {
+			break
+		}
+		if h.Type != dnsmessage.TypeA {
+			if parser.SkipAnswer() != nil {
+				break
+			}
+			continue
+		}
+		a, err := parser.AResource()
+		if err != nil {
+			break
+		}
+		if net.IP(a.A[:]).Equal(srcIP) {
+			ob.hostname = cleanHostname(strings.TrimSuffix(strings.TrimSuffix(h.Name.String(), "."), ".local"))
+			break
+		}
+	}
+	return []observation{ob}
+}
+
+// decodeNetbiosName 解码NetBIOS一级编码的名称（32个字符，每2个字符表示1个字节），去除末尾的类型后缀及空格
+func decodeNetbiosName(name string) string {
+	label, _, _ := strings.Cut(name, ".")
+	if len(label) != 32 {
+		return ""
+	}
+	decoded := make([]byte, 16)
+	for i := 0; i < 16; i++ {
+		hi, lo := label[2*i]-'A', label[2*i+1]-'A'
+		if hi > 15 || lo > 15 {
+			return ""
+		}
+		decoded[i] = hi<<4 | lo
+	}
+	return cleanHostname(string(decoded[:15]))
+}
+
+// cleanHostname 去除主机名中的不可见字符及首尾空白
+func cleanHostname(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(name, ""))
+	name = strings.TrimSpace(name)
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// copyIP 拷贝ip，避免引用复用的读取缓冲区
+func copyIP(ip []byte) net.IP {
+	return net.IP(append([]byte(nil), ip...))
+}
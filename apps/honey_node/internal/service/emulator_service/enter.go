@@ -0,0 +1,113 @@
+package emulator_service
+
+// File: honey_node/service/emulator_service/enter.go
+// Description: 内置低交互协议模拟器模块，端口目标地址为emu://<名称>时由节点本地提供协议横幅与握手，并记录登录尝试
+
+import (
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Prefix 模拟器目标地址前缀
+const Prefix = "emu://"
+
+// sessionTimeout 单个模拟器会话的最长存活时间，防止连接长期占用
+const sessionTimeout = 5 * time.Minute
+
+// Emulator 协议模拟器接口
+type Emulator interface {
+	// Serve 处理单个客户端连接，通过session记录登录尝试
+	Serve(conn net.Conn, session *Session)
+}
+
+// emulatorMap 已注册的协议模拟器，key为模拟器名称
+var emulatorMap = map[string]Emulator{
+	"ssh":    sshEmulator{},
+	"telnet": telnetEmulator{},
+	"redis":  redisEmulator{},
+	"mysql":  mysqlEmulator{},
+	"http":   httpEmulator{},
+}
+
+// loginReporter 登录尝试上报函数，由启动流程注册（避免与mq_service循环依赖）
+var loginReporter func(session Session, username, password string)
+
+// SetLoginReporter 注册登录尝试上报函数
+func SetLoginReporter(fn func(session Session, username, password string)) {
+	loginReporter = fn
+}
+
+// Session 单个模拟器连接的会话信息
+type Session struct {
+	Emulator string // 模拟器名称
+	SrcIp    string // 来源IP
+	SrcPort  int    // 来源端口
+	DestIp   string // 诱捕IP
+	DestPort int    // 诱捕端口
+}
+
+// Parse 解析目标地址，返回模拟器名称及是否为模拟器目标
+func Parse(targetAddr string) (name string, ok bool) {
+	if !strings.HasPrefix(targetAddr, Prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(targetAddr, Prefix), true
+}
+
+// Check 校验模拟器名称是否已注册
+func Check(name string) error {
+	if _, ok := emulatorMap[name]; !ok {
+		return fmt.Errorf("不支持的模拟器 %s", name)
+	}
+	return nil
+}
+
+// Serve 使用指定模拟器处理客户端连接
+func Serve(name string, conn net.Conn) {
+	defer conn.Close()
+
+	emulator, ok := emulatorMap[name]
+	if !ok {
+		logrus.Errorf("不支持的模拟器 %s", name)
+		return
+	}
+	conn.SetDeadline(time.Now().Add(sessionTimeout))
+
+	session := &Session{Emulator: name}
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		session.SrcIp = addr.IP.String()
+		session.SrcPort = addr.Port
+	}
+	if addr, ok := conn.LocalAddr().(*net.TCPAddr); ok {
+		session.DestIp = addr.IP.String()
+		session.DestPort = addr.Port
+	}
+	logrus.Infof("模拟器会话 %s %s:%d => %s:%d", name, session.SrcIp, session.SrcPort, session.DestIp, session.DestPort)
+	emulator.Serve(conn, session)
+}
+
+// Login 记录一次登录尝试：本地入库并通过上报函数发送告警
+func (s *Session) Login(username, password string) {
+	logrus.Infof("模拟器登录尝试 %s %s:%d 用户名 %s 密码 %s", s.Emulator, s.SrcIp, s.SrcPort, username, password)
+
+	// 本地持久化，节点与管理端断开时也不会丢失
+	global.DB.Create(&models.EmuLoginModel{
+		Emulator: s.Emulator,
+		SrcIp:    s.SrcIp,
+		SrcPort:  s.SrcPort,
+		DestIp:   s.DestIp,
+		DestPort: s.DestPort,
+		Username: username,
+		Password: password,
+	})
+
+	if loginReporter != nil {
+		loginReporter(*s, username, password)
+	}
+}
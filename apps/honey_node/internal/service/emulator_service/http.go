@@ -0,0 +1,99 @@
+package emulator_service
+
+// File: honey_node/service/emulator_service/http.go
+// Description: HTTP协议模拟器，提供后台登录页面，记录Basic认证及表单登录提交的用户名密码
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	httpServerHeader   = "nginx/1.18.0" // HTTP服务端横幅
+	httpMaxRequests    = 20             // 单连接最多处理的请求数
+	httpMaxRequestBody = 64 * 1024      // 请求体读取上限
+)
+
+// httpLoginPage 登录页面
+const httpLoginPage = `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Admin Login</title></head>
+<body><form method="post" action="/login">
+<input name="username" placeholder="Username"><input name="password" type="password" placeholder="Password">
+<button type="submit">Login</button>%s</form></body></html>`
+
+// httpEmulator HTTP协议模拟器
+type httpEmulator struct{}
+
+// Serve 处理HTTP连接
+func (httpEmulator) Serve(conn net.Conn, session *Session) {
+	reader := bufio.NewReader(conn)
+	for i := 0; i < httpMaxRequests; i++ {
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			return
+		}
+		req.Body = http.MaxBytesReader(nil, req.Body, httpMaxRequestBody)
+
+		status, tip := http.StatusOK, ""
+		// Basic认证
+		if username, password, ok := req.BasicAuth(); ok {
+			session.Login(username, password)
+			status = http.StatusUnauthorized
+		}
+		// 表单登录
+		if req.Method == http.MethodPost && req.ParseForm() == nil {
+			username := httpFormValue(req, "username", "user", "login", "email")
+			password := httpFormValue(req, "password", "pass", "pwd", "passwd")
+			if username != "" || password != "" {
+				session.Login(username, password)
+				tip = "<p>Invalid username or password</p>"
+			}
+		}
+		req.Body.Close()
+
+		if err = httpWriteResponse(conn, status, fmt.Sprintf(httpLoginPage, tip)); err != nil {
+			return
+		}
+		if req.Close {
+			return
+		}
+	}
+}
+
+// httpFormValue 按候选字段名依次取表单值
+func httpFormValue(req *http.Request, keys ...string) string {
+	for _, key := range keys {
+		if value := req.PostForm.Get(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// httpWriteResponse 写入HTTP响应
+func httpWriteResponse(conn net.Conn, status int, body string) error {
+	resp := &http.Response{
+		StatusCode:    status,
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(strings.NewReader(body)),
+	}
+	resp.Header.Set("Server", httpServerHeader)
+	resp.Header.Set("Content-Type", "text/html; charset=utf-8")
+	if status == http.StatusUnauthorized {
+		resp.Header.Set("WWW-Authenticate", `Basic realm="Restricted"`)
+	}
+	var buf bytes.Buffer
+	if err := resp.Write(&buf); err != nil {
+		return err
+	}
+	_, err := conn.Write(buf.Bytes())
+	return err
+}
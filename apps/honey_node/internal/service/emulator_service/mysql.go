@@ -0,0 +1,149 @@
+package emulator_service
+
+// File: honey_node/service/emulator_service/mysql.go
+// Description: MySQL协议模拟器，发送v10握手包并解析客户端认证响应，记录用户名及加密口令后返回拒绝访问
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+)
+
+const (
+	mysqlServerVersion = "5.7.33-log" // MySQL服务端版本横幅
+
+	mysqlClientProtocol41       = 0x00000200 // CLIENT_PROTOCOL_41
+	mysqlClientSecureConnection = 0x00008000 // CLIENT_SECURE_CONNECTION
+	mysqlClientPluginAuth       = 0x00080000 // CLIENT_PLUGIN_AUTH
+	mysqlClientAuthLenEncData   = 0x00200000 // CLIENT_PLUGIN_AUTH_LENENC_CLIENT_DATA
+)
+
+// mysqlEmulator MySQL协议模拟器
+type mysqlEmulator struct{}
+
+// Serve 处理MySQL连接
+func (mysqlEmulator) Serve(conn net.Conn, session *Session) {
+	// 生成20字节认证随机数
+	salt := make([]byte, 20)
+	rand.Read(salt)
+	for i := range salt {
+		salt[i] = salt[i]%94 + 33 // 与MySQL一致，使用可打印字符
+	}
+
+	if err := mysqlWritePacket(conn, 0, mysqlHandshake(salt)); err != nil {
+		return
+	}
+
+	_, payload, err := mysqlReadPacket(conn)
+	if err != nil {
+		return
+	}
+	username, authData, ok := mysqlParseHandshakeResponse(payload)
+	if !ok {
+		return
+	}
+	session.Login(username, hex.EncodeToString(authData))
+
+	usingPassword := "NO"
+	if len(authData) > 0 {
+		usingPassword = "YES"
+	}
+	msg := fmt.Sprintf("Access denied for user '%s'@'%s' (using password: %s)", username, session.SrcIp, usingPassword)
+	mysqlWritePacket(conn, 2, mysqlErrPacket(1045, "28000", msg))
+}
+
+// mysqlHandshake 构建Protocol::HandshakeV10握手包
+func mysqlHandshake(salt []byte) []byte {
+	capability := uint32(0xf7ff) | mysqlClientPluginAuth | mysqlClientAuthLenEncData
+	var buf bytes.Buffer
+	buf.WriteByte(10) // 协议版本
+	buf.WriteString(mysqlServerVersion)
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.LittleEndian, uint32(1)) // 连接ID
+	buf.Write(salt[:8])
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.LittleEndian, uint16(capability))
+	buf.WriteByte(33)                                  // utf8_general_ci
+	binary.Write(&buf, binary.LittleEndian, uint16(2)) // SERVER_STATUS_AUTOCOMMIT
+	binary.Write(&buf, binary.LittleEndian, uint16(capability>>16))
+	buf.WriteByte(21)           // 认证数据长度
+	buf.Write(make([]byte, 10)) // 保留字段
+	buf.Write(salt[8:])
+	buf.WriteByte(0)
+	buf.WriteString("mysql_native_password")
+	buf.WriteByte(0)
+	return buf.Bytes()
+}
+
+// mysqlParseHandshakeResponse 解析Protocol::HandshakeResponse41，提取用户名及认证数据
+func mysqlParseHandshakeResponse(payload []byte) (username string, authData []byte, ok bool) {
+	if len(payload) < 32 {
+		return "", nil, false
+	}
+	capability := binary.LittleEndian.Uint32(payload[:4])
+	if capability&mysqlClientProtocol41 == 0 {
+		return "", nil, false
+	}
+	rest := payload[32:] // 跳过capability、max packet、charset及23字节保留字段
+	end := bytes.IndexByte(rest, 0)
+	if end < 0 {
+		return "", nil, false
+	}
+	username = string(rest[:end])
+	rest = rest[end+1:]
+
+	switch {
+	case capability&mysqlClientAuthLenEncData != 0 && len(rest) > 0:
+		size := int(rest[0]) // 口令摘要不超过250字节，长度编码整数只占一个字节
+		if size < 0xfb && len(rest) > size {
+			authData = rest[1 : 1+size]
+		}
+	case capability&mysqlClientSecureConnection != 0 && len(rest) > 0:
+		size := int(rest[0])
+		if len(rest) > size {
+			authData = rest[1 : 1+size]
+		}
+	default:
+		if end = bytes.IndexByte(rest, 0); end >= 0 {
+			authData = rest[:end]
+		}
+	}
+	return username, authData, true
+}
+
+// mysqlErrPacket 构建ERR_Packet
+func mysqlErrPacket(code uint16, state, msg string) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(0xff)
+	binary.Write(&buf, binary.LittleEndian, code)
+	buf.WriteByte('#')
+	buf.WriteString(state)
+	buf.WriteString(msg)
+	return buf.Bytes()
+}
+
+// mysqlWritePacket 写入一个MySQL数据包（3字节长度 + 1字节序号）
+func mysqlWritePacket(conn net.Conn, seq byte, payload []byte) error {
+	header := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), seq}
+	_, err := conn.Write(append(header, payload...))
+	return err
+}
+
+// mysqlReadPacket 读取一个MySQL数据包
+func mysqlReadPacket(conn net.Conn) (seq byte, payload []byte, err error) {
+	header := make([]byte, 4)
+	if _, err = io.ReadFull(conn, header); err != nil {
+		return
+	}
+	size := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	if size > 64*1024 {
+		return 0, nil, fmt.Errorf("数据包过大 %d", size)
+	}
+	payload = make([]byte, size)
+	_, err = io.ReadFull(conn, payload)
+	return header[3], payload, err
+}
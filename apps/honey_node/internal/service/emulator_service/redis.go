@@ -0,0 +1,111 @@
+package emulator_service
+
+// File: honey_node/service/emulator_service/redis.go
+// Description: Redis协议模拟器，解析RESP命令，要求认证并记录AUTH尝试
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	redisMaxLine        = 4 * 1024  // 单行（命令头及内联命令）长度上限
+	redisMaxArgs        = 64        // 单条命令参数个数上限
+	redisMaxBulk        = 16 * 1024 // 单个参数长度上限
+	redisMaxCommandSize = 64 * 1024 // 单条命令参数总长度上限
+)
+
+// redisEmulator Redis协议模拟器
+type redisEmulator struct{}
+
+// Serve 处理Redis连接
+func (redisEmulator) Serve(conn net.Conn, session *Session) {
+	reader := bufio.NewReaderSize(conn, redisMaxLine)
+	for {
+		args, err := redisReadCommand(reader)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			conn.Write([]byte("+PONG\r\n"))
+		case "AUTH":
+			switch len(args) {
+			case 2: // AUTH password
+				session.Login("default", args[1])
+				conn.Write([]byte("-ERR invalid password\r\n"))
+			case 3: // AUTH username password（Redis 6+）
+				session.Login(args[1], args[2])
+				conn.Write([]byte("-WRONGPASS invalid username-password pair or user is disabled.\r\n"))
+			default:
+				conn.Write([]byte("-ERR wrong number of arguments for 'auth' command\r\n"))
+			}
+		case "QUIT":
+			conn.Write([]byte("+OK\r\n"))
+			return
+		default:
+			conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+		}
+	}
+}
+
+// redisReadCommand 读取一条RESP数组命令或内联命令
+func redisReadCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := redisReadLine(reader)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		// 内联命令（如 nc/telnet 直接输入）
+		return strings.Fields(line), nil
+	}
+
+	count, err := strconv.Atoi(line[1:])
+	if err != nil || count < 0 || count > redisMaxArgs {
+		return nil, errors.New("invalid multibulk length")
+	}
+	args := make([]string, 0, count)
+	total := 0
+	for i := 0; i < count; i++ {
+		header, err := redisReadLine(reader)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(header, "$") {
+			return nil, errors.New("expected bulk string")
+		}
+		size, err := strconv.Atoi(header[1:])
+		if err != nil || size < 0 || size > redisMaxBulk {
+			return nil, errors.New("invalid bulk length")
+		}
+		total += size
+		if total > redisMaxCommandSize {
+			return nil, errors.New("command too large")
+		}
+		data := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args = append(args, string(data[:size]))
+	}
+	return args, nil
+}
+
+// redisReadLine 读取一行并去掉行尾换行，超过缓冲区长度（redisMaxLine）的行视为非法输入
+func redisReadLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return "", errors.New("line too long")
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
@@ -0,0 +1,65 @@
+package emulator_service
+
+// File: honey_node/service/emulator_service/ssh.go
+// Description: SSH协议模拟器，完成真实的SSH密钥交换并记录密码认证尝试，所有认证均返回失败
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshServerVersion SSH服务端版本横幅
+const sshServerVersion = "SSH-2.0-OpenSSH_7.4"
+
+var (
+	sshHostKey     ssh.Signer // SSH主机密钥（进程内生成一次）
+	sshHostKeyOnce sync.Once
+)
+
+// sshEmulator SSH协议模拟器
+type sshEmulator struct{}
+
+// hostKey 获取SSH主机密钥
+func (sshEmulator) hostKey() ssh.Signer {
+	sshHostKeyOnce.Do(func() {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			logrus.Errorf("生成SSH主机密钥失败 %s", err)
+			return
+		}
+		sshHostKey, err = ssh.NewSignerFromKey(key)
+		if err != nil {
+			logrus.Errorf("加载SSH主机密钥失败 %s", err)
+		}
+	})
+	return sshHostKey
+}
+
+// Serve 处理SSH连接
+func (e sshEmulator) Serve(conn net.Conn, session *Session) {
+	signer := e.hostKey()
+	if signer == nil {
+		return
+	}
+	config := &ssh.ServerConfig{
+		ServerVersion: sshServerVersion,
+		MaxAuthTries:  6,
+		PasswordCallback: func(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			session.Login(meta.User(), string(password))
+			return nil, errors.New("permission denied")
+		},
+	}
+	config.AddHostKey(signer)
+
+	// 认证永远失败，握手结束即返回
+	_, _, _, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		logrus.Debugf("SSH模拟器会话结束 %s:%d %s", session.SrcIp, session.SrcPort, err)
+	}
+}
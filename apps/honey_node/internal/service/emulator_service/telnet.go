@@ -0,0 +1,70 @@
+package emulator_service
+
+// File: honey_node/service/emulator_service/telnet.go
+// Description: Telnet协议模拟器，提供登录提示符并记录用户名密码，所有登录均返回失败
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"strings"
+)
+
+const (
+	telnetMaxAttempts = 3   // 单连接最大登录尝试次数
+	telnetMaxLine     = 256 // 单行输入长度上限（用户名/密码）
+)
+
+// telnetEmulator Telnet协议模拟器
+type telnetEmulator struct{}
+
+// Serve 处理Telnet连接
+func (telnetEmulator) Serve(conn net.Conn, session *Session) {
+	reader := bufio.NewReader(conn)
+	conn.Write([]byte("\r\nUbuntu 18.04.6 LTS\r\n"))
+	for i := 0; i < telnetMaxAttempts; i++ {
+		conn.Write([]byte("login: "))
+		username, err := telnetReadLine(reader)
+		if err != nil {
+			return
+		}
+		conn.Write([]byte("Password: "))
+		password, err := telnetReadLine(reader)
+		if err != nil {
+			return
+		}
+		session.Login(username, password)
+		conn.Write([]byte("\r\nLogin incorrect\r\n"))
+	}
+}
+
+// telnetReadLine 读取一行输入，过滤Telnet协商指令（IAC序列）
+func telnetReadLine(reader *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case b == 0xff: // IAC：跳过命令字节及选项字节
+			cmd, err := reader.ReadByte()
+			if err != nil {
+				return "", err
+			}
+			if cmd >= 0xfb && cmd <= 0xfe { // WILL/WONT/DO/DONT 后跟一个选项字节
+				if _, err = reader.ReadByte(); err != nil {
+					return "", err
+				}
+			}
+		case b == '\n':
+			return strings.TrimSpace(string(line)), nil
+		case b == '\r' || b == 0:
+		default:
+			if len(line) >= telnetMaxLine {
+				return "", errors.New("line too long")
+			}
+			line = append(line, b)
+		}
+	}
+}
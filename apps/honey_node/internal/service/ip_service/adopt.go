@@ -0,0 +1,90 @@
+package ip_service
+
+// File: honey_node/service/ip_service/adopt.go
+// Description: 诱捕网卡接管模块，部署在网卡创建完成、IP记录入库前中断时，恢复执行阶段接管内核中IP及MAC一致的残留诱捕网卡并补齐IP记录
+
+import (
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"net"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// AdoptIp 查找基于指定基础网卡创建、携带待部署IP且未入库的诱捕网卡，IP及MAC一致时补齐IP记录
+// 未指定MAC（无设备画像）时MAC由系统随机分配，直接沿用网卡当前的MAC地址
+// 持有巡检锁执行，避免网卡在接管过程中被巡检当作残留网卡删除
+func AdoptIp(req SetIpRequest) (model models.IpModel, ok bool) {
+	reconcileLock.Lock()
+	defer reconcileLock.Unlock()
+
+	parent, err := netlink.LinkByName(req.Network)
+	if err != nil {
+		return
+	}
+	ip := net.ParseIP(req.Ip)
+	if ip == nil {
+		return
+	}
+	links, err := netlink.LinkList()
+	if err != nil {
+		logrus.Errorf("接管网卡获取网卡列表失败 %s", err)
+		return
+	}
+	for _, link := range links {
+		attrs := link.Attrs()
+		if link.Type() != "macvlan" || !strings.HasPrefix(attrs.Name, linkPrefix) || attrs.ParentIndex != parent.Attrs().Index {
+			continue
+		}
+		if !linkHasIP(link, ip) {
+			continue
+		}
+		mac := attrs.HardwareAddr.String()
+		if req.Mac != "" && !strings.EqualFold(mac, req.Mac) {
+			logrus.Warnf("网卡 %s 携带ip %s 但MAC %s 与预期 %s 不一致，不接管", attrs.Name, req.Ip, mac, req.Mac)
+			return
+		}
+		var count int64
+		global.DB.Model(&models.IpModel{}).Where("link_name = ?", attrs.Name).Count(&count)
+		if count > 0 {
+			return
+		}
+
+		model = models.IpModel{
+			Ip:       req.Ip,
+			Mask:     req.Mask,
+			LinkName: attrs.Name,
+			Network:  req.Network,
+			Mac:      mac,
+		}
+		if err = global.DB.Create(&model).Error; err != nil {
+			logrus.Errorf("接管网卡 %s 入库失败 %s", attrs.Name, err)
+			return
+		}
+		// 中断时可能尚未启用网卡
+		if attrs.Flags&net.FlagUp == 0 {
+			if err = netlink.LinkSetUp(link); err != nil {
+				logrus.Errorf("接管网卡启用 %s 失败 %s", attrs.Name, err)
+			}
+		}
+		logrus.Infof("接管已创建的诱捕网卡 %s ip %s mac %s", attrs.Name, req.Ip, mac)
+		return model, true
+	}
+	return
+}
+
+// linkHasIP 判断网卡上是否配置了指定IP
+func linkHasIP(link netlink.Link, ip net.IP) bool {
+	addrList, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return false
+	}
+	for _, addr := range addrList {
+		if addr.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -14,6 +14,9 @@ import (
 
 // IPLoad 应用启动时加载数据库中的IP配置记录，校验并初始化网卡与IP地址
 func IPLoad() {
+	// 先恢复VLAN子接口，诱捕ip的MACVLAN接口可能基于子接口创建
+	VlanLoad()
+
 	// 从数据库查询所有IP配置记录
 	var ipList []models.IpModel
 	global.DB.Find(&ipList)
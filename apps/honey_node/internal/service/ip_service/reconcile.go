@@ -0,0 +1,142 @@
+package ip_service
+
+// File: honey_node/service/ip_service/reconcile.go
+// Description: 网络接口巡检模块，周期性比对数据库IP配置与内核实际接口及地址，恢复被外部删除的VLAN子接口、诱捕接口/地址并清理残留的hy_接口
+
+import (
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// linkPrefix 诱捕网卡名称前缀
+const linkPrefix = "hy_"
+
+// reconcileLock 保证同一时间只有一次巡检在执行
+var reconcileLock sync.Mutex
+
+// driftMap 上一轮巡检发现的接口缺失/残留记录
+// 接口创建与入库、删除与删库之间存在时间差，需连续两轮巡检都发现差异才执行恢复或清理
+var driftMap = map[string]bool{}
+
+// Reconcile 比对数据库IP配置与内核接口状态并修复差异
+func Reconcile() {
+	if !reconcileLock.TryLock() {
+		return
+	}
+	defer reconcileLock.Unlock()
+
+	// 先恢复VLAN子接口，诱捕网卡可能基于子接口创建
+	VlanLoad()
+
+	var ipList []models.IpModel
+	if err := global.DB.Find(&ipList).Error; err != nil {
+		logrus.Errorf("巡检查询ip记录失败 %s", err)
+		return
+	}
+	links, err := netlink.LinkList()
+	if err != nil {
+		logrus.Errorf("巡检获取网卡列表失败 %s", err)
+		return
+	}
+	linkMap := make(map[string]netlink.Link, len(links))
+	for _, link := range links {
+		linkMap[link.Attrs().Name] = link
+	}
+
+	drift := map[string]bool{}
+	modelMap := make(map[string]bool, len(ipList))
+	for _, model := range ipList {
+		modelMap[model.LinkName] = true
+		link, ok := linkMap[model.LinkName]
+		if !ok {
+			key := "missing:" + model.LinkName
+			drift[key] = true
+			if driftMap[key] {
+				recreateLink(model)
+			}
+			continue
+		}
+		reconcileLink(model, link)
+	}
+
+	// 清理数据库中不存在的诱捕网卡
+	for name := range linkMap {
+		if !strings.HasPrefix(name, linkPrefix) || modelMap[name] {
+			continue
+		}
+		key := "stray:" + name
+		drift[key] = true
+		if !driftMap[key] {
+			continue
+		}
+		if err = RemoveInterface(name); err != nil {
+			logrus.Errorf("巡检删除残留网卡失败 %s", err)
+			continue
+		}
+		logrus.Infof("巡检删除残留网卡 %s", name)
+	}
+
+	driftMap = drift
+}
+
+// recreateLink 重新创建被外部删除的诱捕网卡
+func recreateLink(model models.IpModel) {
+	mac, err := SetIp(SetIpRequest{
+		Ip:       model.Ip,
+		Mask:     model.Mask,
+		LinkName: model.LinkName,
+		Network:  model.Network,
+		Mac:      model.Mac,
+	})
+	if err != nil {
+		logrus.Errorf("巡检恢复网卡 %s 失败 %s", model.LinkName, err)
+		return
+	}
+	logrus.Infof("巡检恢复网卡 %s ip %s mac %s", model.LinkName, model.Ip, mac)
+}
+
+// reconcileLink 修复已存在诱捕网卡的MAC地址、启用状态及IP地址
+func reconcileLink(model models.IpModel, link netlink.Link) {
+	attrs := link.Attrs()
+	if model.Mac != "" && !strings.EqualFold(attrs.HardwareAddr.String(), model.Mac) {
+		if err := setInterfaceMac(model.LinkName, model.Mac); err != nil {
+			logrus.Errorf("巡检修复网卡MAC失败 %s", err)
+		} else {
+			logrus.Infof("巡检修复网卡 %s MAC %s => %s", model.LinkName, attrs.HardwareAddr, model.Mac)
+		}
+	}
+	if attrs.Flags&net.FlagUp == 0 {
+		if err := netlink.LinkSetUp(link); err != nil {
+			logrus.Errorf("巡检启用网卡 %s 失败 %s", model.LinkName, err)
+		} else {
+			logrus.Infof("巡检启用网卡 %s", model.LinkName)
+		}
+	}
+
+	addr, err := parseAddr(model.Ip, model.Mask)
+	if err != nil {
+		logrus.Errorf("巡检解析ip失败 %s", err)
+		return
+	}
+	addrList, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		logrus.Errorf("巡检获取网卡 %s 地址失败 %s", model.LinkName, err)
+		return
+	}
+	for _, a := range addrList {
+		if a.IPNet.String() == addr.IPNet.String() {
+			return
+		}
+	}
+	if err = netlink.AddrAdd(link, addr); err != nil {
+		logrus.Errorf("巡检恢复网卡 %s 地址 %s 失败 %s", model.LinkName, addr, err)
+		return
+	}
+	logrus.Infof("巡检恢复网卡 %s 地址 %s", model.LinkName, addr)
+}
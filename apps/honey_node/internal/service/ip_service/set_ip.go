@@ -1,14 +1,15 @@
 package ip_service
 
 // File: honey_node/service/ip_service/set_ip.go
-// Description: 网络接口配置模块，负责MACVLAN接口创建、IP地址配置、MAC地址设置及相关网络操作，支持失败自动资源清理
+// Description: 网络接口配置模块，基于netlink直接完成MACVLAN接口创建、IP地址配置、MAC地址设置及相关网络操作，支持失败自动资源清理
 
 import (
 	"fmt"
-	"honey_node/internal/utils/cmd"
-	"strings"
+	"net"
 
 	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 )
 
 // SetIpRequest 网络接口配置请求结构体，包含创建MACVLAN接口及配置IP所需参数
@@ -25,7 +26,7 @@ func SetIp(req SetIpRequest) (mac string, err error) {
 	linkName := req.LinkName
 	// 资源清理函数：当配置过程中出现错误时，删除已创建的网络接口，避免残留垃圾资源
 	cleanup := func() {
-		if err := cmd.Cmd(fmt.Sprintf("ip link delete %s", linkName)); err != nil {
+		if err := RemoveInterface(linkName); err != nil {
 			logrus.Errorf("清理失败，删除网络接口 %s 时出错: %v", linkName, err)
 		}
 	}
@@ -74,53 +75,119 @@ func SetIp(req SetIpRequest) (mac string, err error) {
 
 // createMacVlanInterface 基于指定基础网卡创建MACVLAN接口，采用桥接模式
 func createMacVlanInterface(linkName, network string) error {
-	cmdStr := fmt.Sprintf("ip link add %s link %s type macvlan mode bridge", linkName, network)
-	if err := cmd.Cmd(cmdStr); err != nil {
-		return fmt.Errorf("执行命令失败 [%s]: %w", cmdStr, err)
+	parent, err := netlink.LinkByName(network)
+	if err != nil {
+		return fmt.Errorf("基础网卡 %s 不存在: %w", network, err)
+	}
+	link := &netlink.Macvlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        linkName,
+			ParentIndex: parent.Attrs().Index,
+		},
+		Mode: netlink.MACVLAN_MODE_BRIDGE,
+	}
+	if err = netlink.LinkAdd(link); err != nil {
+		return fmt.Errorf("创建接口 %s 失败: %w", linkName, err)
 	}
 	return nil
 }
 
 // setInterfaceUp 启用指定网络接口（将接口状态设置为UP）
 func setInterfaceUp(linkName string) error {
-	cmdStr := fmt.Sprintf("ip link set %s up", linkName)
-	if err := cmd.Cmd(cmdStr); err != nil {
-		return fmt.Errorf("执行命令失败 [%s]: %w", cmdStr, err)
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return fmt.Errorf("接口 %s 不存在: %w", linkName, err)
+	}
+	if err = netlink.LinkSetUp(link); err != nil {
+		return fmt.Errorf("启用接口 %s 失败: %w", linkName, err)
 	}
 	return nil
 }
 
 // setInterfaceMac 为指定网络接口设置自定义MAC地址
 func setInterfaceMac(linkName string, mac string) error {
-	cmdStr := fmt.Sprintf("ip link set %s address %s", linkName, mac)
-	if err := cmd.Cmd(cmdStr); err != nil {
-		return fmt.Errorf("执行命令失败 [%s]: %w", cmdStr, err)
+	hwAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		return fmt.Errorf("无效的MAC地址 %s: %w", mac, err)
+	}
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return fmt.Errorf("接口 %s 不存在: %w", linkName, err)
+	}
+	if err = netlink.LinkSetHardwareAddr(link, hwAddr); err != nil {
+		return fmt.Errorf("设置接口 %s MAC地址失败: %w", linkName, err)
 	}
 	return nil
 }
 
+// parseAddr 将IP地址与子网掩码转换为netlink地址
+func parseAddr(ip string, mask int8) (*netlink.Addr, error) {
+	addr, err := netlink.ParseAddr(fmt.Sprintf("%s/%d", ip, mask))
+	if err != nil {
+		return nil, fmt.Errorf("无效的IP地址 %s/%d: %w", ip, mask, err)
+	}
+	// IPv6地址跳过重复地址检测，部署前已通过NDP确认地址未被占用，避免地址处于tentative状态时无法监听
+	if addr.IP.To4() == nil {
+		addr.Flags = unix.IFA_F_NODAD
+	}
+	return addr, nil
+}
+
 // addIPAddress 为指定网络接口添加IP地址及子网掩码
 func addIPAddress(linkName, ip string, mask int8) error {
-	cmdStr := fmt.Sprintf("ip addr add %s/%d dev %s", ip, mask, linkName)
-	if err := cmd.Cmd(cmdStr); err != nil {
-		return fmt.Errorf("执行命令失败 [%s]: %w", cmdStr, err)
+	addr, err := parseAddr(ip, mask)
+	if err != nil {
+		return err
+	}
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return fmt.Errorf("接口 %s 不存在: %w", linkName, err)
+	}
+	if err = netlink.AddrAdd(link, addr); err != nil {
+		return fmt.Errorf("接口 %s 添加地址 %s 失败: %w", linkName, addr, err)
+	}
+	return nil
+}
+
+// RemoveIPAddress 删除指定网络接口上的IP地址
+func RemoveIPAddress(linkName, ip string, mask int8) error {
+	addr, err := parseAddr(ip, mask)
+	if err != nil {
+		return err
+	}
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return fmt.Errorf("接口 %s 不存在: %w", linkName, err)
+	}
+	if err = netlink.AddrDel(link, addr); err != nil {
+		return fmt.Errorf("接口 %s 删除地址 %s 失败: %w", linkName, addr, err)
 	}
 	return nil
 }
 
 // GetMACAddress 获取指定网络接口的MAC地址
 func GetMACAddress(linkName string) (string, error) {
-	// 通过ip link命令结合awk提取MAC地址（匹配link/ether后的字段）
-	cmdStr := fmt.Sprintf("ip link show %s | awk '/link\\/ether/ {print $2}'", linkName)
-	mac, err := cmd.Command(cmdStr)
+	link, err := netlink.LinkByName(linkName)
 	if err != nil {
-		return "", fmt.Errorf("执行命令失败 [%s]: %w", cmdStr, err)
+		return "", fmt.Errorf("接口 %s 不存在: %w", linkName, err)
 	}
-	return strings.TrimSpace(mac), nil
+	return link.Attrs().HardwareAddr.String(), nil
 }
 
 // RemoveInterface 删除指定网络接口
 func RemoveInterface(iface string) error {
-	err := cmd.Cmd(fmt.Sprintf("ip link del %s", iface))
-	return err
+	link, err := netlink.LinkByName(iface)
+	if err != nil {
+		return fmt.Errorf("接口 %s 不存在: %w", iface, err)
+	}
+	if err = netlink.LinkDel(link); err != nil {
+		return fmt.Errorf("删除接口 %s 失败: %w", iface, err)
+	}
+	return nil
+}
+
+// LinkExists 判断指定网络接口是否存在
+func LinkExists(linkName string) bool {
+	_, err := netlink.LinkByName(linkName)
+	return err == nil
 }
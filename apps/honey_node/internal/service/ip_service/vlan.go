@@ -0,0 +1,146 @@
+package ip_service
+
+// File: honey_node/service/ip_service/vlan.go
+// Description: VLAN子接口管理模块，基于trunk口创建802.1Q子接口并配置探针ip，子接口记录入库后随节点启动及巡检恢复，诱捕ip的MACVLAN接口可基于子接口创建
+
+import (
+	"errors"
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/utils/neighbor"
+	"net"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// CreateVlanRequest VLAN子接口创建请求结构体
+type CreateVlanRequest struct {
+	Network string // 父网卡名称（trunk口）
+	VlanID  int    // VLAN ID 1-4094
+	Ip      string // 子接口ip
+	Mask    int8   // 子网掩码
+}
+
+// CreateVlan 在父网卡上创建VLAN子接口并配置ip，成功后记录入库
+func CreateVlan(req CreateVlanRequest) (model models.VlanModel, err error) {
+	if req.VlanID < 1 || req.VlanID > 4094 {
+		return model, fmt.Errorf("无效的VLAN ID %d", req.VlanID)
+	}
+	var count int64
+	global.DB.Model(&models.VlanModel{}).Where("network = ? and vlan_id = ?", req.Network, req.VlanID).Count(&count)
+	if count > 0 {
+		return model, fmt.Errorf("网卡 %s 已存在VLAN %d 子接口", req.Network, req.VlanID)
+	}
+
+	parent, err := netlink.LinkByName(req.Network)
+	if err != nil {
+		return model, fmt.Errorf("父网卡 %s 不存在: %w", req.Network, err)
+	}
+	model = models.VlanModel{
+		Name:    vlanLinkName(parent, req.VlanID),
+		Network: req.Network,
+		VlanID:  req.VlanID,
+		Ip:      req.Ip,
+		Mask:    req.Mask,
+	}
+	if err = setupVlan(parent, model); err != nil {
+		return model, err
+	}
+	// 接口创建完成后再入库，巡检不会在两步之间重复创建
+	if err = global.DB.Create(&model).Error; err != nil {
+		RemoveInterface(model.Name)
+		return model, fmt.Errorf("VLAN子接口记录入库失败: %w", err)
+	}
+	logrus.Infof("创建VLAN子接口 %s vlan %d %s/%d", model.Name, model.VlanID, model.Ip, model.Mask)
+	return model, nil
+}
+
+// RemoveVlan 删除VLAN子接口及记录，子接口上存在诱捕ip时不可删除，子接口已删除时直接返回成功
+func RemoveVlan(name string) error {
+	var model models.VlanModel
+	if err := global.DB.Take(&model, "name = ?", name).Error; err != nil {
+		// 管理端重试删除时子接口可能已删除，非VLAN子接口的同名网卡不处理
+		if !LinkExists(name) {
+			return nil
+		}
+		return fmt.Errorf("VLAN子接口 %s 不存在", name)
+	}
+	var count int64
+	global.DB.Model(&models.IpModel{}).Where("network = ?", name).Count(&count)
+	if count > 0 {
+		return errors.New("VLAN子接口上存在诱捕ip，不可删除")
+	}
+	// 先删除记录再删除接口，避免巡检在两步之间重建子接口
+	global.DB.Delete(&model)
+	if LinkExists(name) {
+		if err := RemoveInterface(name); err != nil {
+			return err
+		}
+	}
+	logrus.Infof("删除VLAN子接口 %s", name)
+	return nil
+}
+
+// VlanLoad 恢复数据库中记录但内核中不存在的VLAN子接口（如节点重启后）
+func VlanLoad() {
+	var vlanList []models.VlanModel
+	global.DB.Find(&vlanList)
+	for _, model := range vlanList {
+		if LinkExists(model.Name) {
+			continue
+		}
+		parent, err := netlink.LinkByName(model.Network)
+		if err != nil {
+			logrus.Errorf("恢复VLAN子接口 %s 失败，父网卡 %s 不存在", model.Name, model.Network)
+			continue
+		}
+		if err = setupVlan(parent, model); err != nil {
+			logrus.Errorf("恢复VLAN子接口 %s 失败 %s", model.Name, err)
+			continue
+		}
+		logrus.Infof("恢复VLAN子接口 %s", model.Name)
+	}
+}
+
+// setupVlan 创建VLAN子接口、启用并在探针ip空闲时添加ip，失败时清理已创建的接口
+func setupVlan(parent netlink.Link, model models.VlanModel) (err error) {
+	link := &netlink.Vlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        model.Name,
+			ParentIndex: parent.Attrs().Index,
+		},
+		VlanId: model.VlanID,
+	}
+	if err = netlink.LinkAdd(link); err != nil {
+		return fmt.Errorf("创建VLAN子接口 %s 失败: %w", model.Name, err)
+	}
+	defer func() {
+		if err != nil {
+			RemoveInterface(model.Name)
+		}
+	}()
+	// 子接口收发依赖父网卡处于启用状态
+	if err = netlink.LinkSetUp(parent); err != nil {
+		return fmt.Errorf("启用父网卡 %s 失败: %w", parent.Attrs().Name, err)
+	}
+	if err = setInterfaceUp(model.Name); err != nil {
+		return err
+	}
+	// 配置探针ip前确认该ip未被VLAN内其他主机占用
+	if mac, err1 := neighbor.Probe(net.ParseIP(model.Ip), model.Name); err1 == nil {
+		return fmt.Errorf("探针ip %s 已被 %s 占用", model.Ip, mac)
+	}
+	return addIPAddress(model.Name, model.Ip, model.Mask)
+}
+
+// vlanLinkName 生成VLAN子接口名称（父网卡.VLAN ID），超出网卡名称长度限制时使用父网卡序号
+func vlanLinkName(parent netlink.Link, vlanID int) string {
+	name := fmt.Sprintf("%s.%d", parent.Attrs().Name, vlanID)
+	if len(name) < unix.IFNAMSIZ {
+		return name
+	}
+	return fmt.Sprintf("vlan%d.%d", parent.Attrs().Index, vlanID)
+}
@@ -0,0 +1,84 @@
+package metrics_service
+
+// File: honey_node/service/metrics_service/enter.go
+// Description: 节点Prometheus指标模块，定义隧道会话、端口流量、任务耗时、eve日志解析及MQ发布失败等业务指标，并按配置启动/metrics监听
+
+import (
+	"honey_node/internal/global"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// TunnelSessions 进行中的隧道会话数
+	TunnelSessions = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "honey_node_tunnel_sessions",
+		Help: "进行中的隧道会话数",
+	})
+	// TunnelSessionTotal 按诱捕端口统计的隧道会话总数
+	TunnelSessionTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "honey_node_tunnel_sessions_total",
+		Help: "按诱捕端口统计的隧道会话总数",
+	}, []string{"port"})
+	// TunnelBytes 按诱捕端口及方向统计的隧道流量字节数，direction取值 in（攻击者->诱捕服务） out（诱捕服务->攻击者）
+	TunnelBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "honey_node_tunnel_bytes_total",
+		Help: "按诱捕端口及方向统计的隧道流量字节数",
+	}, []string{"port", "direction"})
+	// TaskDuration 按任务类型统计的任务执行耗时，type取值 1 批量部署 2 批量更新部署 3 批量删除部署 4 端口绑定 5 网络扫描
+	TaskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "honey_node_task_duration_seconds",
+		Help:    "按任务类型统计的任务执行耗时 type 1 批量部署 2 批量更新部署 3 批量删除部署 4 端口绑定 5 网络扫描",
+		Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800},
+	}, []string{"type"})
+	// EveLines 已解析的eve日志行数
+	EveLines = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "honey_node_eve_lines_total",
+		Help: "已解析的eve日志行数",
+	})
+	// EveDropped 按原因统计的丢弃的eve日志行数
+	EveDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "honey_node_eve_lines_dropped_total",
+		Help: "按原因统计的丢弃的eve日志行数",
+	}, []string{"reason"})
+	// MQPublishFailures 发件箱消息发布失败次数
+	MQPublishFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "honey_node_mq_publish_failures_total",
+		Help: "发件箱消息发布失败次数",
+	})
+)
+
+// eve日志丢弃原因
+const (
+	EveDropParse   = "parse"   // JSON解析失败
+	EveDropTime    = "time"    // 时间解析失败
+	EveDropPartial = "partial" // 日志轮转时末尾不完整的行
+)
+
+// RegisterGauge 注册取值函数形式的指标，供其他模块在不引入依赖的情况下暴露状态（如发件箱积压数）
+func RegisterGauge(name, help string, fn func() float64) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: name,
+		Help: help,
+	}, fn)
+}
+
+// Run 按配置启动指标监听，未配置监听地址时不启动
+func Run() {
+	addr := global.Config.Metrics.Addr
+	if addr == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		logrus.Infof("指标服务监听 %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logrus.Errorf("指标服务启动失败 %s", err)
+		}
+	}()
+}
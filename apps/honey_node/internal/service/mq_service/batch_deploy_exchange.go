@@ -1,199 +1,240 @@
 package mq_service
 
 // File: honey_node/service/mq_service/batch_deploy_exchange.go
-// Description: 实现节点侧批量部署MQ消息消费逻辑，新增多维度IP合法性校验（探针IP/已部署IP/本地IP/ARP存活），保障部署安全性；包含任务入库、异步IP配置/端口转发、部署状态上报及任务状态更新等核心功能
+// Description: 实现节点侧批量部署MQ消息消费逻辑，新增多维度IP合法性校验（探针IP/已部署IP/中断残留网卡接管/本地IP/ARP及NDP存活），保障部署安全性；部署任务提交至任务引擎按IP逐项执行，包含IP配置/端口转发及部署状态上报等核心功能
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"honey_node/internal/core"
 	"honey_node/internal/global"
 	"honey_node/internal/models"
 	"honey_node/internal/service/ip_service"
 	"honey_node/internal/service/port_service"
+	"honey_node/internal/service/profile_service"
+	"honey_node/internal/service/task_service"
 	info2 "honey_node/internal/utils/info"
+	"honey_node/internal/utils/neighbor"
 	"honey_node/internal/utils/random"
 	"net"
-	"sync"
 
-	"github.com/google/uuid"
-	"github.com/j-keck/arping"
 	"github.com/sirupsen/logrus"
 )
 
+// init 注册批量部署任务类型，任务项为待部署的IP
+func init() {
+	task_service.Register(1, task_service.Kind{
+		Name:        "批量部署",
+		Concurrency: 200, // 限制IP创建的并发数为200，平衡部署效率与系统资源
+		Resumable:   true,
+		Items: func(task models.TaskModel) (itemList []string) {
+			for _, ip := range task.BatchDeployData.IPList {
+				itemList = append(itemList, ip.Ip)
+			}
+			return
+		},
+		New: newDeployHandler,
+	})
+}
+
 // BatchDeployExChange 节点侧批量部署MQ消息处理函数
 func BatchDeployExChange(req models.BatchDeployRequest) error {
-	// 生成唯一任务ID，用于标识本次批量部署任务
-	taskID := uuid.New().String()
-	// 将批量部署任务入库，记录任务基础信息及原始部署指令
-	err := global.DB.Create(&models.TaskModel{
-		TaskID:          taskID, // 任务唯一标识
-		Type:            1,      // 任务类型：1-批量部署
-		BatchDeployData: &req,   // 批量部署原始指令数据
-		Status:          0,      // 任务状态：0-执行中
-	}).Error
-	if err != nil {
-		logrus.Errorf("任务入库失败 %s", err)
-		return err
-	}
+	// 将批量部署任务入库，由任务引擎异步执行（非阻塞，避免阻塞MQ消费流程）
+	_, err := task_service.Submit(models.TaskModel{
+		Type:            1,         // 任务类型：1-批量部署
+		LogID:           req.LogID, // 日志ID
+		BatchDeployData: &req,      // 批量部署原始指令数据
+	})
+	return err
+}
 
-	// 异步执行批量部署核心逻辑（非阻塞，避免阻塞MQ消费流程）
-	go DeployTask(req, taskID)
-	return nil
+// deployHandler 批量部署任务处理器
+type deployHandler struct {
+	req   models.BatchDeployRequest
+	ipMap map[string]models.DeployIp // 按IP索引的部署配置
+	log   *logrus.Entry
 }
 
-// DeployTask 批量部署核心任务执行函数
-func DeployTask(req models.BatchDeployRequest, taskID string) {
-	log := core.GetLogger().WithField("logID", req.LogID)
-	// 协程池控制：限制IP创建的并发数为200，平衡部署效率与系统资源
-	var maxChan = make(chan struct{}, 200)
-	// 等待组：用于等待所有IP创建协程执行完成
-	var wait sync.WaitGroup
-	// 汇总所有待创建的端口转发配置
-	var portList []models.PortInfo
-	log.WithField("data", req).Info("节点开始部署")
-
-	// 第一步：批量异步创建诱捕IP配置（含多维度合法性校验）
-	for _, ip := range req.IPList {
-		maxChan <- struct{}{} // 协程池占位，控制并发数
-		wait.Add(1)           // 增加等待组计数
-
-		// 异步处理单个IP的部署逻辑
-		go func(info models.DeployIp, wait *sync.WaitGroup) {
-			// 协程退出时释放协程池资源、减少等待组计数
-			defer func() {
-				<-maxChan
-				wait.Done()
-			}()
-
-			// 初始化部署状态上报数据（基础字段）
-			res := DeployStatusRequest{
-				NetID: req.NetID,
-				IP:    info.Ip,
-				LogID: req.LogID,
-			}
+// newDeployHandler 创建批量部署任务处理器
+func newDeployHandler(task models.TaskModel) (task_service.Handler, error) {
+	if task.BatchDeployData == nil {
+		return nil, errors.New("批量部署参数为空")
+	}
+	h := &deployHandler{
+		req:   *task.BatchDeployData,
+		ipMap: map[string]models.DeployIp{},
+		log:   core.GetLogger().WithField("logID", task.BatchDeployData.LogID),
+	}
+	for _, ip := range h.req.IPList {
+		h.ipMap[ip.Ip] = ip
+	}
+	h.log.WithField("data", h.req).Info("节点开始部署")
+	return h, nil
+}
 
-			// 校验1：探针IP跳过部署（探针IP为节点核心IP，无需重复创建）
-			if info.Ip == req.TanIp {
-				res.Mac, _ = ip_service.GetMACAddress(req.Network) // 获取探针IP的MAC地址
-				SendDeployStatusMsg(res)                           // 上报探针IP部署状态（无需创建）
-				return
-			}
+// Exec 部署单个IP，已部署的IP直接复用原有配置，保证重复执行的幂等性
+func (h *deployHandler) Exec(ctx context.Context, ip string) error {
+	req := h.req
+	log := h.log
+	info := h.ipMap[ip]
+
+	// 初始化部署状态上报数据（基础字段）
+	res := DeployStatusRequest{
+		NetID: req.NetID,
+		IP:    info.Ip,
+		LogID: req.LogID,
+	}
 
-			// 校验2：已部署IP跳过部署（数据库中存在则复用原有配置）
-			var model models.IpModel
-			global.DB.Find(&model, "network = ? and ip = ?", req.Network, info.Ip)
-			if model.ID != 0 {
-				log.WithField("data", info.Ip).Info("部署ip在model里面")
-				res.Mac = model.Mac           // 复用已部署IP的MAC地址
-				res.LinkName = model.LinkName // 复用已部署IP的网络接口名称
-				SendDeployStatusMsg(res)      // 上报已部署IP状态（无需重复创建）
-				return
-			} else {
-				// 校验3：本地IP冲突检测（待部署IP已存在于本地网卡则报错）
-				ok := info2.FindLocalIp(info.Ip)
-				if ok {
-					res.ErrorMsg = fmt.Sprintf("当前ip存在与本地ip中") // 记录本地IP冲突错误
-					SendDeployStatusMsg(res)                           // 上报IP冲突状态
-					log.WithField("data", info.Ip).Info("当前ip存在与本地ip中")
-					return
-				}
-			}
+	// 校验1：探针IP跳过部署（探针IP为节点核心IP，无需重复创建）
+	if info.Ip == req.TanIp {
+		res.Mac, _ = ip_service.GetMACAddress(req.Network) // 获取探针IP的MAC地址
+		bindDeployPort(info.PortList, log)                 // 探针IP上的端口转发
+		SendDeployStatusMsg(res)                           // 上报探针IP部署状态（无需创建）
+		return nil
+	}
 
-			// 校验4：ARP存活检测（待部署IP已被局域网其他主机占用则报错）
-			_mac, _, err := arping.PingOverIfaceByName(net.ParseIP(info.Ip), req.Network)
-			if err == nil {
-				// 查询MAC地址对应的设备厂商信息，便于排查存活主机
-				manuf, _ := core.ManufQuery(_mac.String())
-				log.WithFields(logrus.Fields{
-					"ip":    info.Ip,
-					"mac":   _mac.String(),
-					"manuf": manuf,
-				}).Warnf("存活主机")
-
-				res.ErrorMsg = "存活主机" // 记录存活主机错误
-				res.Mac = _mac.String()   // 记录存活主机的MAC地址
-				res.Manuf = manuf         // 记录存活主机的设备厂商
-				SendDeployStatusMsg(res)  // 上报存活主机状态
-				return
-			}
+	// 校验2：已部署IP跳过部署（数据库中存在则复用原有配置）
+	var model models.IpModel
+	global.DB.Find(&model, "network = ? and ip = ?", req.Network, info.Ip)
+	if model.ID != 0 {
+		log.WithField("data", info.Ip).Info("部署ip在model里面")
+		res.Mac = model.Mac                // 复用已部署IP的MAC地址
+		res.LinkName = model.LinkName      // 复用已部署IP的网络接口名称
+		bindDeployPort(info.PortList, log) // 补齐未建立的端口转发
+		SendDeployStatusMsg(res)           // 上报已部署IP状态（无需重复创建）
+		return nil
+	}
 
-			// 所有校验通过，开始创建诱捕IP配置
-			// 生成唯一网络接口名称（前缀hy_+6位唯一随机字符串，避免接口名冲突）
-			linkName := fmt.Sprintf("hy_%s", random.RandStrV2(6))
-			// 调用IP服务配置IP地址、掩码及网络接口
-			mac, err := ip_service.SetIp(ip_service.SetIpRequest{
-				Ip:       info.Ip,
-				Mask:     info.Mask,
-				LinkName: linkName,
-				Network:  req.Network,
-			})
-			// IP配置失败时上报错误状态并退出
-			if err != nil {
-				res.ErrorMsg = err.Error()
-				log.WithFields(logrus.Fields{
-					"error": err.Error(),
-				}).Error("部署失败")
-				SendDeployStatusMsg(res)
-				return
-			}
+	// 按设备画像生成与厂商一致的MAC地址，生成失败时由系统随机分配
+	vendorMac, err := profile_service.VendorMac(info.DeviceProfile, info.DeviceVendor, info.Ip)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"ip":    info.Ip,
+			"error": err.Error(),
+		}).Warn("生成设备画像MAC地址失败")
+	}
 
-			// IP配置成功，填充部署状态数据
-			res.Mac = mac
-			res.LinkName = linkName
-			// 持久化IP配置信息到数据库
-			global.DB.Create(&models.IpModel{
-				Ip:       info.Ip,
-				Mask:     info.Mask,
-				LinkName: linkName,
-				Network:  req.Network,
-				Mac:      mac,
-			})
-
-			// 上报IP配置成功的部署状态
-			SendDeployStatusMsg(res)
-		}(ip, &wait)
-
-		// 异步汇总当前IP的端口转发配置到全局列表（不阻塞IP创建流程）
-		go func(info models.DeployIp) {
-			for _, p := range info.PortList {
-				portList = append(portList, p)
-			}
-		}(ip)
+	// 校验3：上次执行在网卡创建后、入库前中断时，接管IP及MAC一致的残留诱捕网卡
+	adopted, ok := ip_service.AdoptIp(ip_service.SetIpRequest{
+		Ip:      info.Ip,
+		Mask:    info.Mask,
+		Network: req.Network,
+		Mac:     vendorMac,
+	})
+	if ok {
+		res.Mac = adopted.Mac              // 复用残留网卡的MAC地址
+		res.LinkName = adopted.LinkName    // 复用残留网卡的网络接口名称
+		bindDeployPort(info.PortList, log) // 补齐未建立的端口转发
+		SendDeployStatusMsg(res)           // 上报接管后的部署状态
+		return nil
 	}
-	wait.Wait() // 等待所有IP创建协程执行完成
 
-	// 第二步：批量创建端口转发配置并异步建立端口隧道
+	// 校验4：本地IP冲突检测（待部署IP已存在于本地网卡则报错）
+	if info2.FindLocalIp(info.Ip) {
+		res.ErrorMsg = fmt.Sprintf("当前ip存在与本地ip中") // 记录本地IP冲突错误
+		SendDeployStatusMsg(res)                           // 上报IP冲突状态
+		log.WithField("data", info.Ip).Info("当前ip存在与本地ip中")
+		return nil
+	}
+
+	// 校验5：存活检测（IPv4通过ARP、IPv6通过NDP，待部署IP已被局域网其他主机占用则报错）
+	_mac, _, err := neighbor.Ping(net.ParseIP(info.Ip), req.Network)
+	if err == nil {
+		// 查询MAC地址对应的设备厂商信息，便于排查存活主机
+		manuf, _ := core.ManufQuery(_mac.String())
+		log.WithFields(logrus.Fields{
+			"ip":    info.Ip,
+			"mac":   _mac.String(),
+			"manuf": manuf,
+		}).Warnf("存活主机")
+
+		res.ErrorMsg = "存活主机" // 记录存活主机错误
+		res.Mac = _mac.String()   // 记录存活主机的MAC地址
+		res.Manuf = manuf         // 记录存活主机的设备厂商
+		SendDeployStatusMsg(res)  // 上报存活主机状态
+		return nil
+	}
+
+	// 所有校验通过，开始创建诱捕IP配置
+	// 生成唯一网络接口名称（前缀hy_+6位唯一随机字符串，避免接口名冲突）
+	linkName := fmt.Sprintf("hy_%s", random.RandStrV2(6))
+	// 调用IP服务配置IP地址、掩码及网络接口
+	mac, err := ip_service.SetIp(ip_service.SetIpRequest{
+		Ip:       info.Ip,
+		Mask:     info.Mask,
+		LinkName: linkName,
+		Network:  req.Network,
+		Mac:      vendorMac,
+	})
+	// IP配置失败时返回错误，由任务引擎重试，重试耗尽后上报错误状态
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("部署失败")
+		return err
+	}
+
+	// IP配置成功，填充部署状态数据
+	res.Mac = mac
+	res.LinkName = linkName
+	// 持久化IP配置信息到数据库
+	global.DB.Create(&models.IpModel{
+		Ip:       info.Ip,
+		Mask:     info.Mask,
+		LinkName: linkName,
+		Network:  req.Network,
+		Mac:      mac,
+	})
+
+	// 创建当前IP的端口转发配置
+	bindDeployPort(info.PortList, log)
+
+	// 上报IP配置成功的部署状态
+	SendDeployStatusMsg(res)
+	return nil
+}
+
+// Fail 上报重试耗尽或被取消的IP部署失败状态
+func (h *deployHandler) Fail(ip string, err error) {
+	SendDeployStatusMsg(DeployStatusRequest{
+		NetID:    h.req.NetID,
+		IP:       ip,
+		LogID:    h.req.LogID,
+		ErrorMsg: err.Error(),
+	})
+}
+
+// Close 批量部署任务结束
+func (h *deployHandler) Close(status int8) {
+	h.log.WithFields(logrus.Fields{
+		"count":  len(h.req.IPList),
+		"status": status,
+	}).Infof("批量部署结束")
+}
+
+// bindDeployPort 创建端口转发配置并建立端口隧道，已在运行的端口转发跳过
+func bindDeployPort(portList []models.PortInfo, log *logrus.Entry) {
 	for _, info := range portList {
+		if port_service.TunnelRunning(info.Agreement, info.LocalAddr()) {
+			continue
+		}
+		// 清理上次执行残留的端口记录，避免重复入库
+		port_service.CloseTunnel(info.Agreement, info.LocalAddr())
 		// 持久化端口转发配置到数据库
 		global.DB.Create(&models.PortModel{
 			TargetAddr: info.TargetAddr(), // 目标地址
 			LocalAddr:  info.LocalAddr(),  // 本地地址
+			Agreement:  info.Agreement,    // 通信协议
 		})
 
-		// 异步建立端口隧道（非阻塞，提升部署效率）
-		go func(port models.PortInfo) {
-			err := port_service.Tunnel(port.LocalAddr(), port.TargetAddr())
-			if err != nil {
-				log.WithFields(logrus.Fields{
-					"error": err.Error(),
-				}).Error("端口绑定失败")
-			}
-			// 异常说明：端口绑定失败大概率是IP未配置完成，或端口未释放
-		}(info)
-	}
-	log.WithFields(logrus.Fields{
-		"count": len(req.IPList),
-	}).Infof("批量部署结束")
-
-	// 第三步：更新批量部署任务状态为执行完成
-	var taskModel models.TaskModel
-	err := global.DB.Take(&taskModel, "task_id = ?", taskID).Error
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"task": taskID,
-		}).Error("任务不存在")
-		return
+		// 建立端口隧道
+		err := port_service.BindTunnel(info.Agreement, info.LocalAddr(), info.TargetAddr())
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("端口绑定失败")
+		}
+		// 异常说明：端口绑定失败大概率是IP未配置完成，或端口未释放
 	}
-	// 更新任务状态为1（执行完成）
-	global.DB.Model(&taskModel).Update("status", 1)
 }
@@ -1,81 +1,112 @@
 package mq_service
 
 // File: honey_node/service/mq_service/batch_remove_deploy_exchange.go
-// Description: MQ消息消费处理模块，实现批量删除部署消息的解析、任务入库及异步执行删除部署任务，包含端口转发关闭、网络接口删除、任务状态更新等核心逻辑
+// Description: MQ消息消费处理模块，实现批量删除部署消息的解析及任务提交，删除任务由任务引擎按IP逐项执行，包含端口转发关闭、网络接口删除及删除状态上报等核心逻辑
 
 import (
+	"context"
+	"errors"
 	"honey_node/internal/core"
 	"honey_node/internal/global"
 	"honey_node/internal/models"
 	"honey_node/internal/service/ip_service"
 	"honey_node/internal/service/port_service"
+	"honey_node/internal/service/task_service"
 
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// init 注册批量删除部署任务类型，任务项为待删除的IP
+func init() {
+	task_service.Register(3, task_service.Kind{
+		Name:      "批量删除部署",
+		Resumable: true,
+		Items: func(task models.TaskModel) (itemList []string) {
+			for _, ip := range task.BatchRemoveDeployData.IPList {
+				itemList = append(itemList, ip.Ip)
+			}
+			return
+		},
+		New: newRemoveDeployHandler,
+	})
+}
+
 // BatchRemoveDeployExChange 处理批量删除部署的MQ消息
 func BatchRemoveDeployExChange(req models.BatchRemoveDeployRequest) error {
-	// 生成唯一任务ID
-	taskID := uuid.New().String()
-	// 创建批量删除部署任务记录并写入数据库
-	err := global.DB.Create(&models.TaskModel{
-		TaskID:                taskID, // 任务唯一标识
-		Type:                  3,      // 任务类型：3表示批量删除部署
-		BatchRemoveDeployData: &req,   // 批量删除部署任务关联的请求数据
-		Status:                0,      // 任务状态：0表示待执行
-	}).Error
-	if err != nil {
-		logrus.Errorf("任务入库失败 %s", err)
-		return err
+	// 创建批量删除部署任务记录并写入数据库，由任务引擎异步执行（非阻塞）
+	_, err := task_service.Submit(models.TaskModel{
+		Type:                  3,         // 任务类型：3表示批量删除部署
+		LogID:                 req.LogID, // 日志ID
+		BatchRemoveDeployData: &req,      // 批量删除部署任务关联的请求数据
+	})
+	return err
+}
+
+// removeDeployHandler 批量删除部署任务处理器
+type removeDeployHandler struct {
+	req   models.BatchRemoveDeployRequest
+	ipMap map[string]models.RemoveDeployIp // 按IP索引的删除配置
+	log   *logrus.Entry
+}
+
+// newRemoveDeployHandler 创建批量删除部署任务处理器
+func newRemoveDeployHandler(task models.TaskModel) (task_service.Handler, error) {
+	if task.BatchRemoveDeployData == nil {
+		return nil, errors.New("批量删除部署参数为空")
 	}
-	// 异步执行删除部署任务（非阻塞）
-	go RemoveDeployTask(req, taskID)
-	return nil
+	h := &removeDeployHandler{
+		req:   *task.BatchRemoveDeployData,
+		ipMap: map[string]models.RemoveDeployIp{},
+		log:   core.GetLogger().WithField("logID", task.BatchRemoveDeployData.LogID),
+	}
+	for _, ip := range h.req.IPList {
+		h.ipMap[ip.Ip] = ip
+	}
+	h.log.WithField("data", h.req).Info("批量删除部署开始")
+	return h, nil
 }
 
-// RemoveDeployTask 执行批量删除部署的具体任务逻辑
-func RemoveDeployTask(req models.BatchRemoveDeployRequest, taskID string) {
-	log := core.GetLogger().WithField("logID", req.LogID)
-	log.WithField("data", req).Info("批量删除部署开始")
-	// 遍历待删除部署的IP列表，逐个处理
-	// 处理逻辑：1. 关闭端口转发 2. 删除网络接口 3. 发送单个IP删除部署状态消息
-	for _, s := range req.IPList {
-		// 关闭当前IP对应的端口转发
-		port_service.CloseIpTunnel(s.Ip)
+// Exec 删除单个IP的部署，处理逻辑：1. 关闭端口转发 2. 删除网络接口 3. 发送单个IP删除部署状态消息
+func (h *removeDeployHandler) Exec(ctx context.Context, ip string) error {
+	s := h.ipMap[ip]
+	// 关闭当前IP对应的端口转发
+	port_service.CloseIpTunnel(s.Ip)
 
-		// 组装IP删除部署状态请求数据
-		res := RemoveDeployStatusRequest{
-			NetID:    req.NetID,
-			IP:       s.Ip,
-			LogID:    req.LogID,
-			ErrorMsg: "",
-		}
-		// 删除当前IP对应的网络接口
+	// 删除当前IP对应的网络接口，接口已不存在（重复执行）时跳过
+	if ip_service.LinkExists(s.LinkName) {
 		err := ip_service.RemoveInterface(s.LinkName)
 		if err != nil {
-			// 接口删除失败时记录错误信息
-			res.ErrorMsg = err.Error()
-			log.WithFields(logrus.Fields{
+			// 接口删除失败时返回错误，由任务引擎重试
+			h.log.WithFields(logrus.Fields{
 				"error": err.Error(),
-				"data":  res,
+				"ip":    s.Ip,
 			}).Error("删除接口报错")
+			return err
 		}
-		global.DB.Delete(&models.IpModel{}, "ip = ?", s.Ip)
-		// 发送当前IP的删除部署状态消息
-		SendRemoveDeployStatusMsg(res)
 	}
+	global.DB.Delete(&models.IpModel{}, "ip = ?", s.Ip)
+	// 发送当前IP的删除部署状态消息
+	SendRemoveDeployStatusMsg(RemoveDeployStatusRequest{
+		NetID:    h.req.NetID,
+		IP:       s.Ip,
+		LogID:    h.req.LogID,
+		ErrorMsg: "",
+	})
+	return nil
+}
 
-	// 记录批量删除部署任务执行完成日志
-	log.Infof("批量删除部署结束")
+// Fail 上报重试耗尽或被取消的IP删除部署失败状态
+func (h *removeDeployHandler) Fail(ip string, err error) {
+	SendRemoveDeployStatusMsg(RemoveDeployStatusRequest{
+		NetID:    h.req.NetID,
+		IP:       ip,
+		LogID:    h.req.LogID,
+		ErrorMsg: err.Error(),
+	})
+}
 
-	// 查询任务记录并更新任务状态
-	var taskModel models.TaskModel
-	err := global.DB.Take(&taskModel, "task_id = ?", taskID).Error
-	if err != nil {
-		logrus.Errorf("%s 任务不存在", taskID)
-		return
-	}
-	// 更新任务状态为已完成（1表示执行完成）
-	global.DB.Model(&taskModel).Update("status", 1)
+// Close 批量删除部署任务结束
+func (h *removeDeployHandler) Close(status int8) {
+	// 记录批量删除部署任务执行完成日志
+	h.log.WithField("status", status).Infof("批量删除部署结束")
 }
@@ -1,106 +1,126 @@
 package mq_service
 
 // File: honey_node/service/mq_service/batch_update_deploy_exchange.go
-// Description: 实现节点侧批量更新部署MQ消息消费逻辑，包含任务入库、异步清理旧端口转发、重建新端口转发、更新状态上报及任务状态更新等核心功能
+// Description: 实现节点侧批量更新部署MQ消息消费逻辑，更新任务提交至任务引擎按IP逐项执行，包含清理旧端口转发、重建新端口转发及更新状态上报等核心功能
 
 import (
+	"context"
+	"errors"
 	"honey_node/internal/core"
 	"honey_node/internal/global"
 	"honey_node/internal/models"
 	"honey_node/internal/service/port_service"
+	"honey_node/internal/service/task_service"
 
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// init 注册批量更新部署任务类型，任务项为待更新的IP
+func init() {
+	task_service.Register(2, task_service.Kind{
+		Name:      "批量更新部署",
+		Resumable: true,
+		Items: func(task models.TaskModel) []string {
+			return task.BatchUpdateDeployData.IpList
+		},
+		New: newUpdateDeployHandler,
+	})
+}
+
 // BatchUpdateDeployExChange 节点侧批量更新部署MQ消息处理入口函数
 func BatchUpdateDeployExChange(req models.BatchUpdateDeployRequest) error {
-	// 生成唯一任务ID，用于标识本次批量更新部署任务
-	taskID := uuid.New().String()
-	// 将批量更新部署任务入库，记录任务基础信息及原始更新指令
-	err := global.DB.Create(&models.TaskModel{
-		TaskID:                taskID, // 任务唯一标识
-		Type:                  2,      // 任务类型：2-批量更新部署
-		BatchUpdateDeployData: &req,   // 批量更新部署原始指令数据
-		Status:                0,      // 任务状态：0-执行中
-	}).Error
-	if err != nil {
-		logrus.Errorf("任务入库失败 %s", err)
-		return err
-	}
+	// 将批量更新部署任务入库，由任务引擎异步执行（非阻塞，避免阻塞MQ消费流程）
+	_, err := task_service.Submit(models.TaskModel{
+		Type:                  2,         // 任务类型：2-批量更新部署
+		LogID:                 req.LogID, // 日志ID
+		BatchUpdateDeployData: &req,      // 批量更新部署原始指令数据
+	})
+	return err
+}
 
-	// 异步执行批量更新部署核心逻辑（非阻塞，避免阻塞MQ消费流程）
-	go UpdateDeployTask(req, taskID)
-	return nil
+// updateDeployHandler 批量更新部署任务处理器
+type updateDeployHandler struct {
+	req       models.BatchUpdateDeployRequest
+	ipPortMap map[string][]models.PortInfo // 按IP分组的新端口转发配置
+	log       *logrus.Entry
 }
 
-// UpdateDeployTask 批量更新部署核心任务执行函数
-func UpdateDeployTask(req models.BatchUpdateDeployRequest, taskID string) {
-	log := core.GetLogger().WithField("logID", req.LogID)
-	log.WithField("data", req).Info("批量更新部署")
-	// 第一步：清理待更新IP的所有旧端口转发（先删后建，保证配置更新的准确性）
-	for _, s := range req.IpList {
-		port_service.CloseIpTunnel(s) // 关闭指定IP的所有端口隧道
+// newUpdateDeployHandler 创建批量更新部署任务处理器
+func newUpdateDeployHandler(task models.TaskModel) (task_service.Handler, error) {
+	if task.BatchUpdateDeployData == nil {
+		return nil, errors.New("批量更新部署参数为空")
 	}
-
-	// 第二步：按IP分组构建新端口转发配置映射表（便于按IP批量处理）
-	var ipPortMap = map[string][]models.PortInfo{}
-	for _, info := range req.PortList {
-		ipPortMap[info.IP] = append(ipPortMap[info.IP], info)
+	h := &updateDeployHandler{
+		req:       *task.BatchUpdateDeployData,
+		ipPortMap: map[string][]models.PortInfo{},
+		log:       core.GetLogger().WithField("logID", task.BatchUpdateDeployData.LogID),
 	}
-	if len(ipPortMap) == 0 {
-		for _, s := range req.IpList {
-			ipPortMap[s] = []models.PortInfo{}
-		}
+	// 按IP分组构建新端口转发配置映射表（便于按IP批量处理）
+	for _, info := range h.req.PortList {
+		h.ipPortMap[info.IP] = append(h.ipPortMap[info.IP], info)
 	}
+	h.log.WithField("data", h.req).Info("批量更新部署")
+	return h, nil
+}
 
-	// 第三步：按IP批量重建新端口转发，并上报更新状态
-	for ip, portList := range ipPortMap {
-		// 初始化更新状态上报数据（基础字段）
-		res := UpdateDeployStatusRequest{
-			NetID:    req.NetID,
-			IP:       ip,
-			LogID:    req.LogID,
-			ErrorMsg: "",
-		}
+// Exec 更新单个IP的端口转发，先删后建，重复执行结果一致
+func (h *updateDeployHandler) Exec(ctx context.Context, ip string) error {
+	// 第一步：清理IP的所有旧端口转发（先删后建，保证配置更新的准确性）
+	port_service.CloseIpTunnel(ip)
 
-		// 为当前IP创建所有新端口转发
-		for _, info := range portList {
-			// 建立端口隧道（本地地址→目标地址）
-			err := port_service.Tunnel(info.LocalAddr(), info.TargetAddr())
-			// 初始化端口状态上报数据
-			pI := PortInfo{
-				Port: info.Port,
-			}
+	// 初始化更新状态上报数据（基础字段）
+	res := UpdateDeployStatusRequest{
+		NetID:    h.req.NetID,
+		IP:       ip,
+		LogID:    h.req.LogID,
+		ErrorMsg: "",
+	}
 
-			// 端口隧道创建失败时记录错误信息
-			if err != nil {
-				pI.ErrorMsg = err.Error()
-				log.WithField("error", err.Error()).Error("创建端口转发失败")
-			} else {
-				// 端口隧道创建成功，持久化端口配置到数据库
-				global.DB.Create(&models.PortModel{
-					TargetAddr: info.TargetAddr(),
-					LocalAddr:  info.LocalAddr(),
-				})
-			}
-			// 将当前端口的状态添加到IP的上报列表中
-			res.PortList = append(res.PortList, pI)
+	// 第二步：为当前IP创建所有新端口转发
+	for _, info := range h.ipPortMap[ip] {
+		// 建立端口隧道（本地地址→目标地址）
+		err := port_service.BindTunnel(info.Agreement, info.LocalAddr(), info.TargetAddr())
+		// 初始化端口状态上报数据
+		pI := PortInfo{
+			Port:      info.Port,
+			Agreement: info.Agreement,
 		}
 
-		// 上报当前IP的更新部署状态（包含所有端口的执行结果）
-		SendUpdateDeployStatusMsg(res)
+		// 端口隧道创建失败时记录错误信息
+		if err != nil {
+			pI.ErrorMsg = err.Error()
+			h.log.WithField("error", err.Error()).Error("创建端口转发失败")
+		} else {
+			// 端口隧道创建成功，持久化端口配置到数据库
+			global.DB.Create(&models.PortModel{
+				TargetAddr: info.TargetAddr(),
+				LocalAddr:  info.LocalAddr(),
+				Agreement:  info.Agreement,
+			})
+		}
+		// 将当前端口的状态添加到IP的上报列表中
+		res.PortList = append(res.PortList, pI)
 	}
 
-	log.WithField("ipCount", len(ipPortMap)).Info("批量更新部署结束")
+	// 第三步：上报当前IP的更新部署状态（包含所有端口的执行结果）
+	SendUpdateDeployStatusMsg(res)
+	return nil
+}
+
+// Fail 上报被取消的IP更新部署失败状态
+func (h *updateDeployHandler) Fail(ip string, err error) {
+	SendUpdateDeployStatusMsg(UpdateDeployStatusRequest{
+		NetID:    h.req.NetID,
+		IP:       ip,
+		LogID:    h.req.LogID,
+		ErrorMsg: err.Error(),
+	})
+}
 
-	// 第四步：更新批量更新部署任务状态为执行完成
-	var taskModel models.TaskModel
-	err := global.DB.Take(&taskModel, "task_id = ?", taskID).Error
-	if err != nil {
-		logrus.Errorf("%s 任务不存在", taskID)
-		return
-	}
-	// 更新任务状态为1（执行完成）
-	global.DB.Model(&taskModel).Update("status", 1)
+// Close 批量更新部署任务结束
+func (h *updateDeployHandler) Close(status int8) {
+	h.log.WithFields(logrus.Fields{
+		"ipCount": len(h.req.IpList),
+		"status":  status,
+	}).Info("批量更新部署结束")
 }
@@ -1,32 +1,68 @@
 package mq_service
 
 // File: honey_node/service/mq_service/bind_port_exchange.go
-// Description: 节点端口绑定MQ消息处理模块，消费端口绑定指令消息，执行端口隧道创建、端口记录持久化，并将绑定结果上报至管理服务的gRPC接口
+// Description: 节点端口绑定MQ消息处理模块，消费端口绑定指令消息，端口绑定任务由任务引擎执行端口隧道创建、端口记录持久化，并将绑定结果上报至管理服务的gRPC接口
 
 import (
 	"context"
+	"errors"
 	"honey_node/internal/core"
 	"honey_node/internal/global"
 	"honey_node/internal/models"
 	"honey_node/internal/rpc/node_rpc"
 	"honey_node/internal/service/port_service"
+	"honey_node/internal/service/task_service"
 
 	"github.com/sirupsen/logrus"
 )
 
-// BindPortRequest 端口绑定请求结构体，接收MQ传递的端口绑定参数
-type BindPortRequest struct {
-	IP        string            `json:"ip"`        // 待绑定端口的目标IP地址
-	HoneyIpID uint              `json:"honeyIpID"` // 关联的诱捕IP ID，用于上报状态时关联管理服务的诱捕IP记录
-	PortList  []models.PortInfo `json:"portList"`  // 端口配置列表，包含待绑定的端口号、本地/目标地址等信息
-	LogID     string            `json:"logID"`     // 日志ID，用于关联端口绑定操作的全链路日志
+// init 注册端口绑定任务类型，任务项为待绑定端口的IP
+func init() {
+	task_service.Register(4, task_service.Kind{
+		Name:      "端口绑定",
+		Resumable: true,
+		Items: func(task models.TaskModel) []string {
+			return []string{task.BindPortData.IP}
+		},
+		New: newBindPortHandler,
+	})
 }
 
-// BindPortExChange 消费端口绑定MQ消息的核心处理函数
-func BindPortExChange(req BindPortRequest) error {
+// BindPortExChange 消费端口绑定MQ消息的核心处理函数，端口绑定任务提交至任务引擎执行
+func BindPortExChange(req models.BindPortRequest) error {
 	log := core.GetLogger().WithField("logID", req.LogID)
 	log.WithField("req_data", req).Infof("port binding message") // 端口绑定信息
 
+	_, err := task_service.Submit(models.TaskModel{
+		Type:         4,         // 任务类型：4-端口绑定
+		LogID:        req.LogID, // 日志ID
+		BindPortData: &req,      // 端口绑定原始指令数据
+	})
+	return err
+}
+
+// bindPortHandler 端口绑定任务处理器
+type bindPortHandler struct {
+	req models.BindPortRequest
+	log *logrus.Entry
+}
+
+// newBindPortHandler 创建端口绑定任务处理器
+func newBindPortHandler(task models.TaskModel) (task_service.Handler, error) {
+	if task.BindPortData == nil {
+		return nil, errors.New("端口绑定参数为空")
+	}
+	return &bindPortHandler{
+		req: *task.BindPortData,
+		log: core.GetLogger().WithField("logID", task.BindPortData.LogID),
+	}, nil
+}
+
+// Exec 重新绑定IP上的全部端口并上报结果，上报失败时返回错误由任务引擎重试
+func (h *bindPortHandler) Exec(ctx context.Context, ip string) error {
+	req := h.req
+	log := h.log
+
 	// 第一步：停止目标IP上已有的所有端口隧道，避免端口占用冲突
 	port_service.CloseIpTunnel(req.IP)
 
@@ -39,15 +75,17 @@ func BindPortExChange(req BindPortRequest) error {
 		global.DB.Create(&models.PortModel{
 			TargetAddr: port.TargetAddr(), // 端口转发的目标地址
 			LocalAddr:  port.LocalAddr(),  // 本地监听地址
+			Agreement:  port.Agreement,    // 通信协议
 		})
 
 		// 初始化端口状态信息（默认无错误）
 		portInfo := &node_rpc.StatusPortInfo{
-			Port: int64(port.Port), // 待绑定的端口号
+			Port:      int64(port.Port),      // 待绑定的端口号
+			Agreement: int32(port.Agreement), // 端口通信协议
 		}
 
 		// 执行端口隧道绑定（创建端口转发）
-		err := port_service.Tunnel(port.LocalAddr(), port.TargetAddr())
+		err := port_service.BindTunnel(port.Agreement, port.LocalAddr(), port.TargetAddr())
 		if err != nil {
 			// 绑定失败时记录错误日志，并填充端口状态的错误信息
 			log.WithField("error", err).Errorf("failed to bind port") // 端口绑定失败
@@ -60,10 +98,20 @@ func BindPortExChange(req BindPortRequest) error {
 	}
 
 	// 第三步：将端口绑定结果上报至管理服务的gRPC接口
-	reportBindPortStatus(req.HoneyIpID, portInfoList, req.LogID)
-	return nil
+	return reportBindPortStatus(req.HoneyIpID, portInfoList, req.LogID)
 }
 
+// Fail 端口绑定结果上报重试耗尽或任务被取消
+func (h *bindPortHandler) Fail(ip string, err error) {
+	h.log.WithFields(logrus.Fields{
+		"ip":    ip,
+		"error": err,
+	}).Errorf("port binding task failed") // 端口绑定任务失败
+}
+
+// Close 端口绑定任务结束
+func (h *bindPortHandler) Close(status int8) {}
+
 // reportBindPortStatus 上报端口绑定状态至管理服务
 func reportBindPortStatus(honeyIPID uint, portInfoList []*node_rpc.StatusPortInfo, logID string) error {
 	log := core.GetLogger().WithField("logID", logID)
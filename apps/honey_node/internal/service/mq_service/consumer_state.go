@@ -0,0 +1,67 @@
+package mq_service
+
+// File: honey_node/service/mq_service/consumer_state.go
+// Description: MQ运行状态记录，跟踪连接状态及各业务消费端的消费情况，供节点诊断使用
+
+import (
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ConsumerState 业务消费端运行状态
+type ConsumerState struct {
+	Exchange        string `json:"exchange"`        // 交换器名称
+	Queue           string `json:"queue"`           // 队列名称
+	Consuming       bool   `json:"consuming"`       // 是否正在消费
+	StartTime       string `json:"startTime"`       // 最近一次开始消费时间
+	StopTime        string `json:"stopTime"`        // 最近一次停止消费时间
+	MessageCount    int64  `json:"messageCount"`    // 已处理消息数
+	FailCount       int64  `json:"failCount"`       // 解析或处理失败的消息数
+	LastMessageTime string `json:"lastMessageTime"` // 最近一次收到消息的时间
+}
+
+var (
+	consumerStateMap  = map[string]*ConsumerState{} // 交换器名称 -> 消费端状态
+	consumerStateLock sync.Mutex                    // 消费端状态读写锁
+	connected         atomic.Bool                   // MQ连接是否正常
+)
+
+// updateConsumerState 更新指定交换器的消费端状态
+func updateConsumerState(exchange string, fn func(state *ConsumerState)) {
+	consumerStateLock.Lock()
+	defer consumerStateLock.Unlock()
+	state, ok := consumerStateMap[exchange]
+	if !ok {
+		state = &ConsumerState{Exchange: exchange}
+		consumerStateMap[exchange] = state
+	}
+	fn(state)
+}
+
+// ConsumerStateList 获取全部消费端状态，按交换器名称排列
+func ConsumerStateList() []ConsumerState {
+	consumerStateLock.Lock()
+	list := make([]ConsumerState, 0, len(consumerStateMap))
+	for _, state := range consumerStateMap {
+		list = append(list, *state)
+	}
+	consumerStateLock.Unlock()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Exchange < list[j].Exchange
+	})
+	return list
+}
+
+// Connected MQ连接是否正常
+func Connected() bool {
+	return connected.Load()
+}
+
+// OutboxCount 发件箱中待发送的消息数
+func OutboxCount() (count int64) {
+	global.DB.Model(&models.OutboxModel{}).Count(&count)
+	return
+}
@@ -11,9 +11,9 @@ import (
 	"honey_node/internal/models"
 	"honey_node/internal/rpc/node_rpc"
 	"honey_node/internal/service/ip_service"
+	"honey_node/internal/utils/neighbor"
 	"net"
 
-	"github.com/j-keck/arping"
 	"github.com/sirupsen/logrus"
 )
 
@@ -41,8 +41,8 @@ func CreateIpExChange(req CreateIPRequest) error {
 		"req_data": req,
 	}).Info("start processing create IP request") // 开始处理创建IP请求
 
-	// ARP预检测：检查目标IP是否已被局域网内其他设备占用
-	_mac, _, err := arping.PingOverIfaceByName(net.ParseIP(req.IP), req.Network)
+	// 存活预检测（IPv4通过ARP、IPv6通过NDP）：检查目标IP是否已被局域网内其他设备占用
+	_mac, _, err := neighbor.Ping(net.ParseIP(req.IP), req.Network)
 	if err == nil {
 		// IP已被占用，直接上报失败状态
 		err = fmt.Errorf("创建诱捕ip失败 ip已存在 ip %s mac %s", req.IP, _mac.String())
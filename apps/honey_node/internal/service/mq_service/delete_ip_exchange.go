@@ -1,15 +1,15 @@
 package mq_service
 
 // File: honey_node/service/mq_service/delete_ip_exchange.go
-// Description: 删除诱捕IP的MQ消息消费处理逻辑，执行虚拟网络接口删除命令并通过gRPC上报删除状态
+// Description: 删除诱捕IP的MQ消息消费处理逻辑，删除虚拟网络接口并通过gRPC上报删除状态
 
 import (
 	"context"
-	"fmt"
 	"honey_node/internal/core"
 	"honey_node/internal/global"
+	"honey_node/internal/models"
 	"honey_node/internal/rpc/node_rpc"
-	"honey_node/internal/utils/cmd"
+	"honey_node/internal/service/ip_service"
 
 	"github.com/sirupsen/logrus"
 )
@@ -42,9 +42,11 @@ func DeleteIpExChange(req DeleteIPRequest) error {
 	var idList []uint32
 	var linkNameList []string
 	for _, info := range req.IpList {
-		// 执行系统命令删除对应的虚拟网络接口（如hy_123）
+		// 删除对应的虚拟网络接口（如hy_123）
 		if !info.IsTan {
-			cmd.Cmd(fmt.Sprintf("ip link del %s", info.Network))
+			if err := ip_service.RemoveInterface(info.Network); err != nil {
+				log.WithField("error", err).Errorf("failed to remove interface") // 删除网络接口失败
+			}
 			linkNameList = append(linkNameList, info.Network)
 		} else {
 			log.WithField("ip", info.IP).Infof("Tanip") // 探针ip
@@ -55,7 +57,7 @@ func DeleteIpExChange(req DeleteIPRequest) error {
 
 	// 删除数据库中的数据
 	if len(linkNameList) > 0 {
-		global.DB.Delete(&linkNameList)
+		global.DB.Delete(&models.IpModel{}, "link_name in ?", linkNameList)
 	}
 
 	// 上报删除状态到服务端（通知服务端删除数据库记录）
@@ -35,6 +35,8 @@ func Run() {
 		queueDeclare(cfg.BatchDeployStatusTopic)       // 批量部署状态队列
 		queueDeclare(cfg.BatchUpdateDeployStatusTopic) // 批量更新部署状态队列
 		queueDeclare(cfg.BatchRemoveDeployStatusTopic) // 批量移除部署状态队列
+		queueDeclare(cfg.SessionTopic)                 // 会话记录队列
+		queueDeclare(cfg.EventTopic)                   // Suricata事件队列
 	}
 
 	// 启动独立协程处理消费端注册，避免阻塞主协程
@@ -71,7 +73,11 @@ func Run() {
 		}
 	}()
 
+	// 启动发件箱发送协程，补发断线期间积压的消息
+	runOutbox()
+
 	// 启动MQ连接健康监控，异常时自动重连
+	connected.Store(true)
 	go watchHealth()
 }
 
@@ -150,12 +156,24 @@ func register[T any](exChangeName string, fun func(msg T) error) {
 	}
 	// 消费端创建完成，WaitGroup计数-1
 	wg.Done()
+	updateConsumerState(exChangeName, func(state *ConsumerState) {
+		state.Queue = queueName
+		state.Consuming = true
+		state.StartTime = time.Now().Format(time.DateTime)
+	})
 
 	// 循环监听并处理队列消息（通道关闭时退出循环）
 	for d := range msgs {
 		var data T
 		// 解析消息体为指定类型的业务结构体
 		err = json.Unmarshal(d.Body, &data)
+		updateConsumerState(exChangeName, func(state *ConsumerState) {
+			state.MessageCount++
+			state.LastMessageTime = time.Now().Format(time.DateTime)
+			if err != nil {
+				state.FailCount++
+			}
+		})
 		if err != nil {
 			logrus.Errorf("json解析失败 %s %s", err, string(d.Body))
 			d.Ack(false) // 解析失败仍手动ACK，避免消息重复投递
@@ -169,32 +187,31 @@ func register[T any](exChangeName string, fun func(msg T) error) {
 			continue
 		}
 
+		updateConsumerState(exChangeName, func(state *ConsumerState) {
+			state.FailCount++
+		})
 		// 处理失败时，暂不重新入队（注释Nack逻辑），直接ACK避免死循环
 		// d.Nack(false, true) // 拒绝消息，重新入队（可根据业务场景开启）
 		d.Ack(false)
 	}
+	updateConsumerState(exChangeName, func(state *ConsumerState) {
+		state.Consuming = false
+		state.StopTime = time.Now().Format(time.DateTime)
+	})
 	logrus.Errorf("%s 接收队列消息结束", queueName)
 }
 
 // sendQueueMessage 发送消息到指定MQ队列
+// 消息先写入本地发件箱，由发件箱协程发布并在收到MQ确认后删除，MQ断线期间不丢失
 func sendQueueMessage(queueName string, req any) (err error) {
 	// 将消息结构体序列化为JSON字节数组，作为MQ消息体
 	byteData, _ := json.Marshal(req)
-	// 发布消息到MQ：使用默认交换器（空字符串），按队列名路由
-	err = global.Queue.Publish(
-		"",        // exchange：默认交换器，直接按routing key匹配队列
-		queueName, // routing key：目标队列名称，默认交换器会路由到同名队列
-		false,     // mandatory：消息无法路由时不返回给生产者
-		false,     // immediate：RabbitMQ 3.0+已废弃，固定传false
-		amqp.Publishing{
-			ContentType: "text/plain", // 消息内容类型：纯文本（JSON格式）
-			Body:        byteData,     // 消息体：JSON序列化后的字节数组
-		})
+	id, err := enqueueMessage(queueName, byteData)
 	if err != nil {
-		logrus.Errorf("%s 发送消息失败: %v %s", queueName, err, string(byteData))
 		return
 	}
-	logrus.Infof("%s 发送消息成功: %s", queueName, string(byteData))
+	// 消息体可能包含会话记录等大段内容，仅记录消息ID及大小
+	logrus.Infof("%s 消息写入发件箱 id %s 大小 %d字节", queueName, id, len(byteData))
 	return nil
 }
 
@@ -212,6 +229,7 @@ func watchHealth() {
 			fmt.Println("mq被关闭了", s)
 		}
 		// 通道关闭，进入重连流程
+		connected.Store(false)
 		fmt.Println("通道被关闭, 等待重连")
 
 		retryMutex.Lock()
@@ -0,0 +1,208 @@
+package mq_service
+
+// File: honey_node/service/mq_service/outbox.go
+// Description: 消息发件箱模块，上报消息先持久化到本地数据库，由后台协程按顺序发布并在收到MQ发布确认后删除，MQ断线期间的消息在重连后补发
+
+import (
+	"errors"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/service/metrics_service"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+)
+
+const (
+	outboxBatchSize      = 100              // 每批发布的消息数
+	outboxConfirmTimeout = 10 * time.Second // 等待发布确认的超时时间
+	outboxPollInterval   = 5 * time.Second  // 无新消息通知时的轮询间隔
+	outboxTrimInterval   = time.Minute      // 超限消息清理间隔
+	outboxMaxCount       = 100000           // 默认最大保留条数
+	outboxMaxAge         = 72               // 默认最长保留时间（小时）
+)
+
+var (
+	outboxOnce   sync.Once
+	outboxNotify = make(chan struct{}, 1) // 新消息写入通知
+
+	confirmChannel *amqp.Channel          // 已开启发布确认模式的通道
+	confirmChan    chan amqp.Confirmation // 发布确认通知通道
+	confirmTag     uint64                 // 当前通道最近一次发布的投递标识
+	pendingTag     = map[uint64]uint{}    // 投递标识与发件箱记录ID的对应关系
+)
+
+// init 注册发件箱积压消息数指标
+func init() {
+	metrics_service.RegisterGauge("honey_node_mq_outbox_backlog", "发件箱积压的待发送消息数", func() float64 {
+		return float64(OutboxCount())
+	})
+}
+
+// enqueueMessage 将消息写入发件箱并通知发送协程，返回消息唯一标识
+func enqueueMessage(queueName string, body []byte) (string, error) {
+	model := models.OutboxModel{
+		MessageID: uuid.New().String(),
+		Queue:     queueName,
+		Body:      string(body),
+	}
+	err := global.DB.Create(&model).Error
+	if err != nil {
+		logrus.Errorf("%s 消息写入发件箱失败: %v 大小 %d字节", queueName, err, len(body))
+		return "", err
+	}
+	select {
+	case outboxNotify <- struct{}{}:
+	default:
+	}
+	return model.MessageID, nil
+}
+
+// runOutbox 启动发件箱发送协程，重连后重复调用只启动一次
+func runOutbox() {
+	outboxOnce.Do(func() {
+		go func() {
+			trimAt := time.Time{}
+			for {
+				if time.Since(trimAt) > outboxTrimInterval {
+					trimOutbox()
+					trimAt = time.Now()
+				}
+				flushOutbox()
+				select {
+				case <-outboxNotify:
+				case <-time.After(outboxPollInterval):
+				}
+			}
+		}()
+	})
+}
+
+// flushOutbox 按写入顺序发布发件箱中的消息，直到发件箱为空或发布失败
+func flushOutbox() {
+	for {
+		var list []models.OutboxModel
+		global.DB.Order("id").Limit(outboxBatchSize).Find(&list)
+		if len(list) == 0 {
+			return
+		}
+		if err := publishBatch(list); err != nil {
+			logrus.Warnf("发件箱消息发送中断，等待重试: %v", err)
+			metrics_service.MQPublishFailures.Inc()
+			return
+		}
+	}
+}
+
+// publishBatch 发布一批消息并等待发布确认，确认成功的消息从发件箱删除
+// 遇到发布失败或未确认的消息即停止，后续消息留待下次按顺序补发
+func publishBatch(list []models.OutboxModel) error {
+	ch := global.Queue
+	if ch == nil {
+		return errors.New("mq未连接")
+	}
+	// 通道重建后重新开启发布确认模式，投递标识从1开始重新计数
+	if ch != confirmChannel {
+		if err := ch.Confirm(false); err != nil {
+			return err
+		}
+		confirmChannel = ch
+		confirmChan = ch.NotifyPublish(make(chan amqp.Confirmation, 2*outboxBatchSize))
+		confirmTag = 0
+		pendingTag = map[uint64]uint{}
+	}
+
+	var publishErr error
+	for _, model := range list {
+		err := ch.Publish("", model.Queue, false, false, amqp.Publishing{
+			ContentType: "text/plain",
+			MessageId:   model.MessageID, // 超时未确认重新发布时沿用同一标识
+			Body:        []byte(model.Body),
+		})
+		if err != nil {
+			publishErr = err
+			global.DB.Model(&model).Update("retry", model.Retry+1)
+			break
+		}
+		confirmTag++
+		pendingTag[confirmTag] = model.ID
+	}
+
+	// 等待本批全部已发布消息的确认
+	var ackList []uint
+	var nackErr error
+	timeout := time.After(outboxConfirmTimeout)
+	for len(pendingTag) > 0 {
+		select {
+		case confirm, ok := <-confirmChan:
+			if !ok {
+				deleteOutbox(ackList)
+				return errors.New("mq通道已关闭")
+			}
+			id, found := pendingTag[confirm.DeliveryTag]
+			if !found {
+				continue
+			}
+			delete(pendingTag, confirm.DeliveryTag)
+			if !confirm.Ack {
+				nackErr = errors.New("mq拒绝了消息")
+				continue
+			}
+			ackList = append(ackList, id)
+		case <-timeout:
+			deleteOutbox(ackList)
+			// 未确认的消息等待下次重新发布，服务端对重复消息做幂等处理
+			pendingTag = map[uint64]uint{}
+			return errors.New("等待发布确认超时")
+		}
+	}
+	deleteOutbox(ackList)
+
+	if publishErr != nil {
+		return publishErr
+	}
+	return nackErr
+}
+
+// deleteOutbox 删除已确认发送的消息
+func deleteOutbox(idList []uint) {
+	if len(idList) == 0 {
+		return
+	}
+	global.DB.Delete(&models.OutboxModel{}, idList)
+	logrus.Infof("发件箱消息发送成功 %d条", len(idList))
+}
+
+// trimOutbox 按配置的最大条数及最长保留时间清理积压的消息
+func trimOutbox() {
+	cfg := global.Config.MQ
+	maxCount := cfg.OutboxMaxCount
+	if maxCount <= 0 {
+		maxCount = outboxMaxCount
+	}
+	maxAge := cfg.OutboxMaxAge
+	if maxAge <= 0 {
+		maxAge = outboxMaxAge
+	}
+
+	res := global.DB.Where("created_at < ?", time.Now().Add(-time.Duration(maxAge)*time.Hour)).Delete(&models.OutboxModel{})
+	if res.RowsAffected > 0 {
+		logrus.Warnf("发件箱消息超过保留时间，丢弃 %d条", res.RowsAffected)
+	}
+
+	var count int64
+	global.DB.Model(&models.OutboxModel{}).Count(&count)
+	if count <= int64(maxCount) {
+		return
+	}
+	var model models.OutboxModel
+	global.DB.Order("id desc").Offset(maxCount).Limit(1).Find(&model)
+	if model.ID == 0 {
+		return
+	}
+	res = global.DB.Where("id <= ?", model.ID).Delete(&models.OutboxModel{})
+	logrus.Warnf("发件箱消息超过最大条数，丢弃最早的 %d条", res.RowsAffected)
+}
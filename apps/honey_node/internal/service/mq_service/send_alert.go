@@ -9,6 +9,7 @@ import (
 
 // AlertMsgType 告警MQ消息结构体，定义告警数据的标准传输格式
 type AlertMsgType struct {
+	ID        string `json:"id"`        // Eve日志行唯一标识，服务端作为ES文档ID去重
 	NodeUid   string `json:"nodeUid"`   // 节点唯一标识
 	SrcIp     string `json:"srcIp"`     // 攻击源IP地址
 	SrcPort   int    `json:"srcPort"`   // 攻击源端口
@@ -19,6 +20,8 @@ type AlertMsgType struct {
 	Level     int8   `json:"level"`     // 告警级别
 	Body      string `json:"body"`      // HTTP响应体内容（仅HTTP相关告警有效)
 	Payload   string `json:"payload"`   // 告警关联数据包负载内容
+	SessionID string `json:"sessionID"` // 关联的会话记录ID
+	FlowID    int64  `json:"flowID"`    // 网络流唯一标识ID，关联同一流的Suricata事件
 }
 
 // SendAlertMsg 将告警数据序列化为JSON格式，发送至MQ指定告警队列
@@ -0,0 +1,29 @@
+package mq_service
+
+// File: honey_node/service/mq_service/send_emu_login_alert.go
+// Description: 模拟器登录告警发送模块，将内置协议模拟器捕获的登录尝试封装为告警消息发送至告警队列
+
+import (
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/service/emulator_service"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SendEmuLoginAlert 发送模拟器登录尝试告警
+func SendEmuLoginAlert(session emulator_service.Session, username, password string) {
+	SendAlertMsg(AlertMsgType{
+		ID:        uuid.New().String(), // 入队前生成，发件箱重复发布时服务端据此去重
+		NodeUid:   global.Config.System.Uid,
+		SrcIp:     session.SrcIp,
+		SrcPort:   session.SrcPort,
+		DestIp:    session.DestIp,
+		DestPort:  session.DestPort,
+		Timestamp: time.Now().Format(time.DateTime),
+		Signature: fmt.Sprintf("%s模拟器登录尝试", session.Emulator),
+		Level:     2,
+		Payload:   fmt.Sprintf("username=%s password=%s", username, password),
+	})
+}
@@ -0,0 +1,38 @@
+package mq_service
+
+// File: honey_node/service/mq_service/send_event.go
+// Description: Suricata事件上报模块，将诱捕ip相关的flow/dns/http/tls/ssh/fileinfo事件及节点连接限制事件发送至事件队列
+
+import (
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+)
+
+// EventMsgType Suricata事件MQ消息结构体，详情字段保留Suricata Eve原始字段名
+type EventMsgType struct {
+	ID        string              `json:"id"`                 // Eve日志行唯一标识，服务端作为ES文档ID去重
+	NodeUid   string              `json:"nodeUid"`            // 节点唯一标识
+	EventType string              `json:"eventType"`          // 事件类型 flow dns http tls ssh fileinfo limit
+	FlowID    int64               `json:"flowID"`             // 网络流唯一标识ID，与告警的flowID关联
+	Timestamp string              `json:"timestamp"`          // 事件发生时间
+	SrcIp     string              `json:"srcIp"`              // 源IP地址
+	SrcPort   int                 `json:"srcPort"`            // 源端口
+	DestIp    string              `json:"destIp"`             // 目标IP地址
+	DestPort  int                 `json:"destPort"`           // 目标端口
+	Proto     string              `json:"proto"`              // 网络协议
+	AppProto  string              `json:"appProto"`           // 应用层协议
+	HoneyIp   string              `json:"honeyIp"`            // 事件涉及的诱捕IP
+	SessionID string              `json:"sessionID"`          // 关联的会话记录ID
+	Flow      *models.EveFlow     `json:"flow,omitempty"`     // 网络流统计信息
+	Dns       *models.EveDns      `json:"dns,omitempty"`      // DNS请求/应答详情
+	Http      *models.EveHttp     `json:"http,omitempty"`     // HTTP请求详情
+	Tls       *models.EveTls      `json:"tls,omitempty"`      // TLS握手详情
+	Ssh       *models.EveSsh      `json:"ssh,omitempty"`      // SSH握手详情
+	Fileinfo  *models.EveFileinfo `json:"fileinfo,omitempty"` // 传输文件详情
+	Limit     *LimitEventType     `json:"limit,omitempty"`    // 连接限制触发详情
+}
+
+// SendEventMsg 发送Suricata事件至MQ事件队列
+func SendEventMsg(data EventMsgType) {
+	sendQueueMessage(global.Config.MQ.EventTopic, data)
+}
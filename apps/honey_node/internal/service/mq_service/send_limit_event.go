@@ -0,0 +1,45 @@
+package mq_service
+
+// File: honey_node/service/mq_service/send_limit_event.go
+// Description: 连接限制事件上报模块，将诱捕端口连接限制的周期汇总触发记录封装为limit事件发送至事件队列
+
+import (
+	"honey_node/internal/global"
+	"honey_node/internal/service/port_service"
+
+	"github.com/google/uuid"
+)
+
+// LimitEventType 连接限制触发详情
+type LimitEventType struct {
+	Scope     string `json:"scope"`     // 限制范围 port honeyIp srcIp
+	Kind      string `json:"kind"`      // 限制类型 maxConn rate
+	Key       string `json:"key"`       // 限制对象（诱捕ip:端口、诱捕ip或来源ip）
+	Action    string `json:"action"`    // 处理方式 tarpit reset drop
+	Count     int64  `json:"count"`     // 汇总周期内的触发次数
+	FirstTime string `json:"firstTime"` // 汇总周期内首次触发时间
+}
+
+// SendLimitEvent 发送连接限制事件至MQ事件队列，来源及诱捕地址为汇总周期内最近一次触发的连接
+func SendLimitEvent(hit port_service.LimitHit) {
+	SendEventMsg(EventMsgType{
+		ID:        uuid.New().String(),
+		NodeUid:   global.Config.System.Uid,
+		EventType: "limit",
+		Timestamp: hit.LastTime,
+		SrcIp:     hit.SrcIp,
+		SrcPort:   hit.SrcPort,
+		DestIp:    hit.DestIp,
+		DestPort:  hit.DestPort,
+		Proto:     "TCP",
+		HoneyIp:   hit.DestIp,
+		Limit: &LimitEventType{
+			Scope:     hit.Scope,
+			Kind:      hit.Kind,
+			Key:       hit.Key,
+			Action:    hit.Action,
+			Count:     hit.Count,
+			FirstTime: hit.FirstTime,
+		},
+	})
+}
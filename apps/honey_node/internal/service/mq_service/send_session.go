@@ -0,0 +1,23 @@
+package mq_service
+
+// File: honey_node/service/mq_service/send_session.go
+// Description: 会话上报模块，将隧道连接结束后的完整会话记录发送至会话队列
+
+import (
+	"honey_node/internal/global"
+	"honey_node/internal/service/port_service"
+)
+
+// SessionMsgType 会话MQ消息结构体
+type SessionMsgType struct {
+	NodeUid string `json:"nodeUid"` // 节点唯一标识
+	*port_service.Session
+}
+
+// SendSessionMsg 发送会话记录至MQ会话队列
+func SendSessionMsg(session *port_service.Session) {
+	sendQueueMessage(global.Config.MQ.SessionTopic, SessionMsgType{
+		NodeUid: global.Config.System.Uid,
+		Session: session,
+	})
+}
@@ -18,8 +18,9 @@ type UpdateDeployStatusRequest struct {
 
 // PortInfo 端口更新状态信息结构体
 type PortInfo struct {
-	Port     int    `json:"port"`     // 端口号
-	ErrorMsg string `json:"errorMsg"` // 该端口更新失败时的错误信息
+	Port      int    `json:"port"`      // 端口号
+	Agreement int8   `json:"agreement"` // 端口通信协议 1 TCP 2 UDP
+	ErrorMsg  string `json:"errorMsg"`  // 该端口更新失败时的错误信息
 }
 
 // SendUpdateDeployStatusMsg 发送更新部署状态MQ消息
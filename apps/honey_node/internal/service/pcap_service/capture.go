@@ -0,0 +1,247 @@
+package pcap_service
+
+// File: honey_node/service/pcap_service/capture.go
+// Description: 抓包实现，基于AF_PACKET原始套接字读取诱捕网卡数据包，按ip过滤后写入有界环形缓冲区
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/rpc/node_rpc"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// packet 单个抓取的数据包
+type packet struct {
+	ts   time.Time // 抓取时间
+	data []byte    // 数据包内容（以太网帧）
+}
+
+// capture 单个抓包任务
+type capture struct {
+	id        string        // 抓包任务id
+	linkName  string        // 抓包接口名称
+	ip        net.IP        // 过滤ip，为空时保留全部数据包
+	maxBytes  int64         // 环形缓冲区容量
+	fd        int           // AF_PACKET套接字
+	packets   []packet      // 环形缓冲区中的数据包（按时间顺序）
+	size      int64         // 环形缓冲区已用字节数
+	dropCount int64         // 因缓冲区已满被丢弃的数据包数量
+	errMsg    string        // 抓包错误信息
+	done      chan struct{} // 停止信号
+	stopOnce  sync.Once     // 保证只停止一次
+}
+
+// htons 主机字节序转网络字节序
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
+
+// newCapture 在指定接口上创建AF_PACKET套接字
+func newCapture(id, linkName string, ip net.IP, maxBytes int64) (*capture, error) {
+	iface, err := net.InterfaceByName(linkName)
+	if err != nil {
+		return nil, fmt.Errorf("接口 %s 不存在 %s", linkName, err)
+	}
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, int(htons(unix.ETH_P_ALL)))
+	if err != nil {
+		return nil, fmt.Errorf("创建抓包套接字失败 %s", err)
+	}
+	err = unix.Bind(fd, &unix.SockaddrLinklayer{
+		Protocol: htons(unix.ETH_P_ALL),
+		Ifindex:  iface.Index,
+	})
+	if err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("绑定抓包接口失败 %s", err)
+	}
+	// 设置读超时，保证停止信号能及时生效
+	tv := unix.Timeval{Sec: 1}
+	if err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("设置抓包超时失败 %s", err)
+	}
+
+	return &capture{
+		id:       id,
+		linkName: linkName,
+		ip:       ip,
+		maxBytes: maxBytes,
+		fd:       fd,
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// stop 发送停止信号
+func (c *capture) stop() {
+	c.stopOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// run 持续抓包直到停止或超时，结束后上传抓包结果
+func (c *capture) run(duration time.Duration) {
+	timer := time.AfterFunc(duration, c.stop)
+	defer timer.Stop()
+
+	buffer := make([]byte, 65536)
+	for {
+		select {
+		case <-c.done:
+			c.finish()
+			return
+		default:
+		}
+
+		n, _, err := unix.Recvfrom(c.fd, buffer, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue // 读超时，重新检查停止信号
+			}
+			c.errMsg = fmt.Sprintf("读取数据包失败 %s", err)
+			logrus.Errorf("抓包 %s %s", c.id, c.errMsg)
+			c.finish()
+			return
+		}
+		if c.ip != nil && !matchIP(buffer[:n], c.ip) {
+			continue
+		}
+		c.push(buffer[:n])
+	}
+}
+
+// push 将数据包写入环形缓冲区，超出容量时丢弃最早的数据包
+func (c *capture) push(data []byte) {
+	chunk := make([]byte, len(data))
+	copy(chunk, data)
+	c.packets = append(c.packets, packet{ts: time.Now(), data: chunk})
+	c.size += int64(len(chunk))
+
+	drop := 0
+	for c.size > c.maxBytes && drop < len(c.packets)-1 {
+		c.size -= int64(len(c.packets[drop].data))
+		drop++
+	}
+	if drop > 0 {
+		c.dropCount += int64(drop)
+		c.packets = c.packets[drop:]
+	}
+}
+
+// finish 关闭套接字、释放任务并上传抓包结果
+func (c *capture) finish() {
+	unix.Close(c.fd)
+	captureMap.Delete(c.id)
+	linkMap.Delete(c.linkName)
+	logrus.Infof("抓包结束 %s 数据包 %d 个 丢弃 %d 个", c.id, len(c.packets), c.dropCount)
+
+	if err := c.upload(); err != nil {
+		logrus.Errorf("上传抓包文件失败 %s %s", c.id, err)
+	}
+}
+
+// upload 生成pcap文件并分块上传至管理端
+func (c *capture) upload() error {
+	data := c.pcap()
+
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+	stream, err := global.GrpcClient.UploadPcap(ctx)
+	if err != nil {
+		return err
+	}
+
+	for offset := 0; offset < len(data); offset += uploadChunkSize {
+		end := offset + uploadChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunk := &node_rpc.PcapChunk{
+			CaptureID: c.id,
+			Chunk:     data[offset:end],
+		}
+		// 首个数据块携带抓包结果信息
+		if offset == 0 {
+			chunk.PacketCount = int64(len(c.packets))
+			chunk.DropCount = c.dropCount
+			chunk.ErrMsg = c.errMsg
+		}
+		if err = stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return errors.New(res.Msg)
+	}
+	logrus.Infof("上传抓包文件成功 %s %d字节", c.id, len(data))
+	return nil
+}
+
+// pcap 将环形缓冲区中的数据包编码为pcap文件格式（以太网链路类型）
+func (c *capture) pcap() []byte {
+	var buf bytes.Buffer
+	// 文件头：魔数、版本2.4、时区、时间精度、快照长度、链路类型
+	binary.Write(&buf, binary.LittleEndian, []uint32{0xa1b2c3d4})
+	binary.Write(&buf, binary.LittleEndian, []uint16{2, 4})
+	binary.Write(&buf, binary.LittleEndian, []uint32{0, 0, 65536, 1})
+	for _, p := range c.packets {
+		// 数据包头：秒、微秒、抓取长度、原始长度
+		binary.Write(&buf, binary.LittleEndian, []uint32{
+			uint32(p.ts.Unix()),
+			uint32(p.ts.Nanosecond() / 1000),
+			uint32(len(p.data)),
+			uint32(len(p.data)),
+		})
+		buf.Write(p.data)
+	}
+	return buf.Bytes()
+}
+
+// matchIP 判断以太网帧是否与指定ip相关（IPv4/IPv6源或目的地址、ARP发送方或目标地址）
+func matchIP(frame []byte, ip net.IP) bool {
+	if len(frame) < 14 {
+		return false
+	}
+	etherType := binary.BigEndian.Uint16(frame[12:14])
+	payload := frame[14:]
+	// 跳过802.1Q标签
+	if etherType == 0x8100 && len(payload) >= 4 {
+		etherType = binary.BigEndian.Uint16(payload[2:4])
+		payload = payload[4:]
+	}
+
+	switch etherType {
+	case 0x0800: // IPv4
+		ip4 := ip.To4()
+		if ip4 == nil || len(payload) < 20 {
+			return false
+		}
+		return bytes.Equal(payload[12:16], ip4) || bytes.Equal(payload[16:20], ip4)
+	case 0x86dd: // IPv6
+		if ip.To4() != nil || len(payload) < 40 {
+			return false
+		}
+		return bytes.Equal(payload[8:24], ip) || bytes.Equal(payload[24:40], ip)
+	case 0x0806: // ARP
+		ip4 := ip.To4()
+		if ip4 == nil || len(payload) < 28 {
+			return false
+		}
+		return bytes.Equal(payload[14:18], ip4) || bytes.Equal(payload[24:28], ip4)
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package pcap_service
+
+// File: honey_node/service/pcap_service/enter.go
+// Description: 抓包服务模块，按需在诱捕网卡上启动有界环形缓冲区抓包，停止或超时后生成pcap文件并分块上传至管理端
+
+import (
+	"errors"
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/rpc/node_rpc"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultDuration = 60               // 默认最长抓包时间（秒）
+	maxDuration     = 3600             // 最长抓包时间上限（秒）
+	defaultMaxBytes = 16 << 20         // 默认环形缓冲区容量
+	maxMaxBytes     = 256 << 20        // 环形缓冲区容量上限
+	uploadChunkSize = 64 << 10         // 上传数据块大小
+	uploadTimeout   = 10 * time.Minute // 上传超时时间
+)
+
+// captureMap 正在运行的抓包任务，key为抓包任务id，value为*capture
+var captureMap = sync.Map{}
+
+// linkMap 正在抓包的接口，保证同一接口同时只有一个抓包任务
+var linkMap = sync.Map{}
+
+// Start 按请求参数启动抓包任务
+func Start(req *node_rpc.PcapCaptureInMessage) error {
+	if req.CaptureID == "" {
+		return errors.New("抓包任务id不能为空")
+	}
+	if _, ok := captureMap.Load(req.CaptureID); ok {
+		return fmt.Errorf("抓包任务 %s 已存在", req.CaptureID)
+	}
+
+	// 未指定接口时按诱捕ip查找对应的诱捕网卡
+	linkName := req.LinkName
+	if linkName == "" {
+		var ipModel models.IpModel
+		if err := global.DB.Take(&ipModel, "ip = ?", req.Ip).Error; err != nil {
+			return fmt.Errorf("诱捕ip %s 不存在", req.Ip)
+		}
+		linkName = ipModel.LinkName
+	}
+	if !strings.HasPrefix(linkName, "hy_") {
+		return fmt.Errorf("仅允许在诱捕网卡上抓包 %s", linkName)
+	}
+
+	var ip net.IP
+	if req.Ip != "" {
+		ip = net.ParseIP(req.Ip)
+		if ip == nil {
+			return fmt.Errorf("无效的ip %s", req.Ip)
+		}
+	}
+
+	duration := int(req.Duration)
+	if duration <= 0 {
+		duration = defaultDuration
+	}
+	if duration > maxDuration {
+		duration = maxDuration
+	}
+	maxBytes := req.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBytes
+	}
+	if maxBytes > maxMaxBytes {
+		maxBytes = maxMaxBytes
+	}
+
+	if _, loaded := linkMap.LoadOrStore(linkName, req.CaptureID); loaded {
+		return fmt.Errorf("接口 %s 正在抓包中", linkName)
+	}
+
+	c, err := newCapture(req.CaptureID, linkName, ip, maxBytes)
+	if err != nil {
+		linkMap.Delete(linkName)
+		return err
+	}
+	captureMap.Store(req.CaptureID, c)
+	logrus.Infof("开始抓包 %s 接口 %s ip %s 时长 %ds 缓冲区 %d字节", req.CaptureID, linkName, req.Ip, duration, maxBytes)
+
+	go c.run(time.Duration(duration) * time.Second)
+	return nil
+}
+
+// Stop 停止指定抓包任务，抓包结果随后异步上传
+func Stop(captureID string) error {
+	value, ok := captureMap.Load(captureID)
+	if !ok {
+		return fmt.Errorf("抓包任务 %s 不存在", captureID)
+	}
+	value.(*capture).stop()
+	return nil
+}
@@ -0,0 +1,393 @@
+package port_service
+
+// File: honey_node/service/port_service/limit.go
+// Description: 端口服务模块，诱捕端口连接限制，按诱捕端口、诱捕ip及来源ip限制并发会话数及新建连接速率，超出限制的连接按配置tarpit、reset或drop，会话空闲及总时长超时后断开，限制触发次数按周期汇总上报
+
+import (
+	"honey_node/internal/config"
+	"honey_node/internal/global"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 限制范围
+const (
+	LimitScopePort    = "port"    // 单个诱捕端口
+	LimitScopeHoneyIp = "honeyIp" // 单个诱捕ip
+	LimitScopeSrcIp   = "srcIp"   // 单个来源ip
+)
+
+// 限制类型
+const (
+	LimitKindConn = "maxConn" // 并发会话数超限
+	LimitKindRate = "rate"    // 新建连接速率超限
+)
+
+// 超出限制的连接处理方式
+const (
+	LimitActionTarpit = "tarpit" // 保持连接不响应，到期后reset
+	LimitActionReset  = "reset"  // 发送RST断开
+	LimitActionDrop   = "drop"   // 不发送任何数据直接关闭
+)
+
+const (
+	defaultTarpitTime     = 30 * time.Second // 未配置时tarpit保持连接的时间
+	defaultMaxTarpit      = 1000             // 未配置时同时处于tarpit的连接数上限
+	defaultReportInterval = 60 * time.Second // 未配置时限制触发事件的汇总上报间隔
+)
+
+// LimitHit 汇总周期内同一限制对象的触发记录
+type LimitHit struct {
+	Scope     string // 限制范围 port honeyIp srcIp
+	Kind      string // 限制类型 maxConn rate
+	Key       string // 限制对象（诱捕ip:端口、诱捕ip或来源ip）
+	Action    string // 处理方式 tarpit reset drop
+	Count     int64  // 汇总周期内的触发次数
+	SrcIp     string // 最近一次触发的来源ip
+	SrcPort   int    // 最近一次触发的来源端口
+	DestIp    string // 最近一次触发的诱捕ip
+	DestPort  int    // 最近一次触发的诱捕端口
+	FirstTime string // 汇总周期内首次触发时间
+	LastTime  string // 汇总周期内最近一次触发时间
+}
+
+// LimitInfo 连接限制运行统计
+type LimitInfo struct {
+	HitTotal int64 `json:"hitTotal"` // 节点启动以来的限制触发总次数
+	Tarpit   int64 `json:"tarpit"`   // 当前处于tarpit的连接数
+	Tracked  int   `json:"tracked"`  // 当前跟踪的限制对象数
+}
+
+// limitReporter 限制触发上报函数，由启动流程注册（避免与mq_service循环依赖）
+var limitReporter func(hit LimitHit)
+
+// SetLimitReporter 注册限制触发上报函数
+func SetLimitReporter(fn func(hit LimitHit)) {
+	limitReporter = fn
+}
+
+// limitEntry 单个限制对象的计数
+type limitEntry struct {
+	active int       // 当前并发会话数
+	tokens float64   // 剩余新建连接令牌
+	last   time.Time // 令牌最近更新时间
+}
+
+// limitCheck 一次连接准入中需要检查的限制对象
+type limitCheck struct {
+	scope string
+	key   string
+	rule  config.LimitRule
+}
+
+// connLimiter 诱捕端口连接限制器
+type connLimiter struct {
+	lock     sync.Mutex             // 计数及触发记录读写锁
+	entries  map[string]*limitEntry // 限制范围:限制对象 -> 计数
+	hits     map[string]*LimitHit   // 限制范围:限制类型:限制对象 -> 触发记录
+	hitTotal atomic.Int64           // 限制触发总次数
+	tarpit   atomic.Int64           // 当前处于tarpit的连接数
+	once     sync.Once              // 保证汇总上报协程只启动一次
+}
+
+// limiter 全局连接限制器
+var limiter = &connLimiter{
+	entries: map[string]*limitEntry{},
+	hits:    map[string]*LimitHit{},
+}
+
+// LimitStat 获取连接限制运行统计
+func LimitStat() LimitInfo {
+	limiter.lock.Lock()
+	tracked := len(limiter.entries)
+	limiter.lock.Unlock()
+	return LimitInfo{
+		HitTotal: limiter.hitTotal.Load(),
+		Tarpit:   limiter.tarpit.Load(),
+		Tracked:  tracked,
+	}
+}
+
+// accept 对新接受的连接执行准入检查，通过时返回带超时控制的连接，超出限制时按配置处理连接并返回nil
+func (l *connLimiter) accept(conn net.Conn) net.Conn {
+	l.once.Do(func() {
+		go l.report()
+	})
+	cfg := global.Config.Limit
+
+	src, _ := conn.RemoteAddr().(*net.TCPAddr)
+	dest, _ := conn.LocalAddr().(*net.TCPAddr)
+	if src == nil || dest == nil {
+		return conn
+	}
+	checkList := []limitCheck{
+		{LimitScopePort, dest.String(), cfg.Port},
+		{LimitScopeHoneyIp, dest.IP.String(), cfg.HoneyIp},
+		{LimitScopeSrcIp, src.IP.String(), cfg.SrcIp},
+	}
+
+	keys, scope, kind, ok := l.acquire(checkList)
+	if !ok {
+		l.hit(scope, kind, checkList, cfg.Action, src, dest)
+		l.reject(conn, cfg)
+		return nil
+	}
+
+	lc := &limitConn{Conn: conn, keys: keys}
+	lc.lastActive.Store(time.Now().UnixNano())
+	lc.watch(time.Duration(cfg.IdleTimeout)*time.Second, time.Duration(cfg.SessionTimeout)*time.Second)
+	return lc
+}
+
+// acquire 检查全部限制对象，均未超限时占用并发数及新建连接令牌并返回占用的计数key，否则返回超限的范围及类型
+func (l *connLimiter) acquire(checkList []limitCheck) (keys []string, scope, kind string, ok bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	var entryList []*limitEntry
+	var ruleList []config.LimitRule
+	for _, check := range checkList {
+		// 未配置限制的范围无需计数
+		if check.rule.MaxConn <= 0 && check.rule.Rate <= 0 {
+			continue
+		}
+		key := check.scope + ":" + check.key
+		entry, ok := l.entries[key]
+		if !ok {
+			entry = &limitEntry{tokens: float64(check.rule.Rate), last: now}
+			l.entries[key] = entry
+		}
+		// 按经过的时间补充令牌，令牌上限为每秒速率
+		if check.rule.Rate > 0 {
+			entry.tokens = min(float64(check.rule.Rate), entry.tokens+now.Sub(entry.last).Seconds()*float64(check.rule.Rate))
+			entry.last = now
+		}
+		if check.rule.MaxConn > 0 && entry.active >= check.rule.MaxConn {
+			return nil, check.scope, LimitKindConn, false
+		}
+		if check.rule.Rate > 0 && entry.tokens < 1 {
+			return nil, check.scope, LimitKindRate, false
+		}
+		entryList = append(entryList, entry)
+		ruleList = append(ruleList, check.rule)
+		keys = append(keys, key)
+	}
+
+	for i, entry := range entryList {
+		entry.active++
+		if ruleList[i].Rate > 0 {
+			entry.tokens--
+		}
+	}
+	return keys, "", "", true
+}
+
+// release 会话结束时释放占用的并发数
+func (l *connLimiter) release(keys []string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, key := range keys {
+		if entry, ok := l.entries[key]; ok && entry.active > 0 {
+			entry.active--
+		}
+	}
+}
+
+// hit 记录一次限制触发，同一限制对象在汇总周期内合并计数
+func (l *connLimiter) hit(scope, kind string, checkList []limitCheck, action string, src, dest *net.TCPAddr) {
+	l.hitTotal.Add(1)
+	var target string
+	for _, check := range checkList {
+		if check.scope == scope {
+			target = check.key
+		}
+	}
+	now := time.Now().Format(time.DateTime)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	key := scope + ":" + kind + ":" + target
+	h, ok := l.hits[key]
+	if !ok {
+		h = &LimitHit{
+			Scope:     scope,
+			Kind:      kind,
+			Key:       target,
+			Action:    limitAction(action),
+			FirstTime: now,
+		}
+		l.hits[key] = h
+		logrus.Warnf("连接限制触发 %s %s %s 来源 %s", scope, kind, target, src)
+	}
+	h.Count++
+	h.SrcIp, h.SrcPort = src.IP.String(), src.Port
+	h.DestIp, h.DestPort = dest.IP.String(), dest.Port
+	h.LastTime = now
+}
+
+// reject 按配置处理超出限制的连接
+func (l *connLimiter) reject(conn net.Conn, cfg config.Limit) {
+	switch limitAction(cfg.Action) {
+	case LimitActionDrop:
+		conn.Close()
+		return
+	case LimitActionTarpit:
+		maxTarpit := int64(cfg.MaxTarpit)
+		if maxTarpit <= 0 {
+			maxTarpit = defaultMaxTarpit
+		}
+		// tarpit连接同样占用文件描述符，超出上限后按reset处理
+		if l.tarpit.Add(1) <= maxTarpit {
+			tarpitTime := time.Duration(cfg.TarpitTime) * time.Second
+			if tarpitTime <= 0 {
+				tarpitTime = defaultTarpitTime
+			}
+			time.AfterFunc(tarpitTime, func() {
+				resetConn(conn)
+				l.tarpit.Add(-1)
+			})
+			return
+		}
+		l.tarpit.Add(-1)
+	}
+	resetConn(conn)
+}
+
+// report 按周期汇总上报限制触发记录，并清理空闲的限制对象计数
+func (l *connLimiter) report() {
+	for {
+		interval := time.Duration(global.Config.Limit.ReportInterval) * time.Second
+		if interval <= 0 {
+			interval = defaultReportInterval
+		}
+		time.Sleep(interval)
+
+		l.lock.Lock()
+		hits := l.hits
+		l.hits = map[string]*LimitHit{}
+		// 无会话且令牌已补满（超过1秒未新建连接）的计数无需保留
+		now := time.Now()
+		for key, entry := range l.entries {
+			if entry.active == 0 && now.Sub(entry.last) > time.Second {
+				delete(l.entries, key)
+			}
+		}
+		l.lock.Unlock()
+
+		list := make([]*LimitHit, 0, len(hits))
+		for _, h := range hits {
+			list = append(list, h)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].FirstTime < list[j].FirstTime
+		})
+		for _, h := range list {
+			logrus.Warnf("连接限制触发汇总 %s %s %s 处理方式 %s %d次", h.Scope, h.Kind, h.Key, h.Action, h.Count)
+			if limitReporter != nil {
+				limitReporter(*h)
+			}
+		}
+	}
+}
+
+// limitAction 规范化处理方式，未配置或不支持时按reset处理
+func limitAction(action string) string {
+	switch action {
+	case LimitActionTarpit, LimitActionDrop:
+		return action
+	}
+	return LimitActionReset
+}
+
+// resetConn 关闭连接并发送RST
+func resetConn(conn net.Conn) {
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		tcpConn.SetLinger(0)
+	}
+	conn.Close()
+}
+
+// limitConn 受连接限制的客户端连接，关闭时释放占用的并发数，空闲或持续时间超时后自动关闭
+type limitConn struct {
+	net.Conn
+	keys       []string     // 占用的限制对象计数key
+	lastActive atomic.Int64 // 最近一次收发数据的时间（纳秒）
+	closeOnce  sync.Once    // 保证只释放一次
+	closed     bool         // 是否已关闭，关闭后不再重新计时
+	idleTimer  *time.Timer  // 空闲超时定时器
+	totalTimer *time.Timer  // 总时长超时定时器
+	lock       sync.Mutex   // 定时器读写锁
+}
+
+// Read 读取数据并更新活跃时间
+func (c *limitConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.lastActive.Store(time.Now().UnixNano())
+	}
+	return n, err
+}
+
+// Write 写入数据并更新活跃时间
+func (c *limitConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	if n > 0 {
+		c.lastActive.Store(time.Now().UnixNano())
+	}
+	return n, err
+}
+
+// Close 关闭连接并释放占用的并发数
+func (c *limitConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.lock.Lock()
+		c.closed = true
+		if c.idleTimer != nil {
+			c.idleTimer.Stop()
+		}
+		if c.totalTimer != nil {
+			c.totalTimer.Stop()
+		}
+		c.lock.Unlock()
+		limiter.release(c.keys)
+	})
+	return c.Conn.Close()
+}
+
+// watch 启动空闲及总时长超时定时器，值为0时不限制
+func (c *limitConn) watch(idle, total time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if total > 0 {
+		c.totalTimer = time.AfterFunc(total, func() {
+			logrus.Infof("会话超过最长持续时间 %s => %s", c.RemoteAddr(), c.LocalAddr())
+			c.Close()
+		})
+	}
+	if idle > 0 {
+		c.idleTimer = time.AfterFunc(idle, func() {
+			c.checkIdle(idle)
+		})
+	}
+}
+
+// checkIdle 空闲定时器到期时检查最近活跃时间，仍有数据收发则按剩余时间重新计时
+func (c *limitConn) checkIdle(idle time.Duration) {
+	remain := idle - time.Since(time.Unix(0, c.lastActive.Load()))
+	if remain > 0 {
+		c.lock.Lock()
+		if !c.closed {
+			c.idleTimer.Reset(remain)
+		}
+		c.lock.Unlock()
+		return
+	}
+	logrus.Infof("会话空闲超时 %s => %s", c.RemoteAddr(), c.LocalAddr())
+	c.Close()
+}
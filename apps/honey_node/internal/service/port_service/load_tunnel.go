@@ -19,6 +19,6 @@ func LoadTunnel() {
 
 	// 遍历所有端口转发记录，异步启动隧道（避免阻塞应用启动流程）
 	for _, model := range portList {
-		go Tunnel(model.LocalAddr, model.TargetAddr)
+		go BindTunnel(model.Agreement, model.LocalAddr, model.TargetAddr)
 	}
 }
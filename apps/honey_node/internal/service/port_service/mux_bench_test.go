@@ -0,0 +1,247 @@
+package port_service
+
+// File: honey_node/service/port_service/mux_bench_test.go
+// Description: 端口服务模块，TCP隧道性能对比基准测试，在本机启动模拟管理端及回显目标服务，分别测试每连接独立gRPC流与多路复用两种隧道的吞吐量及新建连接速率
+// 执行方式：go test -run ^$ -bench BenchmarkTunnel ./internal/service/port_service/
+
+import (
+	"context"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/utils/mux"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	benchTimeout     = 10 * time.Second // 测试中单个连接操作的超时时间
+	benchStreamCount = 4                // 多路复用隧道的gRPC流数量
+)
+
+// benchServer 模拟管理端的隧道服务，与管理端的隧道实现保持一致
+type benchServer struct {
+	node_rpc.UnimplementedNodeServiceServer
+}
+
+// Tunnel 每个gRPC流对应一个目标连接
+func (benchServer) Tunnel(stream node_rpc.NodeService_TunnelServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(stream.Context(), "tcp", req.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	go func() {
+		for {
+			data, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if _, err = conn.Write(data.Chunk); err != nil {
+				return
+			}
+		}
+	}()
+
+	buffer := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buffer)
+		if err != nil {
+			return nil
+		}
+		if err = stream.Send(&node_rpc.TunnelData{Chunk: buffer[:n], Address: req.Address}); err != nil {
+			return err
+		}
+	}
+}
+
+// MuxTunnel 一个gRPC流承载多个会话
+func (benchServer) MuxTunnel(stream node_rpc.NodeService_MuxTunnelServer) error {
+	ctx := stream.Context()
+	conn := mux.NewConn(stream, func(session *mux.Session) {
+		dialer := &net.Dialer{}
+		target, err := dialer.DialContext(ctx, "tcp", session.Address)
+		if err != nil {
+			session.CloseWithError(err)
+			return
+		}
+		defer target.Close()
+		go func() {
+			session.ReadFrom(target)
+			session.Close()
+		}()
+		session.WriteTo(target)
+		target.Close()
+		session.Close()
+	})
+	return conn.Run()
+}
+
+// benchMode 隧道模式，handle将诱捕端口接受的连接经隧道转发到目标地址
+type benchMode struct {
+	name   string
+	handle func(conn net.Conn, targetAddr string)
+}
+
+// benchEnv 测试环境：回显目标服务、模拟管理端及两种隧道模式
+type benchEnv struct {
+	targetAddr string
+	modeList   []benchMode
+}
+
+// newBenchEnv 启动回显目标服务及模拟管理端，测试结束时等待进行中的会话结束后关闭
+func newBenchEnv(b *testing.B) *benchEnv {
+	// 测试过程中的逐连接日志较多，仅保留警告及以上级别
+	level := logrus.GetLevel()
+	logrus.SetLevel(logrus.WarnLevel)
+	b.Cleanup(func() { logrus.SetLevel(level) })
+
+	// 回显目标服务
+	echo, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { echo.Close() })
+	go serveListener(echo, func(conn net.Conn) {
+		io.Copy(conn, conn)
+		conn.Close()
+	})
+
+	// 模拟管理端
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	server := grpc.NewServer()
+	node_rpc.RegisterNodeServiceServer(server, benchServer{})
+	go server.Serve(grpcListener)
+	b.Cleanup(server.Stop)
+
+	grpcConn, err := grpc.NewClient(grpcListener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { grpcConn.Close() })
+	client := node_rpc.NewNodeServiceClient(grpcConn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.Cleanup(func() {
+		// 等待进行中的会话结束后再关闭隧道，避免中止会话产生错误日志
+		for i := 0; i < 20 && len(ActiveSessionList()) > 0; i++ {
+			time.Sleep(100 * time.Millisecond)
+		}
+		cancel()
+	})
+	pool := newMuxPool(ctx, client, benchStreamCount)
+
+	return &benchEnv{
+		targetAddr: echo.Addr().String(),
+		modeList: []benchMode{
+			{"stream", func(conn net.Conn, targetAddr string) { handleConnection(client, conn, targetAddr) }},
+			{"mux", func(conn net.Conn, targetAddr string) { handleMuxConnection(pool, conn, targetAddr) }},
+		},
+	}
+}
+
+// listen 模拟诱捕端口，接受的连接按指定隧道模式转发到回显服务，返回监听地址
+func (e *benchEnv) listen(b *testing.B, mode benchMode) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { listener.Close() })
+	go serveListener(listener, func(conn net.Conn) {
+		mode.handle(conn, e.targetAddr)
+	})
+	return listener.Addr().String()
+}
+
+// BenchmarkTunnelThroughput 并发连接经隧道发送数据块并读取等量回显数据，每次操作为一个数据块的往返
+func BenchmarkTunnelThroughput(b *testing.B) {
+	env := newBenchEnv(b)
+	for _, mode := range env.modeList {
+		b.Run(mode.name, func(b *testing.B) {
+			addr := env.listen(b, mode)
+			b.SetBytes(mux.MaxChunkSize)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				conn, err := net.DialTimeout("tcp", addr, benchTimeout)
+				if err != nil {
+					b.Error(err)
+					return
+				}
+				defer conn.Close()
+				buf := make([]byte, mux.MaxChunkSize)
+				for pb.Next() {
+					conn.SetDeadline(time.Now().Add(benchTimeout))
+					if _, err = conn.Write(buf); err != nil {
+						b.Error(err)
+						return
+					}
+					if _, err = io.ReadFull(conn, buf); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkTunnelConnRate 并发建立短连接，每个连接完成一次请求应答后关闭，每次操作为一个连接
+func BenchmarkTunnelConnRate(b *testing.B) {
+	env := newBenchEnv(b)
+	for _, mode := range env.modeList {
+		b.Run(mode.name, func(b *testing.B) {
+			addr := env.listen(b, mode)
+			var fail atomic.Int64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				buf := make([]byte, 4)
+				for pb.Next() {
+					if err := benchPing(addr, buf); err != nil {
+						fail.Add(1)
+					}
+				}
+			})
+			b.ReportMetric(float64(fail.Load())/float64(b.N), "fails/op")
+		})
+	}
+}
+
+// serveListener 持续接受连接并交给handle处理，监听关闭后退出
+func serveListener(listener net.Listener, handle func(conn net.Conn)) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go handle(conn)
+	}
+}
+
+// benchPing 建立连接发送ping并等待回显
+func benchPing(addr string, buf []byte) error {
+	conn, err := net.DialTimeout("tcp", addr, benchTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(benchTimeout))
+	if _, err = conn.Write([]byte("ping")); err != nil {
+		return err
+	}
+	_, err = io.ReadFull(conn, buf)
+	return err
+}
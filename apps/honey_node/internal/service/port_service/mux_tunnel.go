@@ -0,0 +1,196 @@
+package port_service
+
+// File: honey_node/service/port_service/mux_tunnel.go
+// Description: 端口服务模块，多路复用TCP隧道实现，维护少量长连接gRPC流，每个客户端连接作为一个会话在流上转发，避免大量连接时频繁创建gRPC流
+
+import (
+	"context"
+	"errors"
+	"honey_node/internal/global"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/utils/mux"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultMuxStreamCount = 4                      // 未配置时的多路复用gRPC流数量
+	muxOpenTimeout        = 3 * time.Second        // 打开会话时等待隧道连接就绪的最长时间
+	muxRetryMin           = 1 * time.Second        // 隧道断开后的初始重连间隔
+	muxRetryMax           = 30 * time.Second       // 隧道断开后的最大重连间隔
+	muxPollInterval       = 100 * time.Millisecond // 等待隧道连接就绪的轮询间隔
+)
+
+var (
+	muxPoolOnce    sync.Once // 保证全局连接池只创建一次
+	defaultMuxPool *muxPool  // 全局多路复用隧道连接池，首次使用时创建
+)
+
+// muxPool 多路复用隧道连接池，维护固定数量的gRPC流，新会话分配到承载会话最少的连接
+type muxPool struct {
+	client node_rpc.NodeServiceClient // gRPC服务客户端实例
+	lock   sync.Mutex                 // 连接列表读写锁
+	conns  []*mux.Conn                // 隧道连接列表，断开中的位置为nil
+}
+
+// getMuxPool 获取全局多路复用隧道连接池
+func getMuxPool() *muxPool {
+	muxPoolOnce.Do(func() {
+		count := global.Config.Tunnel.StreamCount
+		if count <= 0 {
+			count = defaultMuxStreamCount
+		}
+		defaultMuxPool = newMuxPool(context.Background(), global.GrpcClient, count)
+	})
+	return defaultMuxPool
+}
+
+// newMuxPool 创建连接池并为每个位置启动连接维护协程
+func newMuxPool(ctx context.Context, client node_rpc.NodeServiceClient, count int) *muxPool {
+	pool := &muxPool{
+		client: client,
+		conns:  make([]*mux.Conn, count),
+	}
+	for i := 0; i < count; i++ {
+		go pool.keep(ctx, i)
+	}
+	return pool
+}
+
+// keep 维护连接池中指定位置的隧道连接，断开后按指数退避重连
+func (p *muxPool) keep(ctx context.Context, index int) {
+	delay := muxRetryMin
+	for ctx.Err() == nil {
+		streamCtx, cancel := context.WithCancel(ctx)
+		stream, err := p.client.MuxTunnel(streamCtx)
+		if err == nil {
+			conn := mux.NewConn(stream, nil)
+			p.set(index, conn)
+			start := time.Now()
+			err = conn.Run()
+			p.set(index, nil)
+			// 连接稳定运行过一段时间后断开，重置重连间隔
+			if time.Since(start) > muxRetryMax {
+				delay = muxRetryMin
+			}
+		}
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		logrus.Warnf("多路复用隧道断开 %d: %v，%s后重连", index, err, delay)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		delay = min(delay*2, muxRetryMax)
+	}
+}
+
+// set 更新指定位置的隧道连接
+func (p *muxPool) set(index int, conn *mux.Conn) {
+	p.lock.Lock()
+	p.conns[index] = conn
+	p.lock.Unlock()
+}
+
+// pick 选择承载会话最少的可用隧道连接
+func (p *muxPool) pick() *mux.Conn {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var best *mux.Conn
+	bestCount := 0
+	for _, conn := range p.conns {
+		if conn == nil {
+			continue
+		}
+		count := conn.SessionCount()
+		if best == nil || count < bestCount {
+			best, bestCount = conn, count
+		}
+	}
+	return best
+}
+
+// open 打开到目标地址的隧道会话，隧道连接尚未就绪时短暂等待
+func (p *muxPool) open(targetAddr, srcAddr, destAddr string) (*mux.Session, error) {
+	deadline := time.Now().Add(muxOpenTimeout)
+	for {
+		if conn := p.pick(); conn != nil {
+			session, err := conn.Open(targetAddr, srcAddr, destAddr)
+			if err == nil || !errors.Is(err, mux.ErrConnClosed) {
+				return session, err
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.New("多路复用隧道未连接")
+		}
+		time.Sleep(muxPollInterval)
+	}
+}
+
+// recordConn 记录会话数据的本地连接包装，多路复用隧道及内置模拟器连接共用
+type recordConn struct {
+	net.Conn
+	session *Session
+}
+
+// Read 读取攻击者发送的数据并记录
+func (c recordConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.session.record(DirectionToServer, p[:n])
+	}
+	return n, err
+}
+
+// Write 记录诱捕服务返回的数据并写入本地连接
+func (c recordConn) Write(p []byte) (int, error) {
+	c.session.record(DirectionToClient, p)
+	return c.Conn.Write(p)
+}
+
+// handleMuxConnection 通过多路复用隧道处理单个客户端连接的双向数据转发
+func handleMuxConnection(pool *muxPool, localConn net.Conn, targetAddr string) {
+	defer localConn.Close() // 函数退出时关闭本地连接
+
+	// 记录本次连接的会话，函数退出时结束并上报
+	session := newConnSession(localConn, targetAddr)
+	defer session.finish()
+
+	// 携带攻击方地址及诱捕地址，供管理端按需向目标服务发送PROXY协议头
+	stream, err := pool.open(targetAddr, localConn.RemoteAddr().String(), localConn.LocalAddr().String())
+	if err != nil {
+		logrus.Infof("创建隧道失败: %v", err)
+		return
+	}
+	conn := recordConn{Conn: localConn, session: session}
+
+	// 本地连接 -> 服务端
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := stream.ReadFrom(conn); err != nil && !isMuxClosed(err) {
+			logrus.Errorf("从本地连接读取失败: %v", err)
+		}
+		stream.Close()
+	}()
+
+	// 服务端 -> 本地连接，对端关闭会话时携带的错误为服务端连接目标失败等原因
+	if _, err := stream.WriteTo(conn); err != nil && !isMuxClosed(err) {
+		logrus.Errorf("隧道转发失败: %v", err)
+	}
+	localConn.Close() // 唤醒上行读取
+	stream.Close()
+	<-done
+}
+
+// isMuxClosed 判断错误是否为会话或连接正常关闭导致，此类错误无需记录
+func isMuxClosed(err error) bool {
+	return errors.Is(err, mux.ErrSessionClosed) || errors.Is(err, mux.ErrConnClosed) || errors.Is(err, net.ErrClosed)
+}
@@ -0,0 +1,177 @@
+package port_service
+
+// File: honey_node/service/port_service/session.go
+// Description: 端口服务模块，负责记录每条接受的连接（TCP隧道连接、UDP来源地址会话及内置模拟器连接）的会话信息（四元组、起止时间、字节数及带时间戳的双向会话记录），会话结束后上报
+
+import (
+	"fmt"
+	"honey_node/internal/service/metrics_service"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxTranscriptSize = 1 << 20         // 单个会话记录的最大载荷字节数，超出后仅统计字节数
+	sessionIndexTTL   = 5 * time.Minute // 会话结束后保留四元组索引的时间，便于延迟到达的告警关联会话
+)
+
+// 会话记录方向
+const (
+	DirectionToServer int8 = 1 // 攻击者 -> 诱捕服务
+	DirectionToClient int8 = 2 // 诱捕服务 -> 攻击者
+)
+
+// TranscriptEntry 会话记录中的单个数据片段
+type TranscriptEntry struct {
+	Time      string `json:"time"`      // 数据片段时间（毫秒精度）
+	Direction int8   `json:"direction"` // 数据方向 1 攻击者->诱捕服务 2 诱捕服务->攻击者
+	Data      []byte `json:"data"`      // 数据片段内容（JSON序列化为base64）
+}
+
+// Session 单条连接的会话信息
+type Session struct {
+	ID         string            `json:"id"`         // 会话唯一标识
+	SrcIp      string            `json:"srcIp"`      // 攻击源IP地址
+	SrcPort    int               `json:"srcPort"`    // 攻击源端口
+	DestIp     string            `json:"destIp"`     // 诱捕IP地址
+	DestPort   int               `json:"destPort"`   // 诱捕端口
+	TargetAddr string            `json:"targetAddr"` // 隧道目标服务地址
+	StartTime  string            `json:"startTime"`  // 会话开始时间
+	EndTime    string            `json:"endTime"`    // 会话结束时间
+	BytesIn    int64             `json:"bytesIn"`    // 攻击者发送的字节数
+	BytesOut   int64             `json:"bytesOut"`   // 诱捕服务返回的字节数
+	Truncated  bool              `json:"truncated"`  // 会话记录是否因超出上限被截断
+	Transcript []TranscriptEntry `json:"transcript"` // 带时间戳的双向会话记录
+
+	lock          sync.Mutex // 双向转发协程并发写入时加锁
+	transcriptLen int        // 已记录的载荷字节数
+}
+
+// sessionReporter 会话上报函数，由启动流程注册（避免与mq_service循环依赖）
+var sessionReporter func(session *Session)
+
+// SetSessionReporter 注册会话上报函数
+func SetSessionReporter(fn func(session *Session)) {
+	sessionReporter = fn
+}
+
+// sessionIndex 四元组到会话ID的映射，供告警关联会话使用
+var sessionIndex = sync.Map{}
+
+// activeSessions 进行中的会话，会话ID -> *Session
+var activeSessions = sync.Map{}
+
+// sessionKey 生成会话四元组索引key
+func sessionKey(srcIp string, srcPort int, destIp string, destPort int) string {
+	return fmt.Sprintf("%s:%d-%s:%d", srcIp, srcPort, destIp, destPort)
+}
+
+// LookupSession 根据四元组查找会话ID，兼容告警方向与连接方向相反的情况，未找到时返回空字符串
+func LookupSession(srcIp string, srcPort int, destIp string, destPort int) string {
+	if value, ok := sessionIndex.Load(sessionKey(srcIp, srcPort, destIp, destPort)); ok {
+		return value.(string)
+	}
+	if value, ok := sessionIndex.Load(sessionKey(destIp, destPort, srcIp, srcPort)); ok {
+		return value.(string)
+	}
+	return ""
+}
+
+// newSession 为新接受的客户端连接创建会话并登记四元组索引
+func newSession(srcIp string, srcPort int, destIp string, destPort int, targetAddr string) *Session {
+	session := &Session{
+		ID:         uuid.New().String(),
+		SrcIp:      srcIp,
+		SrcPort:    srcPort,
+		DestIp:     destIp,
+		DestPort:   destPort,
+		TargetAddr: targetAddr,
+		StartTime:  time.Now().Format(time.DateTime),
+		Transcript: make([]TranscriptEntry, 0),
+	}
+	sessionIndex.Store(sessionKey(srcIp, srcPort, destIp, destPort), session.ID)
+	activeSessions.Store(session.ID, session)
+	metrics_service.TunnelSessions.Inc()
+	metrics_service.TunnelSessionTotal.WithLabelValues(strconv.Itoa(destPort)).Inc()
+	return session
+}
+
+// record 记录一个方向上的数据片段
+func (s *Session) record(direction int8, data []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// 会话已结束（UDP会话关闭时转发协程可能仍在退出中），不再记录，避免与上报时的序列化并发
+	if s.EndTime != "" {
+		return
+	}
+
+	if direction == DirectionToServer {
+		s.BytesIn += int64(len(data))
+		metrics_service.TunnelBytes.WithLabelValues(strconv.Itoa(s.DestPort), "in").Add(float64(len(data)))
+	} else {
+		s.BytesOut += int64(len(data))
+		metrics_service.TunnelBytes.WithLabelValues(strconv.Itoa(s.DestPort), "out").Add(float64(len(data)))
+	}
+
+	// 超出上限后不再记录载荷，避免单个会话占用过多内存
+	if s.transcriptLen+len(data) > maxTranscriptSize {
+		s.Truncated = true
+		return
+	}
+	s.transcriptLen += len(data)
+
+	// 读取缓冲区会被复用，记录前拷贝一份
+	chunk := make([]byte, len(data))
+	copy(chunk, data)
+	s.Transcript = append(s.Transcript, TranscriptEntry{
+		Time:      time.Now().Format("2006-01-02 15:04:05.000"),
+		Direction: direction,
+		Data:      chunk,
+	})
+}
+
+// finish 结束会话并上报，四元组索引延迟清理
+func (s *Session) finish() {
+	s.lock.Lock()
+	s.EndTime = time.Now().Format(time.DateTime)
+	s.lock.Unlock()
+	activeSessions.Delete(s.ID)
+	metrics_service.TunnelSessions.Dec()
+
+	key := sessionKey(s.SrcIp, s.SrcPort, s.DestIp, s.DestPort)
+	time.AfterFunc(sessionIndexTTL, func() {
+		sessionIndex.CompareAndDelete(key, s.ID)
+	})
+
+	if sessionReporter != nil {
+		sessionReporter(s)
+	}
+}
+
+// newConnSession 根据客户端连接的地址信息创建会话
+func newConnSession(conn net.Conn, targetAddr string) *Session {
+	return newAddrSession(conn.RemoteAddr(), conn.LocalAddr(), targetAddr)
+}
+
+// newAddrSession 根据来源地址及本地监听地址创建会话
+func newAddrSession(src, dest net.Addr, targetAddr string) *Session {
+	srcIp, srcPort := addrIpPort(src)
+	destIp, destPort := addrIpPort(dest)
+	return newSession(srcIp, srcPort, destIp, destPort, targetAddr)
+}
+
+// addrIpPort 提取TCP/UDP地址的IP及端口
+func addrIpPort(addr net.Addr) (string, int) {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		return a.IP.String(), a.Port
+	case *net.UDPAddr:
+		return a.IP.String(), a.Port
+	}
+	return "", 0
+}
@@ -0,0 +1,99 @@
+package port_service
+
+// File: honey_node/service/port_service/status.go
+// Description: 端口服务运行状态查询，返回当前的端口监听及进行中的会话，供节点诊断使用
+
+import (
+	"net"
+	"sort"
+	"strconv"
+)
+
+// ListenerInfo 端口监听运行状态
+type ListenerInfo struct {
+	Agreement  int8   `json:"agreement"`  // 通信协议 1 TCP 2 UDP
+	LocalAddr  string `json:"localAddr"`  // 本地监听地址
+	TargetAddr string `json:"targetAddr"` // 目标服务地址（TCP监听不记录，由调用方按端口记录补充）
+	Sessions   int    `json:"sessions"`   // 当前会话数
+}
+
+// SessionInfo 进行中的TCP会话摘要（不含会话记录内容）
+type SessionInfo struct {
+	ID         string `json:"id"`         // 会话唯一标识
+	SrcIp      string `json:"srcIp"`      // 攻击源IP地址
+	SrcPort    int    `json:"srcPort"`    // 攻击源端口
+	DestIp     string `json:"destIp"`     // 诱捕IP地址
+	DestPort   int    `json:"destPort"`   // 诱捕端口
+	TargetAddr string `json:"targetAddr"` // 隧道目标服务地址
+	StartTime  string `json:"startTime"`  // 会话开始时间
+	BytesIn    int64  `json:"bytesIn"`    // 攻击者发送的字节数
+	BytesOut   int64  `json:"bytesOut"`   // 诱捕服务返回的字节数
+}
+
+// ActiveSessionList 获取进行中的TCP会话，按开始时间排列
+func ActiveSessionList() []SessionInfo {
+	list := make([]SessionInfo, 0)
+	activeSessions.Range(func(_, value any) bool {
+		s := value.(*Session)
+		s.lock.Lock()
+		list = append(list, SessionInfo{
+			ID:         s.ID,
+			SrcIp:      s.SrcIp,
+			SrcPort:    s.SrcPort,
+			DestIp:     s.DestIp,
+			DestPort:   s.DestPort,
+			TargetAddr: s.TargetAddr,
+			StartTime:  s.StartTime,
+			BytesIn:    s.BytesIn,
+			BytesOut:   s.BytesOut,
+		})
+		s.lock.Unlock()
+		return true
+	})
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].StartTime < list[j].StartTime
+	})
+	return list
+}
+
+// ListenerList 获取当前的TCP及UDP端口监听，按协议及地址排列
+func ListenerList() []ListenerInfo {
+	// 按诱捕地址统计进行中的TCP会话数
+	sessionCount := map[string]int{}
+	for _, s := range ActiveSessionList() {
+		sessionCount[net.JoinHostPort(s.DestIp, strconv.Itoa(s.DestPort))]++
+	}
+
+	list := make([]ListenerInfo, 0)
+	tunnelStore.Range(func(key, _ any) bool {
+		localAddr := key.(string)
+		list = append(list, ListenerInfo{
+			Agreement: 1,
+			LocalAddr: localAddr,
+			Sessions:  sessionCount[localAddr],
+		})
+		return true
+	})
+	udpTunnelStore.Range(func(key, value any) bool {
+		listener := value.(*udpListener)
+		count := 0
+		listener.sessions.Range(func(_, _ any) bool {
+			count++
+			return true
+		})
+		list = append(list, ListenerInfo{
+			Agreement:  2,
+			LocalAddr:  key.(string),
+			TargetAddr: listener.targetAddr,
+			Sessions:   count,
+		})
+		return true
+	})
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Agreement != list[j].Agreement {
+			return list[i].Agreement < list[j].Agreement
+		}
+		return list[i].LocalAddr < list[j].LocalAddr
+	})
+	return list
+}
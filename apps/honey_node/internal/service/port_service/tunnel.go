@@ -5,9 +5,11 @@ package port_service
 
 import (
 	"context"
+	"fmt"
 	"honey_node/internal/global"
 	"honey_node/internal/models"
 	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/emulator_service"
 	"io"
 	"net"
 	"strings"
@@ -19,6 +21,23 @@ import (
 // tunnelStore 存储端口监听实例的并发安全映射，key为本地监听地址，value为对应的Listener
 var tunnelStore = sync.Map{}
 
+// BindTunnel 按通信协议创建端口隧道（2 UDP，其余按TCP处理）
+func BindTunnel(agreement int8, localAddr, targetAddr string) error {
+	if name, ok := emulator_service.Parse(targetAddr); ok {
+		// 内置模拟器仅支持TCP
+		if agreement == 2 {
+			return fmt.Errorf("模拟器 %s 不支持UDP", name)
+		}
+		if err := emulator_service.Check(name); err != nil {
+			return err
+		}
+	}
+	if agreement == 2 {
+		return UdpTunnel(localAddr, targetAddr)
+	}
+	return Tunnel(localAddr, targetAddr)
+}
+
 // Tunnel 创建本地TCP监听并建立到目标地址的隧道
 func Tunnel(localAddr, targetAddr string) (err error) {
 	// 创建本地TCP监听
@@ -26,11 +45,7 @@ func Tunnel(localAddr, targetAddr string) (err error) {
 	if err != nil {
 		logrus.Errorf("创建本地监听失败: %v", err)
 		// 删除数据库中对应的记录
-		var model models.PortModel
-		global.DB.Where("local_addr = ?", localAddr).First(&model)
-		if model.ID != 0 {
-			global.DB.Delete(&model)
-		}
+		global.DB.Where("local_addr = ? and agreement <> ?", localAddr, 2).Delete(&models.PortModel{})
 		return err
 	}
 	logrus.Infof("本地监听启动，地址: %s", localAddr)
@@ -39,6 +54,8 @@ func Tunnel(localAddr, targetAddr string) (err error) {
 
 	// 持续接受客户端连接
 	go func() {
+		// 监听关闭后移除存储（同一地址可能已重新绑定新的监听实例）
+		defer tunnelStore.CompareAndDelete(localAddr, listener)
 		for {
 			clientConn, err := listener.Accept()
 			if err != nil {
@@ -49,17 +66,44 @@ func Tunnel(localAddr, targetAddr string) (err error) {
 				break
 			}
 
-			// 为每个连接创建一个goroutine处理
+			// 按诱捕端口、诱捕ip及来源ip执行连接限制，超出限制的连接已按配置处理
+			clientConn = limiter.accept(clientConn)
+			if clientConn == nil {
+				continue
+			}
+
+			// 目标为内置模拟器时由节点本地处理，无需经过管理端隧道
+			if name, ok := emulator_service.Parse(targetAddr); ok {
+				go serveEmulator(name, clientConn, targetAddr)
+				continue
+			}
+
+			// 为每个连接创建一个goroutine处理，开启多路复用时连接作为会话共用隧道gRPC流
+			if global.Config.Tunnel.Mux {
+				go handleMuxConnection(getMuxPool(), clientConn, targetAddr)
+				continue
+			}
 			go handleConnection(global.GrpcClient, clientConn, targetAddr)
 		}
 	}()
 	return nil
 }
 
+// serveEmulator 由内置模拟器处理客户端连接，与隧道连接一样记录并上报会话
+func serveEmulator(name string, conn net.Conn, targetAddr string) {
+	session := newConnSession(conn, targetAddr)
+	defer session.finish()
+	emulator_service.Serve(name, recordConn{Conn: conn, session: session})
+}
+
 // handleConnection 处理单个客户端连接的双向数据转发
 func handleConnection(client node_rpc.NodeServiceClient, localConn net.Conn, targetAddr string) {
 	defer localConn.Close() // 函数退出时关闭本地连接
 
+	// 记录本次连接的会话，函数退出时结束并上报
+	session := newConnSession(localConn, targetAddr)
+	defer session.finish()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel() // 确保上下文被取消，释放资源
 
@@ -70,10 +114,12 @@ func handleConnection(client node_rpc.NodeServiceClient, localConn net.Conn, tar
 		return
 	}
 
-	// 发送初始隧道配置消息，携带目标地址信息
+	// 发送初始隧道配置消息，携带目标地址及攻击方地址、诱捕地址（供管理端按需发送PROXY协议头）
 	if err := stream.Send(&node_rpc.TunnelData{
-		Chunk:   []byte{},
-		Address: targetAddr,
+		Chunk:    []byte{},
+		Address:  targetAddr,
+		SrcAddr:  localConn.RemoteAddr().String(),
+		DestAddr: localConn.LocalAddr().String(),
 	}); err != nil {
 		logrus.Errorf("发送初始请求失败: %v", err)
 		return
@@ -106,6 +152,7 @@ func handleConnection(client node_rpc.NodeServiceClient, localConn net.Conn, tar
 				}
 
 				// 将RPC接收的数据写入本地客户端连接
+				session.record(DirectionToClient, resp.Chunk)
 				_, err = localConn.Write(resp.Chunk)
 				if err != nil {
 					if ctx.Err() == nil {
@@ -141,6 +188,7 @@ func handleConnection(client node_rpc.NodeServiceClient, localConn net.Conn, tar
 				}
 
 				// 将本地读取的数据通过RPC发送到目标服务
+				session.record(DirectionToServer, buffer[:n])
 				err = stream.Send(&node_rpc.TunnelData{
 					Chunk:   buffer[:n],
 					Address: targetAddr,
@@ -166,17 +214,60 @@ func CloseIpTunnel(ip string) {
 	// 遍历所有TunnelStore中的隧道
 	tunnelStore.Range(func(key, value any) bool {
 		localAddr := key.(string)
-		// 判断当前隧道的localAddr是否以指定IP开头
-		if strings.HasPrefix(localAddr, ip) {
-			var model models.PortModel
-			global.DB.Find(&model, "local_addr = ?", localAddr)
-			if model.ID != 0 {
-				global.DB.Delete(&model)
-			}
+		// 判断当前隧道的localAddr是否监听在指定IP上
+		if addrOnIP(localAddr, ip) {
+			global.DB.Where("local_addr = ? and agreement <> ?", localAddr, 2).Delete(&models.PortModel{})
 			logrus.Infof("清除%s上的全部服务", ip)
 			listener := value.(net.Listener)
+			tunnelStore.Delete(localAddr)
 			listener.Close() // 关闭监听实例，终止端口服务
 		}
 		return true
 	})
+
+	// 遍历udpTunnelStore中的UDP隧道
+	udpTunnelStore.Range(func(key, value any) bool {
+		localAddr := key.(string)
+		if addrOnIP(localAddr, ip) {
+			global.DB.Where("local_addr = ? and agreement = ?", localAddr, 2).Delete(&models.PortModel{})
+			logrus.Infof("清除%s上的UDP服务 %s", ip, localAddr)
+			udpTunnelStore.Delete(localAddr)
+			value.(*udpListener).close() // 关闭监听及全部会话
+		}
+		return true
+	})
+}
+
+// addrOnIP 判断监听地址（ip:端口，IPv6为[ip]:端口）的主机部分是否为指定IP
+func addrOnIP(localAddr string, ip string) bool {
+	host, _, err := net.SplitHostPort(localAddr)
+	if err != nil {
+		return false
+	}
+	return host == ip
+}
+
+// CloseTunnel 关闭指定本地地址上的端口监听并删除对应的端口记录
+func CloseTunnel(agreement int8, localAddr string) {
+	if agreement == 2 {
+		global.DB.Where("local_addr = ? and agreement = ?", localAddr, 2).Delete(&models.PortModel{})
+		if value, ok := udpTunnelStore.LoadAndDelete(localAddr); ok {
+			value.(*udpListener).close()
+		}
+		return
+	}
+	global.DB.Where("local_addr = ? and agreement <> ?", localAddr, 2).Delete(&models.PortModel{})
+	if value, ok := tunnelStore.LoadAndDelete(localAddr); ok {
+		value.(net.Listener).Close()
+	}
+}
+
+// TunnelRunning 判断指定本地地址上的端口监听是否在运行
+func TunnelRunning(agreement int8, localAddr string) bool {
+	if agreement == 2 {
+		_, ok := udpTunnelStore.Load(localAddr)
+		return ok
+	}
+	_, ok := tunnelStore.Load(localAddr)
+	return ok
 }
@@ -0,0 +1,260 @@
+package port_service
+
+// File: honey_node/service/port_service/udp_tunnel.go
+// Description: 端口服务模块，负责本地UDP端口监听、按来源地址维护会话，并通过RPC数据报隧道转发到目标服务
+
+import (
+	"context"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/rpc/node_rpc"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	udpSessionIdleTimeout = 60 * time.Second // UDP会话空闲超时时间，超时后释放对应的RPC流
+	udpSessionCheckPeriod = 10 * time.Second // 空闲会话检查周期
+	udpMaxDatagramSize    = 65535            // UDP最大报文长度
+	udpMaxSessions        = 1024             // 单个监听的会话数上限，超出时淘汰最久未活跃的会话
+	udpSessionQueueSize   = 64               // 单个会话待发送数据报的队列长度，队列满时丢弃新数据报
+)
+
+// udpTunnelStore 存储UDP监听实例的并发安全映射，key为本地监听地址，value为对应的udpListener
+var udpTunnelStore = sync.Map{}
+
+// udpListener 单个UDP监听实例，维护该端口上所有来源地址的会话
+type udpListener struct {
+	conn         net.PacketConn // 本地UDP监听
+	targetAddr   string         // 目标服务地址
+	sessions     sync.Map       // 来源地址 -> *udpSession
+	sessionCount atomic.Int64   // 当前会话数
+	done         chan struct{}  // 监听关闭信号
+	closeOnce    sync.Once      // 保证只关闭一次
+}
+
+// udpSession 单个来源地址的UDP会话，对应一条RPC数据报隧道
+type udpSession struct {
+	peer       net.Addr           // 来源地址
+	queue      chan []byte        // 待发送的数据报，由会话协程建立RPC隧道后依次发送
+	ctx        context.Context    // 会话上下文，取消后关闭RPC隧道
+	cancel     context.CancelFunc // 关闭会话
+	lastActive atomic.Int64       // 最后活跃时间（UnixNano）
+	recorder   *Session           // 会话记录，会话关闭时结束并上报
+}
+
+// UdpTunnel 创建本地UDP监听并建立到目标地址的数据报隧道
+func UdpTunnel(localAddr, targetAddr string) (err error) {
+	conn, err := net.ListenPacket("udp", localAddr)
+	if err != nil {
+		logrus.Errorf("创建本地UDP监听失败: %v", err)
+		// 删除数据库中对应的记录
+		global.DB.Where("local_addr = ? and agreement = ?", localAddr, 2).Delete(&models.PortModel{})
+		return err
+	}
+	logrus.Infof("本地UDP监听启动，地址: %s", localAddr)
+	logrus.Infof("目标地址: %s", targetAddr)
+
+	listener := &udpListener{
+		conn:       conn,
+		targetAddr: targetAddr,
+		done:       make(chan struct{}),
+	}
+	udpTunnelStore.Store(localAddr, listener) // 将监听实例存入全局存储
+
+	go listener.expire()
+	go func() {
+		listener.serve()
+		// 监听关闭后移除存储（同一地址可能已重新绑定新的监听实例）
+		udpTunnelStore.CompareAndDelete(localAddr, listener)
+	}()
+	return nil
+}
+
+// serve 持续读取本地数据报，按来源地址分发到对应会话
+// 读取循环只做入队，RPC隧道的建立及发送均在会话协程中完成，单个会话阻塞不影响其他来源地址
+func (l *udpListener) serve() {
+	defer l.close()
+
+	buffer := make([]byte, udpMaxDatagramSize)
+	for {
+		n, peer, err := l.conn.ReadFrom(buffer)
+		if err != nil {
+			if !strings.Contains(err.Error(), "closed") {
+				logrus.Errorf("读取UDP数据报失败: %v", err)
+			}
+			return
+		}
+
+		// 数据报在下一次读取时会被覆盖，入队前拷贝一份
+		datagram := make([]byte, n)
+		copy(datagram, buffer[:n])
+		l.session(peer).enqueue(datagram)
+	}
+}
+
+// session 获取来源地址对应的会话，不存在时新建会话并启动会话协程，会话数达到上限时先淘汰最久未活跃的会话
+func (l *udpListener) session(peer net.Addr) *udpSession {
+	key := peer.String()
+	if value, ok := l.sessions.Load(key); ok {
+		return value.(*udpSession)
+	}
+	if l.sessionCount.Load() >= udpMaxSessions {
+		l.evictOldest()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &udpSession{
+		peer:     peer,
+		queue:    make(chan []byte, udpSessionQueueSize),
+		ctx:      ctx,
+		cancel:   cancel,
+		recorder: newAddrSession(peer, l.conn.LocalAddr(), l.targetAddr),
+	}
+	session.lastActive.Store(time.Now().UnixNano())
+	l.sessions.Store(key, session)
+	l.sessionCount.Add(1)
+	logrus.Infof("新建UDP会话 %s -> %s", peer, l.targetAddr)
+
+	go l.run(key, session)
+	return session
+}
+
+// run 会话协程：建立RPC数据报隧道，将队列中的数据报发送到服务端，并把服务端返回的数据报写回来源地址
+func (l *udpListener) run(key string, session *udpSession) {
+	defer l.removeSession(key, session)
+
+	stream, err := global.GrpcClient.UdpTunnel(session.ctx)
+	if err != nil {
+		if session.ctx.Err() == nil {
+			logrus.Errorf("创建UDP会话隧道失败 %s: %v", key, err)
+		}
+		return
+	}
+
+	// 处理RPC服务端到来源地址的数据报转发
+	go func() {
+		defer l.removeSession(key, session)
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF { // 服务端会话结束
+				return
+			}
+			if err != nil {
+				if session.ctx.Err() == nil {
+					logrus.Errorf("接收gRPC服务器数据报失败: %v", err)
+				}
+				return
+			}
+			session.lastActive.Store(time.Now().UnixNano())
+			session.recorder.record(DirectionToClient, resp.Datagram)
+			if _, err = l.conn.WriteTo(resp.Datagram, session.peer); err != nil {
+				if session.ctx.Err() == nil {
+					logrus.Errorf("写入UDP数据报失败: %v", err)
+				}
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-session.ctx.Done():
+			stream.CloseSend()
+			return
+		case datagram := <-session.queue:
+			err = stream.Send(&node_rpc.UdpTunnelData{
+				Datagram: datagram,
+				Address:  l.targetAddr,
+				Peer:     key,
+			})
+			if err != nil {
+				if session.ctx.Err() == nil {
+					logrus.Errorf("发送数据报到gRPC服务器失败: %v", err)
+				}
+				return
+			}
+		}
+	}
+}
+
+// enqueue 将数据报放入会话发送队列，会话已关闭或队列已满时丢弃（与UDP本身的丢包语义一致）
+func (s *udpSession) enqueue(datagram []byte) {
+	s.lastActive.Store(time.Now().UnixNano())
+	s.recorder.record(DirectionToServer, datagram)
+	select {
+	case s.queue <- datagram:
+	default:
+	}
+}
+
+// evictOldest 淘汰最久未活跃的会话
+func (l *udpListener) evictOldest() {
+	var oldestKey string
+	var oldest *udpSession
+	l.sessions.Range(func(key, value any) bool {
+		session := value.(*udpSession)
+		if oldest == nil || session.lastActive.Load() < oldest.lastActive.Load() {
+			oldestKey, oldest = key.(string), session
+		}
+		return true
+	})
+	if oldest != nil {
+		logrus.Warnf("UDP会话数达到上限 %d，淘汰会话 %s", udpMaxSessions, oldestKey)
+		l.removeSession(oldestKey, oldest)
+	}
+}
+
+// removeSession 移除并关闭指定会话（仅当映射中仍是同一会话时）
+func (l *udpListener) removeSession(key string, session *udpSession) {
+	if l.sessions.CompareAndDelete(key, session) {
+		l.sessionCount.Add(-1)
+		session.cancel()
+		session.recorder.finish()
+		logrus.Infof("关闭UDP会话 %s -> %s", key, l.targetAddr)
+	}
+}
+
+// closeSessions 关闭监听上的全部会话
+func (l *udpListener) closeSessions() {
+	l.sessions.Range(func(key, value any) bool {
+		l.removeSession(key.(string), value.(*udpSession))
+		return true
+	})
+}
+
+// expire 定期清理空闲超时的会话，监听关闭后退出
+func (l *udpListener) expire() {
+	ticker := time.NewTicker(udpSessionCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+		}
+		deadline := time.Now().Add(-udpSessionIdleTimeout).UnixNano()
+		l.sessions.Range(func(key, value any) bool {
+			session := value.(*udpSession)
+			if session.lastActive.Load() < deadline {
+				l.removeSession(key.(string), session)
+			}
+			return true
+		})
+	}
+}
+
+// close 关闭UDP监听及其全部会话
+func (l *udpListener) close() {
+	l.closeOnce.Do(func() {
+		close(l.done)
+		l.conn.Close()
+		l.closeSessions()
+	})
+}
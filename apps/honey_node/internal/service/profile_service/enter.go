@@ -0,0 +1,44 @@
+package profile_service
+
+// File: honey_node/service/profile_service/enter.go
+// Description: 设备画像MAC地址生成，按主机模板的设备画像从OUI数据库选取对应厂商的OUI，以设备画像和诱捕IP为种子生成固定的MAC地址，重复部署时MAC地址保持不变
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"honey_node/internal/core"
+	"honey_node/internal/models"
+	"net"
+)
+
+// VendorMac 按设备画像及其厂商信息为诱捕IP生成MAC地址，设备画像为空时返回空字符串（由系统随机分配）
+// 设备画像列表由管理端维护，厂商信息随部署指令下发
+func VendorMac(profile string, vendor models.DeviceVendor, ip string) (string, error) {
+	if profile == "" {
+		return "", nil
+	}
+
+	var ouiList []string
+	for _, name := range vendor.VendorList {
+		ouiList = append(ouiList, core.ManufOUIList(name)...)
+	}
+	if len(ouiList) == 0 {
+		ouiList = vendor.FallbackOUI
+	}
+	if len(ouiList) == 0 {
+		return "", fmt.Errorf("设备画像 %s 缺少厂商信息", profile)
+	}
+
+	// 以设备画像和诱捕IP为种子，同一IP每次生成的MAC地址相同
+	sum := sha256.Sum256([]byte(profile + "|" + ip))
+	oui := ouiList[binary.BigEndian.Uint32(sum[0:4])%uint32(len(ouiList))]
+	if len(oui) != 6 {
+		return "", fmt.Errorf("设备画像 %s 的OUI %s 格式错误", profile, oui)
+	}
+	mac, err := net.ParseMAC(fmt.Sprintf("%s:%s:%s:%02x:%02x:%02x", oui[0:2], oui[2:4], oui[4:6], sum[4], sum[5], sum[6]))
+	if err != nil {
+		return "", fmt.Errorf("生成MAC地址失败 %s", err)
+	}
+	return mac.String(), nil
+}
@@ -0,0 +1,165 @@
+package rule_service
+
+// File: honey_node/service/rule_service/enter.go
+// Description: Suricata规则集应用模块，写入管理端下发的规则文件及阈值配置，通过unix socket重载Suricata并返回加载结果，重载失败时回滚到原有规则
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/rpc/node_rpc"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxErrCount 上报的加载失败规则最大条数
+const maxErrCount = 50
+
+// lock 保证同一时间只有一次规则下发在执行
+var lock sync.Mutex
+
+// ActiveState 节点当前生效的规则集版本，保存在规则文件同目录下
+type ActiveState struct {
+	RuleSetID uint32 `json:"ruleSetID"` // 规则集id
+	Version   string `json:"version"`   // 规则集版本
+	Hash      string `json:"hash"`      // 规则内容摘要
+}
+
+// statePath 生效版本记录文件路径
+func statePath() string {
+	return global.Config.Suricata.RulePath + ".version"
+}
+
+// GetActiveState 读取当前生效的规则集版本
+func GetActiveState() (state ActiveState) {
+	byteData, err := os.ReadFile(statePath())
+	if err != nil {
+		return
+	}
+	json.Unmarshal(byteData, &state)
+	return
+}
+
+// Apply 写入下发的规则集并重载Suricata，返回当前生效的版本及加载结果
+func Apply(req *node_rpc.RuleSetInMessage) *node_rpc.RuleSetOutMessage {
+	lock.Lock()
+	defer lock.Unlock()
+
+	cfg := global.Config.Suricata
+	res := new(node_rpc.RuleSetOutMessage)
+	fail := func(msg string) *node_rpc.RuleSetOutMessage {
+		state := GetActiveState()
+		res.RuleSetID = state.RuleSetID
+		res.Version = state.Version
+		res.Hash = state.Hash
+		res.ErrMsg = msg
+		logrus.Errorf("规则集 %s 下发失败 %s", req.Version, msg)
+		return res
+	}
+	if cfg.RulePath == "" || cfg.SocketPath == "" {
+		return fail("节点未配置suricata规则路径")
+	}
+
+	// 解压并校验规则内容
+	zr, err := gzip.NewReader(bytes.NewReader(req.Rules))
+	if err != nil {
+		return fail(fmt.Sprintf("解压规则文件失败 %s", err))
+	}
+	rules, err := io.ReadAll(zr)
+	if err != nil {
+		return fail(fmt.Sprintf("解压规则文件失败 %s", err))
+	}
+	h := sha256.New()
+	h.Write(rules)
+	h.Write([]byte(req.Threshold))
+	if fmt.Sprintf("%x", h.Sum(nil)) != req.Hash {
+		return fail("规则内容校验失败")
+	}
+
+	// 备份原有规则文件，重载失败时回滚
+	oldRules, rulesErr := os.ReadFile(cfg.RulePath)
+	oldThreshold, thresholdErr := os.ReadFile(cfg.ThresholdPath)
+	rollback := func() {
+		if rulesErr == nil {
+			writeFile(cfg.RulePath, oldRules)
+		}
+		if cfg.ThresholdPath != "" && thresholdErr == nil {
+			writeFile(cfg.ThresholdPath, oldThreshold)
+		}
+	}
+
+	if err = writeFile(cfg.RulePath, rules); err != nil {
+		return fail(fmt.Sprintf("写入规则文件失败 %s", err))
+	}
+	if cfg.ThresholdPath != "" {
+		if err = writeFile(cfg.ThresholdPath, []byte(req.Threshold)); err != nil {
+			rollback()
+			return fail(fmt.Sprintf("写入阈值配置失败 %s", err))
+		}
+	}
+
+	if err = reloadRules(cfg.SocketPath); err != nil {
+		rollback()
+		// 回滚后再次重载，确保Suricata使用的是原有规则
+		if err1 := reloadRules(cfg.SocketPath); err1 != nil {
+			logrus.Errorf("回滚规则后重载失败 %s", err1)
+		}
+		return fail(fmt.Sprintf("重载规则失败 %s", err))
+	}
+
+	// 记录生效版本
+	state := ActiveState{
+		RuleSetID: req.RuleSetID,
+		Version:   req.Version,
+		Hash:      req.Hash,
+	}
+	byteData, _ := json.Marshal(state)
+	if err = writeFile(statePath(), byteData); err != nil {
+		logrus.Errorf("保存规则集版本失败 %s", err)
+	}
+	res.RuleSetID = state.RuleSetID
+	res.Version = state.Version
+	res.Hash = state.Hash
+
+	// 查询加载结果
+	loaded, failed, err := getRulesetStats(cfg.SocketPath)
+	if err != nil {
+		logrus.Warnf("查询规则加载统计失败 %s", err)
+	}
+	res.RulesLoaded = int32(loaded)
+	res.RulesFailed = int32(failed)
+	if failed > 0 {
+		failedList, err := getFailedRules(cfg.SocketPath)
+		if err != nil {
+			logrus.Warnf("查询加载失败的规则失败 %s", err)
+		}
+		for _, rule := range failedList {
+			if len(res.ErrList) >= maxErrCount {
+				break
+			}
+			res.ErrList = append(res.ErrList, fmt.Sprintf("%s:%d %s", filepath.Base(rule.Filename), rule.Line, rule.Rule))
+		}
+	}
+
+	logrus.Infof("规则集 %s 下发完成 加载成功 %d 加载失败 %d", req.Version, loaded, failed)
+	return res
+}
+
+// writeFile 先写临时文件再重命名，避免Suricata读取到写入中的文件
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
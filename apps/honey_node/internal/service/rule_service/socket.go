@@ -0,0 +1,121 @@
+package rule_service
+
+// File: honey_node/service/rule_service/socket.go
+// Description: Suricata unix socket客户端，完成协议版本协商并发送控制命令（重载规则、查询规则加载统计、查询运行时长）
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// socketTimeout 单次命令的超时时间，规则较多时重载耗时较长
+const socketTimeout = 100 * time.Second
+
+// socketResponse Suricata unix socket命令响应
+type socketResponse struct {
+	Return  string          `json:"return"`  // 执行结果 OK NOK
+	Message json.RawMessage `json:"message"` // 响应内容
+}
+
+// rulesetStats ruleset-stats命令返回的单个检测引擎规则统计
+type rulesetStats struct {
+	ID          int `json:"id"`           // 检测引擎ID
+	RulesLoaded int `json:"rules_loaded"` // 加载成功的规则数
+	RulesFailed int `json:"rules_failed"` // 加载失败的规则数
+}
+
+// failedRule ruleset-failed-rules命令返回的加载失败规则
+type failedRule struct {
+	Rule     string `json:"rule"`     // 规则内容
+	Filename string `json:"filename"` // 规则文件
+	Line     int    `json:"line"`     // 行号
+}
+
+// suricataCommand 连接Suricata unix socket并执行单条命令
+func suricataCommand(socketPath string, command string) (json.RawMessage, error) {
+	conn, err := net.DialTimeout("unix", socketPath, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("连接suricata socket失败 %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(socketTimeout))
+
+	decoder := json.NewDecoder(conn)
+	call := func(req any) (json.RawMessage, error) {
+		byteData, _ := json.Marshal(req)
+		if _, err := conn.Write(byteData); err != nil {
+			return nil, err
+		}
+		var res socketResponse
+		if err := decoder.Decode(&res); err != nil {
+			return nil, err
+		}
+		if res.Return != "OK" {
+			return nil, fmt.Errorf("%s", res.Message)
+		}
+		return res.Message, nil
+	}
+
+	// 协议版本协商
+	if _, err = call(map[string]string{"version": "0.2"}); err != nil {
+		return nil, fmt.Errorf("suricata socket协商失败 %w", err)
+	}
+	msg, err := call(map[string]string{"command": command})
+	if err != nil {
+		return nil, fmt.Errorf("执行suricata命令 %s 失败 %w", command, err)
+	}
+	return msg, nil
+}
+
+// reloadRules 重载规则（阻塞至重载完成）
+func reloadRules(socketPath string) error {
+	_, err := suricataCommand(socketPath, "reload-rules")
+	return err
+}
+
+// getRulesetStats 查询规则加载统计，多个检测引擎时累加
+func getRulesetStats(socketPath string) (loaded int, failed int, err error) {
+	msg, err := suricataCommand(socketPath, "ruleset-stats")
+	if err != nil {
+		return 0, 0, err
+	}
+	var list []rulesetStats
+	if err = json.Unmarshal(msg, &list); err != nil {
+		return 0, 0, errors.New("解析规则加载统计失败")
+	}
+	for _, stats := range list {
+		loaded += stats.RulesLoaded
+		failed += stats.RulesFailed
+	}
+	return
+}
+
+// getFailedRules 查询加载失败的规则
+func getFailedRules(socketPath string) ([]failedRule, error) {
+	msg, err := suricataCommand(socketPath, "ruleset-failed-rules")
+	if err != nil {
+		return nil, err
+	}
+	var list []failedRule
+	// 没有失败规则时返回的是提示字符串，按无失败处理
+	if err = json.Unmarshal(msg, &list); err != nil {
+		return nil, nil
+	}
+	return list, nil
+}
+
+// SuricataUptime 查询Suricata运行时长（秒），用于判断Suricata是否存活
+func SuricataUptime(socketPath string) (int, error) {
+	msg, err := suricataCommand(socketPath, "uptime")
+	if err != nil {
+		return 0, err
+	}
+	var uptime int
+	if err = json.Unmarshal(msg, &uptime); err != nil {
+		return 0, errors.New("解析suricata运行时长失败")
+	}
+	return uptime, nil
+}
@@ -1,20 +1,25 @@
 package suricata_service
 
 // File: honey_node/service/suricata_service/enter.go
-// Description: Suricata告警日志处理模块，负责实时监听Suricata的Eve日志文件，解析告警数据并输出关键信息
+// Description: Suricata告警日志处理模块，负责持续读取Suricata的Eve日志文件，解析告警数据并输出关键信息，非告警事件交由事件处理上报
 
 import (
+	"crypto/sha1"
 	"encoding/json"
+	"fmt"
 	"honey_node/internal/global"
+	"honey_node/internal/service/metrics_service"
 	"honey_node/internal/service/mq_service"
-	"io"
+	"honey_node/internal/service/port_service"
 	"strconv"
 	"time"
 
-	"github.com/hpcloud/tail"
 	"github.com/sirupsen/logrus"
 )
 
+// eveTimeLayout Suricata日志原始时间格式（带时区）
+const eveTimeLayout = "2006-01-02T15:04:05.999999Z0700"
+
 // AlertType Suricata Eve日志的告警数据结构体，与日志JSON格式一一对应
 type AlertType struct {
 	Timestamp string `json:"timestamp"`  // 告警发生时间戳
@@ -68,77 +73,82 @@ type AlertType struct {
 	Stream  int    `json:"stream"`  // 流序号
 }
 
-// Run 启动Suricata告警日志监听服务，实时解析Eve日志并输出告警关键信息
+// Run 启动Suricata告警日志监听服务，从检查点续读Eve日志并逐行处理
 func Run() {
 	cfg := global.Config.System
-	// 初始化日志尾追器，从Eve日志文件末尾开始实时监听新日志
-	t, err := tail.TailFile(cfg.EvePath, tail.Config{
-		Follow: true, // 持续跟随日志文件新增内容
-		Location: &tail.SeekInfo{
-			Offset: 0,
-			Whence: io.SeekEnd, // 初始位置设为文件末尾，避免重复解析历史日志
-		},
-	})
-	if err != nil {
-		logrus.Fatalf("suricata路径错误 %s", err)
-	}
 	logrus.Infof("开始监听suricata告警日志")
+	newEveReader(cfg.EvePath).Run(handleLine)
+}
 
-	// 循环读取日志行，处理每条新增日志
-	for line := range t.Lines {
-		var alert AlertType
-		// 将日志JSON字符串解析为告警结构体
-		err = json.Unmarshal([]byte(line.Text), &alert)
-		if err != nil {
-			logrus.Errorf("解析suricata告警记录失败 %s %s", err, line.Text)
-			continue
-		}
-		// 仅处理事件类型为"alert"的告警日志，过滤其他类型事件（如stats、flow）
-		if alert.EventType != "alert" {
-			continue
-		}
-		// 输出告警关键信息：规则描述、源IP、目标IP:端口
-		logrus.Infof("%s %s => %s:%d", alert.Alert.Signature, alert.SrcIp, alert.DestIp, alert.DestPort)
+// handleLine 处理单行Eve日志：告警解析后上报告警队列，其他事件交由事件处理
+func handleLine(line string) {
+	cfg := global.Config.System
+	// 日志行唯一标识，重复上报时服务端按该标识去重
+	id := fmt.Sprintf("%x", sha1.Sum([]byte(cfg.Uid+line)))
 
-		// 解析告警级别：从metadata.level中提取，处理多级别及转换异常场景
-		var level int8
-		levelList := alert.Alert.Metadata.Level
-		if len(levelList) > 0 {
-			// 存在多个级别时记录日志，便于排查数据异常
-			if len(levelList) > 1 {
-				logrus.Infof("存在level多个的情况 %v", levelList)
-			}
-			// 将第一个级别字符串转换为整数（业务约定取首个级别）
-			l, err := strconv.Atoi(levelList[0])
-			if err != nil {
-				logrus.Errorf("level转换失败 %s", err)
-				level = 1 // 转换失败时设置默认级别为1
-			} else {
-				level = int8(l) // 转换为int8类型适配消息结构体
-			}
+	var alert AlertType
+	// 将日志JSON字符串解析为告警结构体
+	err := json.Unmarshal([]byte(line), &alert)
+	if err != nil {
+		logrus.Errorf("解析suricata告警记录失败 %s %s", err, line)
+		metrics_service.EveDropped.WithLabelValues(metrics_service.EveDropParse).Inc()
+		return
+	}
+	metrics_service.EveLines.Inc()
+	// 告警以外的flow/dns/http/tls/ssh/fileinfo事件单独上报，其他类型事件（如stats）过滤
+	if alert.EventType != "alert" {
+		if eventTypeMap[alert.EventType] {
+			handleEvent(line, id)
 		}
+		return
+	}
+	// 输出告警关键信息：规则描述、源IP、目标IP:端口
+	logrus.Infof("%s %s => %s:%d", alert.Alert.Signature, alert.SrcIp, alert.DestIp, alert.DestPort)
 
-		// 时间格式转换：将Suricata日志的UTC时间格式转为业务标准格式
-		layout := "2006-01-02T15:04:05.999999Z0700" // Suricata日志原始时间格式（带时区）
-		ti, err := time.Parse(layout, alert.Timestamp)
+	// 解析告警级别：从metadata.level中提取，处理多级别及转换异常场景
+	var level int8
+	levelList := alert.Alert.Metadata.Level
+	if len(levelList) > 0 {
+		// 存在多个级别时记录日志，便于排查数据异常
+		if len(levelList) > 1 {
+			logrus.Infof("存在level多个的情况 %v", levelList)
+		}
+		// 将第一个级别字符串转换为整数（业务约定取首个级别）
+		l, err := strconv.Atoi(levelList[0])
 		if err != nil {
-			logrus.Errorf("解析时间时出错: %s", err)
-			return // 时间解析失败则终止当前告警处理，避免发送无效数据
+			logrus.Errorf("level转换失败 %s", err)
+			level = 1 // 转换失败时设置默认级别为1
+		} else {
+			level = int8(l) // 转换为int8类型适配消息结构体
 		}
-		timeStamp := ti.Format(time.DateTime) // 转换为业务标准格式：2006-01-02 15:04:05
+	}
 
-		// 构造标准告警消息结构体，调用MQ服务发送至告警队列
-		mq_service.SendAlertMsg(mq_service.AlertMsgType{
-			NodeUid:   cfg.Uid,                     // 节点唯一标识（从配置读取）
-			SrcIp:     alert.SrcIp,                 // 攻击源IP（取自Suricata告警数据）
-			SrcPort:   alert.SrcPort,               // 攻击源端口（取自Suricata告警数据）
-			DestIp:    alert.DestIp,                // 攻击目标IP（取自Suricata告警数据）
-			DestPort:  alert.DestPort,              // 攻击目标端口（取自Suricata告警数据）
-			Signature: alert.Alert.Signature,       // 告警规则描述（取自Suricata告警数据）
-			Body:      alert.Http.HttpResponseBody, // HTTP响应体（仅HTTP类告警有值）
-			Payload:   alert.Payload,               // 数据包负载内容（取自Suricata告警数据）
-			Level:     level,                       // 告警级别（已处理后的整数级别）
-			Timestamp: timeStamp,                   // 标准化后的告警时间
-		})
+	// 时间格式转换：将Suricata日志的UTC时间格式转为业务标准格式
+	ti, err := time.Parse(eveTimeLayout, alert.Timestamp)
+	if err != nil {
+		logrus.Errorf("解析时间时出错: %s", err)
+		metrics_service.EveDropped.WithLabelValues(metrics_service.EveDropTime).Inc()
+		return // 时间解析失败则跳过当前告警，避免发送无效数据
 	}
+	timeStamp := ti.Format(time.DateTime) // 转换为业务标准格式：2006-01-02 15:04:05
+
+	// 按四元组关联节点记录的隧道会话
+	sessionID := port_service.LookupSession(alert.SrcIp, alert.SrcPort, alert.DestIp, alert.DestPort)
+
+	// 构造标准告警消息结构体，调用MQ服务发送至告警队列
+	mq_service.SendAlertMsg(mq_service.AlertMsgType{
+		ID:        id,                          // 日志行唯一标识（服务端去重）
+		NodeUid:   cfg.Uid,                     // 节点唯一标识（从配置读取）
+		SrcIp:     alert.SrcIp,                 // 攻击源IP（取自Suricata告警数据）
+		SrcPort:   alert.SrcPort,               // 攻击源端口（取自Suricata告警数据）
+		DestIp:    alert.DestIp,                // 攻击目标IP（取自Suricata告警数据）
+		DestPort:  alert.DestPort,              // 攻击目标端口（取自Suricata告警数据）
+		Signature: alert.Alert.Signature,       // 告警规则描述（取自Suricata告警数据）
+		Body:      alert.Http.HttpResponseBody, // HTTP响应体（仅HTTP类告警有值）
+		Payload:   alert.Payload,               // 数据包负载内容（取自Suricata告警数据）
+		Level:     level,                       // 告警级别（已处理后的整数级别）
+		Timestamp: timeStamp,                   // 标准化后的告警时间
+		SessionID: sessionID,                   // 关联的隧道会话记录
+		FlowID:    alert.FlowId,                // 网络流唯一标识，关联同一流的事件
+	})
 }
@@ -0,0 +1,251 @@
+package suricata_service
+
+// File: honey_node/service/suricata_service/eve_reader.go
+// Description: Suricata Eve日志持久化读取模块，按完整行读取日志并将inode及偏移检查点保存至本地数据库，支持重启续读、logrotate轮转及copytruncate截断
+
+import (
+	"bufio"
+	"errors"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/service/metrics_service"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	evePollInterval = 250 * time.Millisecond // 读到文件末尾后的轮询间隔
+	eveSaveInterval = time.Second            // 持续读取时检查点的最小保存间隔
+)
+
+// eveReader Eve日志读取器
+type eveReader struct {
+	path       string                    // 日志文件路径
+	file       *os.File                  // 当前打开的日志文件
+	reader     *bufio.Reader             // 当前文件的带缓冲读取器
+	inode      uint64                    // 当前文件inode
+	offset     int64                     // 已处理完成的偏移（完整行末尾）
+	pending    []byte                    // 已读取但尚未遇到换行符的不完整行
+	checkpoint models.EveCheckpointModel // 检查点记录
+	dirty      bool                      // 检查点是否有未保存的变更
+	savedAt    time.Time                 // 检查点上次保存时间
+}
+
+// newEveReader 创建Eve日志读取器
+func newEveReader(path string) *eveReader {
+	return &eveReader{path: path}
+}
+
+// Run 从检查点恢复读取位置并持续读取日志，每读到一个完整行调用一次handle
+func (r *eveReader) Run(handle func(line string)) {
+	r.restore(handle)
+	for {
+		data, err := r.reader.ReadBytes('\n')
+		if len(data) > 0 {
+			r.pending = append(r.pending, data...)
+		}
+		if err == nil {
+			r.commit(handle)
+			if time.Since(r.savedAt) > eveSaveInterval {
+				r.save()
+			}
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			logrus.Errorf("读取suricata日志失败 %s", err)
+		}
+
+		// 读到文件末尾，保存检查点并检查日志是否轮转或截断
+		r.save()
+		if r.rotate(handle) {
+			continue
+		}
+		time.Sleep(evePollInterval)
+	}
+}
+
+// commit 处理一个完整行并推进偏移，单行处理异常不影响后续读取
+func (r *eveReader) commit(handle func(line string)) {
+	line := r.pending
+	r.offset += int64(len(line))
+	r.pending = r.pending[:0]
+	r.dirty = true
+
+	text := string(line[:len(line)-1])
+	if len(text) == 0 {
+		return
+	}
+	func() {
+		defer func() {
+			if err := recover(); err != nil {
+				logrus.Errorf("处理suricata日志异常 %v %s", err, text)
+			}
+		}()
+		handle(text)
+	}()
+}
+
+// restore 打开日志文件并从检查点恢复读取位置
+// 首次运行没有检查点时从文件末尾开始，避免重复解析历史日志
+func (r *eveReader) restore(handle func(line string)) {
+	global.DB.Find(&r.checkpoint, "path = ?", r.path)
+	r.waitOpen()
+
+	switch {
+	case r.checkpoint.ID == 0:
+		r.seek(0, io.SeekEnd)
+		r.checkpoint.Path = r.path
+		logrus.Infof("未找到suricata日志检查点，从文件末尾开始读取 %s", r.path)
+	case r.checkpoint.Inode == r.inode:
+		// 停机期间文件被copytruncate截断，从头读取
+		if info, err := r.file.Stat(); err == nil && info.Size() < r.checkpoint.Offset {
+			logrus.Warnf("suricata日志已被截断，从头读取 %s", r.path)
+			r.seek(0, io.SeekStart)
+			break
+		}
+		r.seek(r.checkpoint.Offset, io.SeekStart)
+		logrus.Infof("从检查点继续读取suricata日志 %s offset %d", r.path, r.offset)
+	default:
+		// 停机期间文件被轮转，先读完轮转后的旧文件剩余部分，再从头读取新文件
+		r.drainRotated(handle)
+		r.seek(0, io.SeekStart)
+	}
+	r.dirty = true
+	r.save()
+}
+
+// drainRotated 查找检查点inode对应的已轮转旧文件，读取检查点之后的剩余日志
+func (r *eveReader) drainRotated(handle func(line string)) {
+	matches, _ := filepath.Glob(r.path + "*")
+	for _, name := range matches {
+		info, err := os.Stat(name)
+		if err != nil || fileInode(info) != r.checkpoint.Inode {
+			continue
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			logrus.Errorf("打开轮转日志失败 %s %s", name, err)
+			return
+		}
+		defer file.Close()
+		if _, err = file.Seek(r.checkpoint.Offset, io.SeekStart); err != nil {
+			logrus.Errorf("定位轮转日志失败 %s %s", name, err)
+			return
+		}
+		count := 0
+		reader := bufio.NewReader(file)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if err != nil {
+				break
+			}
+			r.pending = append(r.pending[:0], line...)
+			r.commit(handle)
+			count++
+		}
+		r.pending = r.pending[:0]
+		logrus.Infof("读取轮转日志剩余部分 %s 共%d行", name, count)
+		return
+	}
+	logrus.Warnf("未找到检查点对应的轮转日志，从头读取新日志 %s", r.path)
+}
+
+// rotate 检查日志文件是否被轮转或截断，发生时切换读取位置并返回true
+func (r *eveReader) rotate(handle func(line string)) bool {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		// 轮转后新文件尚未创建，继续等待
+		return false
+	}
+
+	if inode := fileInode(info); inode != r.inode {
+		// 旧文件在轮转后可能仍有追加内容，读完后再切换
+		for {
+			line, err := r.reader.ReadBytes('\n')
+			r.pending = append(r.pending, line...)
+			if err != nil {
+				break
+			}
+			r.commit(handle)
+		}
+		if len(r.pending) > 0 {
+			logrus.Warnf("轮转日志末尾存在不完整的行，已丢弃 %s", r.pending)
+			metrics_service.EveDropped.WithLabelValues(metrics_service.EveDropPartial).Inc()
+		}
+		logrus.Infof("suricata日志已轮转，切换至新文件 %s", r.path)
+		r.file.Close()
+		r.waitOpen()
+		r.seek(0, io.SeekStart)
+		r.dirty = true
+		r.save()
+		return true
+	}
+
+	// copytruncate方式轮转：文件被原地截断，从头读取
+	if info.Size() < r.offset+int64(len(r.pending)) {
+		logrus.Infof("suricata日志已被截断，从头读取 %s", r.path)
+		r.seek(0, io.SeekStart)
+		r.dirty = true
+		r.save()
+		return true
+	}
+	return false
+}
+
+// waitOpen 打开日志文件，文件不存在时等待Suricata创建
+func (r *eveReader) waitOpen() {
+	for {
+		file, err := os.Open(r.path)
+		if err == nil {
+			info, err := file.Stat()
+			if err == nil {
+				r.file = file
+				r.inode = fileInode(info)
+				return
+			}
+			file.Close()
+		}
+		logrus.Warnf("打开suricata日志失败，等待重试 %s", err)
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// seek 定位读取位置并重置读取缓冲
+func (r *eveReader) seek(offset int64, whence int) {
+	pos, err := r.file.Seek(offset, whence)
+	if err != nil {
+		logrus.Errorf("定位suricata日志失败 %s", err)
+		pos, _ = r.file.Seek(0, io.SeekStart)
+	}
+	r.offset = pos
+	r.pending = r.pending[:0]
+	r.reader = bufio.NewReader(r.file)
+}
+
+// save 保存读取检查点
+func (r *eveReader) save() {
+	if !r.dirty {
+		return
+	}
+	r.checkpoint.Inode = r.inode
+	r.checkpoint.Offset = r.offset
+	if err := global.DB.Save(&r.checkpoint).Error; err != nil {
+		logrus.Errorf("保存suricata日志检查点失败 %s", err)
+		return
+	}
+	r.dirty = false
+	r.savedAt = time.Now()
+}
+
+// fileInode 获取文件inode
+func fileInode(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Ino
+	}
+	return 0
+}
@@ -0,0 +1,111 @@
+package suricata_service
+
+// File: honey_node/service/suricata_service/event.go
+// Description: Suricata非告警事件处理，解析flow/dns/http/tls/ssh/fileinfo事件，仅上报涉及诱捕ip的事件并通过flow_id与告警关联
+
+import (
+	"encoding/json"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/service/metrics_service"
+	"honey_node/internal/service/mq_service"
+	"honey_node/internal/service/port_service"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// eventTypeMap 需要上报的非告警事件类型
+var eventTypeMap = map[string]bool{
+	"flow":     true,
+	"dns":      true,
+	"http":     true,
+	"tls":      true,
+	"ssh":      true,
+	"fileinfo": true,
+}
+
+// honeyIpRefreshInterval 诱捕ip集合的刷新间隔
+const honeyIpRefreshInterval = 10 * time.Second
+
+var (
+	honeyIpMap       = map[string]bool{} // 诱捕ip集合（诱捕网卡ip及端口转发监听ip）
+	honeyIpLock      sync.Mutex          // 诱捕ip集合读写锁
+	honeyIpRefreshAt time.Time           // 诱捕ip集合上次刷新时间
+)
+
+// isHoneyIp 判断ip是否为本节点的诱捕ip，集合按间隔从数据库刷新
+func isHoneyIp(ip string) bool {
+	honeyIpLock.Lock()
+	defer honeyIpLock.Unlock()
+
+	if time.Since(honeyIpRefreshAt) > honeyIpRefreshInterval {
+		ipMap := map[string]bool{}
+		var ipList []models.IpModel
+		global.DB.Find(&ipList)
+		for _, model := range ipList {
+			ipMap[model.Ip] = true
+		}
+		// 探针ip上的端口转发没有诱捕网卡记录，按监听地址补充
+		var portList []models.PortModel
+		global.DB.Find(&portList)
+		for _, model := range portList {
+			if host, _, err := net.SplitHostPort(model.LocalAddr); err == nil {
+				ipMap[host] = true
+			}
+		}
+		honeyIpMap = ipMap
+		honeyIpRefreshAt = time.Now()
+	}
+	return honeyIpMap[ip]
+}
+
+// handleEvent 解析非告警事件并上报至事件队列
+func handleEvent(line string, id string) {
+	var event models.EveEvent
+	if err := json.Unmarshal([]byte(line), &event); err != nil {
+		logrus.Errorf("解析suricata事件失败 %s %s", err, line)
+		metrics_service.EveDropped.WithLabelValues(metrics_service.EveDropParse).Inc()
+		return
+	}
+
+	// 仅上报源或目标为诱捕ip的事件
+	honeyIp := event.DestIp
+	if !isHoneyIp(honeyIp) {
+		honeyIp = event.SrcIp
+		if !isHoneyIp(honeyIp) {
+			return
+		}
+	}
+
+	ti, err := time.Parse(eveTimeLayout, event.Timestamp)
+	if err != nil {
+		logrus.Errorf("解析时间时出错: %s", err)
+		metrics_service.EveDropped.WithLabelValues(metrics_service.EveDropTime).Inc()
+		return
+	}
+
+	mq_service.SendEventMsg(mq_service.EventMsgType{
+		ID:        id,
+		NodeUid:   global.Config.System.Uid,
+		EventType: event.EventType,
+		FlowID:    event.FlowID,
+		Timestamp: ti.Format(time.DateTime),
+		SrcIp:     event.SrcIp,
+		SrcPort:   event.SrcPort,
+		DestIp:    event.DestIp,
+		DestPort:  event.DestPort,
+		Proto:     event.Proto,
+		AppProto:  event.AppProto,
+		HoneyIp:   honeyIp,
+		SessionID: port_service.LookupSession(event.SrcIp, event.SrcPort, event.DestIp, event.DestPort),
+		Flow:      event.Flow,
+		Dns:       event.Dns,
+		Http:      event.Http,
+		Tls:       event.Tls,
+		Ssh:       event.Ssh,
+		Fileinfo:  event.Fileinfo,
+	})
+}
@@ -0,0 +1,319 @@
+package sync_service
+
+// File: honey_node/service/sync_service/enter.go
+// Description: 全量状态同步模块，比对管理端下发的期望诱捕ip及端口转发与本地记录，补齐缺失、删除多余并返回逐项结果
+
+import (
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/ip_service"
+	"honey_node/internal/service/port_service"
+	"honey_node/internal/utils/info"
+	"honey_node/internal/utils/neighbor"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// lock 保证同一时间只有一次同步在执行
+var lock sync.Mutex
+
+// Apply 按期望状态同步本地诱捕ip及端口转发
+func Apply(req *node_rpc.SyncStateInMessage) *node_rpc.SyncStateOutMessage {
+	lock.Lock()
+	defer lock.Unlock()
+
+	res := new(node_rpc.SyncStateOutMessage)
+
+	// 所属子网正在操作中的网卡不做同步，避免与进行中的创建/删除冲突
+	skipMap := map[string]bool{}
+	for _, network := range req.SkipNetworkList {
+		skipMap[network] = true
+	}
+
+	var ipList []models.IpModel
+	if err := global.DB.Find(&ipList).Error; err != nil {
+		res.ErrMsg = fmt.Sprintf("查询ip记录失败 %s", err)
+		return res
+	}
+	currentMap := map[string]models.IpModel{}
+	for _, model := range ipList {
+		if skipMap[model.Network] {
+			continue
+		}
+		currentMap[model.Ip] = model
+	}
+	desiredMap := map[string]*node_rpc.SyncIpInfo{}
+	for _, ipInfo := range req.IpList {
+		if skipMap[ipInfo.Network] {
+			continue
+		}
+		desiredMap[ipInfo.Ip] = ipInfo
+	}
+
+	// 1. 删除管理端不存在的诱捕ip
+	for ip, model := range currentMap {
+		if _, ok := desiredMap[ip]; ok {
+			continue
+		}
+		res.RemoveList = append(res.RemoveList, removeIp(model))
+		delete(currentMap, ip)
+	}
+
+	// 2. 删除不属于任何期望ip的端口转发记录
+	removeStrayPort(desiredMap, len(skipMap) > 0)
+
+	// 3. 逐个同步期望的诱捕ip及其端口转发
+	for _, ipInfo := range req.IpList {
+		if skipMap[ipInfo.Network] {
+			continue
+		}
+		model, ok := currentMap[ipInfo.Ip]
+		result := applyIp(ipInfo, model, ok, currentMap)
+		if result.ErrMsg == "" {
+			result.PortList = applyPort(ipInfo)
+		}
+		res.IpList = append(res.IpList, result)
+	}
+
+	logrus.Infof("全量同步完成 诱捕ip %d个 删除 %d个 跳过网卡 %v", len(res.IpList), len(res.RemoveList), req.SkipNetworkList)
+	return res
+}
+
+// removeIp 删除本地多余的诱捕ip：关闭端口转发、删除网卡及记录
+func removeIp(model models.IpModel) *node_rpc.SyncIpResult {
+	result := &node_rpc.SyncIpResult{
+		Ip:       model.Ip,
+		LinkName: model.LinkName,
+		Mac:      model.Mac,
+	}
+	closeIpPort(model.Ip)
+	if ip_service.LinkExists(model.LinkName) {
+		if err := ip_service.RemoveInterface(model.LinkName); err != nil {
+			result.ErrMsg = err.Error()
+			logrus.Errorf("同步删除诱捕ip %s 失败 %s", model.Ip, err)
+			return result
+		}
+	}
+	global.DB.Delete(&model)
+	logrus.Infof("同步删除诱捕ip %s 网卡 %s", model.Ip, model.LinkName)
+	return result
+}
+
+// applyIp 确保期望的诱捕ip在本地存在并且网卡配置正确
+func applyIp(ipInfo *node_rpc.SyncIpInfo, model models.IpModel, ok bool, currentMap map[string]models.IpModel) *node_rpc.SyncIpResult {
+	result := &node_rpc.SyncIpResult{
+		HoneyIpID: ipInfo.HoneyIpID,
+		Ip:        ipInfo.Ip,
+	}
+
+	// 探针ip为节点网卡自身的ip，无需创建网卡
+	if ipInfo.IsTan {
+		mac, err := ip_service.GetMACAddress(ipInfo.Network)
+		if err != nil {
+			result.ErrMsg = err.Error()
+			return result
+		}
+		result.LinkName = ipInfo.Network
+		result.Mac = mac
+		return result
+	}
+
+	if ok {
+		// 记录与期望一致且网卡存在，无需处理
+		if model.Network == ipInfo.Network && int32(model.Mask) == ipInfo.Mask && ip_service.LinkExists(model.LinkName) {
+			result.LinkName = model.LinkName
+			result.Mac = model.Mac
+			return result
+		}
+		// 配置不一致或网卡被删除，删除后按期望重新创建
+		if ip_service.LinkExists(model.LinkName) {
+			if err := ip_service.RemoveInterface(model.LinkName); err != nil {
+				result.ErrMsg = err.Error()
+				return result
+			}
+		}
+		global.DB.Delete(&model)
+		delete(currentMap, model.Ip)
+	} else if info.FindLocalIp(ipInfo.Ip) {
+		// 本地记录不存在但ip已被其他网卡使用
+		result.ErrMsg = "当前ip存在与本地ip中"
+		return result
+	}
+
+	linkName := ipInfo.LinkName
+	if ok {
+		linkName = model.LinkName
+	}
+	if !strings.HasPrefix(linkName, "hy_") || linkUsed(linkName, currentMap) {
+		linkName = fmt.Sprintf("hy_%d", ipInfo.HoneyIpID)
+	}
+	// 残留的同名网卡先删除
+	if ip_service.LinkExists(linkName) {
+		if err := ip_service.RemoveInterface(linkName); err != nil {
+			result.ErrMsg = err.Error()
+			return result
+		}
+	}
+
+	// 存活预检测：与创建诱捕ip一致，ip已被局域网内其他设备占用时不创建
+	if _mac, _, err := neighbor.Ping(net.ParseIP(ipInfo.Ip), ipInfo.Network); err == nil {
+		result.ErrMsg = fmt.Sprintf("ip已存在 ip %s mac %s", ipInfo.Ip, _mac.String())
+		logrus.Warnf("同步创建诱捕ip失败 %s", result.ErrMsg)
+		return result
+	}
+
+	mac, err := ip_service.SetIp(ip_service.SetIpRequest{
+		Ip:       ipInfo.Ip,
+		Mask:     int8(ipInfo.Mask),
+		LinkName: linkName,
+		Network:  ipInfo.Network,
+		Mac:      ipInfo.Mac,
+	})
+	if err != nil {
+		result.ErrMsg = err.Error()
+		logrus.Errorf("同步创建诱捕ip %s 失败 %s", ipInfo.Ip, err)
+		return result
+	}
+	global.DB.Create(&models.IpModel{
+		Ip:       ipInfo.Ip,
+		Mask:     int8(ipInfo.Mask),
+		LinkName: linkName,
+		Network:  ipInfo.Network,
+		Mac:      mac,
+	})
+	logrus.Infof("同步创建诱捕ip %s 网卡 %s", ipInfo.Ip, linkName)
+
+	result.LinkName = linkName
+	result.Mac = mac
+	return result
+}
+
+// linkUsed 判断网卡名称是否已被其他诱捕ip使用
+func linkUsed(linkName string, currentMap map[string]models.IpModel) bool {
+	for _, model := range currentMap {
+		if model.LinkName == linkName {
+			return true
+		}
+	}
+	return false
+}
+
+// portKey 端口转发唯一标识
+type portKey struct {
+	agreement int8   // 通信协议
+	localAddr string // 本地监听地址
+}
+
+// applyPort 同步指定诱捕ip上的端口转发，返回逐个端口的结果
+func applyPort(ipInfo *node_rpc.SyncIpInfo) (portInfoList []*node_rpc.StatusPortInfo) {
+	portList := ipPortList(ipInfo.Ip)
+	currentMap := map[portKey]models.PortModel{}
+	for _, model := range portList {
+		currentMap[portKey{agreement(model.Agreement), model.LocalAddr}] = model
+	}
+
+	desiredMap := map[portKey]models.PortInfo{}
+	for _, p := range ipInfo.PortList {
+		port := toPortInfo(ipInfo.Ip, p)
+		desiredMap[portKey{port.Agreement, port.LocalAddr()}] = port
+	}
+
+	// 关闭多余或目标地址已变更的端口转发
+	for key, model := range currentMap {
+		port, ok := desiredMap[key]
+		if ok && port.TargetAddr() == model.TargetAddr {
+			continue
+		}
+		port_service.CloseTunnel(key.agreement, key.localAddr)
+		delete(currentMap, key)
+		logrus.Infof("同步关闭端口转发 %s", key.localAddr)
+	}
+
+	for _, p := range ipInfo.PortList {
+		port := toPortInfo(ipInfo.Ip, p)
+		key := portKey{port.Agreement, port.LocalAddr()}
+		portInfo := &node_rpc.StatusPortInfo{
+			Port:      int64(port.Port),
+			Agreement: int32(port.Agreement),
+		}
+		portInfoList = append(portInfoList, portInfo)
+
+		_, ok := currentMap[key]
+		if ok && port_service.TunnelRunning(key.agreement, key.localAddr) {
+			continue
+		}
+		if !ok {
+			global.DB.Create(&models.PortModel{
+				TargetAddr: port.TargetAddr(),
+				LocalAddr:  port.LocalAddr(),
+				Agreement:  port.Agreement,
+			})
+		}
+		if err := port_service.BindTunnel(port.Agreement, port.LocalAddr(), port.TargetAddr()); err != nil {
+			portInfo.Msg = err.Error()
+			logrus.Errorf("同步绑定端口 %s 失败 %s", port.LocalAddr(), err)
+			continue
+		}
+		logrus.Infof("同步绑定端口 %s => %s", port.LocalAddr(), port.TargetAddr())
+	}
+	return
+}
+
+// toPortInfo 将同步的端口转发信息转换为端口配置
+func toPortInfo(ip string, p *node_rpc.SyncPortInfo) models.PortInfo {
+	return models.PortInfo{
+		IP:        ip,
+		Port:      int(p.Port),
+		DestIP:    p.DestIP,
+		DestPort:  int(p.DestPort),
+		Agreement: agreement(int8(p.Agreement)),
+		Emulator:  p.Emulator,
+	}
+}
+
+// removeStrayPort 删除监听ip不属于任何期望诱捕ip的端口转发记录
+// 存在跳过的网卡时无法判断端口（如探针ip上的端口）所属网卡，不做处理
+func removeStrayPort(desiredMap map[string]*node_rpc.SyncIpInfo, hasSkip bool) {
+	if hasSkip {
+		return
+	}
+	var portList []models.PortModel
+	global.DB.Find(&portList)
+	for _, model := range portList {
+		host, _, err := net.SplitHostPort(model.LocalAddr)
+		if err != nil {
+			continue
+		}
+		if _, ok := desiredMap[host]; ok {
+			continue
+		}
+		port_service.CloseTunnel(agreement(model.Agreement), model.LocalAddr)
+		logrus.Infof("同步关闭端口转发 %s", model.LocalAddr)
+	}
+}
+
+// closeIpPort 关闭指定ip上的全部端口转发并删除记录
+func closeIpPort(ip string) {
+	for _, model := range ipPortList(ip) {
+		port_service.CloseTunnel(agreement(model.Agreement), model.LocalAddr)
+	}
+}
+
+// ipPortList 查询指定ip上的端口转发记录，本地地址按 net.JoinHostPort 格式匹配（IPv6为 [ip]:port）
+func ipPortList(ip string) (portList []models.PortModel) {
+	global.DB.Find(&portList, "local_addr like ?", net.JoinHostPort(ip, "")+"%")
+	return
+}
+
+// agreement 规范化通信协议（2 UDP，其余按TCP处理）
+func agreement(value int8) int8 {
+	if value == 2 {
+		return 2
+	}
+	return 1
+}
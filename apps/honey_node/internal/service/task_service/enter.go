@@ -1,68 +1,398 @@
 package task_service
 
 // File: honey_node/service/task_service/enter.go
-// Description: 实现节点侧未完成任务的恢复执行功能，检测并重新执行状态为0的批量部署任务，筛选未部署的IP重新执行配置，保证部署任务的完整性和幂等性
+// Description: 节点侧任务引擎，任务及任务项持久化在本地数据库，按任务类型注册的处理器逐项执行，支持失败重试退避、节点重启后幂等恢复、取消及手动重试
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"honey_node/internal/global"
 	"honey_node/internal/models"
-	"honey_node/internal/service/mq_service"
+	"honey_node/internal/service/metrics_service"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
-// Run 恢复执行节点侧所有未完成的任务
+const (
+	maxAttempt     = 3                // 单个任务项的最大执行次数
+	retryBackoff   = 2 * time.Second  // 首次重试等待时间，之后逐次翻倍
+	maxBackoff     = 30 * time.Second // 重试等待时间上限
+	scheduleTime   = 5 * time.Second  // 调度间隔，拉起待执行（新提交、手动重试）的任务
+	statusPollTime = 2 * time.Second  // 任务状态轮询间隔，感知命令行进程发起的取消
+)
+
+// ErrCanceled 任务被取消，未执行的任务项以该错误结束
+var ErrCanceled = errors.New("任务已取消")
+
+// Handler 单个任务的执行处理器
+type Handler interface {
+	// Exec 执行单个任务项，需保证幂等（节点重启或重试时可能重复执行），返回错误时按退避策略重试
+	Exec(ctx context.Context, item string) error
+	// Fail 任务项重试耗尽或被取消时调用，用于上报失败状态
+	Fail(item string, err error)
+	// Close 任务结束时调用，status为任务最终状态
+	Close(status int8)
+}
+
+// Kind 任务类型定义
+type Kind struct {
+	Name        string                                       // 任务类型名称
+	Concurrency int                                          // 任务项并发数
+	Resumable   bool                                         // 节点重启后是否恢复执行，结果依赖管理端实时等待的任务（如网络扫描）不可恢复
+	Items       func(task models.TaskModel) []string         // 生成任务项列表
+	New         func(task models.TaskModel) (Handler, error) // 创建任务处理器
+}
+
+var (
+	kindMap    = map[int8]Kind{}                 // 已注册的任务类型
+	runningMap = map[string]context.CancelFunc{} // 执行中的任务
+	mutex      sync.Mutex                        // 保护runningMap
+	wakeChan   = make(chan struct{}, 1)          // 新任务提交通知
+	startTime  = time.Now()                      // 进程启动时间，早于该时间的不可恢复任务视为中断
+)
+
+// Register 注册任务类型
+func Register(taskType int8, kind Kind) {
+	if kind.Concurrency <= 0 {
+		kind.Concurrency = 1
+	}
+	kindMap[taskType] = kind
+}
+
+// Submit 提交任务，任务及任务项入库后由任务引擎异步执行
+func Submit(model models.TaskModel) (models.TaskModel, error) {
+	kind, ok := kindMap[model.Type]
+	if !ok {
+		return model, fmt.Errorf("不支持的任务类型 %d", model.Type)
+	}
+	if model.TaskID == "" {
+		model.TaskID = uuid.New().String()
+	}
+	itemList := kind.Items(model)
+	model.Total = len(itemList)
+	model.Status = 0
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+		return createItems(tx, model.TaskID, itemList)
+	})
+	if err != nil {
+		logrus.Errorf("任务入库失败 %s", err)
+		return model, err
+	}
+	logrus.Infof("提交%s任务 %s 任务项%d个", kind.Name, model.TaskID, model.Total)
+
+	select {
+	case wakeChan <- struct{}{}:
+	default:
+	}
+	return model, nil
+}
+
+// Run 启动任务引擎，中断上次运行遗留的不可恢复任务，并恢复执行其余未完成的任务
 func Run() {
-	// 查询数据库中所有状态为0（执行中）的任务记录
 	var taskList []models.TaskModel
-	global.DB.Find(&taskList, "status = 0")
-	// 日志提示未完成任务数量，便于排查
-	if len(taskList) > 0 {
-		logrus.Warnf("存在%d个未完成的任务", len(taskList))
+	global.DB.Find(&taskList, "status = 0 and created_at < ?", startTime)
+	for _, model := range taskList {
+		kind, ok := kindMap[model.Type]
+		if ok && kind.Resumable {
+			continue
+		}
+		global.DB.Model(&model).Updates(map[string]any{
+			"status":  3,
+			"err_msg": "节点重启，任务中断",
+		})
+		global.DB.Model(&models.TaskItemModel{}).Where("task_id = ? and status = 0", model.TaskID).Update("status", 3)
+		logrus.Warnf("任务 %s 不可恢复，已中断", model.TaskID)
 	}
 
-	// 遍历所有未完成任务，按任务类型处理
-	for _, model := range taskList {
-		// 仅处理批量部署任务（Type=1）
-		if model.Type == 1 {
-			logrus.Infof("未完成的批量部署任务 %s %#v", model.CreatedAt, model.BatchDeployData)
-
-			// 第一步：查询该批量部署任务对应网络下已部署的IP列表
-			var ipList []models.IpModel
-			global.DB.Find(&ipList, "network = ?", model.BatchDeployData.Network)
-
-			// 构建已部署IP的映射表，用于快速筛选未部署IP
-			var deployIP = map[string]bool{}
-			for _, ipModel := range ipList {
-				deployIP[ipModel.Ip] = true
+	go func() {
+		ticker := time.NewTicker(scheduleTime)
+		defer ticker.Stop()
+		for {
+			schedule()
+			select {
+			case <-ticker.C:
+			case <-wakeChan:
 			}
+		}
+	}()
+}
 
-			// 初始化未部署IP的批量部署请求结构体（复用原任务的子网、日志、网络信息）
-			var unDeployData = models.BatchDeployRequest{
-				NetID:   model.BatchDeployData.NetID,
-				LogID:   model.BatchDeployData.LogID,
-				Network: model.BatchDeployData.Network,
-			}
+// schedule 拉起所有待执行且未在执行中的任务
+func schedule() {
+	var taskList []models.TaskModel
+	global.DB.Order("id").Find(&taskList, "status = 0")
+	for _, model := range taskList {
+		kind, ok := kindMap[model.Type]
+		if !ok {
+			continue
+		}
+		mutex.Lock()
+		if _, running := runningMap[model.TaskID]; running {
+			mutex.Unlock()
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		runningMap[model.TaskID] = cancel
+		mutex.Unlock()
 
-			// 第二步：筛选原任务中未部署的IP，加入待重新部署列表
-			for _, ip := range model.BatchDeployData.IPList {
-				// 跳过已部署的IP，保证幂等性
-				if deployIP[ip.Ip] {
-					continue
-				}
-				logrus.Infof("未部署的ip %s", ip.Ip)
-				// 将未部署IP加入待部署列表
-				unDeployData.IPList = append(unDeployData.IPList, ip)
-			}
+		go func(model models.TaskModel, kind Kind) {
+			defer func() {
+				mutex.Lock()
+				delete(runningMap, model.TaskID)
+				mutex.Unlock()
+				cancel()
+			}()
+			execute(ctx, model, kind)
+		}(model, kind)
+	}
+}
 
-			// 第三步：存在未部署IP则重新执行部署逻辑，否则更新任务状态为完成
-			if len(unDeployData.IPList) > 0 {
-				// 调用批量部署核心函数，执行未部署IP的配置（复用原任务ID）
-				mq_service.DeployTask(unDeployData, model.TaskID)
-			} else {
-				// 无未部署IP，标记任务为完成（状态1）
-				global.DB.Model(&model).Update("status", 1)
-			}
+// Cancel 取消任务，执行中的任务项完成当前执行后停止，未执行的任务项标记为已取消
+func Cancel(taskID string) error {
+	var model models.TaskModel
+	if err := global.DB.Take(&model, "task_id = ?", taskID).Error; err != nil {
+		return errors.New("任务不存在")
+	}
+	if model.Status != 0 {
+		return errors.New("任务已结束")
+	}
+	global.DB.Model(&model).Updates(map[string]any{
+		"status":  3,
+		"err_msg": ErrCanceled.Error(),
+	})
+	mutex.Lock()
+	cancel, ok := runningMap[taskID]
+	mutex.Unlock()
+	// 在其他进程（命令行）中取消时，由执行任务的进程轮询状态感知
+	if ok {
+		cancel()
+	}
+	logrus.Infof("取消任务 %s", taskID)
+	return nil
+}
+
+// Retry 重试失败或已取消的任务，仅重新执行未完成的任务项
+func Retry(taskID string) error {
+	var model models.TaskModel
+	if err := global.DB.Take(&model, "task_id = ?", taskID).Error; err != nil {
+		return errors.New("任务不存在")
+	}
+	kind, ok := kindMap[model.Type]
+	if !ok {
+		return fmt.Errorf("不支持的任务类型 %d", model.Type)
+	}
+	if !kind.Resumable {
+		return fmt.Errorf("%s任务不支持重试", kind.Name)
+	}
+	if model.Status == 0 {
+		return errors.New("任务执行中")
+	}
+	if model.Status == 1 {
+		return errors.New("任务已完成")
+	}
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.TaskItemModel{}).Where("task_id = ? and status in ?", taskID, []int8{2, 3}).
+			Updates(map[string]any{
+				"status":  0,
+				"attempt": 0,
+				"err_msg": "",
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model).Updates(map[string]any{
+			"status":  0,
+			"failed":  0,
+			"err_msg": "",
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	logrus.Infof("重试任务 %s", taskID)
+
+	select {
+	case wakeChan <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// List 查询任务列表，按创建时间倒序
+func List() (list []models.TaskModel) {
+	global.DB.Order("id desc").Find(&list)
+	return
+}
+
+// execute 执行任务的全部未完成任务项并更新任务最终状态
+func execute(ctx context.Context, model models.TaskModel, kind Kind) {
+	log := logrus.WithFields(logrus.Fields{
+		"logID":  model.LogID,
+		"taskID": model.TaskID,
+	})
+
+	// 旧版本节点创建的任务没有任务项记录，执行时补充生成
+	var count int64
+	global.DB.Model(&models.TaskItemModel{}).Where("task_id = ?", model.TaskID).Count(&count)
+	if count == 0 {
+		itemList := kind.Items(model)
+		if err := createItems(global.DB, model.TaskID, itemList); err != nil {
+			log.Errorf("任务项入库失败 %s", err)
+			return
 		}
+		model.Total = len(itemList)
+		global.DB.Model(&model).Update("total", model.Total)
+	}
+
+	start := time.Now()
+	handler, err := kind.New(model)
+	if err != nil {
+		log.Errorf("创建%s任务处理器失败 %s", kind.Name, err)
+		global.DB.Model(&model).Updates(map[string]any{
+			"status":  2,
+			"err_msg": err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go watch(ctx, cancel, model.TaskID)
+
+	var itemList []models.TaskItemModel
+	global.DB.Order("id").Find(&itemList, "task_id = ? and status = 0", model.TaskID)
+	log.Infof("开始执行%s任务 待执行任务项%d个", kind.Name, len(itemList))
+
+	semaphore := make(chan struct{}, kind.Concurrency)
+	var wg sync.WaitGroup
+	for _, item := range itemList {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(item models.TaskItemModel) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			runItem(ctx, item, handler)
+		}(item)
+	}
+	wg.Wait()
+
+	status := int8(1)
+	if ctx.Err() != nil {
+		// 被取消时结束全部未执行的任务项
+		var canceledList []models.TaskItemModel
+		global.DB.Find(&canceledList, "task_id = ? and status = 0", model.TaskID)
+		for _, item := range canceledList {
+			global.DB.Model(&item).Update("status", 3)
+			handler.Fail(item.Item, ErrCanceled)
+		}
+		global.DB.Model(&model).Update("failed", gorm.Expr("failed + ?", len(canceledList)))
+		status = 3
+	} else {
+		var failed int64
+		global.DB.Model(&models.TaskItemModel{}).Where("task_id = ? and status = 2", model.TaskID).Count(&failed)
+		if failed > 0 {
+			status = 2
+		}
+	}
+	updates := map[string]any{"status": status}
+	if status == 2 {
+		updates["err_msg"] = "部分任务项执行失败"
+	}
+	global.DB.Model(&model).Updates(updates)
+	handler.Close(status)
+	metrics_service.TaskDuration.WithLabelValues(strconv.Itoa(int(model.Type))).Observe(time.Since(start).Seconds())
+	log.Infof("%s任务结束 状态 %d", kind.Name, status)
+}
+
+// runItem 执行单个任务项，失败时按退避策略重试，重试耗尽后标记为失败
+func runItem(ctx context.Context, item models.TaskItemModel, handler Handler) {
+	backoff := retryBackoff
+	var err error
+	for item.Attempt < maxAttempt {
+		if ctx.Err() != nil {
+			return
+		}
+		item.Attempt++
+		err = handler.Exec(ctx, item.Item)
+		if err == nil {
+			global.DB.Model(&item).Updates(map[string]any{
+				"status":  1,
+				"attempt": item.Attempt,
+				"err_msg": "",
+			})
+			global.DB.Model(&models.TaskModel{}).Where("task_id = ?", item.TaskID).Update("done", gorm.Expr("done + 1"))
+			return
+		}
+		global.DB.Model(&item).Updates(map[string]any{
+			"attempt": item.Attempt,
+			"err_msg": err.Error(),
+		})
+		logrus.Warnf("任务项 %s 第%d次执行失败 %s", item.Item, item.Attempt, err)
+		if item.Attempt >= maxAttempt {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+	if err == nil {
+		// 重启前已耗尽重试次数的任务项
+		err = errors.New(item.ErrMsg)
+	}
+	global.DB.Model(&item).Update("status", 2)
+	global.DB.Model(&models.TaskModel{}).Where("task_id = ?", item.TaskID).Update("failed", gorm.Expr("failed + 1"))
+	handler.Fail(item.Item, err)
+}
+
+// watch 轮询任务状态，任务在其他进程（命令行）中被取消时结束执行
+func watch(ctx context.Context, cancel context.CancelFunc, taskID string) {
+	ticker := time.NewTicker(statusPollTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		var model models.TaskModel
+		if err := global.DB.Take(&model, "task_id = ?", taskID).Error; err != nil || model.Status == 3 {
+			cancel()
+			return
+		}
+	}
+}
+
+// createItems 批量创建任务项记录
+func createItems(tx *gorm.DB, taskID string, itemList []string) error {
+	if len(itemList) == 0 {
+		return nil
+	}
+	var list []models.TaskItemModel
+	for _, item := range itemList {
+		list = append(list, models.TaskItemModel{
+			TaskID: taskID,
+			Item:   item,
+		})
 	}
+	return tx.CreateInBatches(&list, 200).Error
 }
@@ -0,0 +1,161 @@
+package upgrade_service
+
+// File: honey_node/service/upgrade_service/docker.go
+// Description: Docker Engine API客户端，通过挂载到升级服务容器内的docker.sock完成镜像导入、容器查询、创建、启停及重命名
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// dockerSocket Docker守护进程的unix socket路径
+const dockerSocket = "/var/run/docker.sock"
+
+// dockerClient 通过unix socket访问Docker Engine API的HTTP客户端
+var dockerClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", dockerSocket)
+		},
+	},
+}
+
+// containerInfo 容器详情，Config及HostConfig原样保留，用于按相同配置创建新容器
+type containerInfo struct {
+	ID         string                 `json:"Id"`         // 容器ID
+	Name       string                 `json:"Name"`       // 容器名称（以/开头）
+	Image      string                 `json:"Image"`      // 容器使用的镜像ID
+	Config     map[string]interface{} `json:"Config"`     // 容器配置
+	HostConfig map[string]interface{} `json:"HostConfig"` // 宿主机相关配置（挂载、网络模式、重启策略等）
+}
+
+// dockerRequest 调用Docker Engine API，out不为空时将响应解析到out
+func dockerRequest(method, path string, body io.Reader, contentType string, out interface{}) error {
+	req, err := http.NewRequest(method, "http://docker"+path, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	res, err := dockerClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// 304表示容器已处于目标状态（已启动或已停止）
+	if res.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if res.StatusCode >= 300 {
+		var e struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(res.Body).Decode(&e)
+		return fmt.Errorf("docker %s %s 失败: %d %s", method, path, res.StatusCode, e.Message)
+	}
+	if out == nil {
+		io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// inspectContainer 查询容器详情
+func inspectContainer(id string) (info containerInfo, err error) {
+	err = dockerRequest(http.MethodGet, "/containers/"+id+"/json", nil, "", &info)
+	return
+}
+
+// createContainer 创建容器，config为容器配置并包含HostConfig
+func createContainer(name string, config map[string]interface{}) (string, error) {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	var res struct {
+		ID string `json:"Id"`
+	}
+	err = dockerRequest(http.MethodPost, "/containers/create?name="+url.QueryEscape(name), bytes.NewReader(body), "application/json", &res)
+	return res.ID, err
+}
+
+// startContainer 启动容器
+func startContainer(id string) error {
+	return dockerRequest(http.MethodPost, "/containers/"+id+"/start", nil, "", nil)
+}
+
+// stopContainer 停止容器，超过10秒未退出时强制结束
+func stopContainer(id string) error {
+	return dockerRequest(http.MethodPost, "/containers/"+id+"/stop?t=10", nil, "", nil)
+}
+
+// removeContainer 强制删除容器（运行中的容器会被直接结束）
+func removeContainer(id string) error {
+	return dockerRequest(http.MethodDelete, "/containers/"+id+"?force=true", nil, "", nil)
+}
+
+// renameContainer 重命名容器
+func renameContainer(id, name string) error {
+	return dockerRequest(http.MethodPost, "/containers/"+id+"/rename?name="+url.QueryEscape(name), nil, "", nil)
+}
+
+// loadImage 导入镜像文件（支持tar及tar.gz）
+func loadImage(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	req, err := http.NewRequest(http.MethodPost, "http://docker/images/load?quiet=1", file)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-tar")
+	res, err := dockerClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("导入镜像失败: %d %s", res.StatusCode, body)
+	}
+
+	// 响应为JSON消息流，导入过程中的错误通过error字段返回
+	decoder := json.NewDecoder(res.Body)
+	for {
+		var msg struct {
+			Error string `json:"error"`
+		}
+		if err = decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("导入镜像失败: %s", msg.Error)
+		}
+	}
+}
+
+// inspectImage 查询镜像ID
+func inspectImage(ref string) (string, error) {
+	var res struct {
+		ID string `json:"Id"`
+	}
+	err := dockerRequest(http.MethodGet, "/images/"+ref+"/json", nil, "", &res)
+	return res.ID, err
+}
@@ -0,0 +1,159 @@
+package upgrade_service
+
+// File: honey_node/service/upgrade_service/enter.go
+// Description: 节点远程升级模块，下载并校验新版本镜像后写入切换记录，请求宿主机上的升级服务导入镜像并完成新旧版本容器切换
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"honey_node/internal/rpc/node_rpc"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	upgradeDir      = "upgrade"        // 镜像文件下载目录，与升级服务容器共享
+	downloadTimeout = 30 * time.Minute // 镜像文件下载超时时间
+)
+
+// Reporter 升级进度上报函数，status 1 下载中 2 切换中 3 失败
+type Reporter func(status int32, progress float32, errMsg string)
+
+// upgrading 是否正在升级，避免重复执行
+var upgrading atomic.Bool
+
+// Upgrade 执行升级命令：下载镜像文件并校验大小及摘要，请求升级服务导入镜像
+// 升级服务接受请求后上报切换中，随后由升级服务停止当前容器
+func Upgrade(req *node_rpc.NodeUpgradeInMessage, report Reporter) {
+	if !upgrading.CompareAndSwap(false, true) {
+		report(3, 0, "节点正在升级中")
+		return
+	}
+	if err := upgrade(req, report); err != nil {
+		upgrading.Store(false)
+		logrus.Errorf("节点升级至 %s 失败 %s", req.Version, err)
+		report(3, 0, err.Error())
+		return
+	}
+	logrus.Infof("升级服务已接受切换请求，等待切换至 %s", req.Version)
+	report(2, 100, "")
+}
+
+// upgrade 完成切换前的全部准备工作
+func upgrade(req *node_rpc.NodeUpgradeInMessage, report Reporter) error {
+	if req.Version == global.Version {
+		return fmt.Errorf("节点已是版本 %s", req.Version)
+	}
+	// 先确认升级服务可用，避免下载完成后才发现无法切换
+	if err := callUpdater(http.MethodGet, "/ping"); err != nil {
+		return fmt.Errorf("升级服务不可用 %s", err)
+	}
+
+	path, err := download(req, report)
+	if err != nil {
+		return err
+	}
+
+	record := models.UpgradeModel{
+		UpgradeID:   uint(req.UpgradeID),
+		FromVersion: global.Version,
+		ToVersion:   req.Version,
+		ToImage:     req.Image,
+		ImageID:     req.ImageID,
+		Deadline:    time.Now().Add(time.Duration(req.Timeout)*time.Second + switchDelay),
+		Status:      1,
+	}
+	if err = global.DB.Create(&record).Error; err != nil {
+		os.Remove(path)
+		return fmt.Errorf("写入升级记录失败 %s", err)
+	}
+	// 升级服务导入镜像并校验通过后返回，镜像文件由升级服务删除
+	if err = callUpdater(http.MethodPost, "/upgrade"); err != nil {
+		global.DB.Delete(&record)
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// download 下载镜像文件并校验大小及sha256摘要，返回文件路径
+func download(req *node_rpc.NodeUpgradeInMessage, report Reporter) (string, error) {
+	if err := os.MkdirAll(upgradeDir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(upgradeDir, req.Version+".tar.gz")
+
+	client := http.Client{Timeout: downloadTimeout}
+	res, err := client.Get(req.Url)
+	if err != nil {
+		return "", fmt.Errorf("下载镜像文件失败 %s", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("下载镜像文件失败 %s", res.Status)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	pw := &progressWriter{total: req.FileSize, report: report}
+	size, err := io.Copy(io.MultiWriter(file, h, pw), res.Body)
+	if err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("下载镜像文件失败 %s", err)
+	}
+	if size != req.FileSize {
+		os.Remove(path)
+		return "", fmt.Errorf("镜像文件大小不一致 期望%d 实际%d", req.FileSize, size)
+	}
+	if hash := hex.EncodeToString(h.Sum(nil)); hash != req.Hash {
+		os.Remove(path)
+		return "", errors.New("镜像文件摘要校验失败")
+	}
+	logrus.Infof("镜像文件下载完成 %s %d", path, size)
+	return path, nil
+}
+
+// progressWriter 统计下载字节数，每完成10%上报一次进度
+type progressWriter struct {
+	total   int64    // 文件总大小
+	written int64    // 已下载字节数
+	step    int64    // 已上报的进度档位
+	report  Reporter // 进度上报函数
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.written += int64(len(p))
+	if w.total > 0 {
+		if step := w.written * 10 / w.total; step > w.step && step < 10 {
+			w.step = step
+			w.report(1, float32(step*10), "")
+		}
+	}
+	return len(p), nil
+}
+
+// Confirm 新版本注册成功后确认切换中的升级记录，升级服务据此删除旧版本容器
+func Confirm() {
+	var record models.UpgradeModel
+	err := global.DB.Take(&record, "status = ? and to_version = ?", 1, global.Version).Error
+	if err != nil {
+		return
+	}
+	global.DB.Model(&record).Update("status", 2)
+	logrus.Infof("节点已从 %s 升级至 %s", record.FromVersion, record.ToVersion)
+}
@@ -0,0 +1,165 @@
+package upgrade_service
+
+// File: honey_node/service/upgrade_service/updater.go
+// Description: 升级服务，运行在挂载docker.sock的独立容器中，通过共享目录下的unix socket只接受节点的切换请求，导入并校验节点下载的镜像后切换节点容器
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	updaterTimeout       = 10 * time.Minute // 请求升级服务的超时时间（包含镜像导入耗时）
+	defaultNodeContainer = "node_server"    // 默认的节点容器名称
+)
+
+// updaterSocket 升级服务监听的unix socket，位于节点容器与升级服务容器共享的目录中
+var updaterSocket = filepath.Join(upgradeDir, "updater.sock")
+
+// updaterClient 通过unix socket访问升级服务的HTTP客户端
+var updaterClient = &http.Client{
+	Timeout: updaterTimeout,
+	Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", updaterSocket)
+		},
+	},
+}
+
+// switching 升级服务是否正在执行切换，同一时间只处理一个升级请求
+var switching atomic.Bool
+
+// callUpdater 节点调用升级服务，非200响应时将响应内容作为错误返回
+func callUpdater(method, path string) error {
+	req, err := http.NewRequest(method, "http://updater"+path, nil)
+	if err != nil {
+		return err
+	}
+	res, err := updaterClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return errors.New(strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// nodeContainer 升级服务管理的节点容器名称，由升级服务容器的环境变量指定，不接受节点传入
+func nodeContainer() string {
+	if name := os.Getenv("NODE_CONTAINER"); name != "" {
+		return name
+	}
+	return defaultNodeContainer
+}
+
+// Serve 升级服务入口，监听共享目录下的unix socket
+func Serve() {
+	if err := os.MkdirAll(upgradeDir, 0755); err != nil {
+		logrus.Fatalf("创建升级目录失败 %s", err)
+	}
+	os.Remove(updaterSocket)
+	listener, err := net.Listen("unix", updaterSocket)
+	if err != nil {
+		logrus.Fatalf("升级服务监听失败 %s", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/upgrade", handleUpgrade)
+
+	logrus.Infof("升级服务已启动 %s 管理容器 %s", updaterSocket, nodeContainer())
+	if err = http.Serve(listener, mux); err != nil {
+		logrus.Fatalf("升级服务异常退出 %s", err)
+	}
+}
+
+// handleUpgrade 处理切换请求：导入并校验镜像后立即返回，随后在后台完成容器切换
+func handleUpgrade(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "不支持的请求方法", http.StatusMethodNotAllowed)
+		return
+	}
+	if !switching.CompareAndSwap(false, true) {
+		http.Error(w, "升级服务正在切换节点版本", http.StatusConflict)
+		return
+	}
+	old, record, err := prepare()
+	if err != nil {
+		switching.Store(false)
+		logrus.Errorf("升级切换请求校验失败 %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+
+	go func() {
+		defer switching.Store(false)
+		watch(old, record)
+	}()
+}
+
+// prepare 读取待切换的升级记录，导入镜像并校验镜像名称及ID
+// 目标镜像必须与当前节点容器属于同一镜像仓库，容器配置始终取自当前节点容器
+func prepare() (old containerInfo, record models.UpgradeModel, err error) {
+	if err = global.DB.Order("id desc").Take(&record, "status = ?", 1).Error; err != nil {
+		return old, record, errors.New("没有待切换的升级记录")
+	}
+	old, err = inspectContainer(nodeContainer())
+	if err != nil {
+		return old, record, fmt.Errorf("查询节点容器失败 %s", err)
+	}
+	if imageRepo(record.ToImage) != imageRepo(old.Config["Image"]) {
+		return old, record, fmt.Errorf("目标镜像 %s 与节点容器镜像 %v 不属于同一仓库", record.ToImage, old.Config["Image"])
+	}
+
+	path := filepath.Join(upgradeDir, filepath.Base(record.ToVersion)+".tar.gz")
+	err = loadImage(path)
+	os.Remove(path)
+	if err != nil {
+		return old, record, err
+	}
+	imageID, err := inspectImage(record.ToImage)
+	if err != nil {
+		return old, record, fmt.Errorf("导入后未找到镜像 %s %s", record.ToImage, err)
+	}
+	if record.ImageID == "" || !strings.HasPrefix(strings.TrimPrefix(imageID, "sha256:"), record.ImageID) {
+		return old, record, fmt.Errorf("镜像 %s 的ID %s 与版本记录不一致", record.ToImage, imageID)
+	}
+	logrus.Infof("镜像导入成功 %s", record.ToImage)
+
+	record.ContainerID = old.ID
+	record.ContainerName = strings.TrimPrefix(old.Name, "/")
+	global.DB.Model(&record).Updates(map[string]interface{}{
+		"container_id":   record.ContainerID,
+		"container_name": record.ContainerName,
+	})
+	return old, record, nil
+}
+
+// imageRepo 取镜像引用中的仓库名（去掉标签）
+func imageRepo(ref interface{}) string {
+	s, _ := ref.(string)
+	if i := strings.LastIndex(s, ":"); i > strings.LastIndex(s, "/") {
+		return s[:i]
+	}
+	return s
+}
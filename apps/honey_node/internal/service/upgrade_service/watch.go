@@ -0,0 +1,109 @@
+package upgrade_service
+
+// File: honey_node/service/upgrade_service/watch.go
+// Description: 升级服务切换逻辑，停止旧版本容器并以新镜像创建同名容器，新版本未在截止时间前注册成功时删除新容器并恢复旧版本容器
+
+import (
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	switchDelay       = 5 * time.Second // 升级服务接受请求后等待旧版本上报切换状态的时间
+	watchPollInterval = 2 * time.Second // 等待新版本注册时的数据库轮询间隔
+)
+
+// watch 完成新旧版本容器切换并在新版本注册超时时回滚
+func watch(old containerInfo, record models.UpgradeModel) {
+	logrus.Infof("开始切换节点版本 %s -> %s", record.FromVersion, record.ToVersion)
+	time.Sleep(switchDelay)
+
+	newID, err := switchContainer(old, record)
+	if err != nil {
+		rollback(old, newID, &record, fmt.Sprintf("启动新版本容器失败 %s", err))
+		return
+	}
+	logrus.Infof("新版本容器已启动 %s，等待注册", record.ToImage)
+
+	for time.Now().Before(record.Deadline) {
+		time.Sleep(watchPollInterval)
+		global.DB.Take(&record, record.ID)
+		if record.Status == 2 {
+			if err = removeContainer(old.ID); err != nil {
+				logrus.Warnf("删除旧版本容器失败 %s", err)
+			}
+			logrus.Infof("节点升级完成 %s", record.ToVersion)
+			return
+		}
+	}
+	rollback(old, newID, &record, "新版本未在超时时间内完成注册")
+}
+
+// switchContainer 停止旧版本容器并改名，以旧容器的配置及新镜像创建并启动同名容器
+func switchContainer(old containerInfo, record models.UpgradeModel) (newID string, err error) {
+	if err = stopContainer(old.ID); err != nil {
+		return
+	}
+	if err = renameContainer(old.ID, record.ContainerName+"_"+record.FromVersion); err != nil {
+		return
+	}
+
+	config := map[string]interface{}{}
+	for key, value := range old.Config {
+		config[key] = value
+	}
+	config["Image"] = record.ToImage
+	// host网络模式下不允许指定主机名
+	if old.HostConfig["NetworkMode"] == "host" {
+		delete(config, "Hostname")
+		delete(config, "Domainname")
+	}
+	config["HostConfig"] = old.HostConfig
+
+	if newID, err = createContainer(record.ContainerName, config); err != nil {
+		return
+	}
+	err = startContainer(newID)
+	return
+}
+
+// rollback 删除新版本容器并恢复旧版本容器的名称及运行状态
+func rollback(old containerInfo, newID string, record *models.UpgradeModel, reason string) {
+	logrus.Warnf("%s，回滚至 %s", reason, record.FromVersion)
+	if newID != "" {
+		if err := removeContainer(newID); err != nil {
+			finish(record, 4, fmt.Sprintf("%s，删除新版本容器失败 %s", reason, err))
+			return
+		}
+	}
+	info, err := inspectContainer(old.ID)
+	if err == nil && strings.TrimPrefix(info.Name, "/") != record.ContainerName {
+		err = renameContainer(old.ID, record.ContainerName)
+	}
+	if err == nil {
+		err = startContainer(old.ID)
+	}
+	if err != nil {
+		finish(record, 4, fmt.Sprintf("%s，恢复旧版本容器失败 %s", reason, err))
+		return
+	}
+	finish(record, 3, reason)
+}
+
+// finish 更新升级记录的最终状态
+func finish(record *models.UpgradeModel, status int8, errMsg string) {
+	global.DB.Model(record).Updates(map[string]interface{}{
+		"status":  status,
+		"err_msg": errMsg,
+	})
+	if status == 4 {
+		logrus.Errorf("节点升级回滚失败 %s", errMsg)
+		return
+	}
+	logrus.Infof("节点已回滚至 %s", record.FromVersion)
+}
@@ -1,7 +1,7 @@
 package info
 
 // File: honey_node/utils/info/local_ip.go
-// Description: 提供本地IP地址相关工具函数，获取非虚拟接口的IPv4地址映射表，检测指定IP是否为本地网卡绑定的IP（排除hy_前缀的诱捕虚拟接口）
+// Description: 提供本地IP地址相关工具函数，获取非虚拟接口的IP地址映射表，检测指定IP是否为本地网卡绑定的IP（排除hy_前缀的诱捕虚拟接口）
 
 import (
 	"net"
@@ -9,7 +9,7 @@ import (
 	"sync"
 )
 
-// LocalIpMap 获取本地非虚拟接口的IPv4地址映射表
+// LocalIpMap 获取本地非虚拟接口的IP地址映射表（IPv4及IPv6）
 func LocalIpMap() map[string]bool {
 	var localIpMap = map[string]bool{}
 	var mutex sync.Mutex // 互斥锁，保证并发场景下映射表写入的线程安全
@@ -31,11 +31,6 @@ func LocalIpMap() map[string]bool {
 				continue // 解析失败则跳过当前地址
 			}
 
-			// 仅保留IPv4地址（过滤IPv6）
-			if _ip.To4() == nil {
-				continue
-			}
-
 			// 加锁写入映射表，避免并发写入冲突
 			mutex.Lock()
 			localIpMap[_ip.String()] = true
@@ -45,7 +40,7 @@ func LocalIpMap() map[string]bool {
 	return localIpMap
 }
 
-// FindLocalIp 检测指定IP是否为本地非虚拟接口绑定的IPv4地址
+// FindLocalIp 检测指定IP是否为本地非虚拟接口绑定的IP地址
 func FindLocalIp(ip string) bool {
 	// 调用LocalIpMap获取本地IP映射表，直接查询指定IP是否存在
 	return LocalIpMap()[ip]
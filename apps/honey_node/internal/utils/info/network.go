@@ -6,6 +6,8 @@ package info
 import (
 	"net"
 	"strings"
+
+	"github.com/vishvananda/netlink"
 )
 
 // NetworkInfo 网卡结构体，存储单个网卡的关键信息
@@ -14,6 +16,11 @@ type NetworkInfo struct {
 	Ip      string // IP地址（字符串格式）
 	Mask    int    // 子网掩码位数
 	Net     string // 网络段地址（CIDR格式）
+	Ip6     string // IPv6地址（全局单播），双栈网卡才有
+	Mask6   int    // IPv6前缀长度
+	Net6    string // IPv6网络段地址（CIDR格式）
+	VlanID  int    // VLAN ID，VLAN子接口才有
+	Parent  string // VLAN子接口所在的父网卡
 }
 
 // isFilteredNetwork 判断网卡是否应该被过滤
@@ -78,6 +85,26 @@ func GetNetworkList(filterNetworkList []string) (list []NetworkInfo, err error)
 		if err != nil {
 			continue
 		}
+		// 提取第一个IPv6全局单播地址（含ULA），与该网卡的IPv4地址组成双栈网络
+		// DHCPv6分配的/127、/128地址无法规划诱捕ip，跳过
+		var ip6, net6 string
+		var mask6 int
+		for _, addr := range addrs {
+			ip, _net, err := net.ParseCIDR(addr.String())
+			if err != nil || ip.To4() != nil || !ip.IsGlobalUnicast() {
+				continue
+			}
+			ones, _ := _net.Mask.Size()
+			if ones > 126 {
+				continue
+			}
+			ip6 = ip.String()
+			mask6 = ones
+			net6 = _net.String()
+			break
+		}
+		// VLAN子接口附加VLAN ID及父网卡
+		vlanID, parent := vlanOf(faceName)
 		// 遍历当前接口的每个地址，提取IPv4信息
 		for _, addr := range addrs {
 			// 解析CIDR格式地址，分离IP和网络段
@@ -85,7 +112,7 @@ func GetNetworkList(filterNetworkList []string) (list []NetworkInfo, err error)
 			if err != nil {
 				continue
 			}
-			// 仅保留IPv4地址（过滤IPv6）
+			// 以IPv4地址为主（IPv6地址已随网卡附加）
 			if ip.To4() == nil {
 				continue
 			}
@@ -97,8 +124,29 @@ func GetNetworkList(filterNetworkList []string) (list []NetworkInfo, err error)
 				Ip:      ip.String(),
 				Mask:    mask,
 				Net:     _net.String(),
+				Ip6:     ip6,
+				Mask6:   mask6,
+				Net6:    net6,
+				VlanID:  vlanID,
+				Parent:  parent,
 			})
 		}
 	}
 	return
+}
+
+// vlanOf 获取VLAN子接口的VLAN ID及父网卡名称，非VLAN子接口返回0
+func vlanOf(name string) (vlanID int, parent string) {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return
+	}
+	vlan, ok := link.(*netlink.Vlan)
+	if !ok {
+		return
+	}
+	if p, err := netlink.LinkByIndex(vlan.ParentIndex); err == nil {
+		parent = p.Attrs().Name
+	}
+	return vlan.VlanId, parent
 }
\ No newline at end of file
@@ -55,6 +55,7 @@ func GetNetworkInfo(i string) (ip string, mac string, err error) {
 }
 
 // ParseIPRange 解析IP范围字符串，支持单个IP、IP段（如192.168.1.1-100或192.168.1.1-192.168.1.100）格式
+// IPv6地址段支持完整格式（如fd00::1-fd00::ff）及末段十六进制简写（如fd00::1-ff）
 func ParseIPRange(ipRange string) ([]string, error) {
 	var result []string
 	// 按逗号分割多个IP范围段
@@ -116,7 +117,11 @@ func ParseIPRange(ipRange string) ([]string, error) {
 					}
 				}
 			} else {
-				return nil, fmt.Errorf("IPv6范围解析暂不支持")
+				ipList, err := parseIPv6Range(startIP, endPart)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, ipList...)
 			}
 		} else {
 			// 处理单个IP地址
@@ -130,3 +135,48 @@ func ParseIPRange(ipRange string) ([]string, error) {
 
 	return result, nil
 }
+
+// maxIPv6RangeSize IPv6地址段最多展开的地址数，避免超大地址段耗尽内存
+const maxIPv6RangeSize = 65536
+
+// parseIPv6Range 解析IPv6地址段，结束部分为完整IPv6地址或末段十六进制数
+func parseIPv6Range(startIP net.IP, endPart string) ([]string, error) {
+	startIP = append(net.IP(nil), startIP.To16()...)
+	var endIP net.IP
+	if endIP = net.ParseIP(endPart); endIP != nil {
+		if endIP.To4() != nil {
+			return nil, fmt.Errorf("无效的结束IP: %s", endPart)
+		}
+	} else {
+		// 处理简写格式（如fd00::1-ff），替换最后一段16位
+		endNum, err := strconv.ParseUint(endPart, 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("无效的结束部分: %s", endPart)
+		}
+		endIP = make(net.IP, net.IPv6len)
+		copy(endIP, startIP)
+		endIP[14] = byte(endNum >> 8)
+		endIP[15] = byte(endNum)
+	}
+
+	var result []string
+	for bytes.Compare(startIP, endIP) <= 0 {
+		if len(result) >= maxIPv6RangeSize {
+			return nil, fmt.Errorf("IPv6地址段超过%d个地址", maxIPv6RangeSize)
+		}
+		result = append(result, startIP.String())
+		// 递增IP地址（处理进位），溢出回到::时说明已遍历到最大地址
+		overflow := true
+		for i := len(startIP) - 1; i >= 0; i-- {
+			startIP[i]++
+			if startIP[i] > 0 {
+				overflow = false
+				break
+			}
+		}
+		if overflow {
+			break
+		}
+	}
+	return result, nil
+}
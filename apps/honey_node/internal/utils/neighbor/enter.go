@@ -0,0 +1,115 @@
+package neighbor
+
+// File: honey_node/utils/neighbor/enter.go
+// Description: 邻居探测工具包，IPv4地址通过ARP、IPv6地址通过NDP邻居请求探测主机是否在线并获取mac地址
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/j-keck/arping"
+	"golang.org/x/net/ipv6"
+)
+
+// ndpTimeout NDP邻居请求等待应答的超时时间
+const ndpTimeout = time.Second
+
+// ErrTimeout 探测超时（目标主机不在线）
+var ErrTimeout = errors.New("timeout")
+
+// Ping 通过指定网卡探测主机，返回主机mac地址及耗时
+func Ping(ip net.IP, iface string) (net.HardwareAddr, time.Duration, error) {
+	if ip == nil {
+		return nil, 0, fmt.Errorf("无效的ip地址")
+	}
+	if ip.To4() != nil {
+		return arping.PingOverIfaceByName(ip, iface)
+	}
+	return ndpPing(ip.To16(), iface)
+}
+
+// ndpPing 向目标地址的请求节点组播地址发送NDP邻居请求，等待目标的邻居通告
+func ndpPing(target net.IP, ifaceName string) (net.HardwareAddr, time.Duration, error) {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("网卡 %s 不存在 %s", ifaceName, err)
+	}
+	c, err := net.ListenPacket("ip6:ipv6-icmp", "::")
+	if err != nil {
+		return nil, 0, fmt.Errorf("创建ICMPv6套接字失败 %s", err)
+	}
+	defer c.Close()
+	conn := ipv6.NewPacketConn(c)
+
+	// 只接收邻居通告，避免并发探测时处理大量无关报文
+	var filter ipv6.ICMPFilter
+	filter.SetAll(true)
+	filter.Accept(ipv6.ICMPTypeNeighborAdvertisement)
+	if err = conn.SetICMPFilter(&filter); err != nil {
+		return nil, 0, fmt.Errorf("设置ICMPv6过滤失败 %s", err)
+	}
+
+	// NDP报文跳数限制必须为255，接收方据此确认报文来自本链路
+	cm := &ipv6.ControlMessage{HopLimit: 255, IfIndex: iface.Index}
+	dst := &net.IPAddr{IP: solicitedNodeAddr(target), Zone: iface.Name}
+	start := time.Now()
+	if _, err = conn.WriteTo(neighborSolicitation(target, iface.HardwareAddr), cm, dst); err != nil {
+		return nil, 0, fmt.Errorf("发送邻居请求失败 %s", err)
+	}
+
+	deadline := start.Add(ndpTimeout)
+	conn.SetReadDeadline(deadline)
+	buffer := make([]byte, 1500)
+	for time.Now().Before(deadline) {
+		n, _, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			break
+		}
+		if mac, ok := parseNeighborAdvertisement(buffer[:n], target); ok {
+			return mac, time.Since(start), nil
+		}
+	}
+	return nil, 0, ErrTimeout
+}
+
+// solicitedNodeAddr 计算目标地址的请求节点组播地址 ff02::1:ffXX:XXXX
+func solicitedNodeAddr(target net.IP) net.IP {
+	addr := net.ParseIP("ff02::1:ff00:0")
+	copy(addr[13:], target[13:])
+	return addr
+}
+
+// neighborSolicitation 构造邻居请求报文，携带源链路层地址选项，校验和由内核计算
+func neighborSolicitation(target net.IP, mac net.HardwareAddr) []byte {
+	msg := make([]byte, 24, 32)
+	msg[0] = byte(ipv6.ICMPTypeNeighborSolicitation)
+	copy(msg[8:24], target)
+	if len(mac) == 6 {
+		msg = append(msg, 1, 1) // 选项类型1 源链路层地址，长度1（8字节）
+		msg = append(msg, mac...)
+	}
+	return msg
+}
+
+// parseNeighborAdvertisement 解析邻居通告，目标地址一致时返回目标链路层地址
+func parseNeighborAdvertisement(msg []byte, target net.IP) (net.HardwareAddr, bool) {
+	if len(msg) < 24 || msg[0] != byte(ipv6.ICMPTypeNeighborAdvertisement) || !net.IP(msg[8:24]).Equal(target) {
+		return nil, false
+	}
+	options := msg[24:]
+	for len(options) >= 8 {
+		length := int(options[1]) * 8
+		if length == 0 || len(options) < length {
+			break
+		}
+		// 选项类型2 目标链路层地址
+		if options[0] == 2 && length >= 8 {
+			return net.HardwareAddr(append([]byte(nil), options[2:8]...)), true
+		}
+		options = options[length:]
+	}
+	// 未携带目标链路层地址选项时仍判定主机在线
+	return net.HardwareAddr{}, true
+}
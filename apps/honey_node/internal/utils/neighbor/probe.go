@@ -0,0 +1,100 @@
+package neighbor
+
+// File: honey_node/utils/neighbor/probe.go
+// Description: 地址占用探测，用于本机网卡尚未配置该地址时确认地址空闲，IPv4发送发送方地址为0.0.0.0的ARP探测报文（RFC 5227），IPv6使用NDP邻居请求
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// arpProbeTimeout ARP探测等待应答的超时时间
+const arpProbeTimeout = time.Second
+
+// Probe 探测地址是否已被其他主机占用，返回占用主机的mac地址，地址空闲时返回ErrTimeout
+// 与Ping不同，探测不要求网卡上已配置同网段地址
+func Probe(ip net.IP, iface string) (net.HardwareAddr, error) {
+	if ip == nil {
+		return nil, fmt.Errorf("无效的ip地址")
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return arpProbe(ip4, iface)
+	}
+	mac, _, err := ndpPing(ip.To16(), iface)
+	return mac, err
+}
+
+// arpProbe 在指定网卡广播ARP探测报文，收到以目标地址为发送方地址的其他主机ARP报文即判定地址已被占用
+func arpProbe(target net.IP, ifaceName string) (net.HardwareAddr, error) {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return nil, fmt.Errorf("网卡 %s 不存在 %s", ifaceName, err)
+	}
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, int(htons(unix.ETH_P_ARP)))
+	if err != nil {
+		return nil, fmt.Errorf("创建ARP套接字失败 %s", err)
+	}
+	defer unix.Close(fd)
+	if err = unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(unix.ETH_P_ARP), Ifindex: iface.Index}); err != nil {
+		return nil, fmt.Errorf("绑定网卡 %s 失败 %s", ifaceName, err)
+	}
+	tv := unix.NsecToTimeval(int64(arpProbeTimeout / 5))
+	unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv)
+
+	broadcast := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	dst := &unix.SockaddrLinklayer{Protocol: htons(unix.ETH_P_ARP), Ifindex: iface.Index, Halen: 6}
+	copy(dst.Addr[:], broadcast)
+	if err = unix.Sendto(fd, arpProbeFrame(target, iface.HardwareAddr, broadcast), 0, dst); err != nil {
+		return nil, fmt.Errorf("发送ARP探测失败 %s", err)
+	}
+
+	deadline := time.Now().Add(arpProbeTimeout)
+	buffer := make([]byte, 1500)
+	for time.Now().Before(deadline) {
+		n, _, err := unix.Recvfrom(fd, buffer, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			break
+		}
+		// 以太网头14字节，ARP报文28字节
+		if n < 42 {
+			continue
+		}
+		arp := buffer[14:42]
+		senderMac, senderIP := arp[8:14], net.IP(arp[14:18])
+		if senderIP.Equal(target) && !bytes.Equal(senderMac, iface.HardwareAddr) {
+			return append(net.HardwareAddr(nil), senderMac...), nil
+		}
+	}
+	return nil, ErrTimeout
+}
+
+// arpProbeFrame 构造ARP探测以太网帧：发送方地址0.0.0.0，目标mac全0
+func arpProbeFrame(target net.IP, mac net.HardwareAddr, broadcast []byte) []byte {
+	frame := make([]byte, 42)
+	copy(frame[0:6], broadcast)
+	copy(frame[6:12], mac)
+	binary.BigEndian.PutUint16(frame[12:14], unix.ETH_P_ARP)
+
+	arp := frame[14:]
+	binary.BigEndian.PutUint16(arp[0:2], 1)             // 硬件类型：以太网
+	binary.BigEndian.PutUint16(arp[2:4], unix.ETH_P_IP) // 协议类型：IPv4
+	arp[4], arp[5] = 6, 4                               // 硬件地址长度、协议地址长度
+	binary.BigEndian.PutUint16(arp[6:8], 1)             // 操作码：请求
+	copy(arp[8:14], mac)
+	copy(arp[24:28], target.To4())
+	return frame
+}
+
+// htons 主机字节序转网络字节序
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
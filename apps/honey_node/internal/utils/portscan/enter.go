@@ -0,0 +1,155 @@
+package portscan
+
+// File: honey_node/utils/portscan/enter.go
+// Description: 端口扫描工具包，支持TCP connect及SYN两种方式探测主机开放端口，并对开放端口抓取服务banner
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// 端口探测方式
+const (
+	ModeConnect = 1 // TCP connect，完成三次握手
+	ModeSyn     = 2 // SYN，收到SYN-ACK即判定开放，无需完成握手
+)
+
+const (
+	defaultTimeout = time.Second     // 未指定时单个端口的探测超时
+	bannerWait     = 2 * time.Second // 等待服务banner的最长时间
+	maxBannerRead  = 1024            // 单次读取banner的最大字节数
+	maxBannerSize  = 256             // 保留的banner最大字节数
+)
+
+// httpProbe 服务端未主动发送banner时发送的探测请求，多数文本协议会返回错误提示或响应头
+var httpProbe = []byte("HEAD / HTTP/1.0\r\n\r\n")
+
+// Port 开放端口信息
+type Port struct {
+	Port   int    // 端口号
+	Banner string // 服务banner
+}
+
+// Scanner 端口扫描器，所有主机的端口探测共用并发上限
+type Scanner struct {
+	mode    int           // 探测方式
+	timeout time.Duration // 单个端口的探测超时
+	sem     chan struct{} // 端口探测并发控制信号量
+	syn     *synProber    // SYN探测器（SYN方式时有效）
+}
+
+// New 创建端口扫描器，SYN方式需要原始套接字权限，iface为发送SYN使用的网卡
+func New(mode int, iface string, timeout time.Duration, concurrency int) (*Scanner, error) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	s := &Scanner{
+		mode:    ModeConnect,
+		timeout: timeout,
+		sem:     make(chan struct{}, concurrency),
+	}
+	if mode == ModeSyn {
+		syn, err := newSynProber(iface)
+		if err != nil {
+			return nil, err
+		}
+		s.mode = ModeSyn
+		s.syn = syn
+	}
+	return s, nil
+}
+
+// Mode 获取扫描器实际使用的探测方式
+func (s *Scanner) Mode() int {
+	return s.mode
+}
+
+// Close 释放扫描器资源
+func (s *Scanner) Close() {
+	if s.syn != nil {
+		s.syn.close()
+	}
+}
+
+// Scan 探测主机的端口列表，返回按端口号排列的开放端口及banner
+func (s *Scanner) Scan(ip string, portList []int) []Port {
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	list := make([]Port, 0)
+	for _, port := range portList {
+		wg.Add(1)
+		s.sem <- struct{}{}
+		go func(port int) {
+			defer wg.Done()
+			defer func() {
+				<-s.sem
+			}()
+			banner, ok := s.probe(ip, port)
+			if !ok {
+				return
+			}
+			lock.Lock()
+			list = append(list, Port{Port: port, Banner: banner})
+			lock.Unlock()
+		}(port)
+	}
+	wg.Wait()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Port < list[j].Port
+	})
+	return list
+}
+
+// probe 探测单个端口，开放时连接端口抓取banner
+func (s *Scanner) probe(ip string, port int) (string, bool) {
+	// SYN探测器仅支持IPv4，IPv6主机使用TCP connect方式
+	useSyn := s.mode == ModeSyn && net.ParseIP(ip).To4() != nil
+	if useSyn && !s.syn.probe(net.ParseIP(ip), port, s.timeout) {
+		return "", false
+	}
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(ip, strconv.Itoa(port)), s.timeout)
+	if err != nil {
+		// SYN方式已确认端口开放，抓取banner失败不影响结果
+		return "", useSyn
+	}
+	defer conn.Close()
+	return grabBanner(conn), true
+}
+
+// grabBanner 读取服务端主动发送的banner，未收到时发送探测请求后再读取
+func grabBanner(conn net.Conn) string {
+	buf := make([]byte, maxBannerRead)
+	conn.SetReadDeadline(time.Now().Add(bannerWait))
+	n, _ := conn.Read(buf)
+	if n == 0 {
+		conn.SetDeadline(time.Now().Add(bannerWait))
+		if _, err := conn.Write(httpProbe); err != nil {
+			return ""
+		}
+		n, _ = conn.Read(buf)
+	}
+	return cleanBanner(buf[:n])
+}
+
+// cleanBanner 将banner中的不可见字符替换为空格并截断
+func cleanBanner(data []byte) string {
+	banner := strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || unicode.IsPrint(r) {
+			return r
+		}
+		if r == '\r' {
+			return -1
+		}
+		return ' '
+	}, strings.ToValidUTF8(string(data), ""))
+	banner = strings.TrimSpace(banner)
+	if len(banner) > maxBannerSize {
+		banner = strings.ToValidUTF8(banner[:maxBannerSize], "")
+	}
+	return banner
+}
@@ -0,0 +1,208 @@
+package portscan
+
+// File: honey_node/utils/portscan/syn.go
+// Description: SYN端口探测，通过原始套接字发送SYN报文并接收SYN-ACK或RST应答判断端口状态，内核对收到的SYN-ACK自动回复RST，不建立完整连接
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"honey_node/internal/utils/ip"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// TCP标志位
+const (
+	tcpFlagSyn = 0x02
+	tcpFlagRst = 0x04
+	tcpFlagAck = 0x10
+)
+
+// synProber SYN探测器，单个原始套接字发送全部SYN报文，接收协程按来源地址分发应答
+type synProber struct {
+	fd       int                  // 原始套接字
+	srcIP    net.IP               // 发送SYN使用的源地址
+	srcPort  uint16               // 发送SYN使用的源端口
+	lock     sync.Mutex           // 等待表读写锁
+	waiters  map[string]chan bool // 目标ip:端口 -> 探测结果通知
+	done     chan struct{}        // 关闭信号
+	stopOnce sync.Once            // 保证只关闭一次
+}
+
+// newSynProber 在指定网卡的IPv4地址上创建SYN探测器
+func newSynProber(iface string) (*synProber, error) {
+	src, _, err := ip.GetNetworkInfo(iface)
+	if err != nil {
+		return nil, err
+	}
+	srcIP := net.ParseIP(src).To4()
+
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_RAW, unix.IPPROTO_TCP)
+	if err != nil {
+		return nil, fmt.Errorf("创建原始套接字失败 %s", err)
+	}
+	// 绑定源地址，保证内核填充的源地址与校验和计算使用的地址一致
+	sa := &unix.SockaddrInet4{}
+	copy(sa.Addr[:], srcIP)
+	if err = unix.Bind(fd, sa); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("绑定源地址失败 %s", err)
+	}
+	// 设置读超时，保证关闭信号能及时生效
+	tv := unix.Timeval{Usec: 200000}
+	if err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("设置读超时失败 %s", err)
+	}
+
+	p := &synProber{
+		fd:      fd,
+		srcIP:   srcIP,
+		srcPort: uint16(40000 + rand.Intn(20000)),
+		waiters: map[string]chan bool{},
+		done:    make(chan struct{}),
+	}
+	go p.recvLoop()
+	return p, nil
+}
+
+// close 停止接收并关闭原始套接字
+func (p *synProber) close() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+// probe 向目标端口发送SYN并等待应答，超时未应答时重发一次，收到SYN-ACK返回true
+func (p *synProber) probe(dstIP net.IP, port int, timeout time.Duration) bool {
+	dstIP = dstIP.To4()
+	if dstIP == nil {
+		return false
+	}
+	key := waiterKey(dstIP, uint16(port))
+	result := make(chan bool, 1)
+	p.lock.Lock()
+	p.waiters[key] = result
+	p.lock.Unlock()
+	defer func() {
+		p.lock.Lock()
+		delete(p.waiters, key)
+		p.lock.Unlock()
+	}()
+
+	for i := 0; i < 2; i++ {
+		if err := p.send(dstIP, uint16(port)); err != nil {
+			return false
+		}
+		select {
+		case open := <-result:
+			return open
+		case <-time.After(timeout):
+		case <-p.done:
+			return false
+		}
+	}
+	return false
+}
+
+// send 构造并发送SYN报文，IP头由内核填充
+func (p *synProber) send(dstIP net.IP, port uint16) error {
+	header := make([]byte, 20)
+	binary.BigEndian.PutUint16(header[0:2], p.srcPort)
+	binary.BigEndian.PutUint16(header[2:4], port)
+	binary.BigEndian.PutUint32(header[4:8], rand.Uint32()) // 序列号
+	header[12] = 5 << 4                                    // 首部长度 5*4 字节
+	header[13] = tcpFlagSyn
+	binary.BigEndian.PutUint16(header[14:16], 64240) // 窗口大小
+	binary.BigEndian.PutUint16(header[16:18], tcpChecksum(p.srcIP, dstIP, header))
+
+	sa := &unix.SockaddrInet4{}
+	copy(sa.Addr[:], dstIP)
+	return unix.Sendto(p.fd, header, 0, sa)
+}
+
+// recvLoop 接收发往探测源端口的TCP应答并通知对应的等待方
+func (p *synProber) recvLoop() {
+	defer unix.Close(p.fd)
+	buffer := make([]byte, 1500)
+	for {
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+
+		n, _, err := unix.Recvfrom(p.fd, buffer, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return
+		}
+		// 原始套接字收到的数据包含IP头
+		packet := buffer[:n]
+		if len(packet) < 20 || packet[0]>>4 != 4 {
+			continue
+		}
+		ihl := int(packet[0]&0x0f) * 4
+		if len(packet) < ihl+14 {
+			continue
+		}
+		tcp := packet[ihl:]
+		if binary.BigEndian.Uint16(tcp[2:4]) != p.srcPort {
+			continue
+		}
+		flags := tcp[13]
+		var open bool
+		switch {
+		case flags&(tcpFlagSyn|tcpFlagAck) == tcpFlagSyn|tcpFlagAck:
+			open = true
+		case flags&tcpFlagRst != 0:
+			open = false
+		default:
+			continue
+		}
+
+		key := waiterKey(net.IP(packet[12:16]), binary.BigEndian.Uint16(tcp[0:2]))
+		p.lock.Lock()
+		result, ok := p.waiters[key]
+		p.lock.Unlock()
+		if ok {
+			select {
+			case result <- open:
+			default:
+			}
+		}
+	}
+}
+
+// waiterKey 生成等待表key
+func waiterKey(ip net.IP, port uint16) string {
+	return fmt.Sprintf("%s:%d", ip, port)
+}
+
+// tcpChecksum 计算包含伪首部的TCP校验和
+func tcpChecksum(srcIP, dstIP net.IP, segment []byte) uint16 {
+	var sum uint32
+	pseudo := make([]byte, 0, 12+len(segment))
+	pseudo = append(pseudo, srcIP.To4()...)
+	pseudo = append(pseudo, dstIP.To4()...)
+	pseudo = append(pseudo, 0, unix.IPPROTO_TCP)
+	pseudo = binary.BigEndian.AppendUint16(pseudo, uint16(len(segment)))
+	pseudo = append(pseudo, segment...)
+	for i := 0; i+1 < len(pseudo); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(pseudo[i:]))
+	}
+	if len(pseudo)%2 == 1 {
+		sum += uint32(pseudo[len(pseudo)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
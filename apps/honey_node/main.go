@@ -4,13 +4,18 @@ import (
 	"honey_node/internal/core"
 	"honey_node/internal/flags"
 	"honey_node/internal/global"
+	"honey_node/internal/service/cert_service"
 	"honey_node/internal/service/command"
+	"honey_node/internal/service/conflict_service"
 	"honey_node/internal/service/cron_service"
+	"honey_node/internal/service/emulator_service"
 	"honey_node/internal/service/ip_service"
+	"honey_node/internal/service/metrics_service"
 	"honey_node/internal/service/mq_service"
 	"honey_node/internal/service/port_service"
 	"honey_node/internal/service/suricata_service"
 	"honey_node/internal/service/task_service"
+	"honey_node/internal/service/upgrade_service"
 
 	"github.com/sirupsen/logrus"
 )
@@ -31,21 +36,43 @@ func main() {
 	// 建表
 	flags.Migrate()
 
+	// 升级服务容器：只负责节点容器切换，不注册节点
+	if flags.Options.Updater {
+		upgrade_service.Serve()
+		return
+	}
+
 	// 创建gRPC客户端连接
 	global.GrpcClient = core.GetGrpcClient()
 
+	// 加载节点证书，首次接入时使用引导证书向管理端申请
+	if err := cert_service.Ensure(global.GrpcClient); err != nil {
+		logrus.Fatalf("节点证书申请失败: %v", err)
+	}
+
 	// 初始化节点客户端
 	nodeClient = command.NewNodeClient(global.GrpcClient, global.Config)
 
 	// 执行节点注册流程
 	if err := nodeClient.Register(); err != nil {
+		// 节点证书被管理端拒绝（节点已删除导致证书吊销、节点接入被拒绝等），清除本地证书，重启后重新申请
+		if cert_service.IsRejected(err) {
+			cert_service.Reset()
+			logrus.Fatalf("节点证书已失效或节点接入被拒绝，已清除本地证书: %v", err)
+		}
 		logrus.Fatalf("节点注册失败: %v", err)
 		return
 	}
 
+	// 新版本注册成功，确认升级切换
+	upgrade_service.Confirm()
+
 	// 运行命令行参数处理
 	flags.Run()
 
+	// 启动Prometheus指标监听
+	metrics_service.Run()
+
 	// 初始化rabbitMQ连接
 	queue, err := core.InitMQ()
 	if err != nil {
@@ -68,9 +95,21 @@ func main() {
 	// 加载诱捕ip
 	ip_service.IPLoad()
 
+	// 注册隧道会话记录上报
+	port_service.SetSessionReporter(mq_service.SendSessionMsg)
+
+	// 注册连接限制事件上报
+	port_service.SetLimitReporter(mq_service.SendLimitEvent)
+
+	// 注册模拟器登录告警上报
+	emulator_service.SetLoginReporter(mq_service.SendEmuLoginAlert)
+
 	// 加载端口转发
 	port_service.LoadTunnel()
 
+	// 启动诱捕ip冲突监测
+	conflict_service.Run()
+
 	// 启动任务服务
 	task_service.Run()
 
@@ -15,6 +15,7 @@ import (
 	"honey_server/internal/api/node_api"
 	"honey_server/internal/api/node_network_api"
 	"honey_server/internal/api/node_version_api"
+	"honey_server/internal/api/rule_set_api"
 	"honey_server/internal/api/site_api"
 	"honey_server/internal/api/user_api"
 )
@@ -34,6 +35,7 @@ type Api struct {
 	IndexApi       index_api.IndexApi
 	SiteApi        site_api.SiteApi
 	NodeVersionApi node_version_api.NodeVersionApi
+	RuleSetApi     rule_set_api.RuleSetApi
 }
 
 var App = Api{}
@@ -11,6 +11,7 @@ import (
 	"honey_server/internal/service/mq_service"
 	"honey_server/internal/service/redis_service/net_lock"
 	"honey_server/internal/utils"
+	"honey_server/internal/utils/ip"
 	"honey_server/internal/utils/response"
 
 	"github.com/gin-gonic/gin"
@@ -28,6 +29,8 @@ func (HoneyIPApi) CreateView(c *gin.Context) {
 
 	// 获取并绑定创建请求参数
 	cr := middleware.GetBind[CreateRequest](c)
+	// IPv6地址统一为标准格式，与可部署ip列表及节点上报的地址保持一致
+	cr.IP = ip.NormalizeIP(cr.IP)
 
 	log.WithFields(map[string]interface{}{
 		"net_id": cr.NetID,
@@ -144,7 +147,7 @@ func (HoneyIPApi) CreateView(c *gin.Context) {
 	mq_service.SendCreateIPMsg(netModel.NodeModel.Uid, mq_service.CreateIPRequest{
 		HoneyIPID: model.ID,
 		IP:        model.IP,
-		Mask:      netModel.Mask,
+		Mask:      netModel.MaskOf(model.IP),
 		Network:   netModel.Network,
 		IsTan:     isTan,
 		LogID:     log.Data["logID"].(string),
@@ -0,0 +1,162 @@
+package honey_ip_api
+
+// File: honey_server/api/honey_ip_api/pcap_capture.go
+// Description: 诱捕IP抓包启动/停止API接口，通过命令流下发抓包指令，抓包文件由节点停止后分块上传
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/utils/response"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PcapStartRequest 抓包启动请求参数结构体
+type PcapStartRequest struct {
+	HoneyIpID uint  `json:"honeyIpID" binding:"required"`                // 诱捕ipID（必填）
+	Duration  int   `json:"duration" binding:"omitempty,min=1,max=3600"` // 最长抓包时间（秒），默认60
+	MaxBytes  int64 `json:"maxBytes" binding:"omitempty,min=1"`          // 环形缓冲区容量（字节），默认16MB
+}
+
+// PcapStartView 启动诱捕IP抓包
+func (HoneyIPApi) PcapStartView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[PcapStartRequest](c)
+
+	log.WithFields(map[string]interface{}{
+		"honey_ip_id": cr.HoneyIpID,
+		"duration":    cr.Duration,
+		"max_bytes":   cr.MaxBytes,
+	}).Info("pcap capture start request received") // 收到抓包启动请求
+
+	var honeyIPModel models.HoneyIpModel
+	if err := global.DB.Preload("NodeModel").Take(&honeyIPModel, cr.HoneyIpID).Error; err != nil {
+		response.FailWithMsg("不存在的诱捕ip", c)
+		return
+	}
+	if honeyIPModel.Status != 2 {
+		response.FailWithMsg("诱捕ip未运行", c)
+		return
+	}
+	cmd, ok := grpc_service.GetNodeCommand(honeyIPModel.NodeModel.Uid)
+	if !ok {
+		log.WithFields(map[string]interface{}{
+			"node_uid": honeyIPModel.NodeModel.Uid,
+		}).Warn("node is offline") // 节点离线
+		response.FailWithMsg("节点离线中", c)
+		return
+	}
+
+	model := models.HoneyIpPcapModel{
+		NodeID:    honeyIPModel.NodeID,
+		HoneyIpID: honeyIPModel.ID,
+		CaptureID: uuid.New().String(),
+		IP:        honeyIPModel.IP,
+		Duration:  cr.Duration,
+		MaxBytes:  cr.MaxBytes,
+		Status:    1, // 抓包中
+	}
+	if err := global.DB.Create(&model).Error; err != nil {
+		log.WithFields(map[string]interface{}{
+			"error": err,
+		}).Error("failed to create pcap record") // 创建抓包记录失败
+		response.FailWithMsg("创建抓包记录失败", c)
+		return
+	}
+
+	err := sendPcapCommand(cmd, &node_rpc.PcapCaptureInMessage{
+		CaptureID: model.CaptureID,
+		Ip:        model.IP,
+		Duration:  int32(cr.Duration),
+		MaxBytes:  cr.MaxBytes,
+	}, log.Data["logID"].(string))
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"capture_id": model.CaptureID,
+			"error":      err,
+		}).Error("failed to start pcap capture") // 启动抓包失败
+		global.DB.Model(&model).Updates(map[string]any{
+			"status":    4,
+			"error_msg": err.Error(),
+		})
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"capture_id": model.CaptureID,
+		"honey_ip":   model.IP,
+	}).Info("pcap capture started") // 抓包已启动
+	response.Ok(model, "抓包已启动", c)
+}
+
+// PcapStopView 停止诱捕IP抓包，节点停止后上传抓包文件
+func (HoneyIPApi) PcapStopView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[models.IDRequest](c)
+
+	var model models.HoneyIpPcapModel
+	if err := global.DB.Preload("HoneyIpModel.NodeModel").Take(&model, cr.Id).Error; err != nil {
+		response.FailWithMsg("抓包记录不存在", c)
+		return
+	}
+	if model.Status != 1 {
+		response.FailWithMsg("当前抓包未在运行", c)
+		return
+	}
+	cmd, ok := grpc_service.GetNodeCommand(model.HoneyIpModel.NodeModel.Uid)
+	if !ok {
+		response.FailWithMsg("节点离线中", c)
+		return
+	}
+
+	err := sendPcapCommand(cmd, &node_rpc.PcapCaptureInMessage{
+		CaptureID: model.CaptureID,
+		Stop:      true,
+	}, log.Data["logID"].(string))
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"capture_id": model.CaptureID,
+			"error":      err,
+		}).Error("failed to stop pcap capture") // 停止抓包失败
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	// 上传完成前可能已由节点超时自动结束，仅在仍为抓包中时更新状态
+	global.DB.Model(&model).Where("status = ?", 1).Update("status", 2)
+	log.WithFields(map[string]interface{}{
+		"capture_id": model.CaptureID,
+	}).Info("pcap capture stopped") // 抓包已停止
+	response.OkWithMsg("抓包已停止，文件上传中", c)
+}
+
+// sendPcapCommand 下发抓包命令并等待节点确认
+func sendPcapCommand(cmd *grpc_service.Command, in *node_rpc.PcapCaptureInMessage, logID string) error {
+	req := &node_rpc.CmdRequest{
+		CmdType:              node_rpc.CmdType_cmdPcapCaptureType,
+		TaskID:               fmt.Sprintf("pcap-%d", time.Now().UnixNano()),
+		LogID:                logID,
+		PcapCaptureInMessage: in,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := cmd.Call(ctx, req)
+	if err != nil {
+		return err
+	}
+	if msg := res.GetPcapCaptureOutMessage().GetErrMsg(); msg != "" {
+		return errors.New(msg)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package honey_ip_api
+
+// File: honey_server/api/honey_ip_api/pcap_list.go
+// Description: 诱捕IP抓包记录列表、下载及删除API接口
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/response"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PcapListRequest 抓包记录列表查询请求参数结构体
+type PcapListRequest struct {
+	models.PageInfo      // 分页参数
+	HoneyIpID       uint `form:"honeyIpID" binding:"required"` // 诱捕ipID（必填）
+}
+
+// PcapListView 查询指定诱捕IP的抓包记录
+func (HoneyIPApi) PcapListView(c *gin.Context) {
+	cr := middleware.GetBind[PcapListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.HoneyIpPcapModel{HoneyIpID: cr.HoneyIpID}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Sort:     "created_at desc",
+	})
+
+	response.OkWithList(list, count, c)
+}
+
+// PcapDownloadView 下载抓包文件
+func (HoneyIPApi) PcapDownloadView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDRequest](c)
+
+	var model models.HoneyIpPcapModel
+	if err := global.DB.Take(&model, cr.Id).Error; err != nil {
+		response.FailWithMsg("抓包记录不存在", c)
+		return
+	}
+	if model.Path == "" {
+		response.FailWithMsg("抓包文件尚未上传", c)
+		return
+	}
+
+	fileName := fmt.Sprintf("%s_%s.pcap", model.IP, model.CreatedAt.Format("20060102150405"))
+	c.Header("Content-Type", "application/vnd.tcpdump.pcap")                           // pcap文件类型
+	c.Header("Content-Disposition", "attachment; filename="+url.QueryEscape(fileName)) // 下载文件名（转义特殊字符）
+	c.Header("Content-Transfer-Encoding", "binary")                                    // 二进制传输编码
+	c.File(model.Path)
+}
+
+// PcapRemoveView 批量删除抓包记录及文件
+func (HoneyIPApi) PcapRemoveView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[models.IDListRequest](c)
+
+	// 抓包中的记录需先停止，避免上传时找不到记录
+	successCount, err := common_service.Remove(models.HoneyIpPcapModel{}, common_service.RemoveRequest{
+		Where:  global.DB.Where("status in ?", []int8{3, 4}),
+		IDList: cr.IdList,
+		Log:    log,
+		Msg:    "抓包记录",
+	})
+	if err != nil {
+		response.FailWithMsg("删除抓包记录失败", c)
+		return
+	}
+	response.OkWithMsg(fmt.Sprintf("删除抓包记录%d个", successCount), c)
+}
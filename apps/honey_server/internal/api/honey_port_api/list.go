@@ -39,9 +39,13 @@ func (HoneyPortApi) ListView(c *gin.Context) {
 	// 转换数据格式，补充关联服务名称
 	var list = make([]ListResponse, 0)
 	for _, model := range _list {
+		serviceTitle := model.ServiceModel.Title // 从关联服务模型获取名称
+		if model.Emulator != "" {
+			serviceTitle = model.Emulator + "模拟器"
+		}
 		list = append(list, ListResponse{
 			HoneyPortModel: model,
-			ServiceTitle:   model.ServiceModel.Title, // 从关联服务模型获取名称
+			ServiceTitle:   serviceTitle,
 		})
 	}
 
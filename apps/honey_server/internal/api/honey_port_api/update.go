@@ -24,8 +24,9 @@ type UpdateRequest struct {
 
 // PortType 端口配置项结构体
 type PortType struct {
-	Port      int  `json:"port" binding:"required,min=1,max=65535"` // 端口号（必填，范围1-65535）
-	ServiceID uint `json:"serviceID" binding:"required"`            // 关联的服务ID（必填）
+	Port      int    `json:"port" binding:"required,min=1,max=65535"`                        // 端口号（必填，范围1-65535）
+	ServiceID uint   `json:"serviceID" binding:"required_without=Emulator"`                  // 关联的服务ID（未指定模拟器时必填）
+	Emulator  string `json:"emulator" binding:"omitempty,oneof=ssh telnet redis mysql http"` // 节点内置模拟器名称（与关联服务二选一）
 }
 
 // UpdateView 处理诱捕转发更新请求，实现端口配置的增量更新
@@ -85,23 +86,13 @@ func (HoneyPortApi) UpdateView(c *gin.Context) {
 		return
 	}
 
-	// 校验端口配置有效性：端口不重复 + 服务ID存在性
-	portMap := make(map[int]struct{}) // 用于检测端口重复
-	serviceIDList := make([]uint, 0)  // 收集所有关联的服务ID
+	// 收集所有关联的服务ID
+	serviceIDList := make([]uint, 0)
 	for _, portType := range cr.PortList {
+		if portType.Emulator != "" {
+			continue // 内置模拟器端口不关联服务
+		}
 		serviceIDList = append(serviceIDList, portType.ServiceID)
-		portMap[portType.Port] = struct{}{}
-	}
-
-	// 检查是否存在重复端口
-	if len(portMap) != len(cr.PortList) {
-		log.WithFields(map[string]interface{}{
-			"honey_ip_id": cr.HoneyIPID,
-			"requested":   len(cr.PortList),
-			"unique":      len(portMap),
-		}).Warn("duplicate ports in request") // 存在重复端口
-		response.FailWithMsg("端口重复", c)
-		return
 	}
 
 	// 查询所有关联的服务信息，验证服务ID有效性
@@ -120,17 +111,17 @@ func (HoneyPortApi) UpdateView(c *gin.Context) {
 		serviceMap[model.ID] = model
 	}
 
-	// 对比现有端口与请求端口，计算新增/删除的端口配置
-	// 1. 构建现有端口的映射表（端口号->端口模型）
-	existingPorts := make(map[int]models.HoneyPortModel)
-	for _, port := range honeyPortList {
-		existingPorts[port.Port] = port
-	}
-
-	// 2. 筛选需要新增的端口(端口不存在)
-	var newPorts []models.HoneyPortModel
+	// 校验端口配置有效性：服务ID存在性 + 同协议下端口不重复
+	portMap := make(map[models.PortKey]PortType) // 请求端口映射表（协议+端口号->端口配置）
 	for _, reqPort := range cr.PortList {
-		// 验证服务ID是否存在
+		if reqPort.Emulator != "" {
+			if reqPort.ServiceID != 0 {
+				response.FailWithMsg(fmt.Sprintf("端口 %d 不能同时关联虚拟服务和模拟器", reqPort.Port), c)
+				return
+			}
+			portMap[models.PortKey{Agreement: 1, Port: reqPort.Port}] = reqPort // 内置模拟器均为TCP
+			continue
+		}
 		service, ok := serviceMap[reqPort.ServiceID]
 		if !ok {
 			log.WithFields(map[string]interface{}{
@@ -140,13 +131,59 @@ func (HoneyPortApi) UpdateView(c *gin.Context) {
 			response.FailWithMsg(fmt.Sprintf("服务%d不存在", reqPort.ServiceID), c)
 			return
 		}
+		portMap[models.PortKey{Agreement: service.Agreement, Port: reqPort.Port}] = reqPort
+	}
+
+	// 检查是否存在重复端口
+	if len(portMap) != len(cr.PortList) {
+		log.WithFields(map[string]interface{}{
+			"honey_ip_id": cr.HoneyIPID,
+			"requested":   len(cr.PortList),
+			"unique":      len(portMap),
+		}).Warn("duplicate ports in request") // 存在重复端口
+		response.FailWithMsg("端口重复", c)
+		return
+	}
+
+	// 对比现有端口与请求端口，计算新增/删除的端口配置
+	// 1. 构建现有端口的映射表（协议+端口号->端口模型）
+	existingPorts := make(map[models.PortKey]models.HoneyPortModel)
+	for _, port := range honeyPortList {
+		existingPorts[port.Key()] = port
+	}
 
-		// 端口不存在则加入新增列表
-		if _, exists := existingPorts[reqPort.Port]; !exists {
+	// 2. 筛选需要新增的端口(端口不存在)
+	var newPorts []models.HoneyPortModel
+	var portsToDelete []models.HoneyPortModel
+	for key, reqPort := range portMap {
+		if existing, exists := existingPorts[key]; exists && existing.Emulator != reqPort.Emulator {
+			// 同一端口在服务与模拟器之间切换时，先删除旧配置再新增
+			delete(existingPorts, key)
+			portsToDelete = append(portsToDelete, existing)
+		}
+		if _, exists := existingPorts[key]; !exists {
+			if reqPort.Emulator != "" {
+				newPorts = append(newPorts, models.HoneyPortModel{
+					HoneyIpID: cr.HoneyIPID,
+					IP:        honeyIPModel.IP,
+					Port:      reqPort.Port,
+					Agreement: 1,
+					Emulator:  reqPort.Emulator,
+					Status:    1, // 启用状态
+				})
+				log.WithFields(map[string]interface{}{
+					"honey_ip_id": cr.HoneyIPID,
+					"port":        reqPort.Port,
+					"emulator":    reqPort.Emulator,
+				}).Debug("new emulator port to be added") // 新增模拟器端口
+				continue
+			}
+			service := serviceMap[reqPort.ServiceID]
 			newPort := models.HoneyPortModel{
 				HoneyIpID: cr.HoneyIPID,
 				IP:        honeyIPModel.IP,
 				Port:      reqPort.Port,
+				Agreement: service.Agreement, // 从服务配置获取通信协议
 				ServiceID: reqPort.ServiceID,
 				DstIP:     service.IP,   // 从服务配置获取目标IP
 				DstPort:   service.Port, // 从服务配置获取目标端口
@@ -156,26 +193,20 @@ func (HoneyPortApi) UpdateView(c *gin.Context) {
 			log.WithFields(map[string]interface{}{
 				"honey_ip_id": cr.HoneyIPID,
 				"port":        newPort.Port,
+				"agreement":   newPort.Agreement,
 				"service_id":  newPort.ServiceID,
 			}).Debug("new port to be added") // 新增端口
 		}
 	}
 
 	// 3. 筛选需要删除的端口(端口存在、请求中无)
-	var portsToDelete []models.HoneyPortModel
-	for port, model := range existingPorts {
-		found := false
-		for _, reqPort := range cr.PortList {
-			if reqPort.Port == port {
-				found = true
-				break
-			}
-		}
-		if !found {
+	for key, model := range existingPorts {
+		if _, found := portMap[key]; !found {
 			portsToDelete = append(portsToDelete, model)
 			log.WithFields(map[string]interface{}{
 				"honey_ip_id": cr.HoneyIPID,
 				"port":        model.Port,
+				"agreement":   model.Agreement,
 				"port_id":     model.ID,
 			}).Debug("port to be deleted") // 删除端口
 		}
@@ -263,10 +294,12 @@ func (HoneyPortApi) UpdateView(c *gin.Context) {
 	}
 	for _, model := range updatedPortList {
 		req.PortList = append(req.PortList, mq_service.PortInfo{
-			IP:       model.IP,
-			Port:     model.Port,
-			DestIP:   model.DstIP,
-			DestPort: model.DstPort,
+			IP:        model.IP,
+			Port:      model.Port,
+			DestIP:    model.DstIP,
+			DestPort:  model.DstPort,
+			Agreement: model.Agreement,
+			Emulator:  model.Emulator,
 		})
 	}
 	mq_service.SendBindPortMsg(nodeModel.Uid, req)
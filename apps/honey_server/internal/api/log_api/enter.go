@@ -19,7 +19,7 @@ type LogApi struct{}
 // LogListRequest 日志列表查询请求参数结构体
 type LogListRequest struct {
 	models.PageInfo        // 分页信息（包含Page、PageSize字段）
-	Type            int8   `form:"type"` // 日志类型：1-登录日志
+	Type            int8   `form:"type"` // 日志类型：1-登录日志 2-操作日志
 	IP              string `form:"ip"` // 日志关联IP地址
 	Addr            string `form:"addr"` // 日志关联地址信息
 }
@@ -5,9 +5,11 @@ package net_api
 
 import (
 	"fmt"
+	"honey_server/internal/global"
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
 	"honey_server/internal/service/common_service"
+	"honey_server/internal/service/grpc_service"
 	"honey_server/internal/utils/response"
 
 	"github.com/gin-gonic/gin"
@@ -26,6 +28,10 @@ func (NetApi) RemoveView(c *gin.Context) {
 		"request_count": len(cr.IdList),
 	}).Info("network deletion request received") // 收到网络批量删除请求
 
+	// 删除前记录网络所属节点，删除后刷新节点的被动资产发现配置
+	var nodeList []models.NodeModel
+	global.DB.Where("id in (?)", global.DB.Model(&models.NetModel{}).Where("id in ?", cr.IdList).Select("node_id")).Find(&nodeList)
+
 	successCount, err := common_service.Remove(
 		models.NetModel{},
 		common_service.RemoveRequest{
@@ -50,6 +56,9 @@ func (NetApi) RemoveView(c *gin.Context) {
 		"request_count": len(cr.IdList),
 		"success_count": successCount,
 	}).Info("networks deleted successfully") // 网络删除完成
+	for _, node := range nodeList {
+		grpc_service.PushDiscoveryAsync(node, log.Data["logID"].(string))
+	}
 	// 构造删除成功提示信息，包含总数和成功数
 	msg := fmt.Sprintf("删除成功 共%d个，成功%d个", len(cr.IdList), successCount)
 	response.OkWithMsg(msg, c)
@@ -1,7 +1,7 @@
 package net_api
 
 // File: honey_server/api/net_api/scan.go
-// Description: 网络扫描API接口，实现扫描状态互斥与诱捕IP过滤，异步处理扫描结果并更新数据库
+// Description: 网络扫描API接口，实现扫描状态互斥与诱捕IP过滤，可选端口服务探测，异步处理扫描结果并更新数据库
 
 import (
 	"context"
@@ -24,16 +24,64 @@ import (
 var mutex sync.Mutex        // 全局互斥锁，控制子网扫描并发执行
 var netProgressMap sync.Map // 存储各子网扫描进度，key为网络ID，value为进度百分比
 
+const maxScanPortCount = 1000 // 单次端口服务探测的最大端口数
+
+// defaultScanPortList 未指定探测端口时使用的常用服务端口
+var defaultScanPortList = []int{
+	21, 22, 23, 25, 53, 80, 110, 111, 135, 139, 143, 389, 443, 445, 465, 587, 993, 995,
+	1433, 1521, 2049, 2375, 3306, 3389, 5432, 5900, 5985, 6379, 7001, 8000, 8080, 8443,
+	8888, 9000, 9090, 9200, 11211, 27017,
+}
+
+// ScanRequest 网络扫描请求参数结构体
+type ScanRequest struct {
+	Id          uint  `json:"id" binding:"required"` // 网络ID(必需)
+	ServiceScan bool  `json:"serviceScan"`           // 是否对存活主机进行端口服务探测
+	ScanMode    int8  `json:"scanMode"`              // 端口探测方式 1 TCP connect 2 SYN，默认TCP connect
+	PortList    []int `json:"portList"`              // 探测端口列表，为空时使用常用服务端口
+	Timeout     int   `json:"timeout"`               // 单个端口探测超时（毫秒），默认1000
+}
+
 // ScanView 带进度跟踪的网络扫描请求处理函数，支持扫描状态控制与进度实时更新
 func (NetApi) ScanView(c *gin.Context) {
-	// 获取请求绑定的网络ID参数
-	cr := middleware.GetBind[models.IDRequest](c)
+	// 获取请求绑定的网络ID及端口服务探测参数
+	cr := middleware.GetBind[ScanRequest](c)
 	log := middleware.GetLog(c)
 
 	log.WithFields(map[string]interface{}{
-		"net_id": cr.Id,
+		"net_id":       cr.Id,
+		"service_scan": cr.ServiceScan,
+		"scan_mode":    cr.ScanMode,
+		"port_count":   len(cr.PortList),
 	}).Info("network scan request received") // 收到网络扫描请求
 
+	// 校验端口服务探测参数
+	var portList []int32
+	if cr.ServiceScan {
+		if cr.ScanMode != 0 && cr.ScanMode != 1 && cr.ScanMode != 2 {
+			response.FailWithMsg("端口探测方式错误", c)
+			return
+		}
+		if len(cr.PortList) == 0 {
+			cr.PortList = defaultScanPortList
+		}
+		portSet := map[int]bool{}
+		for _, port := range cr.PortList {
+			if port < 1 || port > 65535 {
+				response.FailWithMsg(fmt.Sprintf("端口 %d 不合法", port), c)
+				return
+			}
+			if !portSet[port] {
+				portSet[port] = true
+				portList = append(portList, int32(port))
+			}
+		}
+		if len(portList) > maxScanPortCount {
+			response.FailWithMsg(fmt.Sprintf("探测端口数不能超过%d个", maxScanPortCount), c)
+			return
+		}
+	}
+
 	// 校验子网是否存在（预加载关联的节点信息）
 	var model models.NetModel
 	if err := global.DB.Preload("NodeModel").Take(&model, cr.Id).Error; err != nil {
@@ -105,6 +153,10 @@ func (NetApi) ScanView(c *gin.Context) {
 			IpRange:      model.CanUseHoneyIPRange, // 可扫描IP范围
 			FilterIPList: filterIPList,             // 过滤IP列表（诱捕IP）
 			NetID:        uint32(model.ID),         // 子网ID（适配gRPC参数类型）
+			ServiceScan:  cr.ServiceScan,           // 是否进行端口服务探测
+			ScanMode:     int32(cr.ScanMode),       // 端口探测方式
+			PortList:     portList,                 // 探测端口列表
+			Timeout:      int32(cr.Timeout),        // 单个端口探测超时（毫秒）
 		},
 	}
 
@@ -135,26 +187,29 @@ func (NetApi) ScanView(c *gin.Context) {
 		return
 	}
 
-	// 下发扫描指令至节点（非阻塞发送，防止通道阻塞）
-	select {
-	case cmd.ReqChan <- req:
-		log.WithFields(map[string]interface{}{
-			"net_id":      model.ID,
-			"node_uid":    model.NodeModel.Uid,
-			"task_id":     taskID,
-			"ip_range":    model.CanUseHoneyIPRange,
-			"filter_size": len(filterIPList),
-		}).Info("scan command sent to node") // 扫描命令发送到节点成功
-	default:
+	// 注册扫描任务的响应通道并下发扫描指令至节点
+	sendCtx, sendCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer sendCancel()
+	resChan, done, err := cmd.Request(sendCtx, req)
+	if err != nil {
 		log.WithFields(map[string]interface{}{
 			"net_id":   model.ID,
 			"node_uid": model.NodeModel.Uid,
 			"task_id":  taskID,
-			"error":    "command channel is busy",
+			"error":    err,
 		}).Warn("failed to send scan command") // 扫描命令发送到节点失败
-		response.FailWithMsg("发送命令通道繁忙", c)
+		// 指令未下发，恢复子网扫描状态
+		global.DB.Model(&model).Update("scan_status", 1)
+		response.FailWithMsg(err.Error(), c)
 		return
 	}
+	log.WithFields(map[string]interface{}{
+		"net_id":      model.ID,
+		"node_uid":    model.NodeModel.Uid,
+		"task_id":     taskID,
+		"ip_range":    model.CanUseHoneyIPRange,
+		"filter_size": len(filterIPList),
+	}).Info("scan command sent to node") // 扫描命令发送到节点成功
 
 	log.WithFields(map[string]interface{}{
 		"net_id":  model.ID,
@@ -168,9 +223,16 @@ func (NetApi) ScanView(c *gin.Context) {
 	}, "扫描任务已启动", c)
 
 	// 异步处理扫描结果（独立协程，避免阻塞HTTP响应）
-	go func(nodeUid string, netModel models.NetModel, cmdChan *grpc_service.Command, taskID string) {
-		// 设置5分钟超时上下文，防止协程泄漏
-		ctxAsync, cancelAsync := context.WithTimeout(context.Background(), 5*time.Minute)
+	go func(nodeUid string, netModel models.NetModel, resChan <-chan *node_rpc.CmdResponse, taskID string, serviceScan bool) {
+		// 扫描结束后注销任务的响应通道
+		defer done()
+
+		// 设置5分钟超时上下文（端口服务探测耗时较长，放宽至30分钟），防止协程泄漏
+		timeout := 5 * time.Minute
+		if serviceScan {
+			timeout = 30 * time.Minute
+		}
+		ctxAsync, cancelAsync := context.WithTimeout(context.Background(), timeout)
 		defer cancelAsync()
 
 		log := log.WithFields(map[string]interface{}{
@@ -185,21 +247,8 @@ func (NetApi) ScanView(c *gin.Context) {
 	label:
 		for {
 			select {
-			// 接收节点返回的扫描结果
-			case res := <-cmdChan.ResChan:
-				// 过滤非当前任务的响应（放回通道，供其他任务处理）
-				if res.TaskID != taskID {
-					log.WithFields(map[string]interface{}{
-						"received_task_id": res.TaskID,
-					}).Debug("discarding response for different task") // 过滤非当前任务的响应
-					select {
-					case cmdChan.ResChan <- res:
-					case <-ctxAsync.Done():
-						break label
-					}
-					continue
-				}
-
+			// 接收节点返回的当前任务扫描结果
+			case res := <-resChan:
 				log.WithFields(map[string]interface{}{
 					"progress": res.NetScanOutMessage.Progress,
 				}).Debug("received scan progress update") // 接收到扫描进度更新
@@ -228,6 +277,7 @@ func (NetApi) ScanView(c *gin.Context) {
 						"ip":       message.Ip,
 						"mac":      message.Mac,
 						"manuf":    message.Manuf,
+						"ports":    len(message.PortList),
 						"progress": message.Progress,
 					}).Info("discovered host during scan") // 发现主机
 				}
@@ -253,16 +303,16 @@ func (NetApi) ScanView(c *gin.Context) {
 			log.WithFields(map[string]interface{}{
 				"host_count": len(netScanMsg),
 			}).Info("processing scan results") // 处理扫描结果
-			processScanResult(netModel, netScanMsg, log.Data["logID"].(string))
+			processScanResult(netModel, netScanMsg, serviceScan, log.Data["logID"].(string))
 		} else {
 			log.Warn("no scan results received") // 未接收到扫描结果
 		}
 
-	}(model.NodeModel.Uid, model, cmd, taskID)
+	}(model.NodeModel.Uid, model, resChan, taskID, cr.ServiceScan)
 }
 
-// processScanResult 处理子网扫描结果，同步更新数据库中的主机信息
-func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOutMessage, logID string) {
+// processScanResult 处理子网扫描结果，同步更新数据库中的主机信息，开启端口服务探测时同时更新主机开放端口
+func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOutMessage, serviceScan bool, logID string) {
 	log := core.GetLogger().WithField("logID", logID)
 
 	log.Info("starting scan result processing") // 开始处理扫描结果
@@ -309,6 +359,8 @@ func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOut
 		}
 	}
 
+	scanTime := time.Now()
+
 	// 分类处理扫描结果：新增/更新/删除主机
 	var newHosts []models.HostModel     // 新增主机列表
 	var deletedHostIDs []uint           // 待删除主机ID列表
@@ -317,10 +369,14 @@ func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOut
 	// 处理扫描到的主机（新增/更新）
 	for ip, scanMsg := range scanResultMap {
 		if dbHost, exists := dbHostMap[ip]; exists {
-			// 主机已存在，校验MAC/厂商信息是否变更
-			if dbHost.Mac != scanMsg.Mac || dbHost.Manuf != scanMsg.Manuf {
+			// 主机已存在，校验MAC/厂商信息是否变更，端口服务探测结果每次均更新
+			if dbHost.Mac != scanMsg.Mac || dbHost.Manuf != scanMsg.Manuf || serviceScan {
 				dbHost.Mac = scanMsg.Mac
 				dbHost.Manuf = scanMsg.Manuf
+				if serviceScan {
+					dbHost.PortList = scanPortList(scanMsg)
+					dbHost.ScanTime = &scanTime
+				}
 				updatedHosts = append(updatedHosts, dbHost)
 				log.WithFields(map[string]interface{}{
 					"host_ip":   ip,
@@ -333,13 +389,18 @@ func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOut
 			delete(dbHostMap, ip) // 移除已处理的主机，剩余为失联主机
 		} else {
 			// 主机不存在，加入新增列表
-			newHosts = append(newHosts, models.HostModel{
+			host := models.HostModel{
 				NodeID: netModel.NodeModel.ID,
 				NetID:  netModel.ID,
 				IP:     scanMsg.Ip,
 				Mac:    scanMsg.Mac,
 				Manuf:  scanMsg.Manuf,
-			})
+			}
+			if serviceScan {
+				host.PortList = scanPortList(scanMsg)
+				host.ScanTime = &scanTime
+			}
+			newHosts = append(newHosts, host)
 			log.WithFields(map[string]interface{}{
 				"host_ip":  scanMsg.Ip,
 				"host_mac": scanMsg.Mac,
@@ -378,15 +439,14 @@ func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOut
 			}).Info("new hosts created in database") // 新主机创建数据库
 		}
 
-		// 更新主机信息
+		// 更新主机信息（端口列表需按json序列化，使用结构体更新指定字段）
 		if len(updatedHosts) > 0 {
+			columns := []string{"mac", "manuf"}
+			if serviceScan {
+				columns = append(columns, "port_list", "scan_time")
+			}
 			for _, host := range updatedHosts {
-				if err := tx.Model(&models.HostModel{}).
-					Where("id = ?", host.ID).
-					Updates(map[string]interface{}{
-						"mac":   host.Mac,
-						"manuf": host.Manuf,
-					}).Error; err != nil {
+				if err := tx.Model(&host).Select(columns).Updates(&host).Error; err != nil {
 					log.WithFields(map[string]interface{}{
 						"host_id": host.ID,
 						"error":   err,
@@ -429,3 +489,15 @@ func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOut
 		log.Info("database updated successfully with scan results") // 数据库成功更新扫描结果
 	}
 }
+
+// scanPortList 将节点上报的开放端口转换为主机端口列表
+func scanPortList(msg *node_rpc.NetScanOutMessage) models.HostPortList {
+	list := make(models.HostPortList, 0, len(msg.PortList))
+	for _, port := range msg.PortList {
+		list = append(list, models.HostPort{
+			Port:   int(port.Port),
+			Banner: port.Banner,
+		})
+	}
+	return list
+}
@@ -0,0 +1,81 @@
+package net_api
+
+// File: honey_server/api/net_api/service_list.go
+// Description: 网络服务资产API接口，按端口汇总子网内主机的端口服务探测结果
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/utils/response"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServiceListResponse 单个端口的服务资产统计
+type ServiceListResponse struct {
+	Port      int           `json:"port"`      // 端口号
+	HostCount int           `json:"hostCount"` // 开放该端口的主机数
+	HostList  []ServiceHost `json:"hostList"`  // 开放该端口的主机
+}
+
+// ServiceHost 开放端口的主机信息
+type ServiceHost struct {
+	HostID   uint       `json:"hostID"`   // 主机ID
+	IP       string     `json:"ip"`       // 主机ip
+	Mac      string     `json:"mac"`      // MAC地址
+	Manuf    string     `json:"manuf"`    // 厂商信息
+	Banner   string     `json:"banner"`   // 服务banner
+	ScanTime *time.Time `json:"scanTime"` // 探测时间
+}
+
+// ServiceListView 查询子网服务资产，按开放主机数降序排列
+func (NetApi) ServiceListView(c *gin.Context) {
+	// 获取请求绑定的网络ID参数
+	cr := middleware.GetBind[models.IDRequest](c)
+
+	var model models.NetModel
+	if err := global.DB.Take(&model, cr.Id).Error; err != nil {
+		response.FailWithMsg("网络不存在", c)
+		return
+	}
+
+	var hostList []models.HostModel
+	global.DB.Find(&hostList, "net_id = ? and scan_time is not null", model.ID)
+
+	// 按端口归类主机
+	portMap := map[int]*ServiceListResponse{}
+	for _, host := range hostList {
+		for _, port := range host.PortList {
+			item, ok := portMap[port.Port]
+			if !ok {
+				item = &ServiceListResponse{Port: port.Port, HostList: make([]ServiceHost, 0)}
+				portMap[port.Port] = item
+			}
+			item.HostCount++
+			item.HostList = append(item.HostList, ServiceHost{
+				HostID:   host.ID,
+				IP:       host.IP,
+				Mac:      host.Mac,
+				Manuf:    host.Manuf,
+				Banner:   port.Banner,
+				ScanTime: host.ScanTime,
+			})
+		}
+	}
+
+	list := make([]ServiceListResponse, 0, len(portMap))
+	for _, item := range portMap {
+		list = append(list, *item)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].HostCount != list[j].HostCount {
+			return list[i].HostCount > list[j].HostCount
+		}
+		return list[i].Port < list[j].Port
+	})
+
+	response.OkWithList(list, int64(len(list)), c)
+}
@@ -0,0 +1,81 @@
+package node_api
+
+// File: honey_server/api/node_api/approve.go
+// Description: 节点审批API接口，未携带有效接入令牌的节点注册后处于待审批状态，由管理员审批通过或拒绝，审批结果记录操作日志
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/ca_service"
+	"honey_server/internal/service/log_service"
+	"honey_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApproveRequest 节点审批请求参数结构体
+type ApproveRequest struct {
+	ID      uint   `json:"id" binding:"required"`                // 节点ID
+	Approve int8   `json:"approve" binding:"required,oneof=2 3"` // 审批结果 2 通过 3 拒绝
+	Reason  string `json:"reason"`                               // 审批说明
+}
+
+// ApproveView 节点审批接口处理函数
+// 审批通过后节点在下次重新注册时上线；拒绝后吊销节点证书，节点无法再接入
+func (NodeApi) ApproveView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[ApproveRequest](c)
+
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.ID).Error; err != nil {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+	if model.Approve != 1 {
+		response.FailWithMsg("节点不是待审批状态", c)
+		return
+	}
+
+	// 按待审批状态条件更新，避免重复审批
+	result := global.DB.Model(&model).Where("approve = ?", 1).Update("approve", cr.Approve)
+	if result.Error != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": model.ID,
+			"error":   result.Error,
+		}).Error("failed to update node approval") // 节点审批状态更新失败
+		response.FailWithMsg("节点审批失败", c)
+		return
+	}
+	if result.RowsAffected == 0 {
+		response.FailWithMsg("节点不是待审批状态", c)
+		return
+	}
+
+	actionLog := log_service.NewActionLog(c)
+	content := fmt.Sprintf("节点 %s uid=%s ip=%s mac=%s 说明：%s", model.Title, model.Uid, model.IP, model.Mac, cr.Reason)
+	if cr.Approve == 2 {
+		actionLog.Info("节点审批通过", content)
+		log.WithFields(map[string]interface{}{
+			"node_id":  model.ID,
+			"node_uid": model.Uid,
+		}).Info("node approved") // 节点审批通过
+		response.OkWithMsg("节点审批通过", c)
+		return
+	}
+
+	// 拒绝接入，吊销节点已签发的证书
+	if err := ca_service.Revoke(model.Uid, "节点审批拒绝"); err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_uid": model.Uid,
+			"error":    err,
+		}).Error("failed to revoke node certificates") // 节点证书吊销失败
+	}
+	actionLog.Warn("节点审批拒绝", content)
+	log.WithFields(map[string]interface{}{
+		"node_id":  model.ID,
+		"node_uid": model.Uid,
+	}).Info("node rejected") // 节点审批拒绝
+	response.OkWithMsg("已拒绝节点接入", c)
+}
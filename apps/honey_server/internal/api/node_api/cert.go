@@ -0,0 +1,106 @@
+package node_api
+
+// File: honey_server/api/node_api/cert.go
+// Description: 节点证书API接口，查询节点客户端证书的签发记录及状态，通知节点轮换证书，重置节点证书并生成一次性接入码
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/ca_service"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/service/log_service"
+	"honey_server/internal/utils/response"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CertListRequest 节点证书列表请求参数结构体
+type CertListRequest struct {
+	NodeID uint `form:"nodeID" binding:"required"` // 节点ID
+}
+
+// CertListView 节点证书列表接口处理函数，按签发时间倒序返回节点的证书记录
+func (NodeApi) CertListView(c *gin.Context) {
+	cr := middleware.GetBind[CertListRequest](c)
+
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.NodeID).Error; err != nil {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	var list []models.NodeCertModel
+	global.DB.Order("id desc").Find(&list, "node_uid = ?", model.Uid)
+	response.OkWithList(list, int64(len(list)), c)
+}
+
+// CertRotateView 节点证书轮换接口处理函数，节点使用新证书重连后旧证书吊销
+func (NodeApi) CertRotateView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[models.IDRequest](c)
+
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.Id).Error; err != nil {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	out, err := grpc_service.RotateNodeCert(model, log.Data["logID"].(string))
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": model.ID,
+			"error":   err,
+		}).Error("failed to rotate node certificate") // 节点证书轮换失败
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	response.OkWithData(out, c)
+}
+
+// CertResetResponse 节点证书重置响应结构体
+type CertResetResponse struct {
+	EnrollCode string    `json:"enrollCode"` // 一次性接入码，填写到节点配置system.enrollCode后重启节点
+	ExpireAt   time.Time `json:"expireAt"`   // 接入码过期时间
+}
+
+// CertResetView 节点证书重置接口处理函数
+// 吊销节点全部证书并断开节点连接，节点须使用生成的一次性接入码重新申请证书，用于证书过期或丢失的节点恢复接入
+func (NodeApi) CertResetView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[models.IDRequest](c)
+
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.Id).Error; err != nil {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	code, expireAt, err := ca_service.ResetNode(model.Uid, "管理员重置")
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": model.ID,
+			"error":   err,
+		}).Error("failed to reset node certificate") // 节点证书重置失败
+		response.FailWithMsg("节点证书重置失败", c)
+		return
+	}
+
+	// 断开节点当前的命令流，节点须使用新证书重新接入
+	if cmd, ok := grpc_service.GetNodeCommand(model.Uid); ok {
+		cmd.Close()
+	}
+
+	log_service.NewActionLog(c).Warn("重置节点证书", fmt.Sprintf("节点 %s uid=%s ip=%s", model.Title, model.Uid, model.IP))
+	log.WithFields(map[string]interface{}{
+		"node_id":  model.ID,
+		"node_uid": model.Uid,
+	}).Info("node certificate reset") // 节点证书已重置
+	response.OkWithData(CertResetResponse{
+		EnrollCode: code,
+		ExpireAt:   expireAt,
+	}, c)
+}
@@ -0,0 +1,65 @@
+package node_api
+
+// File: honey_server/api/node_api/diagnose.go
+// Description: 节点诊断API接口，按需采集节点运行状态诊断报告及查询节点保留的历史诊断报告
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DiagnoseRequest 节点诊断请求参数结构体
+type DiagnoseRequest struct {
+	NodeID   uint `json:"nodeID" binding:"required"`            // 节点ID
+	LogLines int  `json:"logLines" binding:"omitempty,max=200"` // 返回的最近错误日志行数，为空时使用节点默认值
+}
+
+// DiagnoseView 节点诊断接口处理函数
+func (NodeApi) DiagnoseView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[DiagnoseRequest](c)
+
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.NodeID).Error; err != nil {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	diagnose, err := grpc_service.DiagnoseNode(model, cr.LogLines, log.Data["logID"].(string))
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": model.ID,
+			"error":   err,
+		}).Error("failed to diagnose node") // 节点诊断失败
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	response.Ok(diagnose, "节点诊断完成", c)
+}
+
+// DiagnoseListRequest 节点诊断报告查询请求参数结构体
+type DiagnoseListRequest struct {
+	models.PageInfo      // 分页参数
+	NodeID          uint `form:"nodeID" binding:"required"` // 节点ID
+}
+
+// DiagnoseListView 节点诊断报告分页查询接口，按时间倒序返回
+func (NodeApi) DiagnoseListView(c *gin.Context) {
+	cr := middleware.GetBind[DiagnoseListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.NodeDiagnoseModel{
+		NodeID: cr.NodeID,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Sort:     "id desc",
+	})
+
+	response.OkWithList(list, count, c)
+}
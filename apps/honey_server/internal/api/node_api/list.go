@@ -15,7 +15,8 @@ import (
 // ListRequest 节点列表分页查询请求参数结构体
 type ListRequest struct {
 	models.PageInfo
-	NodeID uint `form:"nodeID"` // 节点ID
+	NodeID  uint `form:"nodeID"`  // 节点ID
+	Approve int8 `form:"approve"` // 审批状态 1 待审批 2 已通过 3 已拒绝
 }
 
 // ListView 节点列表分页查询接口处理函数
@@ -24,6 +25,7 @@ func (NodeApi) ListView(c *gin.Context) {
 	cr := middleware.GetBind[ListRequest](c)
 	nodeModel := models.NodeModel{}
 	nodeModel.ID = cr.NodeID
+	nodeModel.Approve = cr.Approve
 	list, count, _ := common_service.QueryList(nodeModel, common_service.QueryListRequest{
 		Likes:    []string{"title", "ip"}, // 支持按节点名称、IP模糊搜索
 		PageInfo: cr.PageInfo,             // 分页与搜索参数
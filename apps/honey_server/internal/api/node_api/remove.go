@@ -52,7 +52,7 @@ func (NodeApi) RemoveView(c *gin.Context) {
 
 	// 启动独立协程异步下发节点移除RPC命令，避免阻塞HTTP响应
 	go func(uid string, logID string) {
-		// 获取节点对应的RPC命令通道（包含请求/响应通道），校验节点是否在线
+		// 获取节点对应的RPC命令实例，校验节点是否在线
 		cmd, ok := grpc_service.GetNodeCommand(model.Uid)
 		if !ok {
 			log.WithFields(map[string]interface{}{
@@ -73,42 +73,21 @@ func (NodeApi) RemoveView(c *gin.Context) {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel() // 函数退出时释放上下文资源，避免内存泄漏
 
-		// 发送RPC请求到节点的请求通道，带超时控制
-		select {
-		case cmd.ReqChan <- req:
-			// 请求发送成功，记录日志
+		// 发送RPC请求到节点并等待该任务的响应，带超时控制
+		res, err := cmd.Call(ctx, req)
+		if err != nil {
 			log.WithFields(map[string]interface{}{
 				"node_uid": uid,
 				"task_id":  req.TaskID,
-			}).Info("删除节点消息发送成功")
-		case <-ctx.Done():
-			// 请求发送超时，记录错误日志并退出协程
-			log.WithFields(map[string]interface{}{
-				"node_uid": uid,
-				"error":    ctx.Err(),
-			}).Error("删除节点消息发送超时")
-			return
-		}
-
-		// 监听节点的RPC响应通道，接收移除命令执行结果，带超时控制
-		select {
-		case res := <-cmd.ResChan:
-			// 接收响应成功，记录日志
-			log.WithFields(map[string]interface{}{
-				"node_uid": uid,
-				"task_id":  req.TaskID,
-				"response": res.NodeRemoveOutMessage,
-			}).Info("删除节点消息接收成功")
-
-		case <-ctx.Done():
-			// 接收响应超时，记录错误日志
-			log.WithFields(map[string]interface{}{
-				"node_uid": uid,
-				"error":    ctx.Err(),
-			}).Error("删除节点消息接收超时")
-			response.FailWithMsg("获取响应超时", c)
+				"error":    err,
+			}).Error("删除节点消息发送失败")
 			return
 		}
+		log.WithFields(map[string]interface{}{
+			"node_uid": uid,
+			"task_id":  req.TaskID,
+			"response": res.NodeRemoveOutMessage,
+		}).Info("删除节点消息接收成功")
 	}(model.Uid, log.Data["logID"].(string))
 
 	// 记录节点删除成功日志（数据库层面）
@@ -0,0 +1,38 @@
+package node_api
+
+// File: honey_server/api/node_api/sync.go
+// Description: 节点全量状态同步API接口，按需向节点下发期望的诱捕ip及端口转发并修正记录状态
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SyncView 节点全量状态同步接口处理函数
+func (NodeApi) SyncView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[models.IDRequest](c)
+
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.Id).Error; err != nil {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	result, err := grpc_service.SyncNodeState(model, log.Data["logID"].(string))
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": model.ID,
+			"error":   err,
+		}).Error("failed to sync node state") // 节点状态同步失败
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	response.Ok(result, "节点状态同步完成", c)
+}
@@ -0,0 +1,97 @@
+package node_api
+
+// File: honey_server/api/node_api/task.go
+// Description: 节点任务API接口，查询节点任务列表及执行进度，取消或重试节点上的批量部署、网络扫描等任务
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaskListRequest 节点任务列表请求参数结构体
+type TaskListRequest struct {
+	NodeID uint `form:"nodeID" binding:"required"` // 节点ID
+}
+
+// TaskListView 节点任务列表接口处理函数，返回节点最近的任务及执行进度
+func (NodeApi) TaskListView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[TaskListRequest](c)
+
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.NodeID).Error; err != nil {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	list, err := grpc_service.NodeTaskList(model, log.Data["logID"].(string))
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": model.ID,
+			"error":   err,
+		}).Error("failed to get node task list") // 获取节点任务列表失败
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	response.OkWithList(list, int64(len(list)), c)
+}
+
+// TaskActionRequest 节点任务取消、重试请求参数结构体
+type TaskActionRequest struct {
+	NodeID uint   `json:"nodeID" binding:"required"` // 节点ID
+	TaskID string `json:"taskID" binding:"required"` // 任务ID
+}
+
+// TaskCancelView 取消节点任务接口处理函数
+func (NodeApi) TaskCancelView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[TaskActionRequest](c)
+
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.NodeID).Error; err != nil {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	if err := grpc_service.CancelNodeTask(model, cr.TaskID, log.Data["logID"].(string)); err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": model.ID,
+			"task_id": cr.TaskID,
+			"error":   err,
+		}).Error("failed to cancel node task") // 取消节点任务失败
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	response.OkWithMsg("任务已取消", c)
+}
+
+// TaskRetryView 重试节点任务接口处理函数，仅重新执行未完成的任务项
+func (NodeApi) TaskRetryView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[TaskActionRequest](c)
+
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.NodeID).Error; err != nil {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	if err := grpc_service.RetryNodeTask(model, cr.TaskID, log.Data["logID"].(string)); err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": model.ID,
+			"task_id": cr.TaskID,
+			"error":   err,
+		}).Error("failed to retry node task") // 重试节点任务失败
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	response.OkWithMsg("任务已重新提交", c)
+}
@@ -0,0 +1,115 @@
+package node_api
+
+// File: honey_server/api/node_api/token.go
+// Description: 节点接入令牌API接口，生成一次性或限时有效的接入令牌，由节点安装脚本携带，持有有效令牌的节点免审批接入
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/response"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TokenCreateRequest 接入令牌创建请求参数结构体
+type TokenCreateRequest struct {
+	Title       string `json:"title" binding:"required"` // 令牌备注
+	OneTime     bool   `json:"oneTime"`                  // 是否一次性令牌
+	ExpireHours int    `json:"expireHours"`              // 有效期（小时），0表示不过期
+}
+
+// TokenCreateView 接入令牌创建接口处理函数，令牌须为一次性或设置有效期
+// 完整令牌仅在创建时返回，列表中脱敏展示
+func (NodeApi) TokenCreateView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[TokenCreateRequest](c)
+
+	if cr.ExpireHours < 0 {
+		response.FailWithMsg("有效期不能为负数", c)
+		return
+	}
+	if !cr.OneTime && cr.ExpireHours == 0 {
+		response.FailWithMsg("接入令牌须为一次性或设置有效期", c)
+		return
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		response.FailWithMsg("接入令牌生成失败", c)
+		return
+	}
+	model := models.NodeTokenModel{
+		Title:   cr.Title,
+		Token:   hex.EncodeToString(buf),
+		OneTime: cr.OneTime,
+	}
+	if cr.ExpireHours > 0 {
+		expireAt := time.Now().Add(time.Duration(cr.ExpireHours) * time.Hour)
+		model.ExpireAt = &expireAt
+	}
+	if err := global.DB.Create(&model).Error; err != nil {
+		log.WithFields(map[string]interface{}{
+			"title": cr.Title,
+			"error": err,
+		}).Error("failed to create node token") // 接入令牌创建失败
+		response.FailWithMsg("接入令牌创建失败", c)
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"token_id": model.ID,
+		"one_time": model.OneTime,
+	}).Info("node token created") // 接入令牌创建成功
+	response.OkWithData(model, c)
+}
+
+// TokenListRequest 接入令牌列表请求参数结构体
+type TokenListRequest struct {
+	models.PageInfo
+}
+
+// TokenListView 接入令牌列表接口处理函数，令牌脱敏返回
+func (NodeApi) TokenListView(c *gin.Context) {
+	cr := middleware.GetBind[TokenListRequest](c)
+	list, count, _ := common_service.QueryList(models.NodeTokenModel{}, common_service.QueryListRequest{
+		Likes:    []string{"title"}, // 支持按令牌备注模糊搜索
+		PageInfo: cr.PageInfo,
+		Sort:     "created_at desc",
+	})
+	for i := range list {
+		list[i].Token = maskToken(list[i].Token)
+	}
+	response.OkWithList(list, count, c)
+}
+
+// maskToken 令牌脱敏，仅保留前6位用于辨识
+func maskToken(token string) string {
+	if len(token) <= 6 {
+		return "******"
+	}
+	return token[:6] + "******"
+}
+
+// TokenRemoveView 接入令牌删除接口处理函数，删除后令牌立即失效，不影响已接入的节点
+func (NodeApi) TokenRemoveView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[models.IDListRequest](c)
+
+	successCount, err := common_service.Remove(models.NodeTokenModel{}, common_service.RemoveRequest{
+		IDList:   cr.IdList,
+		Log:      log,
+		Msg:      "接入令牌",
+		Unscoped: true,
+	})
+	if err != nil {
+		response.FailWithMsg(fmt.Sprintf("删除接入令牌失败 %s", err), c)
+		return
+	}
+	response.OkWithMsg(fmt.Sprintf("删除成功 共%d个，成功%d个", len(cr.IdList), successCount), c)
+}
@@ -8,6 +8,7 @@ import (
 	"honey_server/internal/global"
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
+	"honey_server/internal/service/grpc_service"
 	"honey_server/internal/utils/ip"
 	"honey_server/internal/utils/response"
 	"sync"
@@ -66,6 +67,20 @@ func (NodeNetworkApi) EnableView(c *gin.Context) {
 			}).Error("failed to parse IP range") // 解析IP范围失败
 			return err
 		}
+		// 双栈网卡追加IPv6可用诱捕ip范围
+		if model.IP6 != "" {
+			ipRange6, err := ip.ParseCIDRGetUseIPRange(fmt.Sprintf("%s/%d", model.IP6, model.Mask6))
+			if err != nil {
+				log.WithFields(map[string]interface{}{
+					"network_id": cr.Id,
+					"ip6":        model.IP6,
+					"mask6":      model.Mask6,
+					"error":      err,
+				}).Error("failed to parse IPv6 range") // 解析IPv6范围失败
+				return err
+			}
+			ipRange = ipRange + "," + ipRange6
+		}
 		// 构建网络表记录，关联节点和网卡信息
 		net := models.NetModel{
 			NodeID:             model.NodeID,
@@ -73,6 +88,9 @@ func (NodeNetworkApi) EnableView(c *gin.Context) {
 			Network:            model.Network,
 			IP:                 model.IP,
 			Mask:               model.Mask,
+			IP6:                model.IP6,
+			Mask6:              model.Mask6,
+			VlanID:             model.VlanID,
 			Gateway:            model.Gateway,
 			CanUseHoneyIPRange: ipRange,
 		}
@@ -122,5 +140,7 @@ func (NodeNetworkApi) EnableView(c *gin.Context) {
 	log.WithFields(map[string]interface{}{
 		"network_id": cr.Id,
 	}).Info("network interface enabled successfully") // 网卡启用成功
+	// 新启用的网络开始被动资产发现
+	grpc_service.PushDiscoveryAsync(model.NodeModel, log.Data["logID"].(string))
 	response.OkWithMsg("网卡启用成功", c)
 }
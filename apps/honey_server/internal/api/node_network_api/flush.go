@@ -73,40 +73,23 @@ func (NodeNetworkApi) FlushView(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
 	defer cancel()
 
-	// 发送网卡刷新命令到节点
-	select {
-	case cmd.ReqChan <- req:
+	// 发送网卡刷新命令到节点并等待该任务的响应
+	res, err := cmd.Call(ctx, req)
+	if err != nil {
 		log.WithFields(map[string]interface{}{
 			"node_uid": model.Uid,
 			"task_id":  req.TaskID,
-		}).Debug("flush request sent to node") // 发送网卡刷新命令到节点
-	case <-ctx.Done():
-		log.WithFields(map[string]interface{}{
-			"node_uid": model.Uid,
-			"error":    ctx.Err(),
-		}).Error("timeout sending flush command") // 发送命令超时
-		response.FailWithMsg("发送命令超时", c)
-		return
-	}
-
-	// 定义变量接收节点返回的网卡信息列表
-	var networkInfoList []*node_rpc.NetworkInfoMessage
-	// 等待节点响应结果
-	select {
-	case res := <-cmd.ResChan:
-		log.WithFields(map[string]interface{}{
-			"node_uid": model.Uid,
-			"task_id":  req.TaskID,
-		}).Debug("flush response received from node")            // 从节点收到的刷新响应
-		networkInfoList = res.NetworkFlushOutMessage.NetworkList // 提取网卡信息
-	case <-ctx.Done():
-		log.WithFields(map[string]interface{}{
-			"node_uid": model.Uid,
-			"error":    ctx.Err(),
-		}).Error("timeout waiting for flush response") // 等待刷新响应超时
-		response.FailWithMsg("获取响应超时", c)
+			"error":    err,
+		}).Error("flush command failed") // 网卡刷新命令执行失败
+		response.FailWithMsg(err.Error(), c)
 		return
 	}
+	log.WithFields(map[string]interface{}{
+		"node_uid": model.Uid,
+		"task_id":  req.TaskID,
+	}).Debug("flush response received from node") // 从节点收到的刷新响应
+	// 提取节点返回的网卡信息列表
+	networkInfoList := res.NetworkFlushOutMessage.NetworkList
 
 	// 遍历网卡信息
 	for _, network := range networkInfoList {
@@ -148,17 +131,23 @@ func (NodeNetworkApi) FlushView(c *gin.Context) {
 		}
 	}
 
-	// 计算待更新网卡（存在但IP/Mask变化）
+	// 计算待更新网卡（存在但IP/Mask、IPv6地址或VLAN变化）
 	var updatedNetworks []models.NodeNetworkModel
 	for networkName := range networkMap {
 		if newIndex, exists := newNetworkMap[networkName]; exists {
 			dbNetwork := networkList[networkMap[networkName]]
 			newNetwork := networkInfoList[newIndex]
 
-			// 检查IP或子网掩码是否变更
-			if dbNetwork.IP != newNetwork.Ip || dbNetwork.Mask != int8(newNetwork.Mask) {
+			// 检查IP、子网掩码、IPv6地址或VLAN是否变更
+			if dbNetwork.IP != newNetwork.Ip || dbNetwork.Mask != int8(newNetwork.Mask) ||
+				dbNetwork.IP6 != newNetwork.Ip6 || dbNetwork.Mask6 != int8(newNetwork.Mask6) ||
+				dbNetwork.VlanID != int(newNetwork.VlanID) {
 				dbNetwork.IP = newNetwork.Ip
 				dbNetwork.Mask = int8(newNetwork.Mask)
+				dbNetwork.IP6 = newNetwork.Ip6
+				dbNetwork.Mask6 = int8(newNetwork.Mask6)
+				dbNetwork.VlanID = int(newNetwork.VlanID)
+				dbNetwork.Parent = newNetwork.Parent
 				updatedNetworks = append(updatedNetworks, dbNetwork)
 			}
 		}
@@ -171,6 +160,10 @@ func (NodeNetworkApi) FlushView(c *gin.Context) {
 			Network: network.Network,
 			IP:      network.Ip,
 			Mask:    int8(network.Mask),
+			IP6:     network.Ip6,
+			Mask6:   int8(network.Mask6),
+			VlanID:  int(network.VlanID),
+			Parent:  network.Parent,
 			Status:  2, // 初始状态设为未启用
 		}
 		if err := global.DB.Create(&newRecord).Error; err != nil {
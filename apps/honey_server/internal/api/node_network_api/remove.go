@@ -1,12 +1,13 @@
 package node_network_api
 
 // File: honey_server/api/node_network_api/remove.go
-// Description: 节点网卡删除API接口
+// Description: 节点网卡删除API接口，VLAN子接口同时通知节点删除
 
 import (
 	"honey_server/internal/global"
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
+	"honey_server/internal/service/grpc_service"
 	"honey_server/internal/utils/response"
 
 	"github.com/gin-gonic/gin"
@@ -33,7 +34,23 @@ func (NodeNetworkApi) RemoveView(c *gin.Context) {
 		return
 	}
 
-	// 执行网卡记录删除操作
+	// VLAN子接口先通知节点删除，节点删除成功后再删除记录，节点删除失败时记录保留
+	// 节点对已删除的子接口直接返回成功，删除记录失败时可重试
+	if model.VlanID != 0 {
+		var nodeModel models.NodeModel
+		if err := global.DB.Take(&nodeModel, model.NodeID).Error; err != nil {
+			response.FailWithMsg("节点不存在", c)
+			return
+		}
+		if err := grpc_service.RemoveVlan(nodeModel, model.Network, log.Data["logID"].(string)); err != nil {
+			log.WithFields(map[string]interface{}{
+				"network_id": cr.Id,
+				"error":      err,
+			}).Error("failed to remove vlan interface on node") // 节点删除VLAN子接口失败
+			response.FailWithMsg("网卡删除失败"+err.Error(), c)
+			return
+		}
+	}
 	if err := global.DB.Delete(&model).Error; err != nil {
 		log.WithFields(map[string]interface{}{
 			"network_id": cr.Id,
@@ -0,0 +1,115 @@
+package node_network_api
+
+// File: honey_server/api/node_network_api/vlan_create.go
+// Description: VLAN子接口创建API接口，通知节点在trunk口上创建VLAN子接口并记录为节点网卡，启用后即可按网络进行扫描及部署
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VlanCreateRequest VLAN子接口创建请求参数结构体
+type VlanCreateRequest struct {
+	NodeID  uint   `json:"nodeID" binding:"required"`                // 节点ID(必填)
+	Network string `json:"network" binding:"required"`               // 父网卡名称(必填)
+	VlanID  int    `json:"vlanID" binding:"required,min=1,max=4094"` // VLAN ID(必填) 1-4094
+	IP      string `json:"ip" binding:"required,ipv4"`               // 子接口探针ip(必填)
+	Mask    int8   `json:"mask" binding:"required,min=8,max=30"`     // 子网掩码(必填) 8-30
+}
+
+// VlanCreateView 处理VLAN子接口创建请求
+func (NodeNetworkApi) VlanCreateView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[VlanCreateRequest](c)
+
+	log.WithFields(map[string]interface{}{
+		"node_id": cr.NodeID,
+		"network": cr.Network,
+		"vlan_id": cr.VlanID,
+		"ip":      cr.IP,
+		"mask":    cr.Mask,
+	}).Info("vlan interface creation request received") // 收到VLAN子接口创建请求
+
+	var nodeModel models.NodeModel
+	if err := global.DB.Take(&nodeModel, cr.NodeID).Error; err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": cr.NodeID,
+			"error":   err,
+		}).Warn("node not found") // 节点不存在
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+	if nodeModel.Status != 1 {
+		log.WithFields(map[string]interface{}{
+			"node_id": nodeModel.ID,
+			"status":  nodeModel.Status,
+		}).Warn("node is not running") // 节点未运行
+		response.FailWithMsg("节点未运行", c)
+		return
+	}
+
+	// 子接口只能基于节点的物理网卡创建
+	var parent models.NodeNetworkModel
+	if err := global.DB.Take(&parent, "node_id = ? and network = ? and vlan_id = 0", nodeModel.ID, cr.Network).Error; err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_id": nodeModel.ID,
+			"network": cr.Network,
+		}).Warn("parent network interface not found") // 父网卡不存在
+		response.FailWithMsg("父网卡不存在", c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.NodeNetworkModel{}).
+		Where("node_id = ? and parent = ? and vlan_id = ?", nodeModel.ID, cr.Network, cr.VlanID).Count(&count)
+	if count > 0 {
+		log.WithFields(map[string]interface{}{
+			"node_id": nodeModel.ID,
+			"network": cr.Network,
+			"vlan_id": cr.VlanID,
+		}).Warn("vlan interface already exists") // VLAN子接口已存在
+		response.FailWithMsg("VLAN子接口已存在", c)
+		return
+	}
+
+	info, err := grpc_service.CreateVlan(nodeModel, cr.Network, cr.VlanID, cr.IP, cr.Mask, log.Data["logID"].(string))
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"node_uid": nodeModel.Uid,
+			"error":    err,
+		}).Error("failed to create vlan interface") // VLAN子接口创建失败
+		response.FailWithMsg("VLAN子接口创建失败 "+err.Error(), c)
+		return
+	}
+
+	// 子接口记录为未启用的节点网卡，与网卡刷新发现的网卡一致
+	model := models.NodeNetworkModel{
+		NodeID:  nodeModel.ID,
+		Network: info.Network,
+		IP:      info.Ip,
+		Mask:    int8(info.Mask),
+		IP6:     info.Ip6,
+		Mask6:   int8(info.Mask6),
+		VlanID:  int(info.VlanID),
+		Parent:  info.Parent,
+		Status:  2,
+	}
+	if err = global.DB.Create(&model).Error; err != nil {
+		log.WithFields(map[string]interface{}{
+			"network": info.Network,
+			"error":   err,
+		}).Error("failed to create network record") // 网卡记录创建失败
+		response.FailWithMsg("网卡记录创建失败", c)
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"network_id": model.ID,
+		"network":    model.Network,
+	}).Info("vlan interface created successfully") // VLAN子接口创建成功
+	response.OkWithData(model, c)
+}
@@ -9,6 +9,7 @@ import (
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
 	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils"
 	"honey_server/internal/utils/docker"
 	"honey_server/internal/utils/response"
 	"net/url"
@@ -105,6 +106,14 @@ func (NodeVersionApi) NodeVersionCreateView(c *gin.Context) {
 		return
 	}
 
+	// 计算镜像文件摘要，供节点远程升级时校验下载的文件
+	hash, err := utils.FileSha256(nodeFilePath)
+	if err != nil {
+		os.Remove(nodeFilePath)
+		response.FailWithMsg(fmt.Sprintf("计算镜像文件摘要失败: %v", err), c)
+		return
+	}
+
 	// 构造节点版本模型，准备写入数据库
 	nodeVersion := models.NodeVersionModel{
 		ImageName: imageName,     // 镜像名称
@@ -113,6 +122,7 @@ func (NodeVersionApi) NodeVersionCreateView(c *gin.Context) {
 		ImageID:   imageID,       // Docker镜像唯一ID
 		FileSize:  file.Size,     // 文件大小（字节）
 		Path:      nodeFilePath,  // 文件存储路径
+		Hash:      hash,          // 文件sha256摘要
 	}
 
 	// 将镜像信息写入数据库，失败则返回上传失败
@@ -23,6 +23,7 @@ type NodeDownloadRequest struct {
 	Log       bool   `form:"log"`       // 日志输出开关：控制脚本执行时是否打印详细日志
 	Network   string `form:"network"`   // 网卡名称
 	Agreement string `form:"agreement"` //  协议
+	Token     string `form:"nodeToken"` // 节点接入令牌，为空时节点接入后等待管理员审批（访问日志中脱敏）
 }
 
 //go:embed node_download.sh
@@ -41,11 +42,27 @@ func (NodeVersionApi) NodeDownloadView(c *gin.Context) {
 		return
 	}
 
+	// 校验接入令牌，避免生成携带无效令牌的安装脚本
+	if cr.Token != "" {
+		var tokenModel models.NodeTokenModel
+		err = global.DB.Take(&tokenModel, "token = ?", cr.Token).Error
+		if err != nil || !tokenModel.Valid() {
+			response.FailWithMsg("接入令牌无效或已过期", c)
+			return
+		}
+	}
+
 	// 设置脚本下载响应头，保证浏览器识别为文件下载并指定文件名
 	c.Header("Content-Type", "application/octet-stream")                                    // 响应类型：二进制流，适配脚本文件下载
 	c.Header("Content-Disposition", "attachment; filename="+url.QueryEscape("download.sh")) // 下载文件名：download.sh（转义特殊字符）
 	c.Header("Content-Transfer-Encoding", "binary")                                         // 传输编码：二进制，保证脚本内容完整传输
 
+	// 访问协议未指定时使用配置的协议，与远程升级的下载地址保持一致
+	agreement := cr.Agreement
+	if agreement == "" {
+		agreement = global.Config.System.Scheme()
+	}
+
 	// 构建节点下载脚本内容
 	shellContent := nodeDownloadShell
 	reg := regexp.MustCompile(`\{\{.*?\}\}`)
@@ -59,11 +76,15 @@ func (NodeVersionApi) NodeDownloadView(c *gin.Context) {
 		case "MANAGE_IP":
 			return global.Config.System.ManageIp
 		case "AGREEMENT":
-			return cr.Agreement
+			return agreement
+		case "NODE_DOWNLOAD_URL":
+			return global.Config.System.NodeDownloadURL(agreement)
 		case "NODE_IMAGE_ID":
 			return model.ImageID
 		case "NET_WORK":
 			return cr.Network
+		case "NODE_TOKEN":
+			return cr.Token
 		}
 		return ""
 	})
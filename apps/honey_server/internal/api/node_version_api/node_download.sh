@@ -7,7 +7,9 @@ NODE_VERSION="{{NODE_VERSION}}"
 MANAGE_IP="{{MANAGE_IP}}"  # 管理端的ip地址
 REMOTE_SERVER="{{AGREEMENT}}://${MANAGE_IP}"  # 请替换为实际服务器地址
 NODE_IMAGE_ID="{{NODE_IMAGE_ID}}"
+NODE_DOWNLOAD_URL="{{NODE_DOWNLOAD_URL}}" # 节点镜像下载地址
 NET_WORK="{{NET_WORK}}" # 网卡
+NODE_TOKEN="{{NODE_TOKEN}}" # 节点接入令牌，为空时节点接入后等待管理员审批
 
 # 颜色定义
 RED='\033[0;31m'
@@ -125,7 +127,7 @@ check_and_get_node_image() {
         return 0
     else
         info "Node镜像不存在，开始下载..."
-        local download_url="${REMOTE_SERVER}/api/honey_server/node_version/download?version=${NODE_VERSION}"
+        local download_url="${NODE_DOWNLOAD_URL}?version=${NODE_VERSION}"
         load_image "node_${NODE_VERSION}" "$download_url"
     fi
 }
@@ -166,8 +168,12 @@ create_directory() {
         mkdir -p node_server
         touch node_server/gorm.db
         mkdir -p node_server/logs
+        mkdir -p node_server/upgrade
         mkdir -p suricata
         mkdir -p suricata/rules
+        mkdir -p suricata/run
+        touch suricata/rules/honey.rules
+        touch suricata/rules/threshold.config
         mkdir -p filebeat
         mkdir -p kafka-certs
     else
@@ -188,6 +194,7 @@ system:
   grpcManageAddr: "hy.io:50001"
   network: ens33
   uid:
+  token: "${NODE_TOKEN}"
   evePath: /var/log/suricata/eve.json
 db:
   db_name: "gorm.db"
@@ -218,6 +225,33 @@ mq:
   batchUpdateDeployStatusTopic: batchUpdateDeployStatusTopic
   batchRemoveDeployExchangeName: batchRemoveDeployExchangeName
   batchRemoveDeployStatusTopic: batchRemoveDeployStatusTopic
+  sessionTopic: sessionTopic
+  eventTopic: eventTopic
+  outboxMaxCount: 100000
+  outboxMaxAge: 72
+suricata:
+  rulePath: /etc/suricata/rules/honey.rules
+  thresholdPath: /etc/suricata/rules/threshold.config
+  socketPath: /var/run/suricata/suricata-command.socket
+tunnel:
+  mux: true
+  streamCount: 4
+limit:
+  port:
+    maxConn: 200
+    rate: 50
+  honeyIp:
+    maxConn: 1000
+    rate: 200
+  srcIp:
+    maxConn: 50
+    rate: 20
+  idleTimeout: 300
+  sessionTimeout: 3600
+  action: tarpit
+  tarpitTime: 30
+  maxTarpit: 1000
+  reportInterval: 60
 EOF
     info "Node配置文件生成成功: node_server/settings.yaml"
 }
@@ -243,6 +277,12 @@ outputs:
             payload: yes
             http-body: yes
         - http
+        - dns
+        - tls:
+            extended: yes
+        - ssh
+        - fileinfo
+        - flow
 
 af-packet:
   - interface: ens33
@@ -260,9 +300,16 @@ flow:
   hash-size: 65536
   prealloc: 10000
 
+unix-command:
+  enabled: yes
+  filename: /var/run/suricata/suricata-command.socket
+
+threshold-file: /etc/suricata/rules/threshold.config
+
 default-rule-path: /etc/suricata/rules
 rule-files:
   - local.rules
+  - honey.rules
 EOF
       cat > "suricata/rules/local.rules" << EOF
 alert http any any -> any any (msg:"使用 curl 请求"; flow:established,to_server; content:"curl"; http_user_agent; classtype:web-application-attack; sid:600033; priority:10; rev:1; metadata: level 2;)
@@ -378,12 +425,14 @@ services:
     volumes:
       - ./suricata:/etc/suricata
       - ./suricata/logs:/var/log/suricata         # 挂载日志目录
+      - ./suricata/run:/var/run/suricata          # 挂载unix socket目录
     command: -i ${NET_WORK}  # 指定监听的网卡（需替换为实际网卡名）
     restart: always
     environment:
       TZ: Asia/Shanghai
   node_server:
     image: node:${NODE_VERSION}
+    container_name: node_server  # 固定容器名称，升级服务按名称切换节点容器
     network_mode: host
     restart: on-failure
     environment:
@@ -397,7 +446,22 @@ services:
       - ./node_server/settings.yaml:/app/settings.yaml
       - ./node_server/gorm.db:/app/gorm.db
       - ./suricata/logs:/var/log/suricata         # 挂载日志目录
+      - ./suricata/rules:/etc/suricata/rules      # 挂载规则目录（写入下发的规则）
+      - ./suricata/run:/var/run/suricata          # 挂载unix socket目录（重载规则）
+      - ./node_server/upgrade:/app/upgrade        # 挂载升级目录（与升级服务共享镜像文件及unix socket）
       - ./node_server/logs:/app/logs
+  node_updater:
+    image: node:${NODE_VERSION}
+    command: ["./main", "-updater"]  # 升级服务：只接受节点的切换请求，节点容器不挂载docker socket
+    restart: always
+    environment:
+      - TZ=Asia/Shanghai
+      - NODE_CONTAINER=node_server
+    volumes:
+      - ./node_server/settings.yaml:/app/settings.yaml:ro
+      - ./node_server/gorm.db:/app/gorm.db
+      - ./node_server/upgrade:/app/upgrade
+      - /var/run/docker.sock:/var/run/docker.sock # 仅升级服务挂载docker socket（远程升级）
 EOF
 
     # 如果需要日志收集，添加Filebeat配置
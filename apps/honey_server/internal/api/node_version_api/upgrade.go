@@ -0,0 +1,160 @@
+package node_version_api
+
+// File: honey_server/api/node_version_api/upgrade.go
+// Description: 节点远程升级API接口，按阶段将所选节点升级到指定版本，并提供升级进度查询
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/utils"
+	"honey_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultUpgradeTimeout 新版本重连的默认超时时间（秒）
+const defaultUpgradeTimeout = 300
+
+// UpgradeRequest 节点升级请求参数结构体
+type UpgradeRequest struct {
+	VersionID  uint   `json:"versionID" binding:"required"`        // 目标节点版本ID
+	NodeIDList []uint `json:"nodeIDList" binding:"required,min=1"` // 升级的节点ID列表
+	StageSize  int    `json:"stageSize" binding:"min=0"`           // 每个阶段升级的节点数，0表示所有节点同时升级
+	Timeout    int    `json:"timeout" binding:"min=0,max=3600"`    // 新版本重连超时时间（秒），超时未重连节点自动回滚
+}
+
+// UpgradeView 节点升级接口，创建发布批次并在后台按阶段执行升级
+func (NodeVersionApi) UpgradeView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[UpgradeRequest](c)
+	logID := log.Data["logID"].(string)
+
+	var version models.NodeVersionModel
+	if err := global.DB.Take(&version, cr.VersionID).Error; err != nil {
+		response.FailWithMsg("节点镜像不存在", c)
+		return
+	}
+	// 早期上传的镜像没有记录摘要，升级前补充计算
+	if version.Hash == "" {
+		hash, err := utils.FileSha256(version.Path)
+		if err != nil {
+			response.FailWithMsg(fmt.Sprintf("读取节点镜像文件失败: %v", err), c)
+			return
+		}
+		version.Hash = hash
+		global.DB.Model(&version).Update("hash", hash)
+	}
+
+	var _nodeList []models.NodeModel
+	global.DB.Find(&_nodeList, "id in ?", cr.NodeIDList)
+	nodeMap := map[uint]models.NodeModel{}
+	for _, node := range _nodeList {
+		nodeMap[node.ID] = node
+	}
+	// 按请求中的节点顺序排列，便于将灰度节点放在第一阶段
+	var nodeList []models.NodeModel
+	var idList []uint
+	for _, id := range cr.NodeIDList {
+		node, ok := nodeMap[id]
+		if !ok {
+			response.FailWithMsg(fmt.Sprintf("节点 %d 不存在", id), c)
+			return
+		}
+		delete(nodeMap, id)
+		if node.Status != 1 {
+			response.FailWithMsg(fmt.Sprintf("节点 %s 不在线", node.Title), c)
+			return
+		}
+		if node.SystemInfo.NodeVersion == version.Tag {
+			response.FailWithMsg(fmt.Sprintf("节点 %s 已是目标版本", node.Title), c)
+			return
+		}
+		nodeList = append(nodeList, node)
+		idList = append(idList, node.ID)
+	}
+	if busyList := grpc_service.LockNodeUpgrade(idList); len(busyList) > 0 {
+		response.FailWithMsg(fmt.Sprintf("节点 %v 正在升级中", busyList), c)
+		return
+	}
+
+	timeout := cr.Timeout
+	if timeout == 0 {
+		timeout = defaultUpgradeTimeout
+	}
+	stageSize := cr.StageSize
+	if stageSize == 0 {
+		stageSize = len(nodeList)
+	}
+
+	// 按所选节点顺序划分阶段，前一阶段全部成功后才升级下一阶段
+	batch := uuid.New().String()
+	var upgradeList []models.NodeUpgradeModel
+	for i, node := range nodeList {
+		upgradeList = append(upgradeList, models.NodeUpgradeModel{
+			Batch:       batch,
+			Stage:       i/stageSize + 1,
+			NodeID:      node.ID,
+			VersionID:   version.ID,
+			FromVersion: node.SystemInfo.NodeVersion,
+			ToVersion:   version.Tag,
+			Status:      1,
+		})
+	}
+	global.DB.Create(&upgradeList)
+
+	log.WithFields(map[string]interface{}{
+		"batch":      batch,
+		"version":    version.Tag,
+		"node_count": len(upgradeList),
+		"stage_size": stageSize,
+		"timeout":    timeout,
+	}).Info("node upgrade batch created") // 创建节点升级批次
+
+	go grpc_service.RunNodeUpgrade(batch, version, timeout, logID)
+
+	response.OkWithData(batch, c)
+}
+
+// UpgradeListRequest 节点升级记录查询请求参数结构体
+type UpgradeListRequest struct {
+	models.PageInfo        // 分页参数
+	Batch           string `form:"batch"`  // 发布批次
+	NodeID          uint   `form:"nodeID"` // 节点ID
+	Status          int8   `form:"status"` // 升级状态
+}
+
+// UpgradeListResponse 节点升级记录查询响应结构体
+type UpgradeListResponse struct {
+	models.NodeUpgradeModel
+	NodeTitle string `json:"nodeTitle"` // 节点名称
+}
+
+// UpgradeListView 节点升级记录分页查询接口
+func (NodeVersionApi) UpgradeListView(c *gin.Context) {
+	cr := middleware.GetBind[UpgradeListRequest](c)
+
+	_list, count, _ := common_service.QueryList(models.NodeUpgradeModel{
+		Batch:  cr.Batch,
+		NodeID: cr.NodeID,
+		Status: cr.Status,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Sort:     "created_at desc, stage, id",
+		Preload:  []string{"NodeModel"},
+	})
+
+	var list = make([]UpgradeListResponse, 0)
+	for _, model := range _list {
+		list = append(list, UpgradeListResponse{
+			NodeUpgradeModel: model,
+			NodeTitle:        model.NodeModel.Title,
+		})
+	}
+
+	response.OkWithList(list, count, c)
+}
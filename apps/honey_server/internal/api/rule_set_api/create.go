@@ -0,0 +1,120 @@
+package rule_set_api
+
+// File: honey_server/api/rule_set_api/create.go
+// Description: 规则集上传API接口，同名规则集上传时生成新版本并继承上一版本的签名覆盖配置
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/rule_service"
+	"honey_server/internal/utils/response"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateView 规则集上传接口处理函数
+func (RuleSetApi) CreateView(c *gin.Context) {
+	log := middleware.GetLog(c)
+
+	title := c.PostForm("title")
+	if title == "" || len(title) > 64 {
+		response.FailWithMsg("请输入规则集名称（不超过64个字符）", c)
+		return
+	}
+	remark := c.PostForm("remark")
+	if len(remark) > 256 {
+		response.FailWithMsg("版本说明不能超过256个字符", c)
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.FailWithMsg("请上传规则文件", c)
+		return
+	}
+	if file.Size > maxFileSize {
+		response.FailWithMsg("规则文件大小不能超过64MB", c)
+		return
+	}
+	if filepath.Ext(file.Filename) != ".rules" {
+		response.FailWithMsg("只支持.rules格式的规则文件", c)
+		return
+	}
+
+	if err = os.MkdirAll(ruleSetDir, 0755); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("failed to create rule set directory") // 创建规则目录失败
+		response.FailWithMsg("创建规则目录失败", c)
+		return
+	}
+	path := filepath.Join(ruleSetDir, fmt.Sprintf("%d_%s", time.Now().UnixNano(), filepath.Base(file.Filename)))
+	if err = c.SaveUploadedFile(file, path); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("failed to save rule file") // 保存规则文件失败
+		response.FailWithMsg("保存规则文件失败", c)
+		return
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		os.Remove(path)
+		response.FailWithMsg("读取规则文件失败", c)
+		return
+	}
+	sidMap := rule_service.SidMap(content)
+	if len(sidMap) == 0 {
+		os.Remove(path)
+		response.FailWithMsg("规则文件中没有有效的规则", c)
+		return
+	}
+
+	// 同名规则集生成新版本，继承上一版本中仍然存在的签名覆盖配置
+	var last models.RuleSetModel
+	global.DB.Order("version desc").Limit(1).Find(&last, "title = ?", title)
+	var overrides []models.RuleOverride
+	for _, override := range last.Overrides {
+		if _, ok := sidMap[override.Sid]; ok {
+			overrides = append(overrides, override)
+		}
+	}
+
+	model := models.RuleSetModel{
+		Title:     title,
+		Version:   last.Version + 1,
+		FileName:  file.Filename,
+		Path:      path,
+		RuleCount: len(sidMap),
+		Overrides: overrides,
+		Remark:    remark,
+	}
+	rules, threshold, err := rule_service.Render(model)
+	if err != nil {
+		os.Remove(path)
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+	model.Hash = rule_service.Hash(rules, threshold)
+
+	if err = global.DB.Create(&model).Error; err != nil {
+		os.Remove(path)
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to create rule set") // 规则集上传失败
+		response.FailWithMsg("规则集上传失败", c)
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"rule_set_id": model.ID,
+		"version":     model.VersionName(),
+		"rule_count":  model.RuleCount,
+	}).Info("rule set created") // 规则集上传成功
+
+	response.OkWithData(model.ID, c)
+}
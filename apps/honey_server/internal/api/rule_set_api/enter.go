@@ -0,0 +1,14 @@
+package rule_set_api
+
+// File: honey_server/api/rule_set_api/enter.go
+// Description: Suricata规则集管理API接口
+
+// RuleSetApi Suricata规则集管理API接口
+type RuleSetApi struct {
+}
+
+// 常量定义：规则文件上传相关配置
+const (
+	maxFileSize = 64 << 20          // 规则文件最大限制：64MB
+	ruleSetDir  = "media/rule_set/" // 规则文件存储根目录，相对服务运行目录
+)
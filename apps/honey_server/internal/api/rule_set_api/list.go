@@ -0,0 +1,57 @@
+package rule_set_api
+
+// File: honey_server/api/rule_set_api/list.go
+// Description: 规则集版本列表及下拉选项API接口
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 规则集列表查询请求参数结构体
+type ListRequest struct {
+	models.PageInfo        // 分页参数
+	Title           string `form:"title"` // 筛选条件：规则集名称
+}
+
+// ListView 规则集版本分页列表查询接口
+func (RuleSetApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.RuleSetModel{
+		Title: cr.Title,
+	}, common_service.QueryListRequest{
+		Likes:    []string{"title", "remark"},
+		PageInfo: cr.PageInfo,
+		Sort:     "title, version desc",
+	})
+
+	response.OkWithList(list, count, c)
+}
+
+// OptionsResponse 规则集下拉选项响应结构体
+type OptionsResponse struct {
+	Label string `json:"label"` // 显示文本：名称:v版本号
+	Value uint   `json:"value"` // 提交值：规则集版本ID
+}
+
+// OptionsView 规则集版本下拉选项查询接口
+func (RuleSetApi) OptionsView(c *gin.Context) {
+	var ruleSetList []models.RuleSetModel
+	global.DB.Order("title, version desc").Find(&ruleSetList)
+
+	var list = make([]OptionsResponse, 0)
+	for _, model := range ruleSetList {
+		list = append(list, OptionsResponse{
+			Label: model.VersionName(),
+			Value: model.ID,
+		})
+	}
+
+	response.OkWithData(list, c)
+}
@@ -0,0 +1,90 @@
+package rule_set_api
+
+// File: honey_server/api/rule_set_api/override.go
+// Description: 规则集签名覆盖配置API接口，修改签名启用状态及阈值后生成新版本
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/rule_service"
+	"honey_server/internal/utils/response"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// OverrideRequest 签名覆盖配置请求参数结构体
+type OverrideRequest struct {
+	ID        uint                  `json:"id" binding:"required"`    // 基于的规则集版本ID
+	Overrides []models.RuleOverride `json:"overrides" binding:"dive"` // 签名覆盖配置（全量）
+	Remark    string                `json:"remark" binding:"max=256"` // 版本说明
+}
+
+// OverrideView 签名覆盖配置接口处理函数
+func (RuleSetApi) OverrideView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[OverrideRequest](c)
+
+	var base models.RuleSetModel
+	if err := global.DB.Take(&base, cr.ID).Error; err != nil {
+		response.FailWithMsg("规则集不存在", c)
+		return
+	}
+	content, err := os.ReadFile(base.Path)
+	if err != nil {
+		response.FailWithMsg("规则文件不存在", c)
+		return
+	}
+
+	// 校验签名存在且不重复
+	sidMap := rule_service.SidMap(content)
+	seen := map[int]bool{}
+	for _, override := range cr.Overrides {
+		if _, ok := sidMap[override.Sid]; !ok {
+			response.FailWithMsg(fmt.Sprintf("签名 %d 不存在", override.Sid), c)
+			return
+		}
+		if seen[override.Sid] {
+			response.FailWithMsg(fmt.Sprintf("签名 %d 重复配置", override.Sid), c)
+			return
+		}
+		seen[override.Sid] = true
+	}
+
+	// 新版本复用同一规则文件
+	var last models.RuleSetModel
+	global.DB.Order("version desc").Limit(1).Find(&last, "title = ?", base.Title)
+	model := models.RuleSetModel{
+		Title:     base.Title,
+		Version:   last.Version + 1,
+		FileName:  base.FileName,
+		Path:      base.Path,
+		RuleCount: base.RuleCount,
+		Overrides: cr.Overrides,
+		Remark:    cr.Remark,
+	}
+	rules, threshold, err := rule_service.Render(model)
+	if err != nil {
+		response.FailWithMsg(err.Error(), c)
+		return
+	}
+	model.Hash = rule_service.Hash(rules, threshold)
+
+	if err = global.DB.Create(&model).Error; err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to create rule set version") // 规则集版本创建失败
+		response.FailWithMsg("规则集版本创建失败", c)
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"rule_set_id": model.ID,
+		"version":     model.VersionName(),
+		"overrides":   len(model.Overrides),
+	}).Info("rule set version created") // 规则集版本创建成功
+
+	response.OkWithData(model.ID, c)
+}
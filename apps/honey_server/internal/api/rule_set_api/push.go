@@ -0,0 +1,76 @@
+package rule_set_api
+
+// File: honey_server/api/rule_set_api/push.go
+// Description: 规则集下发API接口，将指定规则集版本推送到所选节点并返回各节点的加载结果
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/utils/response"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PushRequest 规则集下发请求参数结构体
+type PushRequest struct {
+	ID         uint   `json:"id" binding:"required"`               // 规则集版本ID
+	NodeIDList []uint `json:"nodeIDList" binding:"required,min=1"` // 目标节点ID列表
+}
+
+// PushResult 单个节点的规则集下发结果
+type PushResult struct {
+	NodeID    uint                 `json:"nodeID"`    // 节点ID
+	NodeTitle string               `json:"nodeTitle"` // 节点名称
+	State     models.NodeRuleState `json:"state"`     // 节点规则集状态
+	ErrMsg    string               `json:"errMsg"`    // 错误信息
+}
+
+// PushView 规则集下发接口处理函数
+func (RuleSetApi) PushView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[PushRequest](c)
+	logID := log.Data["logID"].(string)
+
+	var model models.RuleSetModel
+	if err := global.DB.Take(&model, cr.ID).Error; err != nil {
+		response.FailWithMsg("规则集不存在", c)
+		return
+	}
+
+	var nodeList []models.NodeModel
+	global.DB.Find(&nodeList, "id in ?", cr.NodeIDList)
+	if len(nodeList) == 0 {
+		response.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	// 各节点并行下发，互不影响
+	list := make([]PushResult, len(nodeList))
+	var wg sync.WaitGroup
+	for i, node := range nodeList {
+		wg.Add(1)
+		go func(i int, node models.NodeModel) {
+			defer wg.Done()
+			state, err := grpc_service.PushRuleSet(node, model, logID)
+			list[i] = PushResult{
+				NodeID:    node.ID,
+				NodeTitle: node.Title,
+				State:     state,
+			}
+			if err != nil {
+				list[i].ErrMsg = err.Error()
+				log.WithFields(map[string]interface{}{
+					"node_id": node.ID,
+					"version": model.VersionName(),
+					"error":   err,
+				}).Error("failed to push rule set") // 规则集下发失败
+			}
+		}(i, node)
+	}
+	wg.Wait()
+
+	response.OkWithData(list, c)
+}
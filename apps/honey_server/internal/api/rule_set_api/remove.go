@@ -0,0 +1,43 @@
+package rule_set_api
+
+// File: honey_server/api/rule_set_api/remove.go
+// Description: 规则集版本删除API接口，正在节点上生效的版本不允许删除
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RemoveView 规则集版本删除接口处理函数
+func (RuleSetApi) RemoveView(c *gin.Context) {
+	log := middleware.GetLog(c)
+	cr := middleware.GetBind[models.IDRequest](c)
+
+	var model models.RuleSetModel
+	if err := global.DB.Take(&model, cr.Id).Error; err != nil {
+		response.FailWithMsg("规则集不存在", c)
+		return
+	}
+
+	var nodeList []models.NodeModel
+	global.DB.Find(&nodeList)
+	for _, node := range nodeList {
+		if node.RuleState.RuleSetID == model.ID {
+			response.FailWithMsg(fmt.Sprintf("规则集正在节点 %s 上生效，不能删除", node.Title), c)
+			return
+		}
+	}
+
+	global.DB.Delete(&model)
+	log.WithFields(map[string]interface{}{
+		"rule_set_id": model.ID,
+		"version":     model.VersionName(),
+	}).Info("rule set removed") // 规则集删除成功
+
+	response.OkWithMsg("规则集删除成功", c)
+}
@@ -15,6 +15,8 @@ type Config struct {
 	WhiteList []string `yaml:"whiteList"` // 路由白名单
 	MQ        MQ       `yaml:"mq"`        // rabbitMQ配置信息
 	Site      Site     `yaml:"site"`      // 站点配置信息
+	Cert      Cert     `yaml:"cert"`      // 节点证书配置信息
+	Metrics   Metrics  `yaml:"metrics"`   // Prometheus指标配置信息
 }
 
 // DB 数据库连接配置结构体
@@ -56,10 +58,27 @@ type Redis struct {
 
 // System 系统配置结构体
 type System struct {
-	WebAddr  string `yaml:"webAddr"`  // Web服务监听地址
-	GrpcAddr string `yaml:"grpcAddr"` // gRPC服务监听地址
-	Mode     string `yaml:"mode"`     // 运行模式 [debug|release|test]
-	ManageIp string `yaml:"manageIp"` // 管理IP
+	WebAddr   string `yaml:"webAddr"`   // Web服务监听地址
+	GrpcAddr  string `yaml:"grpcAddr"`  // gRPC服务监听地址
+	Mode      string `yaml:"mode"`      // 运行模式 [debug|release|test]
+	ManageIp  string `yaml:"manageIp"`  // 管理IP
+	Agreement string `yaml:"agreement"` // 节点访问管理端的协议 [http|https]，默认http
+}
+
+// nodeDownloadPath 节点镜像文件下载接口路径
+const nodeDownloadPath = "/api/honey_server/node_version/download"
+
+// Scheme 获取节点访问管理端的协议，未配置时使用http
+func (s System) Scheme() string {
+	if s.Agreement == "" {
+		return "http"
+	}
+	return s.Agreement
+}
+
+// NodeDownloadURL 获取节点镜像文件下载地址，安装脚本与远程升级共用
+func (s System) NodeDownloadURL(scheme string) string {
+	return fmt.Sprintf("%s://%s%s", scheme, s.ManageIp, nodeDownloadPath)
 }
 
 // Jwt 配置结构体
@@ -83,6 +102,7 @@ type MQ struct {
 	ClientKey            string `yaml:"clientKey"`            // 客户端密钥
 	CaCertificate        string `yaml:"caCertificate"`        // CA证书
 	WsTopic              string `yaml:"wsTopic"`              // websocket上报状态的topic
+	AlertTopic           string `yaml:"alertTopic"`           // 告警队列的topic
 }
 
 // Addr 获取rabbitMQ地址
@@ -104,6 +124,11 @@ func (m MQ) Addr() string {
 	)
 }
 
+// Cert 节点证书配置结构体
+type Cert struct {
+	NodeDays int `yaml:"nodeDays"` // 节点客户端证书有效期（天），节点在剩余有效期不足三分之一时自动轮换
+}
+
 // Site 站点配置结构体
 type Site struct {
 	Title  string `yaml:"title" json:"title"`   // 站点名称
@@ -112,3 +137,8 @@ type Site struct {
 	Logo   string `yaml:"logo" json:"logo"`     // 站点logo
 	Path   string `yaml:"path" json:"-"`        // 站点路径
 }
+
+// Metrics Prometheus指标配置结构体
+type Metrics struct {
+	Addr string `yaml:"addr"` // /metrics监听地址，为空时不启动；只监听本机或内部网络地址，不与web服务共用端口
+}
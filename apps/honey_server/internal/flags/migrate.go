@@ -28,6 +28,12 @@ func Migrate() {
 		&models.UserModel{},
 		&models.WhiteIPModel{},
 		&models.NodeVersionModel{},
+		&models.HoneyIpPcapModel{},
+		&models.RuleSetModel{},
+		&models.NodeUpgradeModel{},
+		&models.NodeDiagnoseModel{},
+		&models.NodeCertModel{},
+		&models.NodeTokenModel{},
 	)
 	if err != nil {
 		logrus.Fatalf("表结构迁移失败 %s", err)
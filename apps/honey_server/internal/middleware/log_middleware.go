@@ -1,10 +1,13 @@
 package middleware
 
 // File: honey_server/middleware/log_middleware.go
-// Description: 日志上下文中间件模块，为每个请求生成唯一日志ID并注入带标识的日志实例
+// Description: 日志上下文中间件模块，为每个请求生成唯一日志ID并注入带标识的日志实例，提供查询参数脱敏的访问日志中间件
 
 import (
+	"fmt"
 	"honey_server/internal/global"
+	"net/url"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -31,3 +34,51 @@ func GetLog(c *gin.Context) *logrus.Entry {
 	// 从上下文获取日志实例并类型断言
 	return c.MustGet("log").(*logrus.Entry)
 }
+
+// sensitiveQueryKeys 访问日志中需要脱敏的查询参数（JWT令牌、节点接入令牌）
+var sensitiveQueryKeys = []string{"token", "nodeToken"}
+
+// AccessLogMiddleware 访问日志中间件，输出格式与gin默认访问日志一致，查询参数中的令牌脱敏后输出
+func AccessLogMiddleware() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		var statusColor, methodColor, resetColor string
+		if param.IsOutputColor() {
+			statusColor = param.StatusCodeColor()
+			methodColor = param.MethodColor()
+			resetColor = param.ResetColor()
+		}
+		if param.Latency > time.Minute {
+			param.Latency = param.Latency.Truncate(time.Second)
+		}
+		return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			statusColor, param.StatusCode, resetColor,
+			param.Latency,
+			param.ClientIP,
+			methodColor, param.Method, resetColor,
+			redactPath(param.Path),
+			param.ErrorMessage,
+		)
+	})
+}
+
+// redactPath 将请求路径中敏感查询参数的值替换为redacted
+func redactPath(path string) string {
+	u, err := url.Parse(path)
+	if err != nil || u.RawQuery == "" {
+		return path
+	}
+	query := u.Query()
+	redacted := false
+	for _, key := range sensitiveQueryKeys {
+		if query.Has(key) {
+			query.Set(key, "redacted")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return path
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
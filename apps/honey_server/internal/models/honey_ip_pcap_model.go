@@ -0,0 +1,34 @@
+package models
+
+import (
+	"os"
+
+	"gorm.io/gorm"
+)
+
+// HoneyIpPcapModel 诱捕ip抓包记录模型
+type HoneyIpPcapModel struct {
+	Model
+	NodeID       uint         `json:"nodeID"`                                              // 归属节点ID
+	HoneyIpID    uint         `gorm:"index:idx_honey_ip_id" json:"honeyIpID"`              // 关联诱捕ipID
+	HoneyIpModel HoneyIpModel `gorm:"foreignKey:HoneyIpID" json:"-"`                       // 关联诱捕ip
+	CaptureID    string       `gorm:"size:64;uniqueIndex:idx_capture_id" json:"captureID"` // 抓包任务id
+	IP           string       `gorm:"size:64" json:"ip"`                                   // 诱捕ip
+	LinkName     string       `gorm:"size:32" json:"linkName"`                             // 抓包接口名称
+	Duration     int          `json:"duration"`                                            // 最长抓包时间（秒）
+	MaxBytes     int64        `json:"maxBytes"`                                            // 环形缓冲区容量（字节）
+	Status       int8         `json:"status"`                                              // 抓包状态 1 抓包中 2 停止中 3 已完成 4 失败
+	PacketCount  int64        `json:"packetCount"`                                         // 数据包数量
+	DropCount    int64        `json:"dropCount"`                                           // 因缓冲区已满被丢弃的数据包数量
+	FileSize     int64        `json:"fileSize"`                                            // 文件大小
+	Path         string       `gorm:"size:256" json:"-"`                                   // 文件路径
+	ErrorMsg     string       `gorm:"size:256" json:"errorMsg"`                            // 错误信息
+}
+
+// BeforeDelete 删除记录时同步删除抓包文件
+func (p HoneyIpPcapModel) BeforeDelete(tx *gorm.DB) error {
+	if p.Path != "" {
+		os.Remove(p.Path)
+	}
+	return nil
+}
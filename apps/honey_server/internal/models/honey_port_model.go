@@ -11,10 +11,23 @@ type HoneyPortModel struct {
 	HoneyIpModel HoneyIpModel `gorm:"foreignKey:HoneyIpID" json:"-"`          // 关联诱捕ip
 	ServiceID    uint         `json:"serviceID"`                              // 服务id
 	ServiceModel ServiceModel `gorm:"foreignKey:ServiceID" json:"-"`          // 关联服务
-	IP           string       `gorm:"size:32;index:idx_ip" json:"ip"`         // 服务ip
+	IP           string       `gorm:"size:64;index:idx_ip" json:"ip"`         // 服务ip
 	Port         int          `json:"port"`                                   // 服务端口
+	Agreement    int8         `gorm:"default:1" json:"agreement"`             // 通信协议 1 TCP 2 UDP
+	Emulator     string       `gorm:"size:16" json:"emulator"`                // 节点内置模拟器名称（与关联服务二选一）
 	DstIP        string       `gorm:"size:32" json:"dstIP"`                   // 目标转发ip
 	DstPort      int          `json:"dstPort"`                                // 目标转发端口
 	Status       int8         `json:"status"`                                 // 服务状态
 	ErrorMsg     string       `gorm:"size:256" json:"errorMsg"`               // 错误信息
 }
+
+// PortKey 诱捕端口唯一标识，同一诱捕IP上TCP与UDP可复用相同端口号
+type PortKey struct {
+	Agreement int8 // 通信协议
+	Port      int  // 端口号
+}
+
+// Key 获取诱捕端口唯一标识
+func (h HoneyPortModel) Key() PortKey {
+	return PortKey{Agreement: h.Agreement, Port: h.Port}
+}
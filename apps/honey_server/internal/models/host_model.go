@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -8,13 +10,28 @@ import (
 // HostModel 存放主机模型
 type HostModel struct {
 	Model
-	NodeID    uint      `json:"nodeID"`                         // 归属节点ID
-	NodeModel NodeModel `gorm:"foreignKey:NodeID" json:"-"`     // 归属节点
-	NetID     uint      `gorm:"index:idx_net_id" json:"netID"`  // 归属网络ID
-	NetModel  NetModel  `gorm:"foreignKey:NetID" json:"-"`      // 归属网络
-	IP        string    `gorm:"size:32;index:idx_ip" json:"ip"` // 主机ip
-	Mac       string    `gorm:"size:64" json:"mac"`             // MAC地址
-	Manuf     string    `gorm:"size:64" json:"manuf"`           // 厂商信息
+	NodeID    uint         `json:"nodeID"`                          // 归属节点ID
+	NodeModel NodeModel    `gorm:"foreignKey:NodeID" json:"-"`      // 归属节点
+	NetID     uint         `gorm:"index:idx_net_id" json:"netID"`   // 归属网络ID
+	NetModel  NetModel     `gorm:"foreignKey:NetID" json:"-"`       // 归属网络
+	IP        string       `gorm:"size:64;index:idx_ip" json:"ip"`  // 主机ip
+	Mac       string       `gorm:"size:64" json:"mac"`              // MAC地址
+	Manuf     string       `gorm:"size:64" json:"manuf"`            // 厂商信息
+	Hostname  string       `gorm:"size:64" json:"hostname"`         // 主机名（被动资产发现）
+	PortList  HostPortList `gorm:"serializer:json" json:"portList"` // 开放端口列表（端口服务探测结果）
+	ScanTime  *time.Time   `json:"scanTime"`                        // 最近一次端口服务探测时间
+	FirstSeen *time.Time   `json:"firstSeen"`                       // 被动资产发现首次发现时间
+	LastSeen  *time.Time   `json:"lastSeen"`                        // 被动资产发现最近发现时间
+	Status    int8         `gorm:"default:1" json:"status"`         // 主机状态 1 在线 2 离线（被动资产发现持续无流量）
+}
+
+// HostPortList 主机开放端口列表
+type HostPortList []HostPort
+
+// HostPort 主机开放端口
+type HostPort struct {
+	Port   int    `json:"port"`   // 端口号
+	Banner string `json:"banner"` // 服务banner
 }
 
 func (model HostModel) AfterDelete(tx *gorm.DB) error {
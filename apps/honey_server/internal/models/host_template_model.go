@@ -3,8 +3,16 @@ package models
 // HostTemplateModel 主机模板模型
 type HostTemplateModel struct {
 	Model
-	Title    string               `gorm:"size:64" json:"title"`            // 主机模板名称
-	PortList HostTemplatePortList `gorm:"serializer:json" json:"portList"` // 开放端口组
+	Title         string               `gorm:"size:64" json:"title"`                // 主机模板名称
+	DeviceProfile string               `gorm:"size:32" json:"deviceProfile"`        // 设备画像（诱捕ip按画像使用对应厂商的MAC地址，为空时随机分配）
+	DeviceVendor  DeviceVendor         `gorm:"serializer:json" json:"deviceVendor"` // 设备画像的厂商信息（由镜像服务保存模板时写入，部署时下发给节点）
+	PortList      HostTemplatePortList `gorm:"serializer:json" json:"portList"`     // 开放端口组
+}
+
+// DeviceVendor 设备画像的厂商信息
+type DeviceVendor struct {
+	VendorList  []string `json:"vendorList"`  // 厂商名称关键字，用于在OUI数据库中匹配厂商
+	FallbackOUI []string `json:"fallbackOUI"` // OUI数据库未匹配到时使用的内置OUI
 }
 
 // HostTemplatePortList 主机模板端口列表
@@ -12,6 +20,7 @@ type HostTemplatePortList []HostTemplatePort
 
 // HostTemplatePort 主机模板端口模型
 type HostTemplatePort struct {
-	Port      int  `json:"port"`      // 端口号
-	ServiceID uint `json:"serviceID"` // 关联服务ID
+	Port      int    `json:"port"`      // 端口号
+	ServiceID uint   `json:"serviceID"` // 关联服务ID
+	Emulator  string `json:"emulator"`  // 节点内置模拟器名称（与关联服务二选一）
 }
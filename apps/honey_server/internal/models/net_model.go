@@ -17,9 +17,12 @@ type NetModel struct {
 	NodeModel          NodeModel `gorm:"foreignKey:NodeID" json:"-"`         // 归属节点
 	Title              string    `gorm:"size:64" json:"title"`               // 网络名称
 	Network            string    `gorm:"size:32" json:"network"`             // 网卡名称
-	IP                 string    `gorm:"size:32" json:"ip"`                  // 探针ip
+	IP                 string    `gorm:"size:64" json:"ip"`                  // 探针ip
 	Mask               int8      `json:"mask"`                               // 子网掩码 8-32
-	Gateway            string    `gorm:"size:32" json:"gateway"`             // 网关
+	IP6                string    `gorm:"size:64" json:"ip6"`                 // 探针IPv6地址，双栈网络才有
+	Mask6              int8      `json:"mask6"`                              // IPv6前缀长度 8-126
+	VlanID             int       `json:"vlanID"`                             // VLAN ID，0表示未划分VLAN
+	Gateway            string    `gorm:"size:64" json:"gateway"`             // 网关
 	HostCount          int       `json:"hostCount"`                          // 存放资产（子网中活跃的主机）
 	HoneyIpCount       int       `json:"honeyIpCount"`                       // 诱捕ip数
 	ScanStatus         int8      `json:"scanStatus"`                         // 扫描状态  0 待扫描  1 扫描完成  2 扫描中
@@ -32,10 +35,37 @@ func (model NetModel) Subnet() string {
 	return fmt.Sprintf("%s/%d", model.IP, model.Mask)
 }
 
-// InSubnet 判断给定的IP地址是否属于当前网络模型的子网
+// Subnet6 返回网络模型的IPv6子网信息，非双栈网络返回空
+func (model NetModel) Subnet6() string {
+	if model.IP6 == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%d", model.IP6, model.Mask6)
+}
+
+// InSubnet 判断给定的IP地址是否属于当前网络模型的IPv4或IPv6子网
 func (model NetModel) InSubnet(ip string) bool {
-	_, _net, _ := net.ParseCIDR(model.Subnet())
-	return _net.Contains(net.ParseIP(ip))
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	subnet := model.Subnet()
+	if addr.To4() == nil {
+		subnet = model.Subnet6()
+	}
+	_, _net, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return false
+	}
+	return _net.Contains(addr)
+}
+
+// MaskOf 返回给定IP地址所属协议栈的子网掩码，IPv6地址返回IPv6前缀长度
+func (model NetModel) MaskOf(ip string) int8 {
+	if addr := net.ParseIP(ip); addr != nil && addr.To4() == nil {
+		return model.Mask6
+	}
+	return model.Mask
 }
 
 // IpRange 获取网络模型中的IP范围
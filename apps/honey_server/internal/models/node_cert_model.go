@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// NodeCertModel 节点客户端证书签发记录，gRPC请求按证书序列号校验节点身份及吊销状态
+type NodeCertModel struct {
+	Model
+	NodeUid      string    `gorm:"size:64;index:idx_node_uid" json:"nodeUid"`    // 节点uid（证书CN）
+	Serial       string    `gorm:"size:64;uniqueIndex:idx_serial" json:"serial"` // 证书序列号（十六进制）
+	NotAfter     time.Time `json:"notAfter"`                                     // 证书过期时间
+	Status       int8      `json:"status"`                                       // 证书状态 1 待启用 2 使用中 3 已吊销
+	RevokeReason string    `gorm:"size:64" json:"revokeReason"`                  // 吊销原因
+}
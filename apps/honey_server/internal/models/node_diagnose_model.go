@@ -0,0 +1,11 @@
+package models
+
+import "encoding/json"
+
+// NodeDiagnoseModel 节点诊断报告模型，每个节点保留最近的若干份报告用于对比
+type NodeDiagnoseModel struct {
+	Model
+	NodeID    uint            `gorm:"index:idx_node_id" json:"nodeID"` // 节点ID
+	NodeModel NodeModel       `gorm:"foreignKey:NodeID" json:"-"`      // 节点
+	Report    json.RawMessage `gorm:"type:longtext" json:"report"`     // 诊断报告（JSON）
+}
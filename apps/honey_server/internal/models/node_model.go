@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -10,13 +12,17 @@ type NodeModel struct {
 	Model
 	Title        string         `gorm:"size:64" json:"title"`              // 节点名称
 	Uid          string         `gorm:"size:64;index:idx_uid" json:"uid"`  // 节点uid
-	IP           string         `gorm:"size:32" json:"ip"`                 // 节点ip
+	IP           string         `gorm:"size:64" json:"ip"`                 // 节点ip
 	Mac          string         `gorm:"size:64" json:"mac"`                // 节点mac
 	Status       int8           `json:"status"`                            // 节点状态
+	Approve      int8           `gorm:"default:2" json:"approve"`          // 审批状态 1 待审批 2 已通过 3 已拒绝
 	NetCount     int            `json:"netCount"`                          // 网络数
 	HoneyIPCount int            `json:"honeyIPCount"`                      // 诱捕ip数
 	Resource     NodeResource   `gorm:"serializer:json" json:"resource"`   // 节点资源占用
 	SystemInfo   NodeSystemInfo `gorm:"serializer:json" json:"systemInfo"` // 节点系统信息详情
+	RuleState    NodeRuleState  `gorm:"serializer:json" json:"ruleState"`  // 节点规则集状态
+	EnrollCode   string         `gorm:"size:64" json:"-"`                  // 一次性接入码，管理员重置节点证书后生成
+	CodeExpire   *time.Time     `json:"-"`                                 // 接入码过期时间
 }
 
 func (n *NodeModel) BeforeDelete(tx *gorm.DB) error {
@@ -60,6 +66,29 @@ func (n *NodeModel) BeforeDelete(tx *gorm.DB) error {
 	}
 	logrus.Infof("关联节点网卡 %d", len(networkList))
 
+	// 删除与节点关联的升级记录
+	var upgradeList []NodeUpgradeModel
+	err = tx.Find(&upgradeList, "node_id = ?", n.ID).Delete(&upgradeList).Error
+	if err != nil {
+		return err
+	}
+	logrus.Infof("关联升级记录 %d", len(upgradeList))
+
+	// 删除与节点关联的诊断报告
+	var diagnoseList []NodeDiagnoseModel
+	err = tx.Find(&diagnoseList, "node_id = ?", n.ID).Delete(&diagnoseList).Error
+	if err != nil {
+		return err
+	}
+	logrus.Infof("关联诊断报告 %d", len(diagnoseList))
+
+	// 吊销节点的客户端证书，节点删除后无法再以原证书接入
+	err = tx.Model(&NodeCertModel{}).Where("node_uid = ? and status in ?", n.Uid, []int8{1, 2}).
+		Updates(map[string]interface{}{"status": 3, "revoke_reason": "节点删除"}).Error
+	if err != nil {
+		return err
+	}
+
 	// 如果没有错误，返回nil
 	return nil
 }
@@ -86,3 +115,15 @@ type NodeSystemInfo struct {
 	NodeVersion         string `json:"nodeVersion"`         // 节点版本
 	NodeCommit          string `json:"nodeCommit"`          // 节点commit
 }
+
+// NodeRuleState 节点规则集状态模型
+type NodeRuleState struct {
+	RuleSetID   uint     `json:"ruleSetID"`   // 当前生效的规则集ID
+	Version     string   `json:"version"`     // 当前生效的规则集版本
+	Hash        string   `json:"hash"`        // 当前生效的规则内容摘要
+	RulesLoaded int      `json:"rulesLoaded"` // 加载成功的规则数
+	RulesFailed int      `json:"rulesFailed"` // 加载失败的规则数
+	ErrList     []string `json:"errList"`     // 加载失败的规则及原因
+	ErrMsg      string   `json:"errMsg"`      // 最近一次下发的错误信息
+	UpdatedAt   string   `json:"updatedAt"`   // 最近一次下发时间
+}
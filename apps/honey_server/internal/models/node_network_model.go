@@ -13,9 +13,13 @@ type NodeNetworkModel struct {
 	NodeID    uint      `gorm:"index:idx_node_id"json:"nodeID"` // 归属节点ID
 	NodeModel NodeModel `gorm:"foreignKey:NodeID" json:"-"`     // 归属节点
 	Network   string    `gorm:"size:32" json:"network"`         // 网卡名称
-	IP        string    `gorm:"size:32" json:"ip"`              // 探针ip
+	IP        string    `gorm:"size:64" json:"ip"`              // 探针ip
 	Mask      int8      `json:"mask"`                           // 子网掩码 8-32
-	Gateway   string    `gorm:"size:32" json:"gateway"`         // 网关
+	IP6       string    `gorm:"size:64" json:"ip6"`             // 探针IPv6地址，双栈网卡才有
+	Mask6     int8      `json:"mask6"`                          // IPv6前缀长度 8-126
+	VlanID    int       `json:"vlanID"`                         // VLAN ID，0表示物理网卡
+	Parent    string    `gorm:"size:32" json:"parent"`          // VLAN子接口所在的父网卡（trunk口）
+	Gateway   string    `gorm:"size:64" json:"gateway"`         // 网关
 	Status    int8      `json:"status"`                         // 网卡启用状态 1 启用 2 未启用
 	NetID     uint      `json:"netID"`                          // 网络ID 启用才会有
 }
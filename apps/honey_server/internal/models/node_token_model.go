@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// NodeTokenModel 节点接入令牌模型，安装脚本携带有效令牌的节点注册时免审批接入
+type NodeTokenModel struct {
+	Model
+	Title    string     `gorm:"size:64" json:"title"`                       // 令牌备注
+	Token    string     `gorm:"size:64;uniqueIndex:idx_token" json:"token"` // 接入令牌
+	OneTime  bool       `json:"oneTime"`                                    // 是否一次性令牌，使用一次后失效
+	ExpireAt *time.Time `json:"expireAt"`                                   // 过期时间，为空表示不过期
+	UseCount int        `json:"useCount"`                                   // 已使用次数
+	NodeUid  string     `gorm:"size:64" json:"nodeUid"`                     // 最近一次使用该令牌接入的节点uid
+}
+
+// Valid 判断令牌当前是否可用
+func (t NodeTokenModel) Valid() bool {
+	if t.OneTime && t.UseCount > 0 {
+		return false
+	}
+	if t.ExpireAt != nil && time.Now().After(*t.ExpireAt) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,16 @@
+package models
+
+// NodeUpgradeModel 节点升级记录模型，每个节点在一次发布中对应一条记录
+type NodeUpgradeModel struct {
+	Model
+	Batch       string    `gorm:"size:64;index:idx_batch" json:"batch"` // 发布批次标识，同一次发布的节点共用
+	Stage       int       `json:"stage"`                                // 所属发布阶段，从1开始
+	NodeID      uint      `gorm:"index:idx_node_id" json:"nodeID"`      // 节点ID
+	NodeModel   NodeModel `gorm:"foreignKey:NodeID" json:"-"`           // 节点
+	VersionID   uint      `json:"versionID"`                            // 目标节点版本ID
+	FromVersion string    `gorm:"size:64" json:"fromVersion"`           // 升级前版本
+	ToVersion   string    `gorm:"size:64" json:"toVersion"`             // 目标版本
+	Status      int8      `json:"status"`                               // 升级状态 1 等待中 2 下载中 3 切换中 4 升级成功 5 已回滚 6 升级失败 7 已取消
+	Progress    float64   `json:"progress"`                             // 镜像下载进度
+	ErrMsg      string    `gorm:"size:256" json:"errMsg"`               // 错误信息
+}
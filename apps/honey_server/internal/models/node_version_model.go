@@ -15,6 +15,7 @@ type NodeVersionModel struct {
 	ImageID   string `json:"imageID"`   // 镜像id
 	FileSize  int64  `json:"fileSize"`  // 文件大小
 	Path      string `json:"path"`      // 文件路径
+	Hash      string `json:"hash"`      // 文件sha256摘要
 }
 
 func (node NodeVersionModel) BeforeDelete(tx *gorm.DB) error {
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+// RuleSetModel Suricata规则集模型，每次上传规则文件或修改签名覆盖配置都会生成一个新版本
+type RuleSetModel struct {
+	Model
+	Title     string         `gorm:"size:64;index:idx_title" json:"title"` // 规则集名称
+	Version   int            `json:"version"`                              // 版本号，同名规则集内递增
+	FileName  string         `gorm:"size:256" json:"fileName"`             // 上传的规则文件名
+	Path      string         `gorm:"size:256" json:"-"`                    // 规则文件存储路径
+	RuleCount int            `json:"ruleCount"`                            // 规则文件中的规则数
+	Overrides []RuleOverride `gorm:"serializer:json" json:"overrides"`     // 签名覆盖配置
+	Hash      string         `gorm:"size:64" json:"hash"`                  // 下发内容摘要（规则文件及覆盖配置）
+	Remark    string         `gorm:"size:256" json:"remark"`               // 版本说明
+}
+
+// RuleOverride 单个签名的覆盖配置
+type RuleOverride struct {
+	Sid       int            `json:"sid" binding:"required"` // 规则签名ID
+	Disable   bool           `json:"disable"`                // 是否禁用该签名
+	Threshold *RuleThreshold `json:"threshold,omitempty"`    // 阈值配置，为空时使用规则自身配置
+}
+
+// RuleThreshold 签名阈值配置，对应threshold.config中的threshold语句
+type RuleThreshold struct {
+	Type    string `json:"type" binding:"required,oneof=limit threshold both"` // 阈值类型
+	Track   string `json:"track" binding:"required,oneof=by_src by_dst"`       // 统计维度
+	Count   int    `json:"count" binding:"required,min=1"`                     // 触发次数
+	Seconds int    `json:"seconds" binding:"required,min=1"`                   // 统计周期（秒）
+}
+
+// VersionName 规则集版本名称，节点上报及展示使用
+func (r RuleSetModel) VersionName() string {
+	return fmt.Sprintf("%s:v%d", r.Title, r.Version)
+}
+
+// BeforeDelete 删除记录时同步删除规则文件，文件仍被其他版本引用时保留
+func (r RuleSetModel) BeforeDelete(tx *gorm.DB) error {
+	if r.Path == "" {
+		return nil
+	}
+	var count int64
+	tx.Model(&RuleSetModel{}).Where("path = ? and id <> ?", r.Path, r.ID).Count(&count)
+	if count == 0 {
+		os.Remove(r.Path)
+	}
+	return nil
+}
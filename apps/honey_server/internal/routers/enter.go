@@ -18,8 +18,9 @@ func Run() {
 	// 设置Gin运行模式（debug/release/test）
 	gin.SetMode(system.Mode)
 
-	// 创建默认Gin引擎
-	r := gin.Default()
+	// 创建Gin引擎，访问日志中的令牌查询参数脱敏输出
+	r := gin.New()
+	r.Use(middleware.AccessLogMiddleware(), gin.Recovery())
 	// 创建静态路由
 	r.Static("uploads", "uploads")
 	// 创建API根路由分组
@@ -40,6 +41,7 @@ func Run() {
 	IndexRouters(g)       // 首页相关路由
 	SiteRouters(g)        // 站点相关路由
 	NodeVersionRouters(g) // 节点版本相关路由
+	RuleSetRouters(g)     // 规则集相关路由
 
 	// 获取HTTP服务监听地址
 	webAddr := system.WebAddr
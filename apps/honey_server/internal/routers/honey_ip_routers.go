@@ -25,4 +25,15 @@ func HoneyIPRouters(r *gin.RouterGroup) {
 	// DELETE /honey_ip: 诱捕IP删除接口
 	// 使用JSON参数绑定中间件解析通用请求参数
 	r.DELETE("honey_ip", middleware.BindJsonMiddleware[models.IDListRequest], app.RemoveView)
+	// POST /honey_ip/pcap: 诱捕IP抓包启动接口
+	// 使用JSON参数绑定中间件解析抓包启动请求参数
+	r.POST("honey_ip/pcap", middleware.BindJsonMiddleware[honey_ip_api.PcapStartRequest], app.PcapStartView)
+	// PUT /honey_ip/pcap/:id/stop: 诱捕IP抓包停止接口，停止后节点上传抓包文件
+	r.PUT("honey_ip/pcap/:id/stop", middleware.BindUriMiddleware[models.IDRequest], app.PcapStopView)
+	// GET /honey_ip/pcap: 诱捕IP抓包记录列表接口
+	r.GET("honey_ip/pcap", middleware.BindQueryMiddleware[honey_ip_api.PcapListRequest], app.PcapListView)
+	// GET /honey_ip/pcap/:id/download: 抓包文件下载接口
+	r.GET("honey_ip/pcap/:id/download", middleware.BindUriMiddleware[models.IDRequest], app.PcapDownloadView)
+	// DELETE /honey_ip/pcap: 抓包记录批量删除接口（同时删除抓包文件）
+	r.DELETE("honey_ip/pcap", middleware.BindJsonMiddleware[models.IDListRequest], app.PcapRemoveView)
 }
@@ -31,9 +31,12 @@ func NetRouters(r *gin.RouterGroup) {
 	// 绑定JSON参数结构体,解析请求体JSON数据到IDListRequest结构体
 	r.DELETE("net", middleware.BindJsonMiddleware[models.IDListRequest], app.RemoveView)
 	// POST /net/scan - 扫描指定网络
-	// 绑定JSON参数结构体,解析请求体JSON数据到IDRequest结构体
-	r.POST("net/scan", middleware.BindJsonMiddleware[models.IDRequest], app.ScanView)
+	// 绑定JSON参数结构体,解析请求体JSON数据到ScanRequest结构体
+	r.POST("net/scan", middleware.BindJsonMiddleware[net_api.ScanRequest], app.ScanView)
 	// GET /net/ip_list - 获取指定网络的可用IP列表
 	// 绑定Query参数结构体,解析URL查询参数到NetUseIPListRequest结构体
 	r.GET("net/ip_list", middleware.BindQueryMiddleware[net_api.NetUseIPListRequest], app.NetUseIPListView)
+	// GET /net/service - 获取指定网络按端口汇总的服务资产
+	// 绑定Query参数结构体,解析URL查询参数到IDRequest结构体
+	r.GET("net/service", middleware.BindQueryMiddleware[models.IDRequest], app.ServiceListView)
 }
@@ -31,4 +31,7 @@ func NodeNetworkRouters(r *gin.RouterGroup) {
 	// DELETE /node_network/:id - 删除节点网卡
 	// 使用URI参数绑定中间件解析通用ID请求参数
 	r.DELETE("node_network/:id", middleware.BindUriMiddleware[models.IDRequest], app.RemoveView)
+	// POST /node_network/vlan - 在节点trunk口上创建VLAN子接口
+	// 使用JSON参数绑定中间件解析VLAN子接口创建请求参数
+	r.POST("node_network/vlan", middleware.BindJsonMiddleware[node_network_api.VlanCreateRequest], app.VlanCreateView)
 }
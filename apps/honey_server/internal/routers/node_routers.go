@@ -29,6 +29,58 @@ func NodeRouters(r *gin.RouterGroup) {
 	// 绑定JSON参数解析请求体JSON数据到UpdateRequest结构体
 	r.PUT("node", middleware.BindJsonMiddleware[node_api.UpdateRequest], app.UpdateView)
 
+	// POST /node/sync - 节点全量状态同步
+	// 绑定JSON参数解析请求体JSON数据到IDRequest结构体
+	r.POST("node/sync", middleware.BindJsonMiddleware[models.IDRequest], app.SyncView)
+
+	// POST /node/diagnose - 采集节点诊断报告
+	// 绑定JSON参数解析请求体JSON数据到DiagnoseRequest结构体
+	r.POST("node/diagnose", middleware.BindJsonMiddleware[node_api.DiagnoseRequest], app.DiagnoseView)
+
+	// GET /node/diagnose - 查询节点诊断报告
+	// 绑定Query参数解析URL查询参数到DiagnoseListRequest结构体
+	r.GET("node/diagnose", middleware.BindQueryMiddleware[node_api.DiagnoseListRequest], app.DiagnoseListView)
+
+	// GET /node/task - 查询节点任务列表
+	// 绑定Query参数解析URL查询参数到TaskListRequest结构体
+	r.GET("node/task", middleware.BindQueryMiddleware[node_api.TaskListRequest], app.TaskListView)
+
+	// PUT /node/task/cancel - 取消节点任务
+	// 绑定JSON参数解析请求体JSON数据到TaskActionRequest结构体
+	r.PUT("node/task/cancel", middleware.BindJsonMiddleware[node_api.TaskActionRequest], app.TaskCancelView)
+
+	// PUT /node/task/retry - 重试节点任务
+	// 绑定JSON参数解析请求体JSON数据到TaskActionRequest结构体
+	r.PUT("node/task/retry", middleware.BindJsonMiddleware[node_api.TaskActionRequest], app.TaskRetryView)
+
+	// GET /node/cert - 查询节点证书签发记录
+	// 绑定Query参数解析URL查询参数到CertListRequest结构体
+	r.GET("node/cert", middleware.BindQueryMiddleware[node_api.CertListRequest], app.CertListView)
+
+	// PUT /node/cert/rotate - 通知节点轮换客户端证书
+	// 绑定JSON参数解析请求体JSON数据到IDRequest结构体
+	r.PUT("node/cert/rotate", middleware.BindJsonMiddleware[models.IDRequest], app.CertRotateView)
+
+	// PUT /node/cert/reset - 重置节点证书并生成一次性接入码（管理员）
+	// 绑定JSON参数解析请求体JSON数据到IDRequest结构体
+	r.PUT("node/cert/reset", middleware.AdminMiddleware, middleware.BindJsonMiddleware[models.IDRequest], app.CertResetView)
+
+	// PUT /node/approve - 审批待接入节点（管理员）
+	// 绑定JSON参数解析请求体JSON数据到ApproveRequest结构体
+	r.PUT("node/approve", middleware.AdminMiddleware, middleware.BindJsonMiddleware[node_api.ApproveRequest], app.ApproveView)
+
+	// POST /node/token - 创建节点接入令牌（管理员）
+	// 绑定JSON参数解析请求体JSON数据到TokenCreateRequest结构体
+	r.POST("node/token", middleware.AdminMiddleware, middleware.BindJsonMiddleware[node_api.TokenCreateRequest], app.TokenCreateView)
+
+	// GET /node/token - 查询节点接入令牌列表（管理员）
+	// 绑定Query参数解析URL查询参数到TokenListRequest结构体
+	r.GET("node/token", middleware.AdminMiddleware, middleware.BindQueryMiddleware[node_api.TokenListRequest], app.TokenListView)
+
+	// DELETE /node/token - 删除节点接入令牌（管理员）
+	// 绑定JSON参数解析请求体JSON数据到IDListRequest结构体
+	r.DELETE("node/token", middleware.AdminMiddleware, middleware.BindJsonMiddleware[models.IDListRequest], app.TokenRemoveView)
+
 	// GET /node/options - 获取节点选项
 	r.GET("node/options", app.OptionsView)
 
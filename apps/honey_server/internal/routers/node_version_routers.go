@@ -35,6 +35,14 @@ func NodeVersionRouters(r *gin.RouterGroup) {
 	// 功能：返回节点版本选项列表，用于前端下拉框选择
 	r.GET("node_version/options", app.NodeVersionOptionsView)
 
+	// POST /node_version/upgrade：节点远程升级接口
+	// 功能：将所选节点按阶段升级到指定版本，返回发布批次标识
+	r.POST("node_version/upgrade", middleware.BindJsonMiddleware[node_version_api.UpgradeRequest], app.UpgradeView)
+
+	// GET /node_version/upgrade：节点升级记录查询接口
+	// 功能：按发布批次、节点、状态分页查询升级进度
+	r.GET("node_version/upgrade", middleware.BindQueryMiddleware[node_version_api.UpgradeListRequest], app.UpgradeListView)
+
 	// DELETE /node_version/:id：节点版本删除接口
 	// 功能：根据URI中的ID删除指定的节点版本记录及关联文件；
 	// 中间件：BindUriMiddleware[models.IDRequest] - 自动绑定URI路径参数（:id）到ID请求结构体，校验ID合法性
@@ -0,0 +1,37 @@
+package routers
+
+// File: honey_server/routers/rule_set_routers.go
+// Description: Suricata规则集管理路由注册
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/rule_set_api"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RuleSetRouters 注册规则集管理相关路由
+func RuleSetRouters(r *gin.RouterGroup) {
+	// 获取规则集API实例
+	var app = api.App.RuleSetApi
+
+	// POST /rule_set：规则集上传接口，表单字段title、remark及规则文件file，同名规则集生成新版本
+	r.POST("rule_set", app.CreateView)
+
+	// PUT /rule_set/override：签名覆盖配置接口，基于指定版本生成新版本
+	r.PUT("rule_set/override", middleware.BindJsonMiddleware[rule_set_api.OverrideRequest], app.OverrideView)
+
+	// GET /rule_set：规则集版本分页列表接口
+	r.GET("rule_set", middleware.BindQueryMiddleware[rule_set_api.ListRequest], app.ListView)
+
+	// GET /rule_set/options：规则集版本下拉选项接口
+	r.GET("rule_set/options", app.OptionsView)
+
+	// POST /rule_set/push：规则集下发接口，推送到所选节点并返回加载结果
+	r.POST("rule_set/push", middleware.BindJsonMiddleware[rule_set_api.PushRequest], app.PushView)
+
+	// DELETE /rule_set/:id：规则集版本删除接口
+	r.DELETE("rule_set/:id", middleware.BindUriMiddleware[models.IDRequest], app.RemoveView)
+}
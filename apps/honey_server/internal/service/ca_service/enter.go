@@ -0,0 +1,261 @@
+package ca_service
+
+// File: honey_server/service/ca_service/enter.go
+// Description: 内置CA模块，使用gRPC双向认证的CA证书为节点签发客户端证书（CN为节点uid），并按证书序列号校验节点身份、处理证书轮换及吊销
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"math/big"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CA证书及私钥路径，由cert/cert.sh生成
+const (
+	caCertPath = "cert/ca.crt"
+	caKeyPath  = "cert/ca.key"
+)
+
+// defaultNodeDays 未配置时节点证书的默认有效期（天）
+const defaultNodeDays = 90
+
+// enrollCodeTTL 一次性接入码有效期
+const enrollCodeTTL = 24 * time.Hour
+
+var (
+	caCert    *x509.Certificate // CA证书
+	caCertPEM []byte            // PEM格式的CA证书，随节点证书一并下发
+	caKey     crypto.Signer     // CA私钥
+)
+
+var (
+	ErrUnknownCert = errors.New("证书未在管理端签发") // 非节点证书（如节点引导证书）
+	ErrCertNode    = errors.New("证书与节点身份不一致")
+	ErrCertRevoked = errors.New("证书已吊销")
+)
+
+// Init 加载CA证书及私钥
+func Init() error {
+	certPEM, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return fmt.Errorf("读取CA证书失败 %s", err)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return errors.New("CA证书格式错误")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("解析CA证书失败 %s", err)
+	}
+
+	keyPEM, err := os.ReadFile(caKeyPath)
+	if err != nil {
+		return fmt.Errorf("读取CA私钥失败 %s", err)
+	}
+	key, err := parseKey(keyPEM)
+	if err != nil {
+		return err
+	}
+
+	caCert = cert
+	caCertPEM = certPEM
+	caKey = key
+	return nil
+}
+
+// parseKey 解析PEM格式的私钥，兼容PKCS1、PKCS8及EC私钥
+func parseKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("CA私钥格式错误")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("解析CA私钥失败 %s", err)
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	}
+	return nil, errors.New("不支持的CA私钥类型")
+}
+
+// CertPool 返回仅包含CA证书的证书池，用于校验节点客户端证书
+func CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	return pool
+}
+
+// CaPEM 返回PEM格式的CA证书
+func CaPEM() []byte {
+	return caCertPEM
+}
+
+// Serial 证书序列号的十六进制表示，与签发记录中的序列号一致
+func Serial(cert *x509.Certificate) string {
+	return fmt.Sprintf("%x", cert.SerialNumber)
+}
+
+// IssueNodeCert 根据节点提交的证书签名请求签发客户端证书，CN须为节点uid
+// 新证书为待启用状态，节点首次使用新证书连接时启用并吊销旧证书
+func IssueNodeCert(uid string, csrPEM []byte) (certPEM []byte, model models.NodeCertModel, err error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, model, errors.New("证书签名请求格式错误")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, model, fmt.Errorf("解析证书签名请求失败 %s", err)
+	}
+	if err = csr.CheckSignature(); err != nil {
+		return nil, model, fmt.Errorf("证书签名请求校验失败 %s", err)
+	}
+	if csr.Subject.CommonName != uid {
+		return nil, model, ErrCertNode
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		return nil, model, err
+	}
+	days := global.Config.Cert.NodeDays
+	if days <= 0 {
+		days = defaultNodeDays
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: caCert.Subject.Organization,
+			CommonName:   uid,
+		},
+		NotBefore:   now.Add(-5 * time.Minute), // 容忍节点与管理端的时钟偏差
+		NotAfter:    now.AddDate(0, 0, days),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, csr.PublicKey, caKey)
+	if err != nil {
+		return nil, model, fmt.Errorf("签发证书失败 %s", err)
+	}
+
+	model = models.NodeCertModel{
+		NodeUid:  uid,
+		Serial:   fmt.Sprintf("%x", serial),
+		NotAfter: template.NotAfter,
+		Status:   1,
+	}
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		// 此前签发但从未使用的证书作废，节点以最后一次签发的证书为准
+		err := tx.Model(&models.NodeCertModel{}).
+			Where("node_uid = ? and status = 1", uid).
+			Updates(map[string]interface{}{"status": 3, "revoke_reason": "重新签发"}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&model).Error
+	})
+	if err != nil {
+		return nil, model, fmt.Errorf("保存证书签发记录失败 %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), model, nil
+}
+
+// Authorize 校验节点证书与节点uid是否匹配且未被吊销
+// 待启用证书首次使用时启用，并吊销该节点此前使用中的证书，完成证书轮换
+func Authorize(uid string, serial string) error {
+	var model models.NodeCertModel
+	if err := global.DB.Take(&model, "serial = ?", serial).Error; err != nil {
+		return ErrUnknownCert
+	}
+	if model.NodeUid != uid {
+		return ErrCertNode
+	}
+	switch model.Status {
+	case 2:
+		return nil
+	case 3:
+		return ErrCertRevoked
+	}
+
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.NodeCertModel{}).
+			Where("node_uid = ? and status in ? and id <> ?", uid, []int8{1, 2}, model.ID).
+			Updates(map[string]interface{}{"status": 3, "revoke_reason": "证书轮换"}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model).Update("status", 2).Error
+	})
+}
+
+// HasActiveCert 判断节点是否存在未过期的待启用或使用中的证书
+func HasActiveCert(uid string) bool {
+	var count int64
+	global.DB.Model(&models.NodeCertModel{}).
+		Where("node_uid = ? and status in ? and not_after > ?", uid, []int8{1, 2}, time.Now()).
+		Count(&count)
+	return count > 0
+}
+
+// Revoke 吊销节点全部待启用及使用中的证书
+func Revoke(uid string, reason string) error {
+	return global.DB.Model(&models.NodeCertModel{}).
+		Where("node_uid = ? and status in ?", uid, []int8{1, 2}).
+		Updates(map[string]interface{}{"status": 3, "revoke_reason": reason}).Error
+}
+
+// ResetNode 吊销节点全部证书并生成一次性接入码，节点使用引导证书及接入码重新申请证书
+func ResetNode(uid string, reason string) (code string, expireAt time.Time, err error) {
+	buf := make([]byte, 8)
+	if _, err = rand.Read(buf); err != nil {
+		return "", expireAt, err
+	}
+	code = hex.EncodeToString(buf)
+	expireAt = time.Now().Add(enrollCodeTTL)
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.NodeCertModel{}).
+			Where("node_uid = ? and status in ?", uid, []int8{1, 2}).
+			Updates(map[string]interface{}{"status": 3, "revoke_reason": reason}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.NodeModel{}).Where("uid = ?", uid).
+			Updates(map[string]interface{}{"enroll_code": code, "code_expire": expireAt}).Error
+	})
+	return code, expireAt, err
+}
+
+// UseEnrollCode 校验并使用节点的一次性接入码，使用后失效
+func UseEnrollCode(uid string, code string) bool {
+	if code == "" {
+		return false
+	}
+	result := global.DB.Model(&models.NodeModel{}).
+		Where("uid = ? and enroll_code = ? and code_expire > ?", uid, code, time.Now()).
+		Updates(map[string]interface{}{"enroll_code": "", "code_expire": nil})
+	return result.Error == nil && result.RowsAffected == 1
+}
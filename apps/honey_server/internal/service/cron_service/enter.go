@@ -1,7 +1,7 @@
 package cron_service
 
 // File: honey_server/service/cron_service/enter.go
-// Description: 定时任务服务模块，初始化基于上海时区的定时任务调度器，注册虚拟服务端口同步定时任务并启动调度器
+// Description: 定时任务服务模块，初始化基于上海时区的定时任务调度器，注册虚拟服务端口同步、抓包超时检查定时任务并启动调度器
 
 import (
 	"time"
@@ -20,6 +20,9 @@ func Run() {
 	// 注册定时任务：每分钟的0秒、每20分钟执行一次SyncVsServicePort函数
 	crontab.AddFunc("0 */20 * * * *", SyncVsServicePort)
 
+	// 注册定时任务：每分钟检查一次超时未上传的抓包记录
+	crontab.AddFunc("0 * * * * *", PcapTimeout)
+
 	// 启动定时任务调度器（非阻塞，后台运行）
 	crontab.Start()
 }
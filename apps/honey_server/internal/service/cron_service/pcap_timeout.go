@@ -0,0 +1,48 @@
+package cron_service
+
+// File: honey_server/service/cron_service/pcap_timeout.go
+// Description: 定时任务服务模块，将超过最长抓包时间仍未上传抓包文件的记录标记为失败，避免节点断线后记录一直停留在抓包中
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultPcapDuration 抓包请求未指定时长时节点使用的默认抓包时间（秒）
+	defaultPcapDuration = 60
+	// pcapUploadGrace 抓包结束后等待节点上传抓包文件的宽限时间
+	pcapUploadGrace = 5 * time.Minute
+)
+
+// PcapTimeout 检查抓包中、停止中的记录，超时未上传的标记为失败
+func PcapTimeout() {
+	var list []models.HoneyIpPcapModel
+	global.DB.Find(&list, "status in ?", []int8{1, 2})
+
+	now := time.Now()
+	for _, model := range list {
+		duration := model.Duration
+		if duration <= 0 {
+			duration = defaultPcapDuration
+		}
+		deadline := model.CreatedAt.Add(time.Duration(duration)*time.Second + pcapUploadGrace)
+		if now.Before(deadline) {
+			continue
+		}
+
+		// 带上状态条件更新，避免覆盖刚刚上传完成的记录
+		result := global.DB.Model(&models.HoneyIpPcapModel{}).
+			Where("id = ? and status in ?", model.ID, []int8{1, 2}).
+			Updates(map[string]any{
+				"status":    4,
+				"error_msg": "抓包超时，节点未上传抓包文件",
+			})
+		if result.RowsAffected > 0 {
+			logrus.Warnf("抓包超时 %s 节点 %d", model.CaptureID, model.NodeID)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/auth.go
+// Description: gRPC节点身份校验拦截器，以节点客户端证书的CN作为节点身份，校验证书签发记录、吊销状态，并要求请求中携带的节点标识与证书一致
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/ca_service"
+	"path"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// enrollMethod 节点证书申请接口，节点尚未持有节点证书时使用引导证书调用，由接口自行校验身份
+const enrollMethod = "Enroll"
+
+// registerMethod 节点注册接口，待审批节点通过重新注册等待审批结果
+const registerMethod = "Register"
+
+// nodeUidKey 上下文中保存调用方节点uid的键
+type nodeUidKey struct{}
+
+// nodeUidGetter 携带节点标识的请求消息
+type nodeUidGetter interface {
+	GetNodeUid() string
+}
+
+// peerCert 获取对端已通过CA校验的客户端证书
+func peerCert(ctx context.Context) (*x509.Certificate, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, errors.New("missing peer")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, errors.New("missing client certificate")
+	}
+	return tlsInfo.State.VerifiedChains[0][0], nil
+}
+
+// authNode 校验调用方证书对应的节点身份，返回节点uid
+func authNode(ctx context.Context) (string, error) {
+	cert, err := peerCert(ctx)
+	if err != nil {
+		return "", status.Error(codes.Unauthenticated, err.Error())
+	}
+	uid := cert.Subject.CommonName
+	if err = ca_service.Authorize(uid, ca_service.Serial(cert)); err != nil {
+		logrus.Warnf("节点证书校验失败 uid=%s serial=%s %s", uid, ca_service.Serial(cert), err)
+		return "", status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// 命令流通过元数据携带节点标识，须与证书一致
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if nodeIDList := md.Get("nodeID"); len(nodeIDList) > 0 && nodeIDList[0] != uid {
+			return "", status.Error(codes.PermissionDenied, ca_service.ErrCertNode.Error())
+		}
+	}
+	return uid, nil
+}
+
+// ctxNode 获取拦截器校验通过的调用方节点
+func ctxNode(ctx context.Context) (nodeModel models.NodeModel, err error) {
+	uid, _ := ctx.Value(nodeUidKey{}).(string)
+	if uid == "" {
+		return nodeModel, status.Error(codes.Unauthenticated, "missing node identity")
+	}
+	if err = global.DB.Take(&nodeModel, "uid = ?", uid).Error; err != nil {
+		return nodeModel, status.Error(codes.PermissionDenied, "节点不存在")
+	}
+	return nodeModel, nil
+}
+
+// checkNodeOwner 校验记录所属节点为调用方节点，避免节点修改其他节点的记录
+func checkNodeOwner(ctx context.Context, nodeID uint) error {
+	nodeModel, err := ctxNode(ctx)
+	if err != nil {
+		return err
+	}
+	if nodeModel.ID != nodeID {
+		return status.Error(codes.PermissionDenied, "记录不属于当前节点")
+	}
+	return nil
+}
+
+// checkApproved 校验节点已通过审批，待审批及已拒绝的节点只能调用证书申请及注册接口
+func checkApproved(uid string) error {
+	var nodeModel models.NodeModel
+	if err := global.DB.Take(&nodeModel, "uid = ?", uid).Error; err != nil {
+		return status.Error(codes.PermissionDenied, "节点不存在")
+	}
+	switch nodeModel.Approve {
+	case 2:
+		return nil
+	case 3:
+		return status.Error(codes.PermissionDenied, errNodeRejected.Error())
+	}
+	return status.Error(codes.FailedPrecondition, errNodePending.Error())
+}
+
+// checkNodeUid 请求消息携带节点标识时须与证书一致
+func checkNodeUid(uid string, msg any) error {
+	if r, ok := msg.(nodeUidGetter); ok && r.GetNodeUid() != "" && r.GetNodeUid() != uid {
+		return status.Error(codes.PermissionDenied, ca_service.ErrCertNode.Error())
+	}
+	return nil
+}
+
+// unaryAuth 一元调用节点身份校验拦截器
+func unaryAuth(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if path.Base(info.FullMethod) == enrollMethod {
+		return handler(ctx, req)
+	}
+	uid, err := authNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkNodeUid(uid, req); err != nil {
+		return nil, err
+	}
+	if path.Base(info.FullMethod) != registerMethod {
+		if err = checkApproved(uid); err != nil {
+			return nil, err
+		}
+	}
+	return handler(context.WithValue(ctx, nodeUidKey{}, uid), req)
+}
+
+// authStream 校验流内每条消息携带的节点标识
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	uid string
+}
+
+// Context 返回携带调用方节点uid的上下文
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg 接收消息后校验节点标识
+func (s *authStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return checkNodeUid(s.uid, m)
+}
+
+// streamAuth 流式调用节点身份校验拦截器
+func streamAuth(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	uid, err := authNode(stream.Context())
+	if err != nil {
+		return err
+	}
+	if err = checkApproved(uid); err != nil {
+		return err
+	}
+	ctx := context.WithValue(stream.Context(), nodeUidKey{}, uid)
+	return handler(srv, &authStream{ServerStream: stream, ctx: ctx, uid: uid})
+}
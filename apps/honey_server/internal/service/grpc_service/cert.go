@@ -0,0 +1,54 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/cert.go
+// Description: 节点证书轮换模块，通过命令流通知节点重新申请客户端证书，节点使用新证书重连后旧证书自动吊销
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"time"
+)
+
+// certRotateTimeout 等待节点完成证书轮换的超时时间
+const certRotateTimeout = 15 * time.Second
+
+// RotateNodeCert 通知节点轮换客户端证书，返回新证书的序列号及过期时间
+func RotateNodeCert(nodeModel models.NodeModel, logID string) (*node_rpc.CertRotateOutMessage, error) {
+	log := core.GetLogger().WithField("logID", logID)
+	cmd, ok := GetNodeCommand(nodeModel.Uid)
+	if !ok {
+		return nil, errors.New("节点离线中")
+	}
+
+	req := &node_rpc.CmdRequest{
+		CmdType:             node_rpc.CmdType_cmdCertRotateType,
+		TaskID:              fmt.Sprintf("certRotate-%d", time.Now().UnixNano()),
+		LogID:               logID,
+		CertRotateInMessage: &node_rpc.CertRotateInMessage{},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), certRotateTimeout)
+	defer cancel()
+
+	res, err := cmd.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	out := res.GetCertRotateOutMessage()
+	if out == nil {
+		return nil, errors.New("节点响应为空")
+	}
+	if out.ErrMsg != "" {
+		return nil, errors.New(out.ErrMsg)
+	}
+	log.WithFields(map[string]interface{}{
+		"node_uid":  nodeModel.Uid,
+		"serial":    out.Serial,
+		"not_after": out.NotAfter,
+	}).Info("node certificate rotated") // 节点证书轮换完成
+	return out, nil
+}
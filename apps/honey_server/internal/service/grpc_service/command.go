@@ -4,30 +4,34 @@ package grpc_service
 // Description: 节点gRPC命令服务管理模块，处理节点双向流通信、命令分发及连接状态管理
 
 import (
+	"context"
 	"errors"
 	"honey_server/internal/global"
 	"honey_server/internal/models"
+	"honey_server/internal/service/metrics_service"
 	"honey_server/internal/service/mq_service"
 	"io"
 	"sync"
-	"time"
 
 	"honey_server/internal/rpc/node_rpc"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
-// Command 单个节点的命令交互实例，管理节点的请求/响应通道及流连接
+// Command 单个节点的命令交互实例，管理节点的请求通道、等待响应的任务及流连接
 type Command struct {
-	ReqChan  chan *node_rpc.CmdRequest          // 发送给节点的请求通道
-	ResChan  chan *node_rpc.CmdResponse         // 节点返回的响应通道
-	Server   node_rpc.NodeService_CommandServer // 节点的gRPC双向流服务端实例
-	NodeID   string                             // 节点唯一标识
-	stopChan chan struct{}                      // 停止信号通道，用于协程退出控制
-	wg       sync.WaitGroup                     // 协程等待组，确保所有协程正常退出
-	mu       sync.RWMutex                       // 实例状态保护锁
-	closed   bool                               // 实例是否已关闭的状态标记
+	ReqChan  chan *node_rpc.CmdRequest             // 发送给节点的请求通道
+	Server   node_rpc.NodeService_CommandServer    // 节点的gRPC双向流服务端实例
+	NodeID   string                                // 节点唯一标识
+	stopChan chan struct{}                         // 停止信号通道，用于协程退出控制
+	wg       sync.WaitGroup                        // 协程等待组，确保所有协程正常退出
+	mu       sync.RWMutex                          // 实例状态保护锁
+	closed   bool                                  // 实例是否已关闭的状态标记
+	waitMap  map[string]chan *node_rpc.CmdResponse // 等待响应的任务，TaskID -> 响应通道
 }
 
 var (
@@ -35,6 +39,35 @@ var (
 	mapMutex       sync.RWMutex                // 映射表读写保护锁
 )
 
+// init 注册在线节点数及命令队列深度指标
+func init() {
+	metrics_service.RegisterGauge("honey_server_connected_nodes", "建立命令流的在线节点数", func() float64 {
+		mapMutex.RLock()
+		defer mapMutex.RUnlock()
+		return float64(len(NodeCommandMap))
+	})
+	metrics_service.RegisterGauge("honey_server_command_request_queue", "全部节点待下发的命令数", func() float64 {
+		mapMutex.RLock()
+		defer mapMutex.RUnlock()
+		var count int
+		for _, cmd := range NodeCommandMap {
+			count += len(cmd.ReqChan)
+		}
+		return float64(count)
+	})
+	metrics_service.RegisterGauge("honey_server_command_waiting_tasks", "全部节点等待响应的任务数", func() float64 {
+		mapMutex.RLock()
+		defer mapMutex.RUnlock()
+		var count int
+		for _, cmd := range NodeCommandMap {
+			cmd.mu.RLock()
+			count += len(cmd.waitMap)
+			cmd.mu.RUnlock()
+		}
+		return float64(count)
+	})
+}
+
 // Command 实现NodeService的双向流Command接口，处理节点连接与命令交互生命周期
 func (s NodeService) Command(stream node_rpc.NodeService_CommandServer) error {
 	ctx := stream.Context()
@@ -51,10 +84,22 @@ func (s NodeService) Command(stream node_rpc.NodeService_CommandServer) error {
 	}
 	nodeID := nodeIDList[0]
 
+	// 查询节点，未通过审批的节点不建立命令流
+	var model models.NodeModel
+	err := global.DB.Take(&model, "uid = ?", nodeID).Error
+	if err != nil {
+		logrus.Errorf("节点不存在")
+		return nil
+	}
+	if model.Approve != 2 {
+		logrus.Warnf("节点 %s 未通过审批，拒绝建立命令流", nodeID)
+		return status.Error(codes.FailedPrecondition, errNodePending.Error())
+	}
+
 	// 初始化节点命令交互实例
 	cmd := &Command{
 		ReqChan:  make(chan *node_rpc.CmdRequest, 10), // 带缓冲通道避免发送阻塞
-		ResChan:  make(chan *node_rpc.CmdResponse, 10),
+		waitMap:  map[string]chan *node_rpc.CmdResponse{},
 		Server:   stream,
 		NodeID:   nodeID,
 		stopChan: make(chan struct{}),
@@ -68,12 +113,6 @@ func (s NodeService) Command(stream node_rpc.NodeService_CommandServer) error {
 	logrus.Infof("Node %s connected", nodeID)
 
 	// 修改节点状态
-	var model models.NodeModel
-	err := global.DB.Take(&model, "uid = ?", nodeID).Error
-	if err != nil {
-		logrus.Errorf("节点不存在")
-		return nil
-	}
 	if model.Status != 1 {
 		global.DB.Model(&model).Update("status", 1)
 	}
@@ -88,6 +127,17 @@ func (s NodeService) Command(stream node_rpc.NodeService_CommandServer) error {
 	go cmd.sendLoop()
 	go cmd.receiveLoop()
 
+	// 节点上线后下发全量状态同步，修正断线期间丢失消息造成的状态不一致
+	go func() {
+		if _, err := SyncNodeState(model, uuid.New().String()); err != nil {
+			logrus.Errorf("节点 %s 全量状态同步失败 %s", nodeID, err)
+		}
+		// 同步完成后下发被动资产发现配置，恢复节点各网络的被动监听
+		if _, err := PushDiscovery(model, uuid.New().String()); err != nil {
+			logrus.Warnf("节点 %s 被动资产发现配置下发失败 %s", nodeID, err)
+		}
+	}()
+
 	// 监听上下文取消事件（连接断开时触发）
 	go func() {
 		<-ctx.Done()
@@ -140,7 +190,7 @@ func (c *Command) sendLoop() {
 	}
 }
 
-// receiveLoop 循环接收节点响应并写入通道的协程方法
+// receiveLoop 循环接收节点响应并投递给等待方的协程方法
 func (c *Command) receiveLoop() {
 	defer c.wg.Done() // 协程结束时通知WaitGroup
 
@@ -157,15 +207,9 @@ func (c *Command) receiveLoop() {
 			return
 		}
 
-		// 带超时发送响应至通道，防止阻塞
-		select {
-		case c.ResChan <- res:
-			logrus.Debugf("Sent response from node %s to channel", c.NodeID)
-		case <-time.After(5 * time.Second): // 5秒超时丢弃
-			logrus.Warnf("Timeout sending response from node %s, dropping", c.NodeID)
-		case <-c.stopChan: // 收到停止信号
-			logrus.Infof("Stopping receiveLoop for node %s", c.NodeID)
-			return
+		// 响应投递给等待该任务的调用方，调用方已超时退出的迟到响应直接丢弃
+		if !c.dispatch(res) {
+			logrus.Debugf("任务 %s 没有等待方，丢弃节点 %s 的响应", res.TaskID, c.NodeID)
 		}
 	}
 }
@@ -182,15 +226,74 @@ func (c *Command) Close() {
 	logrus.Infof("Closing command channels for node %s", c.NodeID)
 	c.closed = true
 
-	// 发送停止信号并关闭请求通道
+	// 发送停止信号，请求通道不关闭，避免其他协程向已关闭的通道发送请求
 	close(c.stopChan)
-	close(c.ReqChan)
+}
 
-	// 异步清空响应通道（避免阻塞）
-	go func() {
-		for range c.ResChan {
-		}
-	}()
+// Send 发送命令请求至节点，节点连接已关闭或上下文超时时返回错误
+func (c *Command) Send(ctx context.Context, req *node_rpc.CmdRequest) error {
+	select {
+	case c.ReqChan <- req:
+		return nil
+	case <-c.stopChan:
+		return errors.New("节点连接已断开")
+	case <-ctx.Done():
+		return errors.New("发送命令超时")
+	}
+}
+
+// Request 注册任务响应通道并发送命令请求，返回的通道接收该任务的全部响应
+// 调用方结束等待后须调用done注销响应通道
+func (c *Command) Request(ctx context.Context, req *node_rpc.CmdRequest) (resChan <-chan *node_rpc.CmdResponse, done func(), err error) {
+	ch := make(chan *node_rpc.CmdResponse, 10)
+	c.mu.Lock()
+	c.waitMap[req.TaskID] = ch
+	c.mu.Unlock()
+	done = func() {
+		c.mu.Lock()
+		delete(c.waitMap, req.TaskID)
+		c.mu.Unlock()
+	}
+
+	if err = c.Send(ctx, req); err != nil {
+		done()
+		return nil, nil, err
+	}
+	return ch, done, nil
+}
+
+// Call 发送命令请求并等待该任务的响应
+func (c *Command) Call(ctx context.Context, req *node_rpc.CmdRequest) (*node_rpc.CmdResponse, error) {
+	resChan, done, err := c.Request(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	select {
+	case res := <-resChan:
+		return res, nil
+	case <-c.stopChan:
+		return nil, errors.New("节点连接已断开")
+	case <-ctx.Done():
+		return nil, errors.New("获取响应超时")
+	}
+}
+
+// dispatch 将响应投递给等待该任务的调用方，没有等待方时返回false
+func (c *Command) dispatch(res *node_rpc.CmdResponse) bool {
+	c.mu.RLock()
+	ch, ok := c.waitMap[res.TaskID]
+	c.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	select {
+	case ch <- res:
+	default:
+		logrus.Warnf("任务 %s 响应积压，丢弃节点 %s 的响应", res.TaskID, c.NodeID)
+	}
+	return true
 }
 
 // GetNodeCommand 根据节点ID获取命令交互实例
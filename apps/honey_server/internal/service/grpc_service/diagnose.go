@@ -0,0 +1,92 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/diagnose.go
+// Description: 节点诊断模块，通过命令流向节点下发诊断命令，保存节点返回的诊断报告并按节点保留最近的若干份
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"time"
+)
+
+const (
+	diagnoseTimeout   = 30 * time.Second // 等待节点诊断报告的超时时间
+	diagnoseKeepCount = 10               // 每个节点保留的诊断报告数
+)
+
+// DiagnoseNode 向节点下发诊断命令并保存诊断报告，logLines为节点返回的最近错误日志行数
+func DiagnoseNode(nodeModel models.NodeModel, logLines int, logID string) (model models.NodeDiagnoseModel, err error) {
+	log := core.GetLogger().WithField("logID", logID)
+	cmd, ok := GetNodeCommand(nodeModel.Uid)
+	if !ok {
+		return model, errors.New("节点离线中")
+	}
+
+	log.WithFields(map[string]interface{}{
+		"node_uid":  nodeModel.Uid,
+		"log_lines": logLines,
+	}).Info("diagnose node") // 下发节点诊断
+
+	out, err := sendDiagnoseCommand(cmd, &node_rpc.DiagnoseInMessage{LogLines: int32(logLines)}, logID)
+	if err != nil {
+		return model, err
+	}
+	if !json.Valid([]byte(out.Report)) {
+		return model, errors.New("诊断报告格式错误")
+	}
+
+	model = models.NodeDiagnoseModel{
+		NodeID: nodeModel.ID,
+		Report: json.RawMessage(out.Report),
+	}
+	if err = global.DB.Create(&model).Error; err != nil {
+		return model, fmt.Errorf("保存诊断报告失败 %s", err)
+	}
+
+	// 仅保留最近的若干份诊断报告
+	var expiredList []models.NodeDiagnoseModel
+	global.DB.Select("id").Order("id desc").Offset(diagnoseKeepCount).
+		Find(&expiredList, "node_id = ?", nodeModel.ID)
+	if len(expiredList) > 0 {
+		global.DB.Delete(&expiredList)
+	}
+
+	log.WithFields(map[string]interface{}{
+		"node_uid":    nodeModel.Uid,
+		"diagnose_id": model.ID,
+		"report_size": len(out.Report),
+	}).Info("diagnose node completed") // 节点诊断完成
+	return model, nil
+}
+
+// sendDiagnoseCommand 下发节点诊断命令并等待节点返回诊断报告
+func sendDiagnoseCommand(cmd *Command, in *node_rpc.DiagnoseInMessage, logID string) (*node_rpc.DiagnoseOutMessage, error) {
+	req := &node_rpc.CmdRequest{
+		CmdType:           node_rpc.CmdType_cmdDiagnoseType,
+		TaskID:            fmt.Sprintf("diagnose-%d", time.Now().UnixNano()),
+		LogID:             logID,
+		DiagnoseInMessage: in,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), diagnoseTimeout)
+	defer cancel()
+
+	res, err := cmd.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	out := res.GetDiagnoseOutMessage()
+	if out == nil {
+		return nil, errors.New("节点响应为空")
+	}
+	if out.ErrMsg != "" {
+		return nil, errors.New(out.ErrMsg)
+	}
+	return out, nil
+}
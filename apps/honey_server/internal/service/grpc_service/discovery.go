@@ -0,0 +1,265 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/discovery.go
+// Description: 被动资产发现模块，向节点下发需要被动监听的网络列表，接收节点上报的主机新增、变化、离线并同步更新主机表，真实主机与诱捕ip冲突时发出告警
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/mq_service"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	discoveryTimeout = 30 * time.Second // 等待节点应用被动监听配置的超时时间
+	vanishTime       = 30 * time.Minute // 主机持续无流量超过该时间判定为离线
+)
+
+// 主机变化类型
+const (
+	hostEventNew      = 1 // 新增
+	hostEventChanged  = 2 // 信息变化
+	hostEventVanished = 3 // 离线
+	hostEventSeen     = 4 // 在线刷新
+)
+
+// PushDiscovery 向节点下发全部已启用网络的被动监听配置，节点按该列表启停监听，返回监听中的网卡列表
+func PushDiscovery(nodeModel models.NodeModel, logID string) (networkList []string, err error) {
+	log := core.GetLogger().WithField("logID", logID)
+	cmd, ok := GetNodeCommand(nodeModel.Uid)
+	if !ok {
+		return nil, errors.New("节点离线中")
+	}
+
+	var netList []models.NetModel
+	global.DB.Find(&netList, "node_id = ?", nodeModel.ID)
+	in := &node_rpc.DiscoveryInMessage{
+		VanishTime: int32(vanishTime / time.Second),
+	}
+	for _, netModel := range netList {
+		in.NetworkList = append(in.NetworkList, &node_rpc.DiscoveryNetwork{
+			Network: netModel.Network,
+			NetID:   uint32(netModel.ID),
+			Ip:      netModel.IP,
+			Mask:    int32(netModel.Mask),
+			Ip6:     netModel.IP6,
+			Mask6:   int32(netModel.Mask6),
+		})
+	}
+
+	out, err := sendDiscoveryCommand(cmd, in, logID)
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(map[string]interface{}{
+		"node_uid":     nodeModel.Uid,
+		"network_list": out.NetworkList,
+		"error":        out.ErrMsg,
+	}).Info("push discovery completed") // 被动资产发现配置下发完成
+	if out.ErrMsg != "" {
+		return out.NetworkList, errors.New(out.ErrMsg)
+	}
+	return out.NetworkList, nil
+}
+
+// PushDiscoveryAsync 异步下发被动监听配置，用于网络启用、删除后刷新节点监听
+func PushDiscoveryAsync(nodeModel models.NodeModel, logID string) {
+	go func() {
+		if _, err := PushDiscovery(nodeModel, logID); err != nil {
+			logrus.Warnf("节点 %s 被动资产发现配置下发失败 %s", nodeModel.Uid, err)
+		}
+	}()
+}
+
+// sendDiscoveryCommand 下发被动资产发现配置命令并等待节点返回结果
+func sendDiscoveryCommand(cmd *Command, in *node_rpc.DiscoveryInMessage, logID string) (*node_rpc.DiscoveryOutMessage, error) {
+	req := &node_rpc.CmdRequest{
+		CmdType:            node_rpc.CmdType_cmdDiscoveryType,
+		TaskID:             fmt.Sprintf("discovery-%d", time.Now().UnixNano()),
+		LogID:              logID,
+		DiscoveryInMessage: in,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+	defer cancel()
+
+	res, err := cmd.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	out := res.GetDiscoveryOutMessage()
+	if out == nil {
+		return nil, errors.New("节点响应为空")
+	}
+	return out, nil
+}
+
+// ReportHost 处理节点上报的被动资产发现主机变化
+func (NodeService) ReportHost(ctx context.Context, request *node_rpc.ReportHostRequest) (pd *node_rpc.BaseResponse, err error) {
+	pd = new(node_rpc.BaseResponse)
+	var nodeModel models.NodeModel
+	if err1 := global.DB.Take(&nodeModel, "uid = ?", request.NodeUid).Error; err1 != nil {
+		return nil, errors.New("节点不存在")
+	}
+
+	// 按网络分组处理，网络不存在（已删除）的变化直接忽略
+	netMap := map[uint32]*models.NetModel{}
+	changedNet := map[uint]bool{}
+	for _, event := range request.EventList {
+		netModel, ok := netMap[event.NetID]
+		if !ok {
+			var model models.NetModel
+			if err1 := global.DB.Take(&model, "id = ? and node_id = ?", event.NetID, nodeModel.ID).Error; err1 == nil {
+				netModel = &model
+			}
+			netMap[event.NetID] = netModel
+		}
+		if netModel == nil || !netModel.InSubnet(event.Ip) {
+			continue
+		}
+		if applyHostEvent(nodeModel, *netModel, event) {
+			changedNet[netModel.ID] = true
+		}
+	}
+
+	for netID := range changedNet {
+		mq_service.SendWsMsg(mq_service.WsMsgType{
+			Type:   6,
+			NetID:  netID,
+			NodeID: nodeModel.ID,
+		})
+	}
+	return
+}
+
+// applyHostEvent 将单条主机变化同步到主机表，主机表有变化时返回true
+func applyHostEvent(nodeModel models.NodeModel, netModel models.NetModel, event *node_rpc.HostEvent) bool {
+	var host models.HostModel
+	err := global.DB.Take(&host, "net_id = ? and ip = ?", netModel.ID, event.Ip).Error
+
+	// 主机离线只标记状态并保留最近发现时间，主机记录及端口探测结果不删除
+	if event.Type == hostEventVanished {
+		if err != nil || host.Status == 2 {
+			return false
+		}
+		updates := map[string]any{"status": 2}
+		if event.LastSeen != "" {
+			updates["last_seen"] = parseSeenTime(event.LastSeen)
+		}
+		global.DB.Model(&host).Updates(updates)
+		logrus.Infof("被动资产发现 主机离线 %s %s", netModel.Title, event.Ip)
+		return true
+	}
+
+	firstSeen := parseSeenTime(event.FirstSeen)
+	lastSeen := parseSeenTime(event.LastSeen)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		host = models.HostModel{
+			NodeID:    nodeModel.ID,
+			NetID:     netModel.ID,
+			IP:        event.Ip,
+			Mac:       event.Mac,
+			Manuf:     event.Manuf,
+			Hostname:  event.Hostname,
+			FirstSeen: firstSeen,
+			LastSeen:  lastSeen,
+			Status:    1,
+		}
+		if err = global.DB.Create(&host).Error; err != nil {
+			logrus.Errorf("被动资产发现 主机创建失败 %s %s", event.Ip, err)
+			return false
+		}
+		global.DB.Model(&netModel).Update("host_count", gorm.Expr("host_count + 1"))
+		logrus.Infof("被动资产发现 新增主机 %s %s %s %s", netModel.Title, event.Ip, event.Mac, event.Hostname)
+		checkHoneyIPConflict(nodeModel, netModel, event)
+		return true
+	}
+	if err != nil {
+		logrus.Errorf("被动资产发现 主机查询失败 %s %s", event.Ip, err)
+		return false
+	}
+
+	columns := []string{"last_seen"}
+	changed := false
+	host.LastSeen = lastSeen
+	if host.Status != 1 {
+		host.Status = 1
+		columns = append(columns, "status")
+		changed = true
+		logrus.Infof("被动资产发现 主机恢复在线 %s %s", netModel.Title, event.Ip)
+	}
+	// 首次发现时间以管理端最早记录为准，节点重启不会覆盖
+	if host.FirstSeen == nil {
+		host.FirstSeen = firstSeen
+		columns = append(columns, "first_seen")
+	}
+	if event.Mac != "" && host.Mac != event.Mac {
+		host.Mac = event.Mac
+		host.Manuf = event.Manuf
+		columns = append(columns, "mac", "manuf")
+		changed = true
+	}
+	if event.Hostname != "" && host.Hostname != event.Hostname {
+		host.Hostname = event.Hostname
+		columns = append(columns, "hostname")
+		changed = true
+	}
+	global.DB.Model(&host).Select(columns).Updates(&host)
+	if changed {
+		logrus.Infof("被动资产发现 主机信息变化 %s %s %s %s", netModel.Title, event.Ip, event.Mac, event.Hostname)
+	}
+	return changed
+}
+
+// checkHoneyIPConflict 新增主机的ip已被诱捕ip占用或在可用诱捕ip范围内时发出告警，避免诱捕ip与真实主机冲突
+func checkHoneyIPConflict(nodeModel models.NodeModel, netModel models.NetModel, event *node_rpc.HostEvent) {
+	var honeyIPModel models.HoneyIpModel
+	if err := global.DB.Take(&honeyIPModel, "net_id = ? and ip = ?", netModel.ID, event.Ip).Error; err == nil {
+		// 诱捕ip自身的mac地址不是冲突
+		if honeyIPModel.Mac != event.Mac {
+			logrus.Warnf("被动资产发现 真实主机 %s(%s) 与诱捕ip冲突 网络 %s", event.Ip, event.Mac, netModel.Title)
+			sendHostConflictAlert(nodeModel, netModel, event, "真实主机与诱捕ip冲突")
+		}
+		return
+	}
+	ipRange, err := netModel.IpRange()
+	if err != nil {
+		return
+	}
+	if slices.Contains(ipRange, event.Ip) {
+		logrus.Warnf("被动资产发现 真实主机 %s(%s) 出现在可用诱捕ip范围内 网络 %s", event.Ip, event.Mac, netModel.Title)
+		sendHostConflictAlert(nodeModel, netModel, event, "真实主机出现在可用诱捕ip范围内")
+	}
+}
+
+// sendHostConflictAlert 发送真实主机冲突告警，同一网络下同一主机ip及mac只保留一条告警
+func sendHostConflictAlert(nodeModel models.NodeModel, netModel models.NetModel, event *node_rpc.HostEvent, signature string) {
+	mq_service.SendAlertMsg(mq_service.AlertMsgType{
+		ID:        fmt.Sprintf("host-conflict-%d-%s-%s", netModel.ID, event.Ip, event.Mac),
+		NodeUid:   nodeModel.Uid,
+		SrcIp:     event.Ip,
+		DestIp:    event.Ip,
+		Timestamp: time.Now().Format(time.DateTime),
+		Signature: signature,
+		Level:     2,
+		Payload:   fmt.Sprintf("网络 %s mac %s 厂商 %s 主机名 %s", netModel.Title, event.Mac, event.Manuf, event.Hostname),
+	})
+}
+
+// parseSeenTime 解析节点上报的发现时间，解析失败时使用当前时间
+func parseSeenTime(value string) *time.Time {
+	t, err := time.ParseInLocation(time.DateTime, value, time.Local)
+	if err != nil {
+		t = time.Now()
+	}
+	return &t
+}
@@ -0,0 +1,77 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/enroll.go
+// Description: 节点证书申请gRPC接口实现，为首次接入的节点签发客户端证书，并为持有有效证书的节点轮换证书
+
+import (
+	"context"
+	"errors"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/ca_service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Enroll 节点证书申请接口实现
+// 节点首次接入时使用引导证书申请，已接入的节点使用自身证书轮换，或由管理员重置证书后使用一次性接入码重新申请
+func (NodeService) Enroll(ctx context.Context, request *node_rpc.EnrollRequest) (*node_rpc.EnrollResponse, error) {
+	uid := request.NodeUid
+	if uid == "" {
+		return nil, status.Error(codes.InvalidArgument, "节点uid不能为空")
+	}
+	cert, err := peerCert(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	serial := ca_service.Serial(cert)
+	err = ca_service.Authorize(uid, serial)
+	switch {
+	case err == nil:
+		// 使用节点证书申请，即证书轮换
+	case errors.Is(err, ca_service.ErrUnknownCert):
+		// 使用引导证书申请
+		if err = checkBootstrapEnroll(uid, request.EnrollCode); err != nil {
+			logrus.Warnf("节点 %s 引导证书申请被拒绝 serial=%s %s", uid, serial, err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+	default:
+		logrus.Warnf("节点 %s 证书申请校验失败 serial=%s %s", uid, serial, err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	certPEM, model, err := ca_service.IssueNodeCert(uid, request.Csr)
+	if err != nil {
+		logrus.Errorf("节点 %s 证书签发失败 %s", uid, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	logrus.Infof("节点 %s 证书签发成功 serial=%s notAfter=%s", uid, model.Serial, model.NotAfter.Format("2006-01-02 15:04:05"))
+	return &node_rpc.EnrollResponse{
+		Cert: certPEM,
+		Ca:   ca_service.CaPEM(),
+	}, nil
+}
+
+// checkBootstrapEnroll 校验使用引导证书的证书申请
+// 引导证书为节点镜像内置的共享证书，仅能为尚未接入的新节点申请证书，且不能作废已签发的未过期证书；
+// 已接入的节点须由管理员重置节点证书，使用生成的一次性接入码申请
+func checkBootstrapEnroll(uid string, code string) error {
+	var nodeModel models.NodeModel
+	if global.DB.Take(&nodeModel, "uid = ?", uid).Error != nil {
+		if ca_service.HasActiveCert(uid) {
+			return errors.New("节点已签发证书，请使用节点证书轮换")
+		}
+		return nil
+	}
+	if nodeModel.Approve == 3 {
+		return errNodeRejected
+	}
+	if !ca_service.UseEnrollCode(uid, code) {
+		return errors.New("节点已接入，请由管理员重置节点证书后使用接入码申请")
+	}
+	return nil
+}
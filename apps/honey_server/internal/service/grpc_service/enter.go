@@ -5,11 +5,12 @@ package grpc_service
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"honey_server/internal/global"
 	"honey_server/internal/rpc/node_rpc"
-	"io/ioutil"
+	"honey_server/internal/service/ca_service"
+	"honey_server/internal/service/metrics_service"
 	"net"
+	"path"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -37,26 +38,28 @@ func Run() {
 		logrus.Fatalf("failed to load key pair: %v", err)
 	}
 
-	// 加载 CA 证书
-	caCert, err := ioutil.ReadFile("cert/ca.crt")
-	if err != nil {
-		logrus.Fatalf("failed to read CA certificate: %v", err)
+	// 加载内置CA，用于校验及签发节点客户端证书
+	if err = ca_service.Init(); err != nil {
+		logrus.Fatalf("failed to load CA: %v", err)
 	}
-	caCertPool := x509.NewCertPool()
-	caCertPool.AppendCertsFromPEM(caCert)
 
 	// 创建 TLS 配置
 	config := &tls.Config{
 		Certificates: []tls.Certificate{cert},
 		ClientAuth:   tls.RequireAndVerifyClientCert, // 双向认证
-		ClientCAs:    caCertPool,
+		ClientCAs:    ca_service.CertPool(),
 	}
 
 	// 创建 credentials
 	creds := credentials.NewTLS(config)
 
-	// 创建 gRPC 服务器，使用 TLS credentials
-	s := grpc.NewServer(grpc.Creds(creds))
+	// 创建 gRPC 服务器，使用 TLS credentials，统计gRPC流数量并按节点证书校验节点身份
+	s := grpc.NewServer(grpc.Creds(creds),
+		grpc.UnaryInterceptor(unaryAuth),
+		grpc.ChainStreamInterceptor(streamMetrics, streamAuth))
+
+	// 加载PROXY协议开关缓存并启动后台刷新
+	startProxyProtocolRefresh()
 
 	server := NodeService{}
 	// 将NodeService实例注册到gRPC服务器，使其处理对应服务的请求
@@ -66,3 +69,12 @@ func Run() {
 	// 启动gRPC服务器，开始监听并处理客户端请求（阻塞运行）
 	err = s.Serve(listen)
 }
+
+// streamMetrics gRPC流拦截器，统计进行中的流数量及流总数
+func streamMetrics(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	method := path.Base(info.FullMethod)
+	metrics_service.GrpcStreams.WithLabelValues(method).Inc()
+	metrics_service.GrpcStreamTotal.WithLabelValues(method).Inc()
+	defer metrics_service.GrpcStreams.WithLabelValues(method).Dec()
+	return handler(srv, stream)
+}
@@ -0,0 +1,67 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/mux_tunnel.go
+// Description: 节点与服务端之间的多路复用TCP隧道实现，一条gRPC流承载节点侧的多个连接会话，每个会话独立连接目标地址
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/utils/mux"
+	"honey_server/internal/utils/proxyproto"
+	"log"
+	"net"
+)
+
+// MuxTunnel 实现node_rpc.NodeServiceServer接口的双向流MuxTunnel方法
+func (s *NodeService) MuxTunnel(stream node_rpc.NodeService_MuxTunnelServer) error {
+	ctx := stream.Context()
+	conn := mux.NewConn(stream, func(session *mux.Session) {
+		serveMuxSession(ctx, session)
+	})
+	return conn.Run()
+}
+
+// serveMuxSession 连接会话的目标地址并双向转发数据，任一方向结束时关闭会话
+func serveMuxSession(ctx context.Context, session *mux.Session) {
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", session.Address)
+	if err != nil {
+		// 连接失败原因随关闭帧返回节点
+		session.CloseWithError(fmt.Errorf("连接目标地址失败: %v", err))
+		return
+	}
+	defer conn.Close() // 函数退出时关闭目标连接
+
+	// 目标服务开启PROXY协议时先发送协议头，携带攻击方地址及诱捕地址
+	if proxyProtocolEnabled(session.Address) {
+		if err = proxyproto.WriteTCP(conn, session.SrcAddr, session.DestAddr); err != nil {
+			session.CloseWithError(fmt.Errorf("发送PROXY协议头失败: %v", err))
+			return
+		}
+	}
+
+	// 目标连接 -> 节点 (下行流量)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := session.ReadFrom(conn); err != nil && !isMuxClosed(err) {
+			log.Printf("从目标连接读取失败: %v", err)
+		}
+		session.Close()
+	}()
+
+	// 节点 -> 目标连接 (上行流量)
+	if _, err := session.WriteTo(conn); err != nil && !isMuxClosed(err) {
+		log.Printf("写入目标连接失败: %v", err)
+	}
+	conn.Close() // 唤醒下行读取
+	session.Close()
+	<-done
+}
+
+// isMuxClosed 判断错误是否为会话或连接正常关闭导致，此类错误无需记录
+func isMuxClosed(err error) bool {
+	return errors.Is(err, mux.ErrSessionClosed) || errors.Is(err, mux.ErrConnClosed) || errors.Is(err, net.ErrClosed)
+}
@@ -0,0 +1,240 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/node_upgrade.go
+// Description: 节点远程升级模块，按阶段向节点下发升级命令并跟踪下载、切换进度，根据节点重新注册时上报的版本判定升级成功或已回滚
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"sync"
+	"time"
+)
+
+const (
+	upgradeDownloadTimeout = 30 * time.Minute // 等待节点下载并导入镜像的超时时间
+	upgradeRollbackGrace   = time.Minute      // 重连超时后等待节点回滚并重新注册的额外时间
+	upgradePollInterval    = 2 * time.Second  // 等待升级结果时的数据库轮询间隔
+)
+
+var (
+	upgradeNodeMap  = map[uint]bool{} // 正在升级的节点ID集合
+	upgradeNodeLock sync.Mutex        // 正在升级的节点ID集合读写锁
+)
+
+// LockNodeUpgrade 标记节点进入升级流程，返回已在升级中的节点ID，存在时不做任何标记
+func LockNodeUpgrade(nodeIDList []uint) (busyList []uint) {
+	upgradeNodeLock.Lock()
+	defer upgradeNodeLock.Unlock()
+	for _, id := range nodeIDList {
+		if upgradeNodeMap[id] {
+			busyList = append(busyList, id)
+		}
+	}
+	if len(busyList) > 0 {
+		return
+	}
+	for _, id := range nodeIDList {
+		upgradeNodeMap[id] = true
+	}
+	return
+}
+
+// unlockNodeUpgrade 标记节点升级流程结束
+func unlockNodeUpgrade(nodeID uint) {
+	upgradeNodeLock.Lock()
+	delete(upgradeNodeMap, nodeID)
+	upgradeNodeLock.Unlock()
+}
+
+// RunNodeUpgrade 按阶段执行一个发布批次的升级，同一阶段的节点并行升级
+// 某一阶段存在未升级成功的节点时停止发布，后续阶段的节点标记为已取消
+func RunNodeUpgrade(batch string, version models.NodeVersionModel, timeout int, logID string) {
+	log := core.GetLogger().WithField("logID", logID)
+
+	var upgradeList []models.NodeUpgradeModel
+	global.DB.Preload("NodeModel").Order("stage, id").Find(&upgradeList, "batch = ?", batch)
+
+	var stageList [][]models.NodeUpgradeModel
+	for _, upgrade := range upgradeList {
+		if len(stageList) == 0 || stageList[len(stageList)-1][0].Stage != upgrade.Stage {
+			stageList = append(stageList, nil)
+		}
+		stageList[len(stageList)-1] = append(stageList[len(stageList)-1], upgrade)
+	}
+
+	for i, stage := range stageList {
+		log.WithFields(map[string]interface{}{
+			"batch":      batch,
+			"stage":      stage[0].Stage,
+			"node_count": len(stage),
+			"version":    version.Tag,
+		}).Info("node upgrade stage started") // 开始升级阶段
+
+		var wg sync.WaitGroup
+		var failed bool
+		var mu sync.Mutex
+		for _, upgrade := range stage {
+			wg.Add(1)
+			go func(upgrade models.NodeUpgradeModel) {
+				defer wg.Done()
+				defer unlockNodeUpgrade(upgrade.NodeID)
+				if status := UpgradeNode(upgrade, version, timeout, logID); status != 4 {
+					mu.Lock()
+					failed = true
+					mu.Unlock()
+				}
+			}(upgrade)
+		}
+		wg.Wait()
+
+		if !failed {
+			continue
+		}
+		// 当前阶段存在失败，取消剩余阶段
+		var count int
+		for _, rest := range stageList[i+1:] {
+			for _, upgrade := range rest {
+				global.DB.Model(&upgrade).Updates(models.NodeUpgradeModel{
+					Status: 7,
+					ErrMsg: fmt.Sprintf("第%d阶段存在升级失败的节点，已停止发布", stage[0].Stage),
+				})
+				unlockNodeUpgrade(upgrade.NodeID)
+				count++
+			}
+		}
+		log.WithFields(map[string]interface{}{
+			"batch":        batch,
+			"stage":        stage[0].Stage,
+			"cancel_count": count,
+		}).Warn("node upgrade stopped after failed stage") // 阶段升级失败，停止发布
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"batch":   batch,
+		"version": version.Tag,
+	}).Info("node upgrade batch completed") // 发布批次完成
+}
+
+// UpgradeNode 对单个节点执行升级，返回最终的升级状态
+func UpgradeNode(upgrade models.NodeUpgradeModel, version models.NodeVersionModel, timeout int, logID string) int8 {
+	log := core.GetLogger().WithField("logID", logID)
+	node := upgrade.NodeModel
+
+	fail := func(status int8, msg string) int8 {
+		global.DB.Model(&upgrade).Updates(models.NodeUpgradeModel{Status: status, ErrMsg: msg})
+		log.WithFields(map[string]interface{}{
+			"node_uid": node.Uid,
+			"version":  version.Tag,
+			"status":   status,
+			"error":    msg,
+		}).Error("node upgrade failed") // 节点升级失败
+		return status
+	}
+
+	cmd, ok := GetNodeCommand(node.Uid)
+	if !ok {
+		return fail(6, "节点离线中")
+	}
+
+	global.DB.Model(&upgrade).Updates(models.NodeUpgradeModel{Status: 2})
+	log.WithFields(map[string]interface{}{
+		"node_uid": node.Uid,
+		"from":     upgrade.FromVersion,
+		"to":       version.Tag,
+	}).Info("node upgrade started") // 节点开始升级
+
+	err := sendUpgradeCommand(cmd, upgrade, &node_rpc.NodeUpgradeInMessage{
+		UpgradeID: uint32(upgrade.ID),
+		Version:   version.Tag,
+		Image:     fmt.Sprintf("%s:%s", version.ImageName, version.Tag),
+		ImageID:   version.ImageID,
+		Url:       fmt.Sprintf("%s?id=%d", global.Config.System.NodeDownloadURL(global.Config.System.Scheme()), version.ID),
+		FileSize:  version.FileSize,
+		Hash:      version.Hash,
+		Timeout:   int32(timeout),
+	}, logID)
+	if err != nil {
+		return fail(6, err.Error())
+	}
+
+	// 节点已开始切换，等待新版本（或回滚后的旧版本）重新注册
+	deadline := time.Now().Add(time.Duration(timeout)*time.Second + upgradeRollbackGrace)
+	for time.Now().Before(deadline) {
+		time.Sleep(upgradePollInterval)
+		global.DB.Take(&upgrade, upgrade.ID)
+		if upgrade.Status != 3 {
+			log.WithFields(map[string]interface{}{
+				"node_uid": node.Uid,
+				"status":   upgrade.Status,
+				"error":    upgrade.ErrMsg,
+			}).Info("node upgrade finished") // 节点升级结束
+			return upgrade.Status
+		}
+	}
+	return fail(6, "节点未在超时时间内重新注册")
+}
+
+// sendUpgradeCommand 下发升级命令，持续接收节点上报的下载进度，直到节点开始切换版本
+func sendUpgradeCommand(cmd *Command, upgrade models.NodeUpgradeModel, in *node_rpc.NodeUpgradeInMessage, logID string) error {
+	req := &node_rpc.CmdRequest{
+		CmdType:              node_rpc.CmdType_cmdNodeUpgradeType,
+		TaskID:               fmt.Sprintf("upgrade-%d", upgrade.ID),
+		LogID:                logID,
+		NodeUpgradeInMessage: in,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), upgradeDownloadTimeout)
+	defer cancel()
+
+	resChan, done, err := cmd.Request(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	for {
+		select {
+		case res := <-resChan:
+			out := res.GetNodeUpgradeOutMessage()
+			if out == nil {
+				return errors.New("节点响应为空")
+			}
+			switch out.Status {
+			case 1:
+				global.DB.Model(&upgrade).Updates(models.NodeUpgradeModel{Progress: float64(out.Progress)})
+			case 2:
+				global.DB.Model(&upgrade).Updates(models.NodeUpgradeModel{Status: 3, Progress: 100})
+				return nil
+			default:
+				return errors.New(out.ErrMsg)
+			}
+		case <-cmd.stopChan:
+			return errors.New("节点连接已断开")
+		case <-ctx.Done():
+			return errors.New("等待节点下载镜像超时")
+		}
+	}
+}
+
+// checkNodeUpgrade 节点重新注册时根据上报的版本判定切换中的升级结果
+func checkNodeUpgrade(node models.NodeModel, version string) {
+	var upgrade models.NodeUpgradeModel
+	err := global.DB.Order("id desc").Take(&upgrade, "node_id = ? and status = ?", node.ID, 3).Error
+	if err != nil {
+		return
+	}
+	if version == upgrade.ToVersion {
+		global.DB.Model(&upgrade).Updates(models.NodeUpgradeModel{Status: 4})
+		return
+	}
+	global.DB.Model(&upgrade).Updates(models.NodeUpgradeModel{
+		Status: 5,
+		ErrMsg: fmt.Sprintf("新版本未在超时时间内重连，已回滚至 %s", version),
+	})
+}
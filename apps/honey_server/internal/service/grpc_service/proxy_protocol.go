@@ -0,0 +1,56 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/proxy_protocol.go
+// Description: PROXY协议开关查询，按虚拟服务地址判断关联镜像是否开启PROXY协议，服务列表由后台协程定期从数据库刷新，避免隧道连接查询数据库或等待锁
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// proxyProtocolRefresh PROXY协议开关的刷新间隔
+const proxyProtocolRefresh = 10 * time.Second
+
+// proxyProtocolAddrMap 开启PROXY协议的虚拟服务地址（ip:port），由后台协程整体替换，隧道连接只读不加锁
+var proxyProtocolAddrMap atomic.Pointer[map[string]bool]
+
+// startProxyProtocolRefresh 同步加载一次开关缓存，随后在后台定期刷新，数据库查询不在隧道连接路径上执行
+func startProxyProtocolRefresh() {
+	addrMap := loadProxyProtocolAddr()
+	proxyProtocolAddrMap.Store(&addrMap)
+	go func() {
+		ticker := time.NewTicker(proxyProtocolRefresh)
+		defer ticker.Stop()
+		for range ticker.C {
+			addrMap := loadProxyProtocolAddr()
+			proxyProtocolAddrMap.Store(&addrMap)
+		}
+	}()
+}
+
+// proxyProtocolEnabled 判断目标地址对应的虚拟服务是否开启PROXY协议
+func proxyProtocolEnabled(address string) bool {
+	addrMap := proxyProtocolAddrMap.Load()
+	return addrMap != nil && (*addrMap)[address]
+}
+
+// loadProxyProtocolAddr 查询镜像开启了PROXY协议的TCP虚拟服务地址
+func loadProxyProtocolAddr() map[string]bool {
+	var imageIDList []uint
+	global.DB.Model(&models.ImageModel{}).Where("proxy_protocol = ? and agreement = ?", true, 1).Pluck("id", &imageIDList)
+
+	addrMap := map[string]bool{}
+	if len(imageIDList) == 0 {
+		return addrMap
+	}
+	var serviceList []models.ServiceModel
+	global.DB.Find(&serviceList, "image_id in ?", imageIDList)
+	for _, service := range serviceList {
+		addrMap[net.JoinHostPort(service.IP, strconv.Itoa(service.Port))] = true
+	}
+	return addrMap
+}
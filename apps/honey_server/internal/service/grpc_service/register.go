@@ -6,11 +6,22 @@ package grpc_service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"honey_server/internal/global"
 	"honey_server/internal/models"
 	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/log_service"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+var (
+	errNodePending  = errors.New("节点等待管理员审批")
+	errNodeRejected = errors.New("节点接入已被拒绝")
 )
 
 // Register 节点注册接口实现
@@ -26,11 +37,12 @@ func (NodeService) Register(ctx context.Context, request *node_rpc.RegisterReque
 	if err1 != nil {
 		// 节点不存在，创建新节点记录
 		model = models.NodeModel{
-			Title:  request.SystemInfo.HostName, // 节点名称
-			Uid:    uid,                         // 节点唯一标识
-			IP:     request.Ip,                  // 节点ip
-			Mac:    request.Mac,                 // 节点mac
-			Status: 1,                           // 节点状态：1-在线
+			Title:   request.SystemInfo.HostName, // 节点名称
+			Uid:     uid,                         // 节点唯一标识
+			IP:      request.Ip,                  // 节点ip
+			Mac:     request.Mac,                 // 节点mac
+			Status:  2,                           // 节点状态：2-离线，通过审批后更新为在线
+			Approve: 1,                           // 审批状态：1-待审批
 			SystemInfo: models.NodeSystemInfo{ // 节点系统信息
 				NodeVersion:         request.Version,
 				NodeCommit:          request.Commit,
@@ -49,6 +61,12 @@ func (NodeService) Register(ctx context.Context, request *node_rpc.RegisterReque
 		}
 	}
 
+	// 已拒绝的节点不再接入，需删除节点记录后重新部署
+	if model.Approve == 3 {
+		logrus.Warnf("节点 %s 接入已被拒绝", uid)
+		return nil, status.Error(codes.PermissionDenied, errNodeRejected.Error())
+	}
+
 	// 处理网卡记录 - 先查询再决定操作
 	for _, message := range request.NetworkList {
 		var existingNetwork models.NodeNetworkModel
@@ -62,6 +80,10 @@ func (NodeService) Register(ctx context.Context, request *node_rpc.RegisterReque
 				Network: message.Network,
 				IP:      message.Ip,
 				Mask:    int8(message.Mask),
+				IP6:     message.Ip6,
+				Mask6:   int8(message.Mask6),
+				VlanID:  int(message.VlanID),
+				Parent:  message.Parent,
 				Status:  2,
 			}
 			err = global.DB.Create(&networkRecord).Error
@@ -71,11 +93,17 @@ func (NodeService) Register(ctx context.Context, request *node_rpc.RegisterReque
 			}
 		} else {
 			// 记录已存在，检查是否需要更新
-			if existingNetwork.IP != message.Ip || existingNetwork.Mask != int8(message.Mask) {
-				// IP或掩码有变化，更新记录
+			if existingNetwork.IP != message.Ip || existingNetwork.Mask != int8(message.Mask) ||
+				existingNetwork.IP6 != message.Ip6 || existingNetwork.Mask6 != int8(message.Mask6) ||
+				existingNetwork.VlanID != int(message.VlanID) {
+				// IP、掩码、IPv6地址或VLAN有变化，更新记录
 				updates := map[string]interface{}{
-					"ip":   message.Ip,
-					"mask": int8(message.Mask),
+					"ip":      message.Ip,
+					"mask":    int8(message.Mask),
+					"ip6":     message.Ip6,
+					"mask6":   int8(message.Mask6),
+					"vlan_id": int(message.VlanID),
+					"parent":  message.Parent,
 				}
 				err = global.DB.Model(&existingNetwork).Updates(updates).Error
 				if err != nil {
@@ -86,10 +114,48 @@ func (NodeService) Register(ctx context.Context, request *node_rpc.RegisterReque
 		}
 	}
 
+	// 节点信息保存完成后使用接入令牌，待审批节点携带有效接入令牌时直接通过审批
+	if model.Approve == 1 && request.Token != "" {
+		if tokenID, ok := useNodeToken(request.Token, uid); ok {
+			model.Approve = 2
+			global.DB.Model(&model).Update("approve", 2)
+			log_service.NewNodeActionLog(model.IP).Info("节点审批通过",
+				fmt.Sprintf("节点 %s uid=%s ip=%s mac=%s 说明：使用接入令牌 id=%d 自动通过审批", model.Title, model.Uid, model.IP, model.Mac, tokenID))
+			logrus.Infof("节点 %s 使用接入令牌 %d 通过审批", uid, tokenID)
+		}
+	}
+
+	// 未通过审批的节点仅记录节点信息，不上线，节点等待审批后重新注册
+	if model.Approve != 2 {
+		logrus.Infof("节点 %s 等待管理员审批", uid)
+		return nil, status.Error(codes.FailedPrecondition, errNodePending.Error())
+	}
+
 	// 节点已存在，检查状态是否为在线，非在线则更新为在线
 	if model.Status != 1 {
 		global.DB.Model(&model).Update("status", 1)
 	}
 
+	// 节点版本变化（升级或回滚）时更新版本信息，并判定切换中的升级结果
+	if model.SystemInfo.NodeVersion != request.Version || model.SystemInfo.NodeCommit != request.Commit {
+		model.SystemInfo.NodeVersion = request.Version
+		model.SystemInfo.NodeCommit = request.Commit
+		global.DB.Model(&model).Updates(models.NodeModel{SystemInfo: model.SystemInfo})
+	}
+	checkNodeUpgrade(model, request.Version)
+
 	return
 }
+
+// useNodeToken 校验并使用节点接入令牌，返回令牌ID，一次性令牌使用后失效，过期令牌不可用
+func useNodeToken(token string, uid string) (tokenID uint, ok bool) {
+	var model models.NodeTokenModel
+	if err := global.DB.Take(&model, "token = ?", token).Error; err != nil {
+		return 0, false
+	}
+	// 按令牌ID条件更新，并发使用一次性令牌时只有一个节点能更新成功
+	result := global.DB.Model(&model).
+		Where("(one_time = ? or use_count = 0) and (expire_at is null or expire_at > ?)", false, time.Now()).
+		Updates(map[string]interface{}{"use_count": gorm.Expr("use_count + 1"), "node_uid": uid})
+	return model.ID, result.Error == nil && result.RowsAffected == 1
+}
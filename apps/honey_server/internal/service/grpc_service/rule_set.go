@@ -0,0 +1,108 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/rule_set.go
+// Description: 规则集下发模块，通过命令流将规则集推送到节点，根据节点返回的加载结果更新节点规则集状态
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/rule_service"
+	"time"
+)
+
+// ruleSetTimeout 等待节点加载规则的超时时间（规则较多时Suricata重载耗时较长）
+const ruleSetTimeout = 2 * time.Minute
+
+// PushRuleSet 向节点下发规则集，返回节点上报的规则集状态
+func PushRuleSet(nodeModel models.NodeModel, ruleSet models.RuleSetModel, logID string) (state models.NodeRuleState, err error) {
+	log := core.GetLogger().WithField("logID", logID)
+	cmd, ok := GetNodeCommand(nodeModel.Uid)
+	if !ok {
+		return state, errors.New("节点离线中")
+	}
+
+	rules, threshold, err := rule_service.Render(ruleSet)
+	if err != nil {
+		return state, err
+	}
+	// 规则文件体积较大，压缩后下发
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(rules)
+	if err = zw.Close(); err != nil {
+		return state, fmt.Errorf("压缩规则文件失败 %w", err)
+	}
+
+	log.WithFields(map[string]interface{}{
+		"node_uid": nodeModel.Uid,
+		"version":  ruleSet.VersionName(),
+		"size":     buf.Len(),
+	}).Info("push rule set") // 下发规则集
+
+	out, err := sendRuleSetCommand(cmd, &node_rpc.RuleSetInMessage{
+		RuleSetID: uint32(ruleSet.ID),
+		Version:   ruleSet.VersionName(),
+		Hash:      rule_service.Hash(rules, threshold),
+		Rules:     buf.Bytes(),
+		Threshold: threshold,
+	}, logID)
+	if err != nil {
+		// 节点未返回结果，保留原有规则集状态仅记录错误
+		state = nodeModel.RuleState
+		state.ErrMsg = err.Error()
+		state.UpdatedAt = time.Now().Format(time.DateTime)
+		global.DB.Model(&nodeModel).Updates(models.NodeModel{RuleState: state})
+		return state, err
+	}
+
+	state = models.NodeRuleState{
+		RuleSetID:   uint(out.RuleSetID),
+		Version:     out.Version,
+		Hash:        out.Hash,
+		RulesLoaded: int(out.RulesLoaded),
+		RulesFailed: int(out.RulesFailed),
+		ErrList:     out.ErrList,
+		ErrMsg:      out.ErrMsg,
+		UpdatedAt:   time.Now().Format(time.DateTime),
+	}
+	global.DB.Model(&nodeModel).Updates(models.NodeModel{RuleState: state})
+
+	log.WithFields(map[string]interface{}{
+		"node_uid": nodeModel.Uid,
+		"state":    state,
+	}).Info("push rule set completed") // 规则集下发完成
+	if out.ErrMsg != "" {
+		return state, errors.New(out.ErrMsg)
+	}
+	return state, nil
+}
+
+// sendRuleSetCommand 下发规则集命令并等待节点返回加载结果
+func sendRuleSetCommand(cmd *Command, in *node_rpc.RuleSetInMessage, logID string) (*node_rpc.RuleSetOutMessage, error) {
+	req := &node_rpc.CmdRequest{
+		CmdType:          node_rpc.CmdType_cmdRuleSetType,
+		TaskID:           fmt.Sprintf("rule-%d", time.Now().UnixNano()),
+		LogID:            logID,
+		RuleSetInMessage: in,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ruleSetTimeout)
+	defer cancel()
+
+	res, err := cmd.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	out := res.GetRuleSetOutMessage()
+	if out == nil {
+		return nil, errors.New("节点响应为空")
+	}
+	return out, nil
+}
@@ -27,24 +27,32 @@ func (NodeService) StatusBindPort(ctx context.Context, request *node_rpc.StatusB
 		// 诱捕IP不存在时返回错误
 		return nil, fmt.Errorf("诱捕ip不存在 %d", request.HoneyIPID)
 	}
+	if err1 = checkNodeOwner(ctx, honeyIPModel.NodeID); err1 != nil {
+		return nil, err1
+	}
 
 	net_lock.UnLock(honeyIPModel.NetID)
 
-	// 构建端口号到端口模型的映射
-	var portMap = map[int64]*models.HoneyPortModel{}
+	// 构建端口唯一标识到端口模型的映射
+	var portMap = map[models.PortKey]*models.HoneyPortModel{}
 	for _, model := range honeyIPModel.PortList {
-		portMap[int64(model.Port)] = &model
+		portMap[model.Key()] = &model
 	}
 
 	// 遍历节点上报的端口状态列表，仅处理携带错误信息的端口（绑定失败）
 	for _, i2 := range request.PortInfoList {
 		if i2.Msg != "" {
 			log.WithFields(map[string]interface{}{
-				"error": i2.Msg,
-				"port":  i2.Port,
+				"error":     i2.Msg,
+				"port":      i2.Port,
+				"agreement": i2.Agreement,
 			}).Error("failed to bind port") // 绑定端口失败
-			// 根据端口号查找本地端口模型
-			model, ok := portMap[i2.Port]
+			// 根据协议及端口号查找本地端口模型（未上报协议的旧节点按TCP处理）
+			agreement := int8(i2.Agreement)
+			if agreement == 0 {
+				agreement = 1
+			}
+			model, ok := portMap[models.PortKey{Agreement: agreement, Port: int(i2.Port)}]
 			if !ok {
 				// 端口信息不存在时记录错误日志，跳过当前端口处理
 				log.WithField("port", i2.Port).Errorf("port information does not exist")
@@ -27,6 +27,9 @@ func (NodeService) StatusCreateIP(ctx context.Context, request *node_rpc.StatusC
 	if err1 != nil {
 		return nil, fmt.Errorf("诱捕ip不存在 %d", request.HoneyIPID)
 	}
+	if err1 = checkNodeOwner(ctx, honeyIPModel.NodeID); err1 != nil {
+		return nil, err1
+	}
 
 	net_lock.UnLock(honeyIPModel.NetID)
 
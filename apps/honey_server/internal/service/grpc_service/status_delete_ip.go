@@ -21,10 +21,20 @@ func (NodeService) StatusDeleteIP(ctx context.Context, request *node_rpc.StatusD
 	pd = new(node_rpc.BaseResponse) // 初始化gRPC响应对象
 	log := core.GetLogger().WithField("logID", request.LogID)
 	log.WithField("request_data", request).Infof("接收批量删除ip回调")
+	// 仅处理调用方节点的网络及诱捕IP记录
+	caller, err1 := ctxNode(ctx)
+	if err1 != nil {
+		return nil, err1
+	}
+	var callerNet models.NetModel
+	if err1 = global.DB.Take(&callerNet, request.NetID).Error; err1 == nil && callerNet.NodeID != caller.ID {
+		return nil, fmt.Errorf("网络不属于当前节点 %d", request.NetID)
+	}
+
 	// 根据节点上报的ID列表查询对应的诱捕IP记录
 	var honeyIPList []models.HoneyIpModel
 	net_lock.UnLock(uint(request.NetID))
-	global.DB.Find(&honeyIPList, "id in ?", request.HoneyIPIDList)
+	global.DB.Find(&honeyIPList, "id in ? and node_id = ?", request.HoneyIPIDList, caller.ID)
 
 	// 校验查询结果：若没有找到任何记录，返回错误
 	if len(honeyIPList) == 0 {
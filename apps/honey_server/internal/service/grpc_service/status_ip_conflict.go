@@ -0,0 +1,94 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/status_ip_conflict.go
+// Description: 节点gRPC服务实现，处理节点上报的诱捕ip冲突（真实主机占用诱捕ip），将诱捕ip标记为冲突状态并将真实主机记录为资产
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/mq_service"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// StatusIpConflict 处理节点上报的诱捕ip冲突请求
+func (NodeService) StatusIpConflict(ctx context.Context, request *node_rpc.StatusIpConflictRequest) (pd *node_rpc.BaseResponse, err error) {
+	pd = new(node_rpc.BaseResponse)
+	log := core.GetLogger()
+	log.WithField("request_data", request).Warn("honey ip conflict reported") // 节点上报诱捕ip冲突
+
+	var nodeModel models.NodeModel
+	if err1 := global.DB.Take(&nodeModel, "uid = ?", request.NodeUid).Error; err1 != nil {
+		return nil, errors.New("节点不存在")
+	}
+	var honeyIPModel models.HoneyIpModel
+	if err1 := global.DB.Take(&honeyIPModel, "node_id = ? and ip = ? and status <> ?", nodeModel.ID, request.Ip, 4).Error; err1 != nil {
+		// 诱捕ip已删除，节点撤下后无需处理
+		return
+	}
+
+	// 诱捕ip标记为冲突状态，全量状态同步时不再下发，避免节点重建
+	errMsg := fmt.Sprintf("ip冲突 %s", request.Mac)
+	global.DB.Model(&honeyIPModel).Updates(map[string]any{
+		"status":    5,
+		"error_msg": errMsg,
+	})
+
+	// 占用诱捕ip的真实主机记录为资产
+	var netModel models.NetModel
+	if err1 := global.DB.Take(&netModel, honeyIPModel.NetID).Error; err1 == nil {
+		recordConflictHost(netModel, request)
+	}
+
+	mq_service.SendWsMsg(mq_service.WsMsgType{
+		Type:   1,
+		NetID:  honeyIPModel.NetID,
+		NodeID: honeyIPModel.NodeID,
+	})
+	return
+}
+
+// recordConflictHost 新增或更新占用诱捕ip的真实主机
+func recordConflictHost(netModel models.NetModel, request *node_rpc.StatusIpConflictRequest) {
+	now := parseSeenTime(request.DetectTime)
+	var host models.HostModel
+	err := global.DB.Take(&host, "net_id = ? and ip = ?", netModel.ID, request.Ip).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		host = models.HostModel{
+			NodeID:    netModel.NodeID,
+			NetID:     netModel.ID,
+			IP:        request.Ip,
+			Mac:       request.Mac,
+			Manuf:     request.Manuf,
+			FirstSeen: now,
+			LastSeen:  now,
+		}
+		if err = global.DB.Create(&host).Error; err != nil {
+			logrus.Errorf("冲突主机创建失败 %s %s", request.Ip, err)
+			return
+		}
+		global.DB.Model(&netModel).Update("host_count", gorm.Expr("host_count + 1"))
+		mq_service.SendWsMsg(mq_service.WsMsgType{
+			Type:   6,
+			NetID:  netModel.ID,
+			NodeID: netModel.NodeID,
+		})
+		return
+	}
+	if err != nil {
+		return
+	}
+	lastSeen := time.Now()
+	global.DB.Model(&host).Updates(models.HostModel{
+		Mac:      request.Mac,
+		Manuf:    request.Manuf,
+		LastSeen: &lastSeen,
+	})
+}
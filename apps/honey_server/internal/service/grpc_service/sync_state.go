@@ -0,0 +1,231 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/sync_state.go
+// Description: 节点全量状态同步模块，通过命令流下发节点应有的全部诱捕ip及端口转发，根据节点返回的逐项结果修正数据库记录状态
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/mq_service"
+	"honey_server/internal/service/redis_service/net_lock"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// syncTimeout 等待节点同步结果的超时时间
+const syncTimeout = 30 * time.Second
+
+// SyncResult 全量状态同步结果统计
+type SyncResult struct {
+	IpCount       int      `json:"ipCount"`       // 同步的诱捕ip数
+	IpFailCount   int      `json:"ipFailCount"`   // 同步失败的诱捕ip数
+	PortCount     int      `json:"portCount"`     // 同步的端口转发数
+	PortFailCount int      `json:"portFailCount"` // 同步失败的端口转发数
+	RemoveCount   int      `json:"removeCount"`   // 节点删除的多余诱捕ip数
+	DeleteCount   int      `json:"deleteCount"`   // 确认删除完成的诱捕ip记录数
+	SkipNetList   []string `json:"skipNetList"`   // 正在操作中而跳过同步的网络
+}
+
+// SyncNodeState 向节点下发全量期望状态并根据节点返回结果修正诱捕ip及端口记录
+func SyncNodeState(nodeModel models.NodeModel, logID string) (result SyncResult, err error) {
+	log := core.GetLogger().WithField("logID", logID)
+	cmd, ok := GetNodeCommand(nodeModel.Uid)
+	if !ok {
+		return result, errors.New("节点离线中")
+	}
+
+	// 锁定节点下的全部子网，正在操作中的子网跳过同步
+	var netList []models.NetModel
+	global.DB.Find(&netList, "node_id = ?", nodeModel.ID)
+	netMap := map[uint]models.NetModel{}
+	in := &node_rpc.SyncStateInMessage{}
+	for _, netModel := range netList {
+		if err = net_lock.Lock(netModel.ID); err != nil {
+			in.SkipNetworkList = append(in.SkipNetworkList, netModel.Network)
+			result.SkipNetList = append(result.SkipNetList, netModel.Title)
+			continue
+		}
+		netMap[netModel.ID] = netModel
+	}
+	defer func() {
+		for netID := range netMap {
+			net_lock.UnLock(netID)
+		}
+	}()
+
+	// 组装期望状态，删除中的诱捕ip不下发，由节点删除；失败及冲突的诱捕ip不下发，
+	// 失败记录中的mac可能是占用该ip的真实主机mac，下发会导致节点仿冒真实主机
+	var honeyIPList []models.HoneyIpModel
+	global.DB.Preload("PortList").Find(&honeyIPList, "node_id = ?", nodeModel.ID)
+	ipMap := map[uint32]models.HoneyIpModel{}
+	var deletingList []models.HoneyIpModel
+	for _, model := range honeyIPList {
+		netModel, ok := netMap[model.NetID]
+		if !ok {
+			continue
+		}
+		if model.Status == 4 {
+			deletingList = append(deletingList, model)
+			continue
+		}
+		if model.Status == 3 || model.Status == 5 {
+			continue
+		}
+		ipInfo := &node_rpc.SyncIpInfo{
+			HoneyIpID: uint32(model.ID),
+			Ip:        model.IP,
+			Mask:      int32(netModel.MaskOf(model.IP)),
+			Network:   netModel.Network,
+			LinkName:  model.Network,
+			Mac:       model.Mac,
+			IsTan:     netModel.IP == model.IP,
+		}
+		for _, port := range model.PortList {
+			ipInfo.PortList = append(ipInfo.PortList, &node_rpc.SyncPortInfo{
+				Port:      int64(port.Port),
+				Agreement: int32(port.Agreement),
+				DestIP:    port.DstIP,
+				DestPort:  int64(port.DstPort),
+				Emulator:  port.Emulator,
+			})
+		}
+		in.IpList = append(in.IpList, ipInfo)
+		ipMap[ipInfo.HoneyIpID] = model
+	}
+
+	log.WithFields(map[string]interface{}{
+		"node_uid": nodeModel.Uid,
+		"ip_count": len(in.IpList),
+		"skip":     in.SkipNetworkList,
+	}).Info("sync node state") // 下发全量状态同步
+
+	out, err := sendSyncCommand(cmd, in, logID)
+	if err != nil {
+		return result, err
+	}
+
+	// 逐项修正诱捕ip及端口记录状态
+	for _, ipResult := range out.IpList {
+		model, ok := ipMap[ipResult.HoneyIpID]
+		if !ok {
+			continue
+		}
+		result.IpCount++
+		if ipResult.ErrMsg != "" {
+			result.IpFailCount++
+			log.WithFields(map[string]interface{}{
+				"ip":    model.IP,
+				"error": ipResult.ErrMsg,
+			}).Error("failed to sync honey ip") // 同步诱捕ip失败
+			global.DB.Model(&model).Updates(map[string]any{
+				"status":    3,
+				"error_msg": truncate(ipResult.ErrMsg, 64),
+			})
+		} else {
+			global.DB.Model(&model).Updates(map[string]any{
+				"status":    2,
+				"error_msg": "",
+				"mac":       ipResult.Mac,
+				"network":   ipResult.LinkName,
+			})
+		}
+		// 创建回调丢失的诱捕ip补充计数
+		if model.Status == 1 {
+			global.DB.Model(&models.NodeModel{}).Where("id = ?", model.NodeID).Update("honey_ip_count", gorm.Expr("honey_ip_count + 1"))
+			global.DB.Model(&models.NetModel{}).Where("id = ?", model.NetID).Update("honey_ip_count", gorm.Expr("honey_ip_count + 1"))
+		}
+
+		portMap := map[models.PortKey]models.HoneyPortModel{}
+		for _, port := range model.PortList {
+			portMap[port.Key()] = port
+		}
+		for _, portInfo := range ipResult.PortList {
+			port, ok := portMap[models.PortKey{Agreement: int8(portInfo.Agreement), Port: int(portInfo.Port)}]
+			if !ok {
+				continue
+			}
+			result.PortCount++
+			if portInfo.Msg != "" {
+				result.PortFailCount++
+			}
+			if port.ErrorMsg != portInfo.Msg {
+				global.DB.Model(&port).Update("error_msg", truncate(portInfo.Msg, 256))
+			}
+		}
+	}
+
+	// 节点已不存在的删除中诱捕ip，完成删除
+	removeFailMap := map[string]bool{}
+	for _, ipResult := range out.RemoveList {
+		result.RemoveCount++
+		if ipResult.ErrMsg != "" {
+			removeFailMap[ipResult.Ip] = true
+		}
+	}
+	for _, model := range deletingList {
+		if removeFailMap[model.IP] {
+			continue
+		}
+		global.DB.Where("honey_ip_id = ?", model.ID).Delete(&models.HoneyPortModel{})
+		global.DB.Delete(&model)
+		global.DB.Model(&models.NodeModel{}).Where("id = ?", model.NodeID).Update("honey_ip_count", gorm.Expr("honey_ip_count - 1"))
+		global.DB.Model(&models.NetModel{}).Where("id = ?", model.NetID).Update("honey_ip_count", gorm.Expr("honey_ip_count - 1"))
+		result.DeleteCount++
+	}
+
+	for netID := range netMap {
+		mq_service.SendWsMsg(mq_service.WsMsgType{
+			LogID:  logID,
+			Type:   1,
+			NetID:  netID,
+			NodeID: nodeModel.ID,
+		})
+	}
+
+	log.WithFields(map[string]interface{}{
+		"node_uid": nodeModel.Uid,
+		"result":   result,
+	}).Info("sync node state completed") // 全量状态同步完成
+	return result, nil
+}
+
+// sendSyncCommand 下发全量状态同步命令并等待节点返回结果
+func sendSyncCommand(cmd *Command, in *node_rpc.SyncStateInMessage, logID string) (*node_rpc.SyncStateOutMessage, error) {
+	req := &node_rpc.CmdRequest{
+		CmdType:            node_rpc.CmdType_cmdSyncStateType,
+		TaskID:             fmt.Sprintf("sync-%d", time.Now().UnixNano()),
+		LogID:              logID,
+		SyncStateInMessage: in,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+
+	res, err := cmd.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	out := res.GetSyncStateOutMessage()
+	if out == nil {
+		return nil, errors.New("节点响应为空")
+	}
+	if out.ErrMsg != "" {
+		return nil, errors.New(out.ErrMsg)
+	}
+	return out, nil
+}
+
+// truncate 按字符数截断字符串，避免超出字段长度
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
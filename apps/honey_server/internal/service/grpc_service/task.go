@@ -0,0 +1,100 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/task.go
+// Description: 节点任务管理模块，通过命令流查询节点任务列表及执行进度，取消或重试节点上的任务（批量部署、网络扫描等）
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"time"
+)
+
+// taskTimeout 等待节点处理任务管理命令的超时时间
+const taskTimeout = 10 * time.Second
+
+// 节点任务操作类型
+const (
+	taskActionList   = 1 // 列表
+	taskActionCancel = 2 // 取消
+	taskActionRetry  = 3 // 重试
+)
+
+// NodeTaskList 查询节点最近的任务列表
+func NodeTaskList(nodeModel models.NodeModel, logID string) ([]*node_rpc.TaskInfo, error) {
+	cmd, ok := GetNodeCommand(nodeModel.Uid)
+	if !ok {
+		return nil, errors.New("节点离线中")
+	}
+	out, err := sendTaskCommand(cmd, &node_rpc.TaskInMessage{
+		Action: taskActionList,
+	}, logID)
+	if err != nil {
+		return nil, err
+	}
+	if out.ErrMsg != "" {
+		return nil, errors.New(out.ErrMsg)
+	}
+	return out.TaskList, nil
+}
+
+// CancelNodeTask 取消节点上执行中的任务
+func CancelNodeTask(nodeModel models.NodeModel, taskID string, logID string) error {
+	return nodeTaskAction(nodeModel, taskActionCancel, taskID, logID)
+}
+
+// RetryNodeTask 重试节点上失败或已取消的任务
+func RetryNodeTask(nodeModel models.NodeModel, taskID string, logID string) error {
+	return nodeTaskAction(nodeModel, taskActionRetry, taskID, logID)
+}
+
+// nodeTaskAction 下发任务取消或重试命令
+func nodeTaskAction(nodeModel models.NodeModel, action int32, taskID string, logID string) error {
+	log := core.GetLogger().WithField("logID", logID)
+	cmd, ok := GetNodeCommand(nodeModel.Uid)
+	if !ok {
+		return errors.New("节点离线中")
+	}
+	out, err := sendTaskCommand(cmd, &node_rpc.TaskInMessage{
+		Action: action,
+		TaskID: taskID,
+	}, logID)
+	if err != nil {
+		return err
+	}
+	if out.ErrMsg != "" {
+		return errors.New(out.ErrMsg)
+	}
+	log.WithFields(map[string]interface{}{
+		"node_uid": nodeModel.Uid,
+		"task_id":  taskID,
+		"action":   action,
+	}).Info("node task action completed") // 节点任务操作完成
+	return nil
+}
+
+// sendTaskCommand 下发节点任务管理命令并等待节点返回结果
+func sendTaskCommand(cmd *Command, in *node_rpc.TaskInMessage, logID string) (*node_rpc.TaskOutMessage, error) {
+	req := &node_rpc.CmdRequest{
+		CmdType:       node_rpc.CmdType_cmdTaskType,
+		TaskID:        fmt.Sprintf("task-%d", time.Now().UnixNano()),
+		LogID:         logID,
+		TaskInMessage: in,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), taskTimeout)
+	defer cancel()
+
+	res, err := cmd.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	out := res.GetTaskOutMessage()
+	if out == nil {
+		return nil, errors.New("节点响应为空")
+	}
+	return out, nil
+}
@@ -6,6 +6,7 @@ package grpc_service
 import (
 	"fmt"
 	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/utils/proxyproto"
 	"io"
 	"log"
 	"net"
@@ -27,6 +28,13 @@ func (s *NodeService) Tunnel(stream node_rpc.NodeService_TunnelServer) error {
 	}
 	defer conn.Close() // 函数退出时关闭目标连接
 
+	// 目标服务开启PROXY协议时先发送协议头，携带攻击方地址及诱捕地址
+	if proxyProtocolEnabled(req.Address) {
+		if err = proxyproto.WriteTCP(conn, req.SrcAddr, req.DestAddr); err != nil {
+			return fmt.Errorf("发送PROXY协议头失败: %v", err)
+		}
+	}
+
 	// 协程1：处理 gRPC 流 -> TCP 连接 (上行流量)
 	// 读取gRPC客户端（节点）数据并转发到目标TCP连接
 	go func() {
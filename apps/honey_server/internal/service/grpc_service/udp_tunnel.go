@@ -0,0 +1,89 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/udp_tunnel.go
+// Description: 节点与服务端之间的UDP隧道实现，一条gRPC流对应节点侧的一个来源会话，每条消息承载一个完整数据报
+
+import (
+	"errors"
+	"fmt"
+	"honey_server/internal/rpc/node_rpc"
+	"io"
+	"log"
+	"net"
+	"os"
+	"time"
+)
+
+// udpSessionIdleTimeout UDP会话空闲超时时间，目标服务长时间无响应时结束会话
+const udpSessionIdleTimeout = 2 * time.Minute
+
+// UdpTunnel 实现node_rpc.NodeServiceServer接口的双向流UdpTunnel方法
+func (s *NodeService) UdpTunnel(stream node_rpc.NodeService_UdpTunnelServer) error {
+	// 接收客户端的第一个消息（初始化消息）：获取隧道目标地址及首个数据报
+	req, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("接收初始请求失败: %v", err)
+	}
+
+	// 为当前会话建立独立的UDP连接，保证目标服务看到的来源与会话一一对应
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(stream.Context(), "udp", req.Address)
+	if err != nil {
+		return fmt.Errorf("连接目标地址失败: %v", err)
+	}
+	defer conn.Close() // 函数退出时关闭目标连接
+
+	// 初始化消息携带的首个数据报直接转发
+	if len(req.Datagram) > 0 {
+		if _, err = conn.Write(req.Datagram); err != nil {
+			return fmt.Errorf("写入目标连接失败: %v", err)
+		}
+	}
+
+	// 协程1：处理 gRPC 流 -> UDP 连接 (上行数据报)
+	go func() {
+		defer conn.Close() // 节点侧会话结束时关闭目标连接，唤醒下行读取
+		for {
+			data, err := stream.Recv()
+			if err == io.EOF {
+				return // 节点侧会话过期关闭，退出协程
+			}
+			if err != nil {
+				log.Printf("接收客户端数据报失败: %v", err)
+				return
+			}
+
+			// 每条消息原样作为一个数据报写入，保持报文边界
+			_, err = conn.Write(data.Datagram)
+			if err != nil {
+				log.Printf("写入目标连接失败: %v", err)
+				return
+			}
+		}
+	}()
+
+	// 协程2（主逻辑）：处理 UDP 连接 -> gRPC 流 (下行数据报)
+	buffer := make([]byte, 65535) // UDP最大报文长度，避免数据报被截断
+	for {
+		conn.SetReadDeadline(time.Now().Add(udpSessionIdleTimeout))
+		n, err := conn.Read(buffer)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				log.Printf("UDP会话空闲超时 %s -> %s", req.Peer, req.Address)
+			} else if !errors.Is(err, net.ErrClosed) {
+				log.Printf("从目标连接读取失败: %v", err)
+			}
+			return nil // 会话结束
+		}
+
+		err = stream.Send(&node_rpc.UdpTunnelData{
+			Datagram: buffer[:n],
+			Address:  req.Address,
+			Peer:     req.Peer,
+		})
+		if err != nil {
+			log.Printf("发送数据报到客户端失败: %v", err)
+			return err
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/upload_pcap.go
+// Description: 节点gRPC服务实现，接收节点分块上传的抓包文件并更新抓包记录
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// pcapDir 抓包文件存储目录
+const pcapDir = "uploads/pcap"
+
+// UploadPcap 实现node_rpc.NodeServiceServer接口的客户端流UploadPcap方法
+func (NodeService) UploadPcap(stream node_rpc.NodeService_UploadPcapServer) error {
+	// 首个数据块携带抓包任务id及抓包结果信息
+	first, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("接收抓包数据失败: %v", err)
+	}
+
+	var model models.HoneyIpPcapModel
+	if err = global.DB.Take(&model, "capture_id = ?", first.CaptureID).Error; err != nil {
+		logrus.Errorf("抓包记录不存在 %s", first.CaptureID)
+		return stream.SendAndClose(&node_rpc.BaseResponse{Code: 1, Msg: "抓包记录不存在"})
+	}
+	if err = checkNodeOwner(stream.Context(), model.NodeID); err != nil {
+		logrus.Errorf("抓包记录不属于当前节点 %s", first.CaptureID)
+		return err
+	}
+
+	fail := func(msg string) error {
+		global.DB.Model(&model).Updates(map[string]any{
+			"status":    4,
+			"error_msg": msg,
+		})
+		return stream.SendAndClose(&node_rpc.BaseResponse{Code: 1, Msg: msg})
+	}
+
+	if err = os.MkdirAll(pcapDir, 0755); err != nil {
+		logrus.Errorf("创建抓包目录失败 %s", err)
+		return fail("创建抓包目录失败")
+	}
+	path := filepath.Join(pcapDir, model.CaptureID+".pcap")
+	file, err := os.Create(path)
+	if err != nil {
+		logrus.Errorf("创建抓包文件失败 %s", err)
+		return fail("创建抓包文件失败")
+	}
+	defer file.Close()
+
+	// 依次写入全部数据块
+	size := int64(0)
+	chunk := first
+	for {
+		n, err := file.Write(chunk.Chunk)
+		if err != nil {
+			logrus.Errorf("写入抓包文件失败 %s", err)
+			os.Remove(path)
+			return fail("写入抓包文件失败")
+		}
+		size += int64(n)
+
+		chunk, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			logrus.Errorf("接收抓包数据失败 %s %s", model.CaptureID, err)
+			os.Remove(path)
+			return fail("接收抓包数据失败")
+		}
+	}
+
+	// 抓包过程中出错时仍保留已抓取的数据包，状态标记为失败
+	status := int8(3)
+	if first.ErrMsg != "" {
+		status = 4
+	}
+	global.DB.Model(&model).Updates(map[string]any{
+		"status":       status,
+		"packet_count": first.PacketCount,
+		"drop_count":   first.DropCount,
+		"file_size":    size,
+		"path":         path,
+		"error_msg":    first.ErrMsg,
+	})
+	logrus.Infof("抓包文件上传完成 %s %d字节 数据包%d个", model.CaptureID, size, first.PacketCount)
+
+	return stream.SendAndClose(&node_rpc.BaseResponse{Code: 0, Msg: "上传成功"})
+}
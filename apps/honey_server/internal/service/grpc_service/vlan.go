@@ -0,0 +1,103 @@
+package grpc_service
+
+// File: honey_server/service/grpc_service/vlan.go
+// Description: VLAN子接口管理模块，通过命令流通知节点在trunk口上创建或删除VLAN子接口
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"time"
+)
+
+// vlanTimeout 等待节点创建或删除VLAN子接口的超时时间
+const vlanTimeout = 30 * time.Second
+
+// VLAN子接口操作类型
+const (
+	vlanActionCreate = 1 // 创建
+	vlanActionRemove = 2 // 删除
+)
+
+// CreateVlan 通知节点在父网卡上创建VLAN子接口，返回子接口的网卡信息
+func CreateVlan(nodeModel models.NodeModel, network string, vlanID int, ip string, mask int8, logID string) (*node_rpc.NetworkInfoMessage, error) {
+	log := core.GetLogger().WithField("logID", logID)
+	cmd, ok := GetNodeCommand(nodeModel.Uid)
+	if !ok {
+		return nil, errors.New("节点离线中")
+	}
+
+	out, err := sendVlanCommand(cmd, &node_rpc.VlanInMessage{
+		Action:  vlanActionCreate,
+		Network: network,
+		VlanID:  int32(vlanID),
+		Ip:      ip,
+		Mask:    int32(mask),
+	}, logID)
+	if err != nil {
+		return nil, err
+	}
+	if out.ErrMsg != "" {
+		return nil, errors.New(out.ErrMsg)
+	}
+	if out.Network == nil {
+		return nil, errors.New("节点未返回子接口信息")
+	}
+	log.WithFields(map[string]interface{}{
+		"node_uid": nodeModel.Uid,
+		"network":  out.Network.Network,
+		"vlan_id":  vlanID,
+	}).Info("vlan interface created") // VLAN子接口创建完成
+	return out.Network, nil
+}
+
+// RemoveVlan 通知节点删除VLAN子接口
+func RemoveVlan(nodeModel models.NodeModel, name string, logID string) error {
+	log := core.GetLogger().WithField("logID", logID)
+	cmd, ok := GetNodeCommand(nodeModel.Uid)
+	if !ok {
+		return errors.New("节点离线中")
+	}
+
+	out, err := sendVlanCommand(cmd, &node_rpc.VlanInMessage{
+		Action: vlanActionRemove,
+		Name:   name,
+	}, logID)
+	if err != nil {
+		return err
+	}
+	if out.ErrMsg != "" {
+		return errors.New(out.ErrMsg)
+	}
+	log.WithFields(map[string]interface{}{
+		"node_uid": nodeModel.Uid,
+		"network":  name,
+	}).Info("vlan interface removed") // VLAN子接口删除完成
+	return nil
+}
+
+// sendVlanCommand 下发VLAN子接口管理命令并等待节点返回结果
+func sendVlanCommand(cmd *Command, in *node_rpc.VlanInMessage, logID string) (*node_rpc.VlanOutMessage, error) {
+	req := &node_rpc.CmdRequest{
+		CmdType:       node_rpc.CmdType_cmdVlanType,
+		TaskID:        fmt.Sprintf("vlan-%d", time.Now().UnixNano()),
+		LogID:         logID,
+		VlanInMessage: in,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), vlanTimeout)
+	defer cancel()
+
+	res, err := cmd.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	out := res.GetVlanOutMessage()
+	if out == nil {
+		return nil, errors.New("节点响应为空")
+	}
+	return out, nil
+}
@@ -0,0 +1,66 @@
+package log_service
+
+// File: honey_server/service/log_service/action_log.go
+// Description: 日志服务模块，负责用户操作日志的记录，用于节点审批等需要审计的管理操作
+
+import (
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActionLogService 操作日志服务结构体
+type ActionLogService struct {
+	IP       string // 客户端IP地址
+	Addr     string // 客户端地理位置
+	UserID   uint   // 操作用户ID
+	Username string // 操作用户名
+}
+
+// NewActionLog 创建ActionLogService实例的构造函数，从请求上下文获取操作用户
+func NewActionLog(c *gin.Context) *ActionLogService {
+	claims := middleware.GetAuth(c)
+	var user models.UserModel
+	global.DB.Take(&user, claims.UserID)
+	return &ActionLogService{
+		IP:       c.ClientIP(),                 // 从上下文获取客户端IP
+		Addr:     core.GetIpAddr(c.ClientIP()), // 地理位置信息
+		UserID:   claims.UserID,                // 操作用户ID
+		Username: user.Username,                // 操作用户名
+	}
+}
+
+// NewNodeActionLog 创建由节点触发的操作日志实例（如节点使用接入令牌自动通过审批），无操作用户，客户端IP为节点IP
+func NewNodeActionLog(ip string) *ActionLogService {
+	return &ActionLogService{
+		IP:   ip,
+		Addr: core.GetIpAddr(ip),
+	}
+}
+
+// Info 记录普通级别的操作日志
+func (l ActionLogService) Info(title string, content string) {
+	l.save(title, content, 1)
+}
+
+// Warn 记录警告级别的操作日志
+func (l ActionLogService) Warn(title string, content string) {
+	l.save(title, content, 2)
+}
+
+// save 内部日志存储方法，统一处理操作日志的持久化
+func (l ActionLogService) save(title string, content string, level int8) {
+	global.DB.Create(&models.LogModel{
+		Type:     2,          // 日志类型：2-操作日志
+		IP:       l.IP,       // 客户端IP
+		Addr:     l.Addr,     // 客户端地址
+		UserID:   l.UserID,   // 操作用户ID
+		Username: l.Username, // 操作用户名
+		Title:    title,      // 日志别名
+		Level:    level,      // 日志级别：1-普通 2-警告
+		Content:  content,    // 操作详情
+	})
+}
@@ -0,0 +1,51 @@
+package metrics_service
+
+// File: honey_server/service/metrics_service/enter.go
+// Description: 管理服务Prometheus指标模块，定义在线节点数、节点命令队列深度及gRPC流数量等业务指标，并按配置在独立的内部地址启动/metrics监听
+
+import (
+	"honey_server/internal/global"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// GrpcStreams 按方法统计的进行中的gRPC流数量（命令流、隧道流等）
+	GrpcStreams = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "honey_server_grpc_streams",
+		Help: "按方法统计的进行中的gRPC流数量",
+	}, []string{"method"})
+	// GrpcStreamTotal 按方法统计的gRPC流总数
+	GrpcStreamTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "honey_server_grpc_streams_total",
+		Help: "按方法统计的gRPC流总数",
+	}, []string{"method"})
+)
+
+// RegisterGauge 注册取值函数形式的指标，采集时调用fn获取当前值
+func RegisterGauge(name, help string, fn func() float64) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: name,
+		Help: help,
+	}, fn)
+}
+
+// Run 按配置在独立地址启动指标监听，不与对外的web服务共用端口，未配置监听地址时不启动
+func Run() {
+	addr := global.Config.Metrics.Addr
+	if addr == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		logrus.Infof("指标服务监听 %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logrus.Errorf("指标服务启动失败 %s", err)
+		}
+	}()
+}
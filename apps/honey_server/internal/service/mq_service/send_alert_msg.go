@@ -0,0 +1,23 @@
+package mq_service
+
+// File: honey_server/service/mq_service/send_alert_msg.go
+// Description: 消息队列服务模块，提供管理端告警发送能力，告警与节点上报的告警使用相同的消息格式投递到告警队列
+
+import "honey_server/internal/global"
+
+// AlertMsgType 告警MQ消息结构体，与节点上报的告警消息格式一致
+type AlertMsgType struct {
+	ID        string `json:"id"`        // 告警唯一标识，告警服务作为ES文档ID去重
+	NodeUid   string `json:"nodeUid"`   // 节点唯一标识
+	SrcIp     string `json:"srcIp"`     // 源IP地址
+	DestIp    string `json:"destIp"`    // 目标IP地址
+	Timestamp string `json:"timestamp"` // 告警发生时间
+	Signature string `json:"signature"` // 告警描述
+	Level     int8   `json:"level"`     // 告警级别
+	Payload   string `json:"payload"`   // 告警详情
+}
+
+// SendAlertMsg 发送告警消息到MQ告警队列
+func SendAlertMsg(data AlertMsgType) error {
+	return sendQueueMessage(global.Config.MQ.AlertTopic, data)
+}
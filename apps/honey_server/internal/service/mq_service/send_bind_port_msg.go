@@ -17,10 +17,12 @@ type BindPortRequest struct {
 
 // PortInfo 单个端口的绑定信息结构体
 type PortInfo struct {
-	IP       string `json:"ip"`       // 关联的诱捕IP地址
-	Port     int    `json:"port"`     // 对外暴露的诱捕端口号
-	DestIP   string `json:"destIP"`   // 目标转发服务的IP地址
-	DestPort int    `json:"destPort"` // 目标转发服务的端口号
+	IP        string `json:"ip"`        // 关联的诱捕IP地址
+	Port      int    `json:"port"`      // 对外暴露的诱捕端口号
+	DestIP    string `json:"destIP"`    // 目标转发服务的IP地址
+	DestPort  int    `json:"destPort"`  // 目标转发服务的端口号
+	Agreement int8   `json:"agreement"` // 通信协议 1 TCP 2 UDP
+	Emulator  string `json:"emulator"`  // 节点内置模拟器名称，非空时不转发到虚拟服务
 }
 
 // SendBindPortMsg 发送绑定端口的消息到RabbitMQ
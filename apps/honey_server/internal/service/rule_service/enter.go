@@ -0,0 +1,119 @@
+package rule_service
+
+// File: honey_server/service/rule_service/enter.go
+// Description: Suricata规则集处理模块，负责统计规则签名、按签名覆盖配置生成下发给节点的规则内容及阈值配置
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"honey_server/internal/models"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sidRegexp 匹配规则中的签名ID
+var sidRegexp = regexp.MustCompile(`[(;]\s*sid\s*:\s*(\d+)\s*;`)
+
+// actionList Suricata规则动作，规则行以动作开头
+var actionList = []string{"alert", "drop", "pass", "reject", "rejectsrc", "rejectdst", "rejectboth"}
+
+// ParseSid 解析规则行的签名ID，enabled表示该规则是否处于启用状态（未被注释）
+func ParseSid(line string) (sid int, enabled bool, ok bool) {
+	text := strings.TrimSpace(line)
+	enabled = true
+	if strings.HasPrefix(text, "#") {
+		enabled = false
+		text = strings.TrimSpace(strings.TrimLeft(text, "#"))
+	}
+	if !isRule(text) {
+		return 0, false, false
+	}
+	match := sidRegexp.FindStringSubmatch(text)
+	if match == nil {
+		return 0, false, false
+	}
+	sid, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false, false
+	}
+	return sid, enabled, true
+}
+
+// isRule 判断文本是否为规则语句
+func isRule(text string) bool {
+	for _, action := range actionList {
+		if strings.HasPrefix(text, action+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// SidMap 统计规则文件中的签名ID，值为该签名是否启用
+func SidMap(content []byte) map[int]bool {
+	sidMap := map[int]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		sid, enabled, ok := ParseSid(scanner.Text())
+		if !ok {
+			continue
+		}
+		sidMap[sid] = sidMap[sid] || enabled
+	}
+	return sidMap
+}
+
+// Render 按签名覆盖配置生成下发给节点的规则内容及阈值配置
+// 禁用的签名在规则内容中注释掉，阈值配置生成threshold.config的threshold语句
+func Render(model models.RuleSetModel) (rules []byte, threshold string, err error) {
+	content, err := os.ReadFile(model.Path)
+	if err != nil {
+		return nil, "", fmt.Errorf("读取规则文件失败 %w", err)
+	}
+
+	disableMap := map[int]bool{}
+	var thresholdList []string
+	for _, override := range model.Overrides {
+		if override.Disable {
+			disableMap[override.Sid] = true
+			continue
+		}
+		if t := override.Threshold; t != nil {
+			thresholdList = append(thresholdList, fmt.Sprintf("threshold gen_id 1, sig_id %d, type %s, track %s, count %d, seconds %d",
+				override.Sid, t.Type, t.Track, t.Count, t.Seconds))
+		}
+	}
+
+	var buf bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if sid, enabled, ok := ParseSid(line); ok && enabled && disableMap[sid] {
+			line = "# " + line
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, "", fmt.Errorf("解析规则文件失败 %w", err)
+	}
+
+	if len(thresholdList) > 0 {
+		threshold = strings.Join(thresholdList, "\n") + "\n"
+	}
+	return buf.Bytes(), threshold, nil
+}
+
+// Hash 计算下发内容摘要，节点据此判断规则是否需要更新
+func Hash(rules []byte, threshold string) string {
+	h := sha256.New()
+	h.Write(rules)
+	h.Write([]byte(threshold))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,213 @@
+package mux
+
+// File: honey_server/utils/mux/conn.go
+// Description: 多路复用隧道工具，在单个gRPC双向流上承载多个逻辑会话，负责数据帧的收发分发、会话管理及发送背压
+
+import (
+	"errors"
+	"honey_server/internal/rpc/node_rpc"
+	"io"
+	"sync"
+)
+
+// 数据帧类型
+const (
+	FrameOpen   int32 = 1 // 打开会话
+	FrameData   int32 = 2 // 数据
+	FrameClose  int32 = 3 // 关闭会话
+	FrameWindow int32 = 4 // 窗口更新
+)
+
+const (
+	InitialWindow = 256 << 10 // 每个会话的发送窗口（字节），接收方最多缓存该大小的未消费数据
+	MaxChunkSize  = 16 << 10  // 单个数据帧的最大载荷，序列化后不超过gRPC 32KB缓冲区档位，避免收发时使用并清零1MB缓冲区
+)
+
+var (
+	ErrConnClosed    = errors.New("隧道连接已关闭")
+	ErrSessionClosed = errors.New("隧道会话已关闭")
+)
+
+// bufPool 数据帧缓冲区池，数据帧发送完成后归还
+var bufPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, MaxChunkSize)
+		return &buf
+	},
+}
+
+// FrameStream 承载数据帧的双向流，节点侧及管理端侧的gRPC流均满足该接口
+type FrameStream interface {
+	Send(*node_rpc.MuxFrame) error
+	Recv() (*node_rpc.MuxFrame, error)
+}
+
+// Conn 多路复用隧道连接，对应一个gRPC双向流
+type Conn struct {
+	stream    FrameStream            // 底层gRPC双向流
+	accept    func(session *Session) // 对端打开会话时的处理函数，为空时拒绝对端打开会话
+	sendLock  sync.Mutex             // 发送锁，gRPC流不支持并发发送
+	lock      sync.Mutex             // 会话表读写锁
+	sessions  map[uint32]*Session    // 会话id -> 会话
+	nextID    uint32                 // 本端打开会话的id计数
+	done      chan struct{}          // 连接关闭信号
+	closeOnce sync.Once              // 保证连接只关闭一次
+	err       error                  // 连接关闭原因
+}
+
+// NewConn 基于双向流创建多路复用隧道连接，调用Run后开始收发数据帧
+func NewConn(stream FrameStream, accept func(session *Session)) *Conn {
+	return &Conn{
+		stream:   stream,
+		accept:   accept,
+		sessions: map[uint32]*Session{},
+		done:     make(chan struct{}),
+	}
+}
+
+// Run 启动数据帧的收发，阻塞直到流断开，返回时全部会话均已关闭
+func (c *Conn) Run() error {
+	go func() {
+		c.close(c.readLoop())
+	}()
+	<-c.done
+	return c.err
+}
+
+// Done 返回连接关闭信号
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// SessionCount 当前承载的会话数
+func (c *Conn) SessionCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.sessions)
+}
+
+// Open 打开到目标地址的会话，目标连接由对端建立，srcAddr及destAddr为会话的来源地址及诱捕地址
+func (c *Conn) Open(address, srcAddr, destAddr string) (*Session, error) {
+	c.lock.Lock()
+	select {
+	case <-c.done:
+		c.lock.Unlock()
+		return nil, ErrConnClosed
+	default:
+	}
+	c.nextID++
+	session := newSession(c, c.nextID, address)
+	session.SrcAddr, session.DestAddr = srcAddr, destAddr
+	c.sessions[session.ID] = session
+	c.lock.Unlock()
+
+	err := c.send(&node_rpc.MuxFrame{
+		SessionID: session.ID,
+		Type:      FrameOpen,
+		Address:   address,
+		SrcAddr:   srcAddr,
+		DestAddr:  destAddr,
+	})
+	if err != nil {
+		c.remove(session.ID)
+		return nil, err
+	}
+	return session, nil
+}
+
+// readLoop 读取数据帧并分发到对应会话，不在此处发送数据帧以免阻塞读取
+func (c *Conn) readLoop() error {
+	for {
+		frame, err := c.stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		switch frame.Type {
+		case FrameOpen:
+			if c.accept == nil {
+				go c.send(&node_rpc.MuxFrame{
+					SessionID: frame.SessionID,
+					Type:      FrameClose,
+					ErrMsg:    "不支持打开会话",
+				})
+				continue
+			}
+			c.lock.Lock()
+			if _, ok := c.sessions[frame.SessionID]; ok {
+				c.lock.Unlock()
+				continue
+			}
+			session := newSession(c, frame.SessionID, frame.Address)
+			session.SrcAddr, session.DestAddr = frame.SrcAddr, frame.DestAddr
+			c.sessions[session.ID] = session
+			c.lock.Unlock()
+			go c.accept(session)
+		case FrameData:
+			// 本端已关闭的会话可能仍会收到在途的数据帧，直接丢弃
+			if session := c.session(frame.SessionID); session != nil {
+				session.push(frame.Chunk)
+			}
+		case FrameClose:
+			if session := c.session(frame.SessionID); session != nil {
+				session.remoteClose(frame.ErrMsg)
+			}
+		case FrameWindow:
+			if session := c.session(frame.SessionID); session != nil {
+				session.addWindow(int(frame.Window))
+			}
+		}
+	}
+}
+
+// send 发送数据帧，底层流发送受阻时阻塞调用方形成背压
+func (c *Conn) send(frame *node_rpc.MuxFrame) error {
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+	select {
+	case <-c.done:
+		return ErrConnClosed
+	default:
+	}
+	if err := c.stream.Send(frame); err != nil {
+		c.close(err)
+		return ErrConnClosed
+	}
+	return nil
+}
+
+// session 根据id查找会话
+func (c *Conn) session(id uint32) *Session {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sessions[id]
+}
+
+// remove 从会话表中移除会话
+func (c *Conn) remove(id uint32) {
+	c.lock.Lock()
+	delete(c.sessions, id)
+	c.lock.Unlock()
+}
+
+// close 关闭连接并中止全部会话
+func (c *Conn) close(err error) {
+	c.closeOnce.Do(func() {
+		c.lock.Lock()
+		c.err = err
+		close(c.done)
+		sessions := c.sessions
+		c.sessions = map[uint32]*Session{}
+		c.lock.Unlock()
+
+		if err == nil {
+			err = ErrConnClosed
+		}
+		for _, session := range sessions {
+			session.abort(err)
+		}
+	})
+}
@@ -0,0 +1,259 @@
+package mux
+
+// File: honey_server/utils/mux/session.go
+// Description: 多路复用隧道会话，实现基于发送窗口的单会话流控，接收方消费数据后通告窗口增量，慢速会话不会阻塞同一连接上的其他会话
+
+import (
+	"errors"
+	"honey_server/internal/rpc/node_rpc"
+	"io"
+	"sync"
+)
+
+// windowUpdateThreshold 累计消费达到该字节数后向对端通告窗口增量
+const windowUpdateThreshold = InitialWindow / 4
+
+// Session 多路复用隧道上的单个逻辑会话
+type Session struct {
+	ID       uint32 // 会话id
+	Address  string // 目标地址
+	SrcAddr  string // 来源地址（攻击方ip:port）
+	DestAddr string // 诱捕地址（诱捕ip:port）
+
+	conn         *Conn      // 所属隧道连接
+	lock         sync.Mutex // 会话状态读写锁
+	cond         *sync.Cond // 接收数据、窗口变化及关闭通知
+	queue        [][]byte   // 已接收未消费的数据块
+	buffered     int        // 已接收未消费的字节数
+	window       int        // 剩余发送窗口
+	consumed     int        // 已消费未通告的字节数
+	closed       bool       // 本端是否已关闭
+	remoteClosed bool       // 对端是否已关闭
+	err          error      // 会话异常关闭原因
+}
+
+// newSession 创建会话，发送窗口为初始窗口大小
+func newSession(conn *Conn, id uint32, address string) *Session {
+	s := &Session{
+		ID:      id,
+		Address: address,
+		conn:    conn,
+		window:  InitialWindow,
+	}
+	s.cond = sync.NewCond(&s.lock)
+	return s
+}
+
+// push 缓存对端发送的数据块，对端超出发送窗口时中止会话
+func (s *Session) push(chunk []byte) {
+	if len(chunk) == 0 {
+		return
+	}
+	s.lock.Lock()
+	if s.closed || s.remoteClosed {
+		s.lock.Unlock()
+		return
+	}
+	if s.buffered+len(chunk) > InitialWindow {
+		s.lock.Unlock()
+		go s.CloseWithError(errors.New("对端超出流控窗口"))
+		return
+	}
+	s.queue = append(s.queue, chunk)
+	s.buffered += len(chunk)
+	s.cond.Broadcast()
+	s.lock.Unlock()
+}
+
+// remoteClose 对端关闭会话，已缓存的数据仍可读取
+func (s *Session) remoteClose(errMsg string) {
+	s.lock.Lock()
+	s.remoteClosed = true
+	if errMsg != "" {
+		s.err = errors.New(errMsg)
+	}
+	s.cond.Broadcast()
+	s.lock.Unlock()
+	s.conn.remove(s.ID)
+}
+
+// addWindow 增加发送窗口并唤醒等待窗口的发送方
+func (s *Session) addWindow(n int) {
+	s.lock.Lock()
+	s.window += n
+	s.cond.Broadcast()
+	s.lock.Unlock()
+}
+
+// abort 隧道连接断开时中止会话
+func (s *Session) abort(err error) {
+	s.lock.Lock()
+	if !s.closed {
+		s.closed = true
+		s.err = err
+		s.queue = nil
+		s.cond.Broadcast()
+	}
+	s.lock.Unlock()
+}
+
+// acquire 申请不超过max字节的发送窗口，窗口耗尽时阻塞直到对端通告窗口增量或会话关闭
+func (s *Session) acquire(max int) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for s.window == 0 && !s.closed && !s.remoteClosed {
+		s.cond.Wait()
+	}
+	if s.closed || s.remoteClosed {
+		if s.err != nil {
+			return 0, s.err
+		}
+		return 0, ErrSessionClosed
+	}
+	n := min(s.window, max)
+	s.window -= n
+	return n, nil
+}
+
+// release 归还未使用的发送窗口
+func (s *Session) release(n int) {
+	if n <= 0 {
+		return
+	}
+	s.addWindow(n)
+}
+
+// sendData 发送数据帧，buf为承载数据的池化缓冲区，发送完成后归还
+func (s *Session) sendData(buf *[]byte, n int) error {
+	err := s.conn.send(&node_rpc.MuxFrame{
+		SessionID: s.ID,
+		Type:      FrameData,
+		Chunk:     (*buf)[:n],
+	})
+	// Send返回时数据帧已完成序列化，缓冲区可以复用
+	bufPool.Put(buf)
+	return err
+}
+
+// Write 向对端发送数据，受发送窗口限制
+func (s *Session) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		size, err := s.acquire(min(len(p), MaxChunkSize))
+		if err != nil {
+			return n, err
+		}
+		buf := bufPool.Get().(*[]byte)
+		copy(*buf, p[:size])
+		if err = s.sendData(buf, size); err != nil {
+			return n, err
+		}
+		n += size
+		p = p[size:]
+	}
+	return n, nil
+}
+
+// ReadFrom 从r读取数据并发送到对端，直到r读取结束或会话关闭
+func (s *Session) ReadFrom(r io.Reader) (n int64, err error) {
+	for {
+		size, err := s.acquire(MaxChunkSize)
+		if err != nil {
+			return n, err
+		}
+		buf := bufPool.Get().(*[]byte)
+		nr, rerr := r.Read((*buf)[:size])
+		s.release(size - nr)
+		if nr > 0 {
+			if err = s.sendData(buf, nr); err != nil {
+				return n, err
+			}
+			n += int64(nr)
+		} else {
+			bufPool.Put(buf)
+		}
+		if rerr != nil {
+			if rerr == io.EOF {
+				return n, nil
+			}
+			return n, rerr
+		}
+	}
+}
+
+// WriteTo 将对端发送的数据写入w，直到对端关闭会话且缓存数据写完，或本端关闭会话
+func (s *Session) WriteTo(w io.Writer) (n int64, err error) {
+	for {
+		s.lock.Lock()
+		for len(s.queue) == 0 && !s.closed && !s.remoteClosed {
+			s.cond.Wait()
+		}
+		if s.closed || len(s.queue) == 0 {
+			err = s.err
+			s.lock.Unlock()
+			return n, err
+		}
+		chunk := s.queue[0]
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		s.buffered -= len(chunk)
+		s.lock.Unlock()
+
+		nw, err := w.Write(chunk)
+		n += int64(nw)
+		if err != nil {
+			return n, err
+		}
+		s.consume(len(chunk))
+	}
+}
+
+// consume 记录已消费的字节数，累计达到阈值后向对端通告窗口增量
+func (s *Session) consume(size int) {
+	s.lock.Lock()
+	s.consumed += size
+	if s.consumed < windowUpdateThreshold || s.closed || s.remoteClosed {
+		s.lock.Unlock()
+		return
+	}
+	window := s.consumed
+	s.consumed = 0
+	s.lock.Unlock()
+
+	s.conn.send(&node_rpc.MuxFrame{
+		SessionID: s.ID,
+		Type:      FrameWindow,
+		Window:    uint32(window),
+	})
+}
+
+// Close 关闭会话并通知对端
+func (s *Session) Close() error {
+	return s.CloseWithError(nil)
+}
+
+// CloseWithError 关闭会话并将关闭原因通知对端
+func (s *Session) CloseWithError(err error) error {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return nil
+	}
+	s.closed = true
+	notify := !s.remoteClosed
+	s.queue = nil
+	s.cond.Broadcast()
+	s.lock.Unlock()
+	s.conn.remove(s.ID)
+
+	if !notify {
+		return nil
+	}
+	frame := &node_rpc.MuxFrame{
+		SessionID: s.ID,
+		Type:      FrameClose,
+	}
+	if err != nil {
+		frame.ErrMsg = err.Error()
+	}
+	return s.conn.send(frame)
+}
@@ -0,0 +1,63 @@
+package proxyproto
+
+// File: honey_server/utils/proxyproto/enter.go
+// Description: PROXY协议v2工具，生成携带原始来源地址及目的地址的二进制协议头，供诱捕服务获取真实的攻击方地址及被访问的诱捕地址
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+)
+
+// signature PROXY协议v2固定签名
+var signature = []byte{0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A}
+
+const (
+	cmdLocal   = 0x20 // 版本2，LOCAL命令（不携带地址，接收方使用连接自身地址）
+	cmdProxy   = 0x21 // 版本2，PROXY命令
+	familyTCP4 = 0x11 // TCP over IPv4
+	familyTCP6 = 0x21 // TCP over IPv6
+)
+
+// HeaderTCP 生成TCP连接的PROXY协议v2头，srcAddr为攻击方地址，destAddr为诱捕地址（ip:port）
+// 地址无法解析时返回LOCAL命令头，任一地址为IPv6时两端均按IPv6编码
+func HeaderTCP(srcAddr, destAddr string) []byte {
+	src, srcErr := netip.ParseAddrPort(srcAddr)
+	dest, destErr := netip.ParseAddrPort(destAddr)
+	if srcErr != nil || destErr != nil {
+		return header(cmdLocal, 0, nil)
+	}
+
+	srcIP, destIP := src.Addr().Unmap(), dest.Addr().Unmap()
+	if srcIP.Is4() && destIP.Is4() {
+		body := make([]byte, 0, 12)
+		body = append(body, srcIP.AsSlice()...)
+		body = append(body, destIP.AsSlice()...)
+		body = binary.BigEndian.AppendUint16(body, src.Port())
+		body = binary.BigEndian.AppendUint16(body, dest.Port())
+		return header(cmdProxy, familyTCP4, body)
+	}
+
+	srcIP16, destIP16 := srcIP.As16(), destIP.As16()
+	body := make([]byte, 0, 36)
+	body = append(body, srcIP16[:]...)
+	body = append(body, destIP16[:]...)
+	body = binary.BigEndian.AppendUint16(body, src.Port())
+	body = binary.BigEndian.AppendUint16(body, dest.Port())
+	return header(cmdProxy, familyTCP6, body)
+}
+
+// WriteTCP 向目标连接写入PROXY协议v2头
+func WriteTCP(conn net.Conn, srcAddr, destAddr string) error {
+	_, err := conn.Write(HeaderTCP(srcAddr, destAddr))
+	return err
+}
+
+// header 拼接签名、版本命令、地址族、地址长度及地址信息
+func header(cmd, family byte, body []byte) []byte {
+	buf := make([]byte, 0, len(signature)+4+len(body))
+	buf = append(buf, signature...)
+	buf = append(buf, cmd, family)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(body)))
+	return append(buf, body...)
+}
@@ -3,6 +3,13 @@ package utils
 // File: honey_server/utils/utils.go
 // Description: 通用工具函数模块
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+)
+
 // InList 检查指定元素是否存在于切片中（支持任意可比较类型）
 func InList[T comparable](list []T, key T) bool {
 	for _, t := range list {
@@ -12,3 +19,18 @@ func InList[T comparable](list []T, key T) bool {
 	}
 	return false
 }
+
+// FileSha256 计算文件内容的sha256摘要（十六进制）
+func FileSha256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
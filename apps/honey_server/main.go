@@ -7,6 +7,7 @@ import (
 	"honey_server/internal/routers"
 	"honey_server/internal/service/cron_service"
 	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/service/metrics_service"
 	"honey_server/internal/service/mq_service"
 )
 
@@ -23,5 +24,6 @@ func main() {
 	flags.Run()                          // 运行命令行参数
 	cron_service.Run()                   // 启动定时任务
 	go grpc_service.Run()                // 启动gRPC服务
+	metrics_service.Run()                // 启动指标监听
 	routers.Run()                        // 启动路由
 }
@@ -15,8 +15,9 @@ import (
 
 // CreateRequest 主机模板创建请求参数结构体
 type CreateRequest struct {
-	Title    string                      `json:"title" binding:"required"` // 主机模板名称（必需）
-	PortList models.HostTemplatePortList `json:"portList" binding:"dive"`  // 端口列表（需校验端口唯一性及服务有效性）
+	Title         string                      `json:"title" binding:"required"` // 主机模板名称（必需）
+	DeviceProfile string                      `json:"deviceProfile"`            // 设备画像（可选，取值见设备画像选项列表）
+	PortList      models.HostTemplatePortList `json:"portList" binding:"dive"`  // 端口列表（需校验端口唯一性及服务有效性）
 }
 
 // CreateView 主机模板创建接口处理函数
@@ -40,22 +41,36 @@ func (HostTemplateApi) CreateView(c *gin.Context) {
 		return
 	}
 
-	// 校验端口唯一性及收集关联服务ID
+	// 校验设备画像（须在设备画像列表中），并保存画像对应的厂商信息供节点生成MAC地址
+	var deviceVendor models.DeviceVendor
+	if cr.DeviceProfile != "" {
+		profile, ok := models.FindDeviceProfile(cr.DeviceProfile)
+		if !ok {
+			log.WithFields(map[string]interface{}{
+				"device_profile": cr.DeviceProfile,
+			}).Warn("device profile not found") // 设备画像不存在
+			response.FailWithMsg(fmt.Sprintf("设备画像 %s 不存在", cr.DeviceProfile), c)
+			return
+		}
+		deviceVendor = profile.DeviceVendor
+	}
+
+	// 收集关联服务ID（使用内置模拟器的端口不关联服务）
 	var serviceIDList []uint
-	portMap := make(map[int]bool)
 	for _, port := range cr.PortList {
+		if port.Emulator != "" {
+			if port.ServiceID != 0 {
+				log.WithFields(map[string]interface{}{
+					"port":       port.Port,
+					"service_id": port.ServiceID,
+					"emulator":   port.Emulator,
+				}).Warn("port references both service and emulator") // 端口同时关联了服务和模拟器
+				response.FailWithMsg(fmt.Sprintf("端口 %d 不能同时关联虚拟服务和模拟器", port.Port), c)
+				return
+			}
+			continue
+		}
 		serviceIDList = append(serviceIDList, port.ServiceID)
-		portMap[port.Port] = true // 用Map去重校验端口唯一性
-	}
-
-	// 检查是否存在重复端口
-	if len(portMap) != len(cr.PortList) {
-		log.WithFields(map[string]interface{}{
-			"port_count":        len(cr.PortList),
-			"unique_port_count": len(portMap),
-		}).Warn("duplicate ports detected in request") // 找到重复的端口
-		response.FailWithMsg("端口存在重复", c)
-		return
 	}
 
 	// 查询关联的虚拟服务记录并构建映射
@@ -77,6 +92,9 @@ func (HostTemplateApi) CreateView(c *gin.Context) {
 
 	// 校验所有关联服务是否存在
 	for _, port := range cr.PortList {
+		if port.Emulator != "" {
+			continue
+		}
 		if _, exists := serviceMap[port.ServiceID]; !exists {
 			log.WithFields(map[string]interface{}{
 				"service_id":    port.ServiceID,
@@ -87,10 +105,32 @@ func (HostTemplateApi) CreateView(c *gin.Context) {
 		}
 	}
 
+	// 校验端口唯一性（同协议下端口不可重复，TCP与UDP可复用同一端口号）
+	portMap := make(map[models.PortKey]bool)
+	for _, port := range cr.PortList {
+		agreement := serviceMap[port.ServiceID].Agreement
+		if port.Emulator != "" {
+			agreement = 1 // 内置模拟器均为TCP
+		}
+		portMap[models.PortKey{Agreement: agreement, Port: port.Port}] = true
+	}
+
+	// 检查是否存在重复端口
+	if len(portMap) != len(cr.PortList) {
+		log.WithFields(map[string]interface{}{
+			"port_count":        len(cr.PortList),
+			"unique_port_count": len(portMap),
+		}).Warn("duplicate ports detected in request") // 找到重复的端口
+		response.FailWithMsg("端口存在重复", c)
+		return
+	}
+
 	// 组装主机模板数据并入库
 	model := models.HostTemplateModel{
-		Title:    cr.Title,
-		PortList: cr.PortList,
+		Title:         cr.Title,
+		DeviceProfile: cr.DeviceProfile,
+		DeviceVendor:  deviceVendor,
+		PortList:      cr.PortList,
 	}
 	if err := global.DB.Create(&model).Error; err != nil {
 		log.WithFields(map[string]interface{}{
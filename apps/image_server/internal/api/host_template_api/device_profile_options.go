@@ -0,0 +1,29 @@
+package host_template_api
+
+// File: image_server/api/host_template_api/device_profile_options.go
+// Description: 设备画像选项列表API接口，供主机模板选择诱捕ip伪装的设备类型
+
+import (
+	"image_server/internal/models"
+	"image_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceProfileOptionsResponse 设备画像选项响应结构体
+type DeviceProfileOptionsResponse struct {
+	Label string `json:"label"` // 选项展示文本
+	Value string `json:"value"` // 选项值（画像名称）
+}
+
+// DeviceProfileOptionsView 获取设备画像选项列表接口
+func (HostTemplateApi) DeviceProfileOptionsView(c *gin.Context) {
+	var list = make([]DeviceProfileOptionsResponse, 0)
+	for _, profile := range models.DeviceProfileList {
+		list = append(list, DeviceProfileOptionsResponse{
+			Label: profile.Title,
+			Value: profile.Name,
+		})
+	}
+	response.OkWithData(list, c)
+}
@@ -0,0 +1,29 @@
+package host_template_api
+
+// File: image_server/api/host_template_api/emulator_options.go
+// Description: 节点内置模拟器选项列表API接口，供主机模板端口选择模拟器代替虚拟服务
+
+import (
+	"image_server/internal/models"
+	"image_server/internal/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmulatorOptionsResponse 模拟器选项响应结构体
+type EmulatorOptionsResponse struct {
+	Label string `json:"label"` // 选项展示文本
+	Value string `json:"value"` // 选项值（模拟器名称）
+}
+
+// EmulatorOptionsView 获取节点内置模拟器选项列表接口
+func (HostTemplateApi) EmulatorOptionsView(c *gin.Context) {
+	var list = make([]EmulatorOptionsResponse, 0)
+	for _, name := range models.EmulatorList {
+		list = append(list, EmulatorOptionsResponse{
+			Label: name + "模拟器",
+			Value: name,
+		})
+	}
+	response.OkWithData(list, c)
+}
@@ -25,6 +25,8 @@ type HostTemplatePortInfo struct {
 	ServiceID     uint   `json:"serviceID"`     // 关联虚拟服务ID
 	ServiceTitle  string `json:"serviceTitle"`  // 关联虚拟服务名称
 	ServiceStatus int8   `json:"serviceStatus"` // 关联虚拟服务状态
+	Agreement     int8   `json:"agreement"`     // 通信协议（取自关联虚拟服务）
+	Emulator      string `json:"emulator"`      // 节点内置模拟器名称
 }
 
 // ListView 主机模板列表查询接口处理函数
@@ -63,11 +65,22 @@ func (HostTemplateApi) ListView(c *gin.Context) {
 	for _, model := range _list {
 		portList := make([]HostTemplatePortInfo, 0)
 		for _, port := range model.PortList {
+			if port.Emulator != "" {
+				portList = append(portList, HostTemplatePortInfo{
+					Port:          port.Port,
+					ServiceTitle:  port.Emulator + "模拟器",
+					ServiceStatus: 1, // 内置模拟器随节点运行
+					Agreement:     1,
+					Emulator:      port.Emulator,
+				})
+				continue
+			}
 			portList = append(portList, HostTemplatePortInfo{
 				Port:          port.Port,
 				ServiceID:     port.ServiceID,
 				ServiceTitle:  serviceMap[port.ServiceID].Title,
 				ServiceStatus: serviceMap[port.ServiceID].Status,
+				Agreement:     serviceMap[port.ServiceID].Agreement,
 			})
 		}
 		list = append(list, ListResponse{
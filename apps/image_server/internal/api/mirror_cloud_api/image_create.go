@@ -22,13 +22,14 @@ import (
 
 // ImageCreateRequest 镜像创建接口请求参数结构体
 type ImageCreateRequest struct {
-	ImageID   string `json:"imageID" binding:"required"`              // 镜像ID（来自ImageSee接口，仅作备用标识）
-	ImageName string `json:"imageName" binding:"required"`            // 镜像仓库名称
-	ImageTag  string `json:"imageTag" binding:"required"`             // 镜像标签
-	ImagePath string `json:"imagePath" binding:"required"`            // 镜像临时文件存储路径
-	Title     string `json:"title" binding:"required"`                // 镜像展示别名
-	Port      int    `json:"port" binding:"required,min=1,max=65535"` // 镜像运行端口
-	Agreement int8   `json:"agreement" binding:"required,oneof=1"`    // 镜像通信协议：1-TCP协议（当前仅支持TCP）
+	ImageID       string `json:"imageID" binding:"required"`              // 镜像ID（来自ImageSee接口，仅作备用标识）
+	ImageName     string `json:"imageName" binding:"required"`            // 镜像仓库名称
+	ImageTag      string `json:"imageTag" binding:"required"`             // 镜像标签
+	ImagePath     string `json:"imagePath" binding:"required"`            // 镜像临时文件存储路径
+	Title         string `json:"title" binding:"required"`                // 镜像展示别名
+	Port          int    `json:"port" binding:"required,min=1,max=65535"` // 镜像运行端口
+	Agreement     int8   `json:"agreement" binding:"required,oneof=1 2"`  // 镜像通信协议：1-TCP协议 2-UDP协议
+	ProxyProtocol bool   `json:"proxyProtocol"`                           // 是否向诱捕服务发送PROXY协议v2头（仅TCP）
 }
 
 // ImageCreateView 镜像创建接口处理函数
@@ -154,6 +155,7 @@ func (MirrorCloudApi) ImageCreateView(c *gin.Context) {
 		Title:         cr.Title,
 		Port:          cr.Port,
 		Agreement:     cr.Agreement,
+		ProxyProtocol: cr.ProxyProtocol && cr.Agreement == 1,
 		Status:        1, // 镜像状态：1-可用（默认创建后为可用状态）
 	}
 
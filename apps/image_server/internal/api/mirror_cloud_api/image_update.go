@@ -14,13 +14,14 @@ import (
 
 // ImageUpdateRequest 镜像更新接口请求参数结构体
 type ImageUpdateRequest struct {
-	ID        uint   `json:"id"`                                      // 镜像ID
-	Title     string `json:"title" binding:"required"`                // 镜像别名
-	Port      int    `json:"port" binding:"required,min=1,max=65535"` // 镜像运行端口
-	Agreement int8   `json:"agreement" binding:"required,oneof=1"`    // 镜像通信协议（1为固定协议类型）
-	Status    int8   `json:"status" binding:"required,oneof=1 2"`     // 镜像状态
-	Logo      string `json:"logo"`                                    // 镜像logo
-	Desc      string `json:"desc"`                                    // 镜像描述
+	ID            uint   `json:"id"`                                      // 镜像ID
+	Title         string `json:"title" binding:"required"`                // 镜像别名
+	Port          int    `json:"port" binding:"required,min=1,max=65535"` // 镜像运行端口
+	Agreement     int8   `json:"agreement" binding:"required,oneof=1 2"`  // 镜像通信协议：1-TCP协议 2-UDP协议
+	Status        int8   `json:"status" binding:"required,oneof=1 2"`     // 镜像状态
+	Logo          string `json:"logo"`                                    // 镜像logo
+	Desc          string `json:"desc"`                                    // 镜像描述
+	ProxyProtocol bool   `json:"proxyProtocol"`                           // 是否向诱捕服务发送PROXY协议v2头（仅TCP）
 }
 
 // ImageUpdateView 镜像更新接口处理函数
@@ -72,7 +73,12 @@ func (MirrorCloudApi) ImageUpdateView(c *gin.Context) {
 		"update_fields": updateData,
 	}).Info("initiating image update operation") // 初始化镜像更新操作
 
-	if err := global.DB.Model(&model).Updates(updateData).Error; err != nil {
+	err := global.DB.Model(&model).Updates(updateData).Error
+	if err == nil {
+		// 结构体更新会忽略零值，PROXY协议开关单独写入以支持关闭
+		err = global.DB.Model(&model).Update("proxy_protocol", cr.ProxyProtocol && cr.Agreement == 1).Error
+	}
+	if err != nil {
 		log.WithFields(map[string]interface{}{
 			"image_id":    model.ID,
 			"update_data": updateData,
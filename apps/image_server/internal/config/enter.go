@@ -14,6 +14,7 @@ type Config struct {
 	Jwt       Jwt      `yaml:"jwt"`       // jwt配置信息
 	WhiteList []string `yaml:"whiteList"` // 路由白名单
 	VsNet     VsNet    `yaml:"vsNet"`     // 虚拟网络配置信息
+	Metrics   Metrics  `yaml:"metrics"`   // Prometheus指标配置信息
 }
 
 // DB 数据库连接配置结构体
@@ -72,3 +73,8 @@ type VsNet struct {
 	Prefix string `yaml:"prefix" json:"prefix"` // 虚拟网络前缀
 	Net    string `yaml:"net" json:"net"`       // 虚拟网络地址
 }
+
+// Metrics Prometheus指标配置结构体
+type Metrics struct {
+	Addr string `yaml:"addr"` // /metrics监听地址，为空时不启动；只监听本机或内部网络地址，不与web服务共用端口
+}
@@ -3,8 +3,10 @@ package models
 // HostTemplateModel 主机模板模型
 type HostTemplateModel struct {
 	Model
-	Title    string               `gorm:"size:64" json:"title"`            // 主机模板名称
-	PortList HostTemplatePortList `gorm:"serializer:json" json:"portList"` // 开放端口组
+	Title         string               `gorm:"size:64" json:"title"`                // 主机模板名称
+	DeviceProfile string               `gorm:"size:32" json:"deviceProfile"`        // 设备画像（诱捕ip按画像使用对应厂商的MAC地址，为空时随机分配）
+	DeviceVendor  DeviceVendor         `gorm:"serializer:json" json:"deviceVendor"` // 设备画像的厂商信息（保存模板时取自设备画像列表，部署时下发给节点）
+	PortList      HostTemplatePortList `gorm:"serializer:json" json:"portList"`     // 开放端口组
 }
 
 // HostTemplatePortList 主机模板端口列表
@@ -12,6 +14,54 @@ type HostTemplatePortList []HostTemplatePort
 
 // HostTemplatePort 主机模板端口模型
 type HostTemplatePort struct {
-	Port      int  `json:"port" binding:"min=1,max=65535"` // 端口号
-	ServiceID uint `json:"serviceID" binding:"required"`   // 关联服务ID
+	Port      int    `json:"port" binding:"min=1,max=65535"`                                 // 端口号
+	ServiceID uint   `json:"serviceID" binding:"required_without=Emulator"`                  // 关联服务ID
+	Emulator  string `json:"emulator" binding:"omitempty,oneof=ssh telnet redis mysql http"` // 节点内置模拟器（与关联服务二选一）
+}
+
+// EmulatorList 节点内置的协议模拟器列表（均为TCP协议）
+var EmulatorList = []string{"ssh", "telnet", "redis", "mysql", "http"}
+
+// DeviceVendor 设备画像的厂商信息，节点据此从OUI数据库选取厂商OUI生成MAC地址
+type DeviceVendor struct {
+	VendorList  []string `json:"vendorList"`  // 厂商名称关键字，用于在OUI数据库中匹配厂商
+	FallbackOUI []string `json:"fallbackOUI"` // OUI数据库未匹配到时使用的内置OUI
+}
+
+// DeviceProfile 设备画像
+type DeviceProfile struct {
+	Name         string // 画像名称
+	Title        string // 画像展示名称
+	DeviceVendor        // 厂商信息
+}
+
+// DeviceProfileList 主机模板可选的设备画像列表，模板创建/更新按此列表校验，节点按模板保存的厂商信息生成MAC地址
+var DeviceProfileList = []DeviceProfile{
+	{Name: "hikvision_camera", Title: "海康威视摄像头", DeviceVendor: DeviceVendor{VendorList: []string{"Hikvision"}, FallbackOUI: []string{"2857BE", "4419B6", "BCAD28", "C056E3"}}},
+	{Name: "dahua_camera", Title: "大华摄像头", DeviceVendor: DeviceVendor{VendorList: []string{"Dahua"}, FallbackOUI: []string{"3CEF8C", "9002A9", "E0508B"}}},
+	{Name: "axis_camera", Title: "Axis摄像头", DeviceVendor: DeviceVendor{VendorList: []string{"Axis Communications"}, FallbackOUI: []string{"00408C", "ACCC8E", "B8A44F"}}},
+	{Name: "dell_server", Title: "Dell服务器", DeviceVendor: DeviceVendor{VendorList: []string{"Dell Inc"}, FallbackOUI: []string{"001422", "1866DA", "B82A72", "F8BC12"}}},
+	{Name: "supermicro_server", Title: "Supermicro服务器", DeviceVendor: DeviceVendor{VendorList: []string{"Super Micro Computer"}, FallbackOUI: []string{"002590", "0CC47A", "AC1F6B"}}},
+	{Name: "cisco_switch", Title: "Cisco交换机", DeviceVendor: DeviceVendor{VendorList: []string{"Cisco Systems"}, FallbackOUI: []string{"001B54", "0025B5", "58AC78", "70105C"}}},
+	{Name: "huawei_switch", Title: "华为交换机", DeviceVendor: DeviceVendor{VendorList: []string{"Huawei Technologies"}, FallbackOUI: []string{"00E0FC", "286ED4", "4846FB"}}},
+	{Name: "fortinet_firewall", Title: "Fortinet防火墙", DeviceVendor: DeviceVendor{VendorList: []string{"Fortinet"}, FallbackOUI: []string{"00090F", "085B0E", "906CAC"}}},
+	{Name: "hp_printer", Title: "HP打印机", DeviceVendor: DeviceVendor{VendorList: []string{"Hewlett Packard"}, FallbackOUI: []string{"3CD92B", "9457A5", "A0D3C1"}}},
+	{Name: "synology_nas", Title: "群晖NAS", DeviceVendor: DeviceVendor{VendorList: []string{"Synology"}, FallbackOUI: []string{"001132"}}},
+	{Name: "siemens_plc", Title: "西门子PLC", DeviceVendor: DeviceVendor{VendorList: []string{"Siemens"}, FallbackOUI: []string{"000E8C", "001B1B", "286336"}}},
+}
+
+// FindDeviceProfile 按名称查找设备画像
+func FindDeviceProfile(name string) (DeviceProfile, bool) {
+	for _, profile := range DeviceProfileList {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return DeviceProfile{}, false
+}
+
+// PortKey 主机模板端口唯一标识，同一模板内TCP与UDP可复用相同端口号
+type PortKey struct {
+	Agreement int8 // 通信协议（取自关联服务）
+	Port      int  // 端口号
 }
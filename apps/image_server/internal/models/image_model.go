@@ -23,6 +23,7 @@ type ImageModel struct {
 	Status        int8           `json:"status"`                       // 镜像状态 1 成功
 	Logo          string         `gorm:"size:256" json:"logo"`         // 镜像logo
 	Desc          string         `gorm:"size:512" json:"desc"`         // 镜像描述
+	ProxyProtocol bool           `json:"proxyProtocol"`                // 是否向诱捕服务发送PROXY协议v2头，携带攻击方地址及诱捕地址（仅TCP）
 }
 
 func (i *ImageModel) BeforeDelete(tx *gorm.DB) error {
@@ -25,6 +25,10 @@ func HostTemplateRouter(r *gin.RouterGroup) {
 	r.GET("host_template", middleware.BindQueryMiddleware[models.PageInfo], app.ListView)
 	// GET /host_template/options: 主机模板选项查询接口
 	r.GET("host_template/options", app.OptionsView)
+	// GET /host_template/emulator_options: 节点内置模拟器选项查询接口
+	r.GET("host_template/emulator_options", app.EmulatorOptionsView)
+	// GET /host_template/device_profile_options: 设备画像选项查询接口
+	r.GET("host_template/device_profile_options", app.DeviceProfileOptionsView)
 	// DELETE /host_template: 主机模板删除接口
 	// 绑定JSON请求参数并处理删除逻辑
 	r.DELETE("host_template", middleware.BindJsonMiddleware[models.IDListRequest], app.Remove)
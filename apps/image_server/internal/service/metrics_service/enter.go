@@ -0,0 +1,28 @@
+package metrics_service
+
+// File: image_server/service/metrics_service/enter.go
+// Description: 镜像服务Prometheus指标模块，暴露进程及Go运行时默认指标，并按配置在独立的内部地址启动/metrics监听
+
+import (
+	"image_server/internal/global"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// Run 按配置在独立地址启动指标监听，不与对外的web服务共用端口，未配置监听地址时不启动
+func Run() {
+	addr := global.Config.Metrics.Addr
+	if addr == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		logrus.Infof("指标服务监听 %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logrus.Errorf("指标服务启动失败 %s", err)
+		}
+	}()
+}
@@ -6,6 +6,7 @@ import (
 	"image_server/internal/global"
 	"image_server/internal/routers"
 	"image_server/internal/service/cron_service"
+	"image_server/internal/service/metrics_service"
 	"image_server/internal/service/vs_net_service"
 )
 
@@ -20,5 +21,6 @@ func main() {
 	flags.Run()                             // 运行命令行参数
 	cron_service.Run()                      // 运行定时任务
 	vs_net_service.Run()                    // 运行虚拟网络服务
+	metrics_service.Run()                   // 启动指标监听
 	routers.Run()                           // 启动路由
 }
@@ -13,6 +13,7 @@ import (
 	"matrix_server/internal/service/redis_service/net_lock"
 	"matrix_server/internal/service/redis_service/net_progress"
 	"matrix_server/internal/utils"
+	"matrix_server/internal/utils/ip"
 	"matrix_server/internal/utils/response"
 
 	"github.com/gin-gonic/gin"
@@ -104,8 +105,8 @@ func (Api) DeployView(c *gin.Context) {
 	for _, templateModel := range hostTemplateList {
 		hostTemplateMap[templateModel.ID] = templateModel
 		for _, port := range templateModel.PortList {
-			// 收集唯一的服务ID，避免重复加载
-			if !utils.InList(serviceIDList, port.ServiceID) {
+			// 收集唯一的服务ID，避免重复加载（使用内置模拟器的端口不关联服务）
+			if port.Emulator == "" && !utils.InList(serviceIDList, port.ServiceID) {
 				serviceIDList = append(serviceIDList, port.ServiceID)
 			}
 		}
@@ -181,6 +182,19 @@ func (Api) DeployView(c *gin.Context) {
 
 	// 遍历待部署IP列表，完成IP冲突校验并构建部署数据
 	for _, info := range cr.List {
+		// IPv6地址统一为标准格式，与资产及诱捕ip记录保持一致
+		info.Ip = ip.NormalizeIP(info.Ip)
+		// 校验IP属于子网的IPv4或IPv6网段
+		if !model.InSubnet(info.Ip) {
+			log.WithFields(map[string]interface{}{
+				"ip":      info.Ip,
+				"subnet":  model.Subnet(),
+				"subnet6": model.Subnet6(),
+			}).Warn("IP not in subnet")
+			response.FailWithMsg(fmt.Sprintf("%s 不属于当前子网", info.Ip), c)
+			return
+		}
+
 		// 校验IP是否为已存在的资产IP
 		if hostMap[info.Ip] {
 			log.WithFields(map[string]interface{}{
@@ -214,6 +228,8 @@ func (Api) DeployView(c *gin.Context) {
 
 		// 构建端口转发信息（仅当关联主机模板时）
 		var portList []mq_service.PortInfo
+		var deviceProfile string
+		var deviceVendor models.DeviceVendor
 		if info.HostTemplateID != nil {
 			// 校验主机模板是否存在
 			hostTemplateModel, ok := hostTemplateMap[*info.HostTemplateID]
@@ -225,9 +241,31 @@ func (Api) DeployView(c *gin.Context) {
 				response.FailWithMsg(fmt.Sprintf("%d 主机模板不存在", info.HostTemplateID), c)
 				return
 			}
+			deviceProfile = hostTemplateModel.DeviceProfile
+			deviceVendor = hostTemplateModel.DeviceVendor
 
 			// 遍历模板关联的端口，构建端口转发信息及诱捕端口记录
 			for _, port := range hostTemplateModel.PortList {
+				// 使用节点内置模拟器的端口，由节点本地提供服务
+				if port.Emulator != "" {
+					portList = append(portList, mq_service.PortInfo{
+						IP:        info.Ip,
+						Port:      port.Port,
+						Agreement: 1, // 内置模拟器均为TCP
+						Emulator:  port.Emulator,
+					})
+					createHoneyPortList = append(createHoneyPortList, models.HoneyPortModel{
+						NodeID:    model.NodeID,
+						NetID:     cr.NetID,
+						IP:        info.Ip,
+						Port:      port.Port,
+						Agreement: 1,
+						Emulator:  port.Emulator,
+						Status:    1, // 状态1：部署中
+					})
+					continue
+				}
+
 				// 校验模板关联的服务是否存在
 				service, ok1 := serviceMap[port.ServiceID]
 				if !ok1 {
@@ -243,10 +281,11 @@ func (Api) DeployView(c *gin.Context) {
 
 				// 构建MQ端口转发信息
 				portInfo := mq_service.PortInfo{
-					IP:       info.Ip,
-					Port:     port.Port,
-					DestIP:   service.IP,
-					DestPort: service.Port,
+					IP:        info.Ip,
+					Port:      port.Port,
+					DestIP:    service.IP,
+					DestPort:  service.Port,
+					Agreement: service.Agreement,
 				}
 				portList = append(portList, portInfo)
 
@@ -257,6 +296,7 @@ func (Api) DeployView(c *gin.Context) {
 					ServiceID: port.ServiceID,
 					IP:        info.Ip,
 					Port:      port.Port,
+					Agreement: service.Agreement,
 					DstIP:     service.IP,
 					DstPort:   service.Port,
 					Status:    1, // 状态1：部署中
@@ -266,9 +306,11 @@ func (Api) DeployView(c *gin.Context) {
 
 		// 将当前IP的部署信息添加到批量部署数据中
 		batchDeployData.IPList = append(batchDeployData.IPList, mq_service.DeployIp{
-			Ip:       info.Ip,
-			Mask:     model.Mask,
-			PortList: portList,
+			Ip:            info.Ip,
+			Mask:          model.MaskOf(info.Ip),
+			DeviceProfile: deviceProfile,
+			DeviceVendor:  deviceVendor,
+			PortList:      portList,
 		})
 
 		// 构建待创建的诱捕IP记录
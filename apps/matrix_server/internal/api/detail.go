@@ -46,6 +46,8 @@ type PortInfo struct {
 	ServiceID   uint   `json:"serviceID"`   // 端口关联的虚拟服务ID
 	ServiceName string `json:"serviceName"` // 端口关联的虚拟服务名称
 	Port        int    `json:"port"`        // 端口号
+	Agreement   int8   `json:"agreement"`   // 通信协议 1 TCP 2 UDP
+	Emulator    string `json:"emulator"`    // 节点内置模拟器名称
 }
 
 // DetailView IP详情查询接口处理函数
@@ -94,6 +96,8 @@ func (Api) DetailView(c *gin.Context) {
 			ServiceID:   model.ServiceID,
 			ServiceName: model.ServiceModel.Title,
 			Port:        model.Port,
+			Agreement:   model.Agreement,
+			Emulator:    model.Emulator,
 		})
 	}
 
@@ -30,6 +30,7 @@ type NetIpInfo struct {
 	// 3 - 部署中IP
 	// 4 - 删除中IP
 	// 5 - 操作失败的IP
+	// 6 - 冲突IP（被真实主机占用而撤下的诱捕IP）
 }
 
 // NetIpListResponse 子网IP列表查询响应结构体
@@ -42,6 +43,7 @@ type NetIpListResponse struct {
 	TotalPages         int         `json:"totalPages"`         // 分页总页数
 	Title              string      `json:"title"`              // 子网名称
 	Subnet             string      `json:"subnet"`             // 子网网段
+	Subnet6            string      `json:"subnet6"`            // IPv6子网网段，双栈网络才有
 	IsAction           bool        `json:"isAction"`           // 当前子网是否正在执行操作
 	NodeStatus         int8        `json:"nodeStatus"`         // 子网节点状态：1 在线 2 离线
 	List               []NetIpInfo `json:"list"`               // 分页后的IP列表数据
@@ -157,6 +159,9 @@ func (Api) NetIpListView(c *gin.Context) {
 			if honeyIpModel.Status == 2 { // 运行中
 				info.Type = 2
 			}
+			if honeyIpModel.Status == 5 { // 冲突的
+				info.Type = 6
+			}
 		}
 		// 判断IP类型：部署中IP
 		if creatingMap[p] {
@@ -169,8 +174,9 @@ func (Api) NetIpListView(c *gin.Context) {
 	// 填充响应数据
 	data.List = list
 	data.TotalPages = totalPages
-	data.Title = model.Title     // 子网名称
-	data.Subnet = model.Subnet() // 子网网段
+	data.Title = model.Title       // 子网名称
+	data.Subnet = model.Subnet()   // 子网网段
+	data.Subnet6 = model.Subnet6() // IPv6子网网段
 	data.NodeStatus = model.NodeModel.Status
 
 	// 判断当前子网是否正在执行操作
@@ -92,8 +92,8 @@ func (Api) UpdateDeployView(c *gin.Context) {
 	for _, templateModel := range hostTemplateList {
 		hostTemplateMap[templateModel.ID] = templateModel
 		for _, port := range templateModel.PortList {
-			// 收集唯一的服务ID，避免重复加载
-			if !utils.InList(serviceIDList, port.ServiceID) {
+			// 收集唯一的服务ID，避免重复加载（使用内置模拟器的端口不关联服务）
+			if port.Emulator == "" && !utils.InList(serviceIDList, port.ServiceID) {
 				serviceIDList = append(serviceIDList, port.ServiceID)
 			}
 		}
@@ -202,6 +202,27 @@ func (Api) UpdateDeployView(c *gin.Context) {
 
 			// 遍历新模板关联的端口，构建MQ端口更新信息及待创建的端口记录
 			for _, port := range hostTemplateModel.PortList {
+				// 使用节点内置模拟器的端口，由节点本地提供服务
+				if port.Emulator != "" {
+					data.PortList = append(data.PortList, mq_service.PortInfo{
+						IP:        info.Ip,
+						Port:      port.Port,
+						Agreement: 1, // 内置模拟器均为TCP
+						Emulator:  port.Emulator,
+					})
+					createPortList = append(createPortList, models.HoneyPortModel{
+						NodeID:    model.NodeID,
+						NetID:     cr.NetID,
+						HoneyIpID: honeyIPModel.ID,
+						IP:        info.Ip,
+						Port:      port.Port,
+						Agreement: 1,
+						Emulator:  port.Emulator,
+						Status:    1, // 状态1：部署中
+					})
+					continue
+				}
+
 				// 校验模板关联的服务是否存在
 				service, ok1 := serviceMap[port.ServiceID]
 				if !ok1 {
@@ -217,10 +238,11 @@ func (Api) UpdateDeployView(c *gin.Context) {
 
 				// 构建MQ端口更新信息
 				data.PortList = append(data.PortList, mq_service.PortInfo{
-					IP:       info.Ip,
-					Port:     port.Port,
-					DestIP:   service.IP,
-					DestPort: service.Port,
+					IP:        info.Ip,
+					Port:      port.Port,
+					DestIP:    service.IP,
+					DestPort:  service.Port,
+					Agreement: service.Agreement,
 				})
 
 				// 构建待创建的新蜜罐端口记录
@@ -231,6 +253,7 @@ func (Api) UpdateDeployView(c *gin.Context) {
 					ServiceID: port.ServiceID,
 					IP:        info.Ip,
 					Port:      port.Port,
+					Agreement: service.Agreement,
 					DstIP:     service.IP,
 					DstPort:   service.Port,
 					Status:    1, // 状态1：部署中
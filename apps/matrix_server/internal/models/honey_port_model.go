@@ -11,8 +11,10 @@ type HoneyPortModel struct {
 	HoneyIpModel HoneyIpModel `gorm:"foreignKey:HoneyIpID" json:"-"`          // 关联诱捕ip
 	ServiceID    uint         `json:"serviceID"`                              // 服务id
 	ServiceModel ServiceModel `gorm:"foreignKey:ServiceID" json:"-"`          // 关联服务
-	IP           string       `gorm:"size:32;index:idx_ip" json:"ip"`         // 服务ip
+	IP           string       `gorm:"size:64;index:idx_ip" json:"ip"`         // 服务ip
 	Port         int          `json:"port"`                                   // 服务端口
+	Agreement    int8         `gorm:"default:1" json:"agreement"`             // 通信协议 1 TCP 2 UDP
+	Emulator     string       `gorm:"size:16" json:"emulator"`                // 节点内置模拟器名称（与关联服务二选一）
 	DstIP        string       `gorm:"size:32" json:"dstIP"`                   // 目标转发ip
 	DstPort      int          `json:"dstPort"`                                // 目标转发端口
 	Status       int8         `json:"status"`                                 // 服务状态
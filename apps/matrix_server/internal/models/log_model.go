@@ -4,7 +4,7 @@ package models
 type LogModel struct {
 	Model
 	Type        int8   `json:"type"`                            // 日志类型 1 登录日志
-	IP          string `gorm:"size:32;index:idx_ip" json:"ip"`  // ip（登录日志）
+	IP          string `gorm:"size:64;index:idx_ip" json:"ip"`  // ip（登录日志）
 	Addr        string `gorm:"size:64" json:"addr"`             // 地址
 	UserID      uint   `gorm:"index:idx_user_id" json:"userID"` // 用户id
 	Username    string `gorm:"size:32" json:"username"`         // 用户名
@@ -0,0 +1,55 @@
+package metrics_service
+
+// File: matrix_server/service/metrics_service/enter.go
+// Description: 矩阵服务Prometheus指标模块，定义子网部署进度及子网分布式锁争用、持有时长等业务指标，并按配置在独立的内部地址启动/metrics监听
+
+import (
+	"matrix_server/internal/global"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// DeployIPs 子网当前部署操作的IP数，state取值 all 总数 completed 已完成 error 失败
+	DeployIPs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "matrix_server_deploy_ips",
+		Help: "子网当前部署操作的IP数 state all 总数 completed 已完成 error 失败",
+	}, []string{"net_id", "state"})
+	// DeployProgress 子网当前部署操作的进度百分比
+	DeployProgress = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "matrix_server_deploy_progress",
+		Help: "子网当前部署操作的进度百分比",
+	}, []string{"net_id"})
+	// NetLockWait 获取子网分布式锁的耗时，result取值 ok 成功 busy 子网操作中
+	NetLockWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "matrix_server_net_lock_wait_seconds",
+		Help:    "获取子网分布式锁的耗时 result ok 成功 busy 子网操作中",
+		Buckets: prometheus.ExponentialBuckets(0.001, 4, 8),
+	}, []string{"result"})
+	// NetLockHeld 子网分布式锁从加锁到解锁的持有时长（即一次部署操作的耗时）
+	NetLockHeld = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "matrix_server_net_lock_held_seconds",
+		Help:    "子网分布式锁从加锁到解锁的持有时长",
+		Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800},
+	})
+)
+
+// Run 按配置在独立地址启动指标监听，不与对外的web服务共用端口，未配置监听地址时不启动
+func Run() {
+	addr := global.Config.Metrics.Addr
+	if addr == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		logrus.Infof("指标服务监听 %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logrus.Errorf("指标服务启动失败 %s", err)
+		}
+	}()
+}
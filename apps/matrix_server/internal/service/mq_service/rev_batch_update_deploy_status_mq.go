@@ -23,8 +23,9 @@ type UpdateDeployStatusRequest struct {
 
 // PortStatus 端口更新部署状态结构体
 type PortStatus struct {
-	Port     int    `json:"port"`     // 执行更新部署操作的端口号
-	ErrorMsg string `json:"errorMsg"` // 端口级更新部署（绑定）失败时的错误信息
+	Port      int    `json:"port"`      // 执行更新部署操作的端口号
+	Agreement int8   `json:"agreement"` // 端口通信协议 1 TCP 2 UDP
+	ErrorMsg  string `json:"errorMsg"`  // 端口级更新部署（绑定）失败时的错误信息
 }
 
 // revBatchUpdateDeployStatusMq 批量更新部署状态消息的具体处理函数
@@ -72,10 +73,15 @@ func revBatchUpdateDeployStatusMq(data UpdateDeployStatusRequest) {
 	for _, status := range data.PortList {
 		if status.ErrorMsg != "" {
 			// 记录端口绑定失败日志（IP+端口+错误信息）
-			logrus.Errorf("端口绑定失败 %s %d %s", data.IP, status.Port, status.ErrorMsg)
+			logrus.Errorf("端口绑定失败 %s %d/%d %s", data.IP, status.Port, status.Agreement, status.ErrorMsg)
+			// 未上报协议的旧节点按TCP处理
+			agreement := status.Agreement
+			if agreement == 0 {
+				agreement = 1
+			}
 			// 更新端口状态为绑定失败（状态2）
 			var portModel models.HoneyPortModel
-			global.DB.Take(&portModel, "net_id = ? and ip = ? and port = ?", data.NetID, data.IP, status.Port).Update("status", 2)
+			global.DB.Take(&portModel, "net_id = ? and ip = ? and port = ? and agreement = ?", data.NetID, data.IP, status.Port, agreement).Update("status", 2)
 		}
 	}
 
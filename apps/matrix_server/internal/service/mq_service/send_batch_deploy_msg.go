@@ -5,6 +5,7 @@ package mq_service
 
 import (
 	"matrix_server/internal/global"
+	"matrix_server/internal/models"
 )
 
 // BatchDeployRequest 批量部署指令发送请求结构体
@@ -18,17 +19,21 @@ type BatchDeployRequest struct {
 
 // DeployIp 单IP部署配置结构体
 type DeployIp struct {
-	Ip       string     `json:"ip"`       // 待部署的诱捕IP地址
-	Mask     int8       `json:"mask"`     // IP子网掩码
-	PortList []PortInfo `json:"portList"` // 该IP关联的端口转发配置列表
+	Ip            string              `json:"ip"`            // 待部署的诱捕IP地址
+	Mask          int8                `json:"mask"`          // IP子网掩码
+	DeviceProfile string              `json:"deviceProfile"` // 设备画像（取自主机模板，节点按画像生成厂商一致的MAC地址）
+	DeviceVendor  models.DeviceVendor `json:"deviceVendor"`  // 设备画像的厂商信息（取自主机模板）
+	PortList      []PortInfo          `json:"portList"`      // 该IP关联的端口转发配置列表
 }
 
 // PortInfo 端口转发配置结构体
 type PortInfo struct {
-	IP       string `json:"ip"`       // 源IP地址
-	Port     int    `json:"port"`     // 源端口号
-	DestIP   string `json:"destIP"`   // 目标IP地址
-	DestPort int    `json:"destPort"` // 目标端口号
+	IP        string `json:"ip"`        // 源IP地址
+	Port      int    `json:"port"`      // 源端口号
+	DestIP    string `json:"destIP"`    // 目标IP地址
+	DestPort  int    `json:"destPort"`  // 目标端口号
+	Agreement int8   `json:"agreement"` // 通信协议 1 TCP 2 UDP
+	Emulator  string `json:"emulator"`  // 节点内置模拟器名称，非空时不转发到虚拟服务
 }
 
 // SendBatchDeployMsg 发送批量部署指令到MQ队列
@@ -6,6 +6,7 @@ package net_lock
 import (
 	"fmt"
 	"matrix_server/internal/global"
+	"matrix_server/internal/service/metrics_service"
 	"sync"
 	"time"
 
@@ -17,6 +18,9 @@ import (
 // netLockStore 子网分布式锁实例的缓存容器
 var netLockStore = sync.Map{}
 
+// lockTimeStore 子网加锁时间，用于统计锁持有时长
+var lockTimeStore = sync.Map{}
+
 // Lock 为指定子网加分布式锁
 func Lock(netID uint) error {
 	// 从缓存获取该子网对应的锁实例
@@ -40,7 +44,15 @@ func Lock(netID uint) error {
 	// 类型断言转换为redsync.Mutex实例
 	mutex := _mutex.(*redsync.Mutex)
 	// 尝试获取分布式锁
-	return mutex.Lock()
+	start := time.Now()
+	err := mutex.Lock()
+	if err != nil {
+		metrics_service.NetLockWait.WithLabelValues("busy").Observe(time.Since(start).Seconds())
+		return err
+	}
+	metrics_service.NetLockWait.WithLabelValues("ok").Observe(time.Since(start).Seconds())
+	lockTimeStore.Store(netID, time.Now())
+	return nil
 }
 
 // UnLock 释放指定子网的分布式锁
@@ -54,6 +66,9 @@ func UnLock(netID uint) (bool, error) {
 	}
 	// 类型断言转换为redsync.Mutex实例
 	mutex := _mutex.(*redsync.Mutex)
+	if value, ok := lockTimeStore.LoadAndDelete(netID); ok {
+		metrics_service.NetLockHeld.Observe(time.Since(value.(time.Time)).Seconds())
+	}
 	// 执行解锁操作并返回结果
 	return mutex.Unlock()
 }
@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"matrix_server/internal/global"
+	"matrix_server/internal/service/metrics_service"
+	"strconv"
 )
 
 // ErrorIp 部署失败的IP信息结构体
@@ -41,9 +43,25 @@ func Set(netID uint, data NetDeployInfo) error {
 	key := fmt.Sprintf("net_deploy_%d", netID)
 	// 存入Redis，过期时间-2表示使用Redis默认配置（永不过期）
 	err := global.Redis.Set(context.Background(), key, data, -2).Err()
+	if err == nil {
+		setMetrics(netID, data)
+	}
 	return err
 }
 
+// setMetrics 同步子网部署进度指标
+func setMetrics(netID uint, data NetDeployInfo) {
+	label := strconv.Itoa(int(netID))
+	metrics_service.DeployIPs.WithLabelValues(label, "all").Set(float64(data.AllCount))
+	metrics_service.DeployIPs.WithLabelValues(label, "completed").Set(float64(data.CompletedCount))
+	metrics_service.DeployIPs.WithLabelValues(label, "error").Set(float64(data.ErrorCount))
+	var progress float64
+	if data.AllCount > 0 {
+		progress = float64(data.CompletedCount) / float64(data.AllCount) * 100
+	}
+	metrics_service.DeployProgress.WithLabelValues(label).Set(progress)
+}
+
 // Get 从Redis读取子网部署进度信息
 func Get(netID uint) (data NetDeployInfo, err error) {
 	// 构建Redis读取Key（格式：net_deploy_子网ID）
@@ -5,6 +5,7 @@ import (
 	"matrix_server/internal/flags"
 	"matrix_server/internal/global"
 	"matrix_server/internal/routers"
+	"matrix_server/internal/service/metrics_service"
 	"matrix_server/internal/service/mq_service"
 )
 
@@ -18,5 +19,6 @@ func main() {
 	global.Queue = core.InitMQ()         // 初始化消息队列
 	mq_service.Run()                     // 启动MQ服务
 	flags.Run()                          // 运行命令行参数
+	metrics_service.Run()                // 启动指标监听
 	routers.Run()                        // 启动路由
 }
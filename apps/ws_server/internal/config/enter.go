@@ -7,10 +7,11 @@ import "fmt"
 
 // Config 应用整体配置结构体
 type Config struct {
-	Logger Logger `yaml:"logger"` // 日志配置信息
-	System System `yaml:"system"` // 系统配置信息
-	Jwt    Jwt    `yaml:"jwt"`    // jwt配置信息
-	MQ     MQ     `yaml:"mq"`     // rabbitMQ配置信息
+	Logger  Logger  `yaml:"logger"`  // 日志配置信息
+	System  System  `yaml:"system"`  // 系统配置信息
+	Jwt     Jwt     `yaml:"jwt"`     // jwt配置信息
+	MQ      MQ      `yaml:"mq"`      // rabbitMQ配置信息
+	Metrics Metrics `yaml:"metrics"` // Prometheus指标配置信息
 }
 
 // Logger 日志配置结构体
@@ -64,3 +65,8 @@ func (m MQ) Addr() string {
 		m.Port,
 	)
 }
+
+// Metrics Prometheus指标配置结构体
+type Metrics struct {
+	Addr string `yaml:"addr"` // /metrics监听地址，为空时不启动；只监听本机或内部网络地址，不与web服务共用端口
+}
@@ -0,0 +1,51 @@
+package metrics_service
+
+// File: ws_server/service/metrics_service/enter.go
+// Description: WebSocket服务Prometheus指标模块，定义在线客户端数、消息推送数及推送失败数等业务指标，并按配置在独立的内部地址启动/metrics监听
+
+import (
+	"net/http"
+	"ws_server/internal/global"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// SendTotal 推送成功的WebSocket消息数
+	SendTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ws_server_send_total",
+		Help: "推送成功的WebSocket消息数",
+	})
+	// SendFailures 推送失败的WebSocket消息数，kind取值 message 业务消息 ping 心跳帧
+	SendFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ws_server_send_failures_total",
+		Help: "推送失败的WebSocket消息数 kind message 业务消息 ping 心跳帧",
+	}, []string{"kind"})
+)
+
+// RegisterGauge 注册取值函数形式的指标，采集时调用fn获取当前值
+func RegisterGauge(name, help string, fn func() float64) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: name,
+		Help: help,
+	}, fn)
+}
+
+// Run 按配置在独立地址启动指标监听，不与对外的web服务共用端口，未配置监听地址时不启动
+func Run() {
+	addr := global.Config.Metrics.Addr
+	if addr == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		logrus.Infof("指标服务监听 %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logrus.Errorf("指标服务启动失败 %s", err)
+		}
+	}()
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 	"ws_server/internal/core"
+	"ws_server/internal/service/metrics_service"
 
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
@@ -16,6 +17,13 @@ import (
 // WsStore WebSocket连接存储容器
 var WsStore = sync.Map{}
 
+// init 注册在线客户端数指标
+func init() {
+	metrics_service.RegisterGauge("ws_server_connected_clients", "当前在线的WebSocket客户端数", func() float64 {
+		return float64(getConnectionCount())
+	})
+}
+
 // WSConn WebSocket连接封装结构体
 type WSConn struct {
 	conn       *websocket.Conn // 原生WebSocket连接实例
@@ -115,6 +123,7 @@ func SendMsg(msg []byte, logID string) {
 		wsConn.mu.Unlock()
 
 		if err != nil {
+			metrics_service.SendFailures.WithLabelValues("message").Inc()
 			// 分类处理消息推送错误，针对性记录日志并清理无效连接
 			if errors.Is(err, websocket.ErrCloseSent) {
 				log.WithFields(map[string]interface{}{
@@ -138,6 +147,7 @@ func SendMsg(msg []byte, logID string) {
 		}
 
 		// 推送成功，记录日志并计数
+		metrics_service.SendTotal.Inc()
 		log.WithFields(map[string]interface{}{
 			"addr": addr,
 		}).Infof("消息推送成功")
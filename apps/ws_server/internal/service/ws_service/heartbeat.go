@@ -5,6 +5,7 @@ package ws_service
 
 import (
 	"time"
+	"ws_server/internal/service/metrics_service"
 
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
@@ -28,6 +29,7 @@ func HeartbeatChecker() {
 			// 连接未超时，发送Ping帧检测连接可用性（触发客户端回复Pong帧更新lastActive）
 			err := wsConn.conn.WriteMessage(websocket.PingMessage, []byte{})
 			if err != nil {
+				metrics_service.SendFailures.WithLabelValues("ping").Inc()
 				logrus.Errorf("发送心跳失败: %s, %v", addr, err)
 				timeoutConnections = append(timeoutConnections, addr)
 			}
@@ -23,10 +23,28 @@ func HasLocalIPAddr(_ip string) bool {
 	if ip.IsLoopback() {
 		return true
 	}
+	// 判断是否为链路本地地址（169.254.0.0/16或fe80::/10）
+	if ip.IsLinkLocalUnicast() {
+		return true
+	}
 	return false
 }
 
+// NormalizeIP 将IP地址转换为标准格式（IPv6压缩格式、IPv4映射的IPv6地址转为IPv4），无效地址原样返回
+// IPv6地址存在多种等价写法，入库及比较前统一格式，避免白名单、资产等按字符串匹配失败
+func NormalizeIP(_ip string) string {
+	ip := net.ParseIP(strings.TrimSpace(_ip))
+	if ip == nil {
+		return _ip
+	}
+	return ip.String()
+}
+
+// maxIPv6RangeSize IPv6地址段最多展开的地址数，避免超大地址段耗尽内存
+const maxIPv6RangeSize = 65536
+
 // ParseIPRange 解析IP范围字符串，支持单个IP、IP段（如192.168.1.1-100或192.168.1.1-192.168.1.100）格式
+// IPv6地址段支持完整格式（如fd00::1-fd00::ff）及末段十六进制简写（如fd00::1-ff）
 func ParseIPRange(ipRange string) ([]string, error) {
 	var result []string
 	// 按逗号分割多个IP范围段
@@ -88,7 +106,11 @@ func ParseIPRange(ipRange string) ([]string, error) {
 					}
 				}
 			} else {
-				return nil, fmt.Errorf("IPv6范围解析暂不支持")
+				ipList, err := parseIPv6Range(startIP, endPart)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, ipList...)
 			}
 		} else {
 			// 处理单个IP地址
@@ -103,6 +125,41 @@ func ParseIPRange(ipRange string) ([]string, error) {
 	return result, nil
 }
 
+// parseIPv6Range 解析IPv6地址段，结束部分为完整IPv6地址或末段十六进制数
+func parseIPv6Range(startIP net.IP, endPart string) ([]string, error) {
+	startIP = append(net.IP(nil), startIP.To16()...)
+	var endIP net.IP
+	if endIP = net.ParseIP(endPart); endIP != nil {
+		if endIP.To4() != nil {
+			return nil, fmt.Errorf("无效的结束IP: %s", endPart)
+		}
+	} else {
+		// 处理简写格式（如fd00::1-ff），替换最后一段16位
+		endNum, err := strconv.ParseUint(endPart, 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("无效的结束部分: %s", endPart)
+		}
+		endIP = make(net.IP, net.IPv6len)
+		copy(endIP, startIP)
+		endIP[14] = byte(endNum >> 8)
+		endIP[15] = byte(endNum)
+	}
+
+	var result []string
+	for bytes.Compare(startIP, endIP) <= 0 {
+		if len(result) >= maxIPv6RangeSize {
+			return nil, fmt.Errorf("IPv6地址段超过%d个地址", maxIPv6RangeSize)
+		}
+		result = append(result, startIP.String())
+		startIP = IncrementIP(startIP)
+		// 递增溢出回到::，说明已遍历到最大地址
+		if startIP.IsUnspecified() {
+			break
+		}
+	}
+	return result, nil
+}
+
 // IncrementIP 将IP地址加1，支持IPv4和IPv6地址
 func IncrementIP(ip net.IP) net.IP {
 	if ip == nil {
@@ -220,11 +277,10 @@ func ParseCIDRGetUseIPRange(cidr string) (r string, err error) {
 	// 获取子网掩码位数（如/24对应24）
 	mask, _ := ipNet.Mask.Size()
 
-	// 转换为IPv4地址，确保输入为IPv4网段
+	// IPv6网段单独计算
 	ip4 := ipObj.To4()
 	if ip4 == nil {
-		err = errors.New("不是有效的IPv4地址")
-		return
+		return cidrGetUseIPv6Range(ipNet, mask)
 	}
 
 	// 处理掩码小于24的情况（如/16、/8），自动截取第一个C段
@@ -258,3 +314,26 @@ func ParseCIDRGetUseIPRange(cidr string) (r string, err error) {
 	r = fmt.Sprintf("%s-%s", intToIP(firstUsable), intToIP(lastUsable))
 	return
 }
+
+// cidrGetUseIPv6Range 计算IPv6网段的可用IP范围
+// IPv6网段地址空间过大，掩码小于120时自动截取第一个/120子段（256个地址），排除子网路由器任播地址（主机位全0）
+func cidrGetUseIPv6Range(ipNet *net.IPNet, mask int) (r string, err error) {
+	if mask > 126 {
+		err = errors.New("IPv6网段过小")
+		return
+	}
+	if mask < 120 {
+		mask = 120
+	}
+	network := ipNet.IP.To16()
+	first := make(net.IP, net.IPv6len)
+	copy(first, network)
+	last := make(net.IP, net.IPv6len)
+	copy(last, network)
+	// 主机位全部落在最后一个字节内
+	hostBits := uint(128 - mask)
+	last[15] |= byte(0xff >> (8 - hostBits))
+
+	r = FormatIPRange(IncrementIP(first), last)
+	return
+}
@@ -5,6 +5,7 @@ import (
 	"ws_server/internal/global"
 	"ws_server/internal/routers"
 	"ws_server/internal/service/cron_service"
+	"ws_server/internal/service/metrics_service"
 	"ws_server/internal/service/mq_service"
 )
 
@@ -16,5 +17,6 @@ func main() {
 	global.Queue = core.InitMQ()      // 初始化消息队列
 	mq_service.Run()                  // 启动MQ服务
 	cron_service.Run()                // 启动定时任务
+	metrics_service.Run()             // 启动指标监听
 	routers.Run()                     // 启动路由
 }